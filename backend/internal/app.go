@@ -14,6 +14,7 @@ import (
 	"github.com/imlargo/go-api-template/pkg/kv"
 	"github.com/imlargo/go-api-template/pkg/push"
 	"github.com/imlargo/go-api-template/pkg/ratelimiter"
+	"github.com/imlargo/go-api-template/pkg/scheduler"
 	"github.com/imlargo/go-api-template/pkg/sse"
 	"github.com/imlargo/go-api-template/pkg/storage"
 	"github.com/imlargo/go-api-template/pkg/utils"
@@ -36,6 +37,7 @@ type Application struct {
 	RateLimiter ratelimiter.RateLimiter
 	Logger      *zap.SugaredLogger
 	Router      *gin.Engine
+	Scheduler   *scheduler.Scheduler
 }
 
 func (app *Application) Mount() {
@@ -64,15 +66,35 @@ func (app *Application) Mount() {
 	notificationService := services.NewNotificationService(serviceContainer, sseManager, pushNotificationDispatcher)
 
 	// Platform services
-	courseService := services.NewCourseService(serviceContainer)
-	moduleService := services.NewModuleService(serviceContainer)
-	contentService := services.NewContentService(serviceContainer)
-	evaluationService := services.NewEvaluationService(serviceContainer)
-	questionService := services.NewQuestionService(serviceContainer)
-	answerService := services.NewAnswerService(serviceContainer)
+	translationService := services.NewTranslationService(serviceContainer)
+	courseService := services.NewCourseService(serviceContainer, translationService)
+	releaseService := services.NewReleaseService(serviceContainer, notificationService)
+	moduleService := services.NewModuleService(serviceContainer, releaseService, translationService)
+	revisionService := services.NewRevisionService(serviceContainer)
+	contentService := services.NewContentService(serviceContainer, revisionService, releaseService, translationService)
+	evaluationService := services.NewEvaluationService(serviceContainer, revisionService, translationService)
+	questionService := services.NewQuestionService(serviceContainer, revisionService)
+	answerService := services.NewAnswerService(serviceContainer, revisionService)
 	enrollmentService := services.NewEnrollmentService(serviceContainer)
-	userProgressService := services.NewUserProgressService(serviceContainer, enrollmentService)
-	evaluationAttemptService := services.NewEvaluationAttemptService(serviceContainer, answerService, userProgressService)
+	userProgressService := services.NewUserProgressService(serviceContainer, enrollmentService, releaseService)
+	deadlineService := services.NewDeadlineService(serviceContainer)
+	evaluationAttemptService := services.NewEvaluationAttemptService(serviceContainer, answerService, userProgressService, revisionService, deadlineService, translationService)
+	searchService := services.NewSearchService(serviceContainer, translationService)
+	categoryService := services.NewCategoryService(serviceContainer)
+	tagService := services.NewTagService(serviceContainer)
+	trashService := services.NewTrashService(serviceContainer)
+	attachmentService := services.NewAttachmentService(serviceContainer, revisionService, fileService)
+	trackService := services.NewTrackService(serviceContainer, fileService)
+	questionBankService := services.NewQuestionBankService(serviceContainer)
+	itemAnalysisService := services.NewItemAnalysisService(serviceContainer)
+	playbackService := services.NewPlaybackService(serviceContainer, enrollmentService, userProgressService)
+	activityService := services.NewActivityService(serviceContainer, enrollmentService)
+	reviewService := services.NewReviewService(serviceContainer, enrollmentService, notificationService)
+	discussionService := services.NewDiscussionService(serviceContainer, enrollmentService, notificationService)
+	noteService := services.NewNoteService(serviceContainer, enrollmentService)
+	announcementService := services.NewAnnouncementService(serviceContainer, enrollmentService, notificationService)
+	learningPathService := services.NewLearningPathService(serviceContainer, enrollmentService)
+	cohortService := services.NewCohortService(serviceContainer, enrollmentService)
 
 	// Handlers
 	handlerContainer := handlers.NewHandler(app.Logger)
@@ -90,10 +112,40 @@ func (app *Application) Mount() {
 	enrollmentHandler := handlers.NewEnrollmentHandler(handlerContainer, enrollmentService)
 	evaluationAttemptHandler := handlers.NewEvaluationAttemptHandler(handlerContainer, evaluationAttemptService)
 	userProgressHandler := handlers.NewUserProgressHandler(handlerContainer, userProgressService)
+	searchHandler := handlers.NewSearchHandler(handlerContainer, searchService)
+	categoryHandler := handlers.NewCategoryHandler(handlerContainer, categoryService)
+	tagHandler := handlers.NewTagHandler(handlerContainer, tagService)
+	trashHandler := handlers.NewTrashHandler(handlerContainer, trashService)
+	revisionHandler := handlers.NewRevisionHandler(handlerContainer, revisionService)
+	attachmentHandler := handlers.NewAttachmentHandler(handlerContainer, attachmentService)
+	trackHandler := handlers.NewTrackHandler(handlerContainer, trackService)
+	questionBankHandler := handlers.NewQuestionBankHandler(handlerContainer, questionBankService)
+	itemAnalysisHandler := handlers.NewItemAnalysisHandler(handlerContainer, itemAnalysisService)
+	playbackHandler := handlers.NewPlaybackHandler(handlerContainer, playbackService)
+	activityHandler := handlers.NewActivityHandler(handlerContainer, activityService)
+	reviewHandler := handlers.NewReviewHandler(handlerContainer, reviewService)
+	discussionHandler := handlers.NewDiscussionHandler(handlerContainer, discussionService)
+	noteHandler := handlers.NewNoteHandler(handlerContainer, noteService)
+	announcementHandler := handlers.NewAnnouncementHandler(handlerContainer, announcementService)
+	learningPathHandler := handlers.NewLearningPathHandler(handlerContainer, learningPathService)
+	cohortHandler := handlers.NewCohortHandler(handlerContainer, cohortService)
+	deadlineHandler := handlers.NewDeadlineHandler(handlerContainer, deadlineService)
+	translationHandler := handlers.NewTranslationHandler(handlerContainer, translationService)
+
+	// Background jobs
+	app.Scheduler = scheduler.NewScheduler(app.Logger)
+	app.Scheduler.Every("trash-purge", app.Config.Trash.PurgeInterval, trashService.PurgeExpired)
+	app.Scheduler.Every("released-files-purge", app.Config.Attachments.PurgeInterval, fileService.PurgeReleasedFiles)
+	app.Scheduler.Every("idle-activity-sessions", app.Config.Activity.IdleTimeout, activityService.CloseIdleSessions)
+	app.Scheduler.Every("announcements-publish", app.Config.Announcements.DispatchInterval, announcementService.PublishDueAnnouncements)
+	app.Scheduler.Every("module-release-notices", app.Config.Releases.CheckInterval, releaseService.NotifyReleasedModules)
+	app.Scheduler.Every("item-analysis", app.Config.Evaluations.ItemAnalysisInterval, itemAnalysisService.RefreshStaleAnalyses)
+	app.Scheduler.Every("expired-attempts", app.Config.Evaluations.AttemptSweepInterval, evaluationAttemptService.ExpireAttempts)
 
 	// Middlewares
 	apiKeyMiddleware := middleware.ApiKeyMiddleware(app.Config.Auth.ApiKey)
 	authMiddleware := middleware.AuthTokenMiddleware(jwtAuth)
+	optionalAuthMiddleware := middleware.OptionalAuthTokenMiddleware(jwtAuth)
 	metricsMiddleware := middleware.NewMetricsMiddleware(app.Metrics)
 	rateLimiterMiddleware := middleware.NewRateLimiterMiddleware(app.RateLimiter)
 	corsMiddleware := middleware.NewCorsMiddleware(app.Config.Server.Host, []string{"http://localhost:5173", "https://cnre.imlargo.dev"})
@@ -114,6 +166,7 @@ func (app *Application) Mount() {
 	app.Router.POST("/auth/login", authHandler.Login)
 	app.Router.POST("/auth/register", authHandler.Register)
 	app.Router.GET("/auth/me", authMiddleware, authHandler.GetUserInfo)
+	app.Router.PUT("/auth/me/locale", authMiddleware, translationHandler.SetMyLocale)
 	app.Router.POST("/auth/google", authHandler.GoogleLogin)
 
 	app.Router.GET("/api/v1/notifications/subscribe", notificationHandler.SubscribeSSE)
@@ -121,7 +174,17 @@ func (app *Application) Mount() {
 	v1 := app.Router.Group("/api/v1")
 
 	// Files
-	v1.GET("/files/:id/download", fileHandler.DownloadFile)
+	v1.POST("/files", authMiddleware, fileHandler.UploadFile)
+	v1.GET("/files/:id", authMiddleware, fileHandler.GetFile)
+	v1.DELETE("/files/:id", authMiddleware, fileHandler.DeleteFile)
+	v1.POST("/files/:id/presigned-url", authMiddleware, fileHandler.GetPresignedURL)
+	v1.GET("/files/:id/download", authMiddleware, fileHandler.DownloadFile)
+
+	// Attachments
+	v1.GET("/attachments/:type/:id", attachmentHandler.GetAttachments)
+	v1.POST("/attachments/:type/:id", authMiddleware, attachmentHandler.Attach)
+	v1.PATCH("/attachments/:id", authMiddleware, attachmentHandler.UpdateAttachmentPatch)
+	v1.DELETE("/attachments/:id", authMiddleware, attachmentHandler.Detach)
 
 	// Notifications
 	v1.GET("/notifications", notificationHandler.GetUserNotifications)
@@ -138,49 +201,97 @@ func (app *Application) Mount() {
 	// Courses
 	v1.POST("/courses", courseHandler.CreateCourse)
 	v1.GET("/courses", courseHandler.GetAllCourses)
-	v1.GET("/courses/:id", courseHandler.GetCourse)
+	v1.GET("/courses/:id", optionalAuthMiddleware, courseHandler.GetCourse)
 	v1.PUT("/courses/:id", courseHandler.UpdateCourse)
 	v1.PATCH("/courses/:id", courseHandler.UpdateCoursePatch)
 	v1.DELETE("/courses/:id", courseHandler.DeleteCourse)
+	v1.PUT("/courses/:id/tags", courseHandler.SetCourseTags)
+
+	// Catalog
+	v1.GET("/catalog", courseHandler.GetCatalog)
+
+	// Categories
+	v1.POST("/categories", categoryHandler.CreateCategory)
+	v1.GET("/categories", categoryHandler.GetCategoryTree)
+	v1.GET("/categories/:id", categoryHandler.GetCategory)
+	v1.PATCH("/categories/:id", categoryHandler.UpdateCategoryPatch)
+	v1.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+
+	// Tags
+	v1.GET("/tags", tagHandler.GetAllTags)
+	v1.DELETE("/tags/:id", tagHandler.DeleteTag)
+
+	// Trash
+	v1.GET("/trash/courses", authMiddleware, trashHandler.GetDeletedCourses)
+	v1.GET("/courses/:id/trash", authMiddleware, trashHandler.GetCourseTrash)
+	v1.POST("/trash/:type/:id/restore", authMiddleware, trashHandler.Restore)
+
+	// Revisions
+	v1.GET("/revisions/:type/:id", authMiddleware, revisionHandler.ListRevisions)
+	v1.GET("/revisions/:type/:id/versions/:version", authMiddleware, revisionHandler.GetRevision)
+	v1.GET("/revisions/:type/:id/diff", authMiddleware, revisionHandler.DiffRevisions)
+	v1.POST("/revisions/:type/:id/rollback", authMiddleware, revisionHandler.Rollback)
 
 	// Modules
 	v1.POST("/modules", moduleHandler.CreateModule)
-	v1.GET("/modules/:id", moduleHandler.GetModule)
+	v1.GET("/modules/:id", optionalAuthMiddleware, moduleHandler.GetModule)
 	v1.PUT("/modules/:id", moduleHandler.UpdateModule)
 	v1.PATCH("/modules/:id", moduleHandler.UpdateModulePatch)
 	v1.DELETE("/modules/:id", moduleHandler.DeleteModule)
-	v1.GET("/courses/:id/modules", moduleHandler.GetModulesByCourse)
+	v1.GET("/courses/:id/modules", optionalAuthMiddleware, moduleHandler.GetModulesByCourse)
 	v1.POST("/courses/:id/modules/reorder", moduleHandler.ReorderModules)
 
 	// Content
-	v1.POST("/content", contentHandler.CreateContent)
-	v1.GET("/content/:id", contentHandler.GetContent)
-	v1.PUT("/content/:id", contentHandler.UpdateContent)
-	v1.PATCH("/content/:id", contentHandler.UpdateContentPatch)
+	v1.POST("/content", optionalAuthMiddleware, contentHandler.CreateContent)
+	v1.GET("/content/:id", optionalAuthMiddleware, contentHandler.GetContent)
+	v1.PUT("/content/:id", optionalAuthMiddleware, contentHandler.UpdateContent)
+	v1.PATCH("/content/:id", optionalAuthMiddleware, contentHandler.UpdateContentPatch)
 	v1.DELETE("/content/:id", contentHandler.DeleteContent)
-	v1.GET("/modules/:id/content", contentHandler.GetContentsByModule)
+	v1.GET("/modules/:id/content", optionalAuthMiddleware, contentHandler.GetContentsByModule)
+
+	// Video playback
+	v1.POST("/content/:id/playback", authMiddleware, playbackHandler.RecordHeartbeat)
+	v1.GET("/content/:id/playback", authMiddleware, playbackHandler.GetPlayback)
+	v1.GET("/content/:id/playback/stats", authMiddleware, playbackHandler.GetPlaybackStats)
+
+	// Captions and transcripts
+	v1.GET("/content/:id/tracks", trackHandler.GetTracks)
+	v1.POST("/content/:id/tracks", authMiddleware, trackHandler.UploadTrack)
+	v1.DELETE("/tracks/:id", authMiddleware, trackHandler.DeleteTrack)
 
 	// Evaluations
-	v1.POST("/evaluations", evaluationHandler.CreateEvaluation)
-	v1.GET("/evaluations/:id", evaluationHandler.GetEvaluation)
-	v1.PUT("/evaluations/:id", evaluationHandler.UpdateEvaluation)
-	v1.PATCH("/evaluations/:id", evaluationHandler.UpdateEvaluationPatch)
+	v1.POST("/evaluations", optionalAuthMiddleware, evaluationHandler.CreateEvaluation)
+	v1.GET("/evaluations/:id", optionalAuthMiddleware, evaluationHandler.GetEvaluation)
+	v1.PUT("/evaluations/:id", optionalAuthMiddleware, evaluationHandler.UpdateEvaluation)
+	v1.PATCH("/evaluations/:id", optionalAuthMiddleware, evaluationHandler.UpdateEvaluationPatch)
 	v1.DELETE("/evaluations/:id", evaluationHandler.DeleteEvaluation)
-	v1.GET("/modules/:id/evaluations", evaluationHandler.GetEvaluationsByModule)
+	v1.GET("/modules/:id/evaluations", optionalAuthMiddleware, evaluationHandler.GetEvaluationsByModule)
+	v1.GET("/evaluations/:id/item-analysis", authMiddleware, itemAnalysisHandler.GetItemAnalysis)
 
 	// Questions
-	v1.POST("/questions", questionHandler.CreateQuestion)
+	v1.POST("/questions", optionalAuthMiddleware, questionHandler.CreateQuestion)
 	v1.GET("/questions/:id", questionHandler.GetQuestion)
-	v1.PUT("/questions/:id", questionHandler.UpdateQuestion)
-	v1.PATCH("/questions/:id", questionHandler.UpdateQuestionPatch)
+	v1.PUT("/questions/:id", optionalAuthMiddleware, questionHandler.UpdateQuestion)
+	v1.PATCH("/questions/:id", optionalAuthMiddleware, questionHandler.UpdateQuestionPatch)
 	v1.DELETE("/questions/:id", questionHandler.DeleteQuestion)
 	v1.GET("/evaluations/:id/questions", questionHandler.GetQuestionsByEvaluation)
+	v1.PUT("/questions/:id/tags", authMiddleware, questionHandler.SetQuestionTags)
+
+	// Question banks
+	v1.GET("/courses/:id/question-banks", authMiddleware, questionBankHandler.ListBanks)
+	v1.POST("/courses/:id/question-banks", authMiddleware, questionBankHandler.CreateBank)
+	v1.GET("/question-banks/:id", authMiddleware, questionBankHandler.GetBank)
+	v1.PATCH("/question-banks/:id", authMiddleware, questionBankHandler.UpdateBankPatch)
+	v1.DELETE("/question-banks/:id", authMiddleware, questionBankHandler.DeleteBank)
+	v1.GET("/question-banks/:id/questions", authMiddleware, questionBankHandler.GetBankQuestions)
+	v1.GET("/evaluations/:id/draw-rules", authMiddleware, questionBankHandler.GetDrawRules)
+	v1.PUT("/evaluations/:id/draw-rules", authMiddleware, questionBankHandler.SetDrawRules)
 
 	// Answers
-	v1.POST("/answers", answerHandler.CreateAnswer)
+	v1.POST("/answers", optionalAuthMiddleware, answerHandler.CreateAnswer)
 	v1.GET("/answers/:id", answerHandler.GetAnswer)
-	v1.PUT("/answers/:id", answerHandler.UpdateAnswer)
-	v1.PATCH("/answers/:id", answerHandler.UpdateAnswerPatch)
+	v1.PUT("/answers/:id", optionalAuthMiddleware, answerHandler.UpdateAnswer)
+	v1.PATCH("/answers/:id", optionalAuthMiddleware, answerHandler.UpdateAnswerPatch)
 	v1.DELETE("/answers/:id", answerHandler.DeleteAnswer)
 	v1.GET("/questions/:id/answers", answerHandler.GetAnswersByQuestion)
 
@@ -193,6 +304,7 @@ func (app *Application) Mount() {
 	v1.GET("/users/:userId/enrollments", enrollmentHandler.GetUserEnrollments)
 	v1.GET("/courses/:id/enrollments", enrollmentHandler.GetCourseEnrollments)
 	v1.GET("/courses/:id/kpis", enrollmentHandler.GetCourseKPIs)
+	v1.GET("/courses/:id/kpis/cohorts", enrollmentHandler.GetCohortKPIs)
 	v1.GET("/users/:userId/courses/:courseId/enrollment", enrollmentHandler.GetUserCourseEnrollment)
 	v1.POST("/users/:userId/courses/:id/complete", enrollmentHandler.CompleteEnrollment)
 	v1.PUT("/users/:userId/courses/:id/progress", enrollmentHandler.UpdateProgress)
@@ -211,14 +323,107 @@ func (app *Application) Mount() {
 	v1.GET("/users/:userId/recent-progress", userProgressHandler.GetRecentUserProgress)
 	v1.PATCH("/user-progress/:id", userProgressHandler.UpdateUserProgressPatch)
 
+	// Time on task
+	v1.POST("/activity/heartbeat", authMiddleware, activityHandler.RecordHeartbeat)
+	v1.GET("/activity/week", authMiddleware, activityHandler.GetWeeklyActivity)
+	v1.GET("/courses/:id/time-on-task", authMiddleware, activityHandler.GetCourseTimeOnTask)
+
+	// Reviews
+	v1.GET("/courses/:id/reviews", reviewHandler.GetCourseReviews)
+	v1.GET("/courses/:id/reviews/me", authMiddleware, reviewHandler.GetMyCourseReview)
+	v1.POST("/courses/:id/reviews", authMiddleware, reviewHandler.CreateReview)
+	v1.PATCH("/reviews/:id", authMiddleware, reviewHandler.UpdateReviewPatch)
+	v1.DELETE("/reviews/:id", authMiddleware, reviewHandler.DeleteReview)
+	v1.POST("/reviews/:id/reply", authMiddleware, reviewHandler.ReplyReview)
+	v1.POST("/reviews/:id/report", authMiddleware, reviewHandler.ReportReview)
+	v1.POST("/reviews/:id/moderate", authMiddleware, reviewHandler.ModerateReview)
+	v1.GET("/reviews/moderation", authMiddleware, reviewHandler.GetModerationQueue)
+
+	// Discussions
+	v1.GET("/courses/:id/discussions", authMiddleware, discussionHandler.ListCourseThreads)
+	v1.POST("/courses/:id/discussions", authMiddleware, discussionHandler.CreateThread)
+	v1.GET("/content/:id/discussions", authMiddleware, discussionHandler.ListContentThreads)
+	v1.GET("/discussions/:id", authMiddleware, discussionHandler.GetThread)
+	v1.PATCH("/discussions/:id", authMiddleware, discussionHandler.UpdateThreadPatch)
+	v1.DELETE("/discussions/:id", authMiddleware, discussionHandler.DeleteThread)
+	v1.POST("/discussions/:id/moderate", authMiddleware, discussionHandler.ModerateThread)
+	v1.POST("/discussions/:id/posts", authMiddleware, discussionHandler.CreatePost)
+	v1.POST("/discussions/:id/upvote", authMiddleware, discussionHandler.UpvoteThread)
+	v1.DELETE("/discussions/:id/upvote", authMiddleware, discussionHandler.RemoveThreadUpvote)
+	v1.PATCH("/discussion-posts/:id", authMiddleware, discussionHandler.UpdatePostPatch)
+	v1.DELETE("/discussion-posts/:id", authMiddleware, discussionHandler.DeletePost)
+	v1.POST("/discussion-posts/:id/moderate", authMiddleware, discussionHandler.ModeratePost)
+	v1.POST("/discussion-posts/:id/endorse", authMiddleware, discussionHandler.EndorsePost)
+	v1.DELETE("/discussion-posts/:id/endorse", authMiddleware, discussionHandler.UnendorsePost)
+	v1.POST("/discussion-posts/:id/upvote", authMiddleware, discussionHandler.UpvotePost)
+	v1.DELETE("/discussion-posts/:id/upvote", authMiddleware, discussionHandler.RemovePostUpvote)
+
+	// Notes
+	v1.GET("/courses/:id/notes", authMiddleware, noteHandler.GetCourseNotes)
+	v1.GET("/courses/:id/notes/export", authMiddleware, noteHandler.ExportCourseNotes)
+	v1.GET("/content/:id/notes", authMiddleware, noteHandler.GetContentNotes)
+	v1.POST("/content/:id/notes", authMiddleware, noteHandler.CreateNote)
+	v1.GET("/notes/search", authMiddleware, noteHandler.SearchNotes)
+	v1.PATCH("/notes/:id", authMiddleware, noteHandler.UpdateNotePatch)
+	v1.DELETE("/notes/:id", authMiddleware, noteHandler.DeleteNote)
+
+	// Announcements
+	v1.GET("/courses/:id/announcements", authMiddleware, announcementHandler.ListAnnouncements)
+	v1.POST("/courses/:id/announcements", authMiddleware, announcementHandler.CreateAnnouncement)
+	v1.GET("/announcements/:id", authMiddleware, announcementHandler.GetAnnouncement)
+	v1.PATCH("/announcements/:id", authMiddleware, announcementHandler.UpdateAnnouncementPatch)
+	v1.DELETE("/announcements/:id", authMiddleware, announcementHandler.DeleteAnnouncement)
+	v1.POST("/announcements/:id/read", authMiddleware, announcementHandler.MarkAsRead)
+
+	// Learning Paths
+	v1.GET("/learning-paths", optionalAuthMiddleware, learningPathHandler.ListPaths)
+	v1.POST("/learning-paths", authMiddleware, learningPathHandler.CreatePath)
+	v1.GET("/learning-paths/enrolled", authMiddleware, learningPathHandler.ListUserPaths)
+	v1.GET("/learning-paths/:id", optionalAuthMiddleware, learningPathHandler.GetPath)
+	v1.PATCH("/learning-paths/:id", authMiddleware, learningPathHandler.UpdatePathPatch)
+	v1.DELETE("/learning-paths/:id", authMiddleware, learningPathHandler.DeletePath)
+	v1.PUT("/learning-paths/:id/structure", authMiddleware, learningPathHandler.SetStructure)
+	v1.POST("/learning-paths/:id/enroll", authMiddleware, learningPathHandler.Enroll)
+	v1.GET("/learning-paths/:id/progress", authMiddleware, learningPathHandler.GetProgress)
+
+	// Cohorts
+	v1.GET("/courses/:id/cohorts", authMiddleware, cohortHandler.ListCohorts)
+	v1.POST("/courses/:id/cohorts", authMiddleware, cohortHandler.CreateCohort)
+	v1.GET("/cohorts/:id", authMiddleware, cohortHandler.GetCohort)
+	v1.PATCH("/cohorts/:id", authMiddleware, cohortHandler.UpdateCohortPatch)
+	v1.DELETE("/cohorts/:id", authMiddleware, cohortHandler.DeleteCohort)
+	v1.POST("/cohorts/:id/enrollments", authMiddleware, enrollmentHandler.EnrollCohort)
+	v1.DELETE("/cohorts/:id/members/:userId", authMiddleware, enrollmentHandler.RemoveFromCohort)
+	v1.POST("/cohorts/:id/deadlines", authMiddleware, cohortHandler.CreateDeadline)
+	v1.PATCH("/cohort-deadlines/:id", authMiddleware, cohortHandler.UpdateDeadlinePatch)
+	v1.DELETE("/cohort-deadlines/:id", authMiddleware, cohortHandler.DeleteDeadline)
+
+	// Deadlines
+	v1.GET("/deadlines/upcoming", authMiddleware, deadlineHandler.GetUpcomingDeadlines)
+	v1.GET("/evaluations/:id/window", authMiddleware, deadlineHandler.GetEvaluationWindow)
+	v1.POST("/deadline-overrides", authMiddleware, deadlineHandler.CreateOverride)
+	v1.PATCH("/deadline-overrides/:id", authMiddleware, deadlineHandler.UpdateOverridePatch)
+	v1.DELETE("/deadline-overrides/:id", authMiddleware, deadlineHandler.DeleteOverride)
+	v1.GET("/users/:userId/deadline-overrides", authMiddleware, deadlineHandler.ListUserOverrides)
+
+	// Translations
+	v1.GET("/translations/:type/:id", translationHandler.GetTranslations)
+	v1.PUT("/translations/:type/:id/:locale", authMiddleware, translationHandler.SetTranslations)
+	v1.DELETE("/translations/:type/:id/:locale", authMiddleware, translationHandler.DeleteTranslations)
+	v1.GET("/courses/:id/translations/completeness", authMiddleware, translationHandler.GetCourseCompleteness)
+
 	// Evaluation Attempts
 	v1.POST("/evaluation-attempts/start", evaluationAttemptHandler.StartAttempt)
 	v1.POST("/evaluation-attempts/:id/submit", evaluationAttemptHandler.SubmitAttempt)
+	v1.PUT("/evaluation-attempts/:id/answers/:questionId", evaluationAttemptHandler.SaveAnswer)
 	v1.GET("/evaluation-attempts/:id", evaluationAttemptHandler.GetAttempt)
 	v1.PATCH("/evaluation-attempts/:id", evaluationAttemptHandler.UpdateEvaluationAttemptPatch)
 	v1.GET("/users/:userId/evaluations/:evaluationId/attempts", evaluationAttemptHandler.GetUserAttempts)
 	v1.GET("/users/:userId/evaluations/:evaluationId/can-attempt", evaluationAttemptHandler.CanUserAttempt)
 	v1.POST("/evaluation-attempts/:id/score", evaluationAttemptHandler.ScoreAttempt)
+
+	// Search
+	v1.GET("/search", authMiddleware, searchHandler.Search)
 }
 
 func (app *Application) registerDocs() {
@@ -244,6 +449,11 @@ func (app *Application) registerDocs() {
 }
 
 func (app *Application) Run() {
+	if app.Scheduler != nil {
+		app.Scheduler.Start()
+		defer app.Scheduler.Stop()
+	}
+
 	addr := utils.CleanHostURL(":" + app.Config.Server.Port)
 	app.Router.Run(addr)
 }
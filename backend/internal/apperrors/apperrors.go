@@ -0,0 +1,114 @@
+package apperrors
+
+import (
+	"errors"
+	"maps"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/i18n"
+	"gorm.io/gorm"
+)
+
+// Kind classifies a domain error; the responses package maps each kind to an HTTP status
+type Kind string
+
+const (
+	KindInvalid         Kind = "invalid"
+	KindUnauthorized    Kind = "unauthorized"
+	KindForbidden       Kind = "forbidden"
+	KindNotFound        Kind = "not_found"
+	KindConflict        Kind = "conflict"
+	KindTooManyRequests Kind = "too_many_requests"
+	KindInternal        Kind = "internal"
+)
+
+var (
+	ErrNotFound = New("NOT_FOUND", KindNotFound, "elemento no encontrado", "item not found")
+	ErrInternal = New("INTERNAL_SERVER_ERROR", KindInternal, "error interno del servidor", "internal server error")
+)
+
+// Error is a domain error with a stable code clients can rely on instead of the message.
+// The declared value acts as a sentinel: copies made by Wrap and WithDetails still match
+// it with errors.Is because errors compare by code.
+type Error struct {
+	Code     string
+	Kind     Kind
+	messages map[enums.Locale]string
+	details  map[string]interface{}
+	cause    error
+}
+
+// New declares a domain error with its message in Spanish and English
+func New(code string, kind Kind, es, en string) *Error {
+	return &Error{
+		Code: code,
+		Kind: kind,
+		messages: map[enums.Locale]string{
+			enums.LocaleSpanish: es,
+			enums.LocaleEnglish: en,
+		},
+	}
+}
+
+// Error returns the Spanish message followed by the cause, for logs
+func (e *Error) Error() string {
+	message := e.messages[i18n.DefaultLocale]
+	if e.cause != nil {
+		return message + ": " + e.cause.Error()
+	}
+	return message
+}
+
+// Message returns the message in the given locale, falling back to Spanish
+func (e *Error) Message(locale string) string {
+	if message, ok := e.messages[enums.Locale(locale)]; ok {
+		return message
+	}
+	return e.messages[i18n.DefaultLocale]
+}
+
+// Details returns the structured data attached to the error, nil when there is none
+func (e *Error) Details() map[string]interface{} {
+	return e.details
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// WithDetails returns a copy of the error carrying the given details
+func (e *Error) WithDetails(details map[string]interface{}) *Error {
+	copied := *e
+	copied.details = maps.Clone(e.details)
+	if copied.details == nil {
+		copied.details = make(map[string]interface{}, len(details))
+	}
+	maps.Copy(copied.details, details)
+	return &copied
+}
+
+// Wrap returns a copy of the error caused by err. The cause is logged but never sent
+// to clients.
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.cause = err
+	return &copied
+}
+
+// From returns the domain error in the chain of err. Missing records are reported as
+// ErrNotFound so repositories need not know about domain errors.
+func From(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound.Wrap(err), true
+	}
+	return nil, false
+}
@@ -14,6 +14,13 @@ type AppConfig struct {
 	Auth             AuthConfig
 	Storage          StorageConfig
 	Redis            RedisConfig
+	Trash            TrashConfig
+	Attachments      AttachmentConfig
+	Playback         PlaybackConfig
+	Activity         ActivityConfig
+	Announcements    AnnouncementConfig
+	Releases         ReleaseConfig
+	Evaluations      EvaluationConfig
 }
 
 type ServerConfig struct {
@@ -62,6 +69,40 @@ type RedisConfig struct {
 	RedisURL string
 }
 
+type TrashConfig struct {
+	Retention     time.Duration // Time deleted material stays restorable
+	PurgeInterval time.Duration
+}
+
+type AttachmentConfig struct {
+	ReleaseGrace  time.Duration // Time a file with no attachments is kept before being deleted from storage
+	PurgeInterval time.Duration
+}
+
+type PlaybackConfig struct {
+	CompletionThreshold float64 // Percentage of distinct seconds watched that completes a video
+}
+
+type ActivityConfig struct {
+	IdleTimeout time.Duration // Gap between heartbeats after which a new session starts
+}
+
+type AnnouncementConfig struct {
+	BatchSize        int           // Recipients notified per batch during the fan-out
+	DispatchInterval time.Duration // How often scheduled announcements are checked
+}
+
+type ReleaseConfig struct {
+	CheckInterval time.Duration // How often newly unlocked modules are notified
+	NotifyWindow  time.Duration // Unlocks older than this are not notified, e.g. after changing a release rule
+}
+
+type EvaluationConfig struct {
+	ItemAnalysisInterval time.Duration // How often item statistics of evaluations with new submissions are recomputed
+	AttemptGrace         time.Duration // Time after the limit in which a submission is still accepted
+	AttemptSweepInterval time.Duration // How often expired attempts are submitted with their saved answers
+}
+
 func LoadConfig() AppConfig {
 	err := loadEnv()
 	if err != nil {
@@ -106,5 +147,32 @@ func LoadConfig() AppConfig {
 		Redis: RedisConfig{
 			RedisURL: env.GetEnvString(REDIS_URL, ""),
 		},
+		Trash: TrashConfig{
+			Retention:     time.Duration(env.GetEnvInt(TRASH_RETENTION_DAYS, 30)) * 24 * time.Hour,
+			PurgeInterval: time.Duration(env.GetEnvInt(TRASH_PURGE_INTERVAL, 60)) * time.Minute,
+		},
+		Attachments: AttachmentConfig{
+			ReleaseGrace:  time.Duration(env.GetEnvInt(FILE_RELEASE_GRACE_HOURS, 24)) * time.Hour,
+			PurgeInterval: time.Duration(env.GetEnvInt(FILE_PURGE_INTERVAL, 60)) * time.Minute,
+		},
+		Playback: PlaybackConfig{
+			CompletionThreshold: float64(env.GetEnvInt(VIDEO_COMPLETION_THRESHOLD, 90)),
+		},
+		Activity: ActivityConfig{
+			IdleTimeout: time.Duration(env.GetEnvInt(ACTIVITY_IDLE_TIMEOUT, 120)) * time.Second,
+		},
+		Announcements: AnnouncementConfig{
+			BatchSize:        env.GetEnvInt(ANNOUNCEMENT_BATCH_SIZE, 200),
+			DispatchInterval: time.Duration(env.GetEnvInt(ANNOUNCEMENT_DISPATCH_INTERVAL, 60)) * time.Second,
+		},
+		Releases: ReleaseConfig{
+			CheckInterval: time.Duration(env.GetEnvInt(RELEASE_CHECK_INTERVAL, 5)) * time.Minute,
+			NotifyWindow:  time.Duration(env.GetEnvInt(RELEASE_NOTIFY_WINDOW, 72)) * time.Hour,
+		},
+		Evaluations: EvaluationConfig{
+			ItemAnalysisInterval: time.Duration(env.GetEnvInt(ITEM_ANALYSIS_INTERVAL, 30)) * time.Minute,
+			AttemptGrace:         time.Duration(env.GetEnvInt(ATTEMPT_GRACE_SECONDS, 30)) * time.Second,
+			AttemptSweepInterval: time.Duration(env.GetEnvInt(ATTEMPT_SWEEP_INTERVAL, 60)) * time.Second,
+		},
 	}
 }
@@ -37,6 +37,26 @@ const (
 	STORAGE_USE_PUBLIC_URL    = "STORAGE_USE_PUBLIC_URL"
 
 	REDIS_URL = "REDIS_URL"
+
+	TRASH_RETENTION_DAYS = "TRASH_RETENTION_DAYS"
+	TRASH_PURGE_INTERVAL = "TRASH_PURGE_INTERVAL"
+
+	FILE_RELEASE_GRACE_HOURS = "FILE_RELEASE_GRACE_HOURS"
+	FILE_PURGE_INTERVAL      = "FILE_PURGE_INTERVAL"
+
+	VIDEO_COMPLETION_THRESHOLD = "VIDEO_COMPLETION_THRESHOLD"
+
+	ACTIVITY_IDLE_TIMEOUT = "ACTIVITY_IDLE_TIMEOUT"
+
+	ANNOUNCEMENT_BATCH_SIZE        = "ANNOUNCEMENT_BATCH_SIZE"
+	ANNOUNCEMENT_DISPATCH_INTERVAL = "ANNOUNCEMENT_DISPATCH_INTERVAL"
+
+	RELEASE_CHECK_INTERVAL = "RELEASE_CHECK_INTERVAL"
+	RELEASE_NOTIFY_WINDOW  = "RELEASE_NOTIFY_WINDOW"
+
+	ITEM_ANALYSIS_INTERVAL = "ITEM_ANALYSIS_INTERVAL"
+	ATTEMPT_GRACE_SECONDS  = "ATTEMPT_GRACE_SECONDS"
+	ATTEMPT_SWEEP_INTERVAL = "ATTEMPT_SWEEP_INTERVAL"
 )
 
 // Initialize loads environment variables from .env file
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/internal/repositories"
 	"gorm.io/gorm"
 )
 
@@ -21,7 +22,44 @@ func Migrate(db *gorm.DB) error {
 		&models.Module{},
 		&models.UserProgress{},
 		&models.Question{},
+		&models.Category{},
+		&models.Tag{},
+		&models.Revision{},
+		&models.Attachment{},
+		&models.PlaybackProgress{},
+		&models.ActivitySession{},
+		&models.DailyActivity{},
+		&models.Review{},
+		&models.ReviewReport{},
+		&models.DiscussionThread{},
+		&models.DiscussionPost{},
+		&models.DiscussionVote{},
+		&models.Note{},
+		&models.Announcement{},
+		&models.AnnouncementRead{},
+		&models.LearningPath{},
+		&models.LearningPathGroup{},
+		&models.LearningPathCourse{},
+		&models.LearningPathEnrollment{},
+		&models.Cohort{},
+		&models.CohortDeadline{},
+		&models.UserDeadlineOverride{},
+		&models.ModuleReleaseNotice{},
+		&models.Translation{},
+		&models.ContentTrack{},
+		&models.QuestionBank{},
+		&models.EvaluationDrawRule{},
+		&models.EvaluationItemAnalysis{},
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	for _, statement := range repositories.SearchIndexes() {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// ActivityHeartbeatRequest is sent periodically while a learner has a content or evaluation open
+type ActivityHeartbeatRequest struct {
+	ItemType enums.ActivityItemType `json:"item_type" binding:"required"`
+	ItemID   uint                   `json:"item_id" binding:"required"`
+	Idle     bool                   `json:"idle"` // El cliente no detectó interacción desde el último heartbeat
+}
+
+// DailyActivitySummary is the time spent on a single day
+type DailyActivitySummary struct {
+	Day     string `json:"day"` // YYYY-MM-DD
+	Seconds int    `json:"seconds"`
+}
+
+// CourseActivitySummary is the time spent on a course in the period
+type CourseActivitySummary struct {
+	CourseID    uint   `json:"course_id"`
+	CourseTitle string `json:"course_title"`
+	Seconds     int    `json:"seconds"`
+}
+
+// WeeklyActivity is the learner's time on task for the current week
+type WeeklyActivity struct {
+	WeekStart    time.Time               `json:"week_start"`
+	TotalSeconds int                     `json:"total_seconds"`
+	Days         []DailyActivitySummary  `json:"days"`
+	Courses      []CourseActivitySummary `json:"courses"`
+}
+
+// ItemTimeOnTask aggregates the time learners spend on a content or evaluation
+type ItemTimeOnTask struct {
+	ItemType       enums.ActivityItemType `json:"item_type"`
+	ItemID         uint                   `json:"item_id"`
+	ModuleID       uint                   `json:"module_id"`
+	Title          string                 `json:"title"`
+	Learners       int                    `json:"learners"`
+	MedianSeconds  float64                `json:"median_seconds"`
+	AverageSeconds float64                `json:"average_seconds"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type CreateAnnouncementRequest struct {
+	Title     string     `json:"title" binding:"required"`
+	Body      string     `json:"body"`
+	PublishAt *time.Time `json:"publish_at,omitempty"` // Se publica de inmediato si se omite
+}
+
+// UpdateAnnouncementRequest DTO for editing a scheduled announcement (PATCH)
+type UpdateAnnouncementRequest struct {
+	Title     *string    `json:"title,omitempty"`
+	Body      *string    `json:"body,omitempty"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+}
@@ -2,15 +2,21 @@ package dto
 
 // CreateAnswerRequest DTO for creating answers
 type CreateAnswerRequest struct {
-	Text       string `json:"text" binding:"required"`
-	IsCorrect  bool   `json:"is_correct"`
-	Order      int    `json:"order" binding:"required"`
-	QuestionID uint   `json:"question_id" binding:"required"`
+	Text       string  `json:"text" binding:"required"`
+	IsCorrect  bool    `json:"is_correct"`
+	Order      int     `json:"order" binding:"required"`
+	QuestionID uint    `json:"question_id" binding:"required"`
+	MatchText  string  `json:"match_text"`
+	Tolerance  float64 `json:"tolerance"`
+	Blank      int     `json:"blank"`
 }
 
 // UpdateAnswerRequest DTO for updating answers (PUT)
 type UpdateAnswerRequest struct {
-	Text      string `json:"text"`
-	IsCorrect bool   `json:"is_correct"`
-	Order     int    `json:"order"`
+	Text      string  `json:"text"`
+	IsCorrect bool    `json:"is_correct"`
+	Order     int     `json:"order"`
+	MatchText string  `json:"match_text"`
+	Tolerance float64 `json:"tolerance"`
+	Blank     int     `json:"blank"`
 }
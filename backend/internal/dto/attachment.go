@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/imlargo/go-api-template/internal/enums"
+
+type CreateAttachmentRequest struct {
+	FileID uint                 `json:"file_id" binding:"required"`
+	Role   enums.AttachmentRole `json:"role" binding:"required"`
+	Order  *int                 `json:"order,omitempty"` // Al final de la lista si se omite
+}
+
+// UpdateAttachmentRequest DTO for updating an attachment (PATCH)
+type UpdateAttachmentRequest struct {
+	Role  *enums.AttachmentRole `json:"role,omitempty"`
+	Order *int                  `json:"order,omitempty"`
+}
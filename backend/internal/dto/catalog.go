@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+// CatalogRequest DTO for the course catalog query parameters
+type CatalogRequest struct {
+	Query       string              `form:"q"`
+	CategoryID  uint                `form:"category_id"`
+	Tags        []string            `form:"tags"`
+	Levels      []enums.CourseLevel `form:"level"`
+	Languages   []string            `form:"language"`
+	MinDuration int                 `form:"min_duration"`
+	MaxDuration int                 `form:"max_duration"`
+	MinRating   float64             `form:"min_rating"`
+	Sort        enums.CatalogSort   `form:"sort"`
+	Limit       int                 `form:"limit"`
+	Offset      int                 `form:"offset"`
+}
+
+// FacetCount represents the number of catalog courses for a facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// CatalogFacets groups the facet counts of the catalog
+type CatalogFacets struct {
+	Categories []*FacetCount `json:"categories"`
+	Tags       []*FacetCount `json:"tags"`
+	Levels     []*FacetCount `json:"levels"`
+	Languages  []*FacetCount `json:"languages"`
+	Ratings    []*FacetCount `json:"ratings"`
+}
+
+// CatalogResponse DTO for the filtered course catalog
+type CatalogResponse struct {
+	Courses       []*models.Course       `json:"courses"`
+	Total         int64                  `json:"total"`
+	Facets        CatalogFacets          `json:"facets"`
+	LearningPaths []*models.LearningPath `json:"learning_paths"`
+}
+
+// CreateCategoryRequest DTO for creating categories
+type CreateCategoryRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Order       int    `json:"order"`
+	ParentID    *uint  `json:"parent_id"`
+}
+
+// UpdateCategoryRequest DTO for updating categories (PATCH)
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Order       *int    `json:"order,omitempty"`
+	ParentID    *uint   `json:"parent_id,omitempty"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+type CreateCohortRequest struct {
+	Name          string     `json:"name" binding:"required"`
+	Description   string     `json:"description"`
+	StartDate     time.Time  `json:"start_date" binding:"required"`
+	EndDate       *time.Time `json:"end_date,omitempty"`
+	Schedule      string     `json:"schedule"`
+	InstructorIDs []uint     `json:"instructor_ids"`
+}
+
+// UpdateCohortRequest DTO for updating cohorts (PATCH)
+type UpdateCohortRequest struct {
+	Name          *string    `json:"name,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+	StartDate     *time.Time `json:"start_date,omitempty"`
+	EndDate       *time.Time `json:"end_date,omitempty"`
+	Schedule      *string    `json:"schedule,omitempty"`
+	InstructorIDs *[]uint    `json:"instructor_ids,omitempty"`
+}
+
+type CreateCohortDeadlineRequest struct {
+	Title        string     `json:"title" binding:"required"`
+	OpensAt      *time.Time `json:"opens_at,omitempty"`
+	DueAt        time.Time  `json:"due_at" binding:"required"`
+	CloseAt      *time.Time `json:"close_at,omitempty"`
+	ModuleID     *uint      `json:"module_id,omitempty"`
+	EvaluationID *uint      `json:"evaluation_id,omitempty"`
+}
+
+// UpdateCohortDeadlineRequest DTO for updating cohort deadlines (PATCH)
+type UpdateCohortDeadlineRequest struct {
+	Title   *string    `json:"title,omitempty"`
+	OpensAt *time.Time `json:"opens_at,omitempty"`
+	DueAt   *time.Time `json:"due_at,omitempty"`
+	CloseAt *time.Time `json:"close_at,omitempty"`
+}
+
+// EnrollCohortRequest DTO for enrolling several learners in a cohort at once
+type EnrollCohortRequest struct {
+	UserIDs []uint `json:"user_ids" binding:"required,min=1"`
+}
+
+// CohortEnrollmentResult summarizes a cohort enrollment: learners newly enrolled in the
+// course, learners already enrolled that were moved to the cohort and the failures
+type CohortEnrollmentResult struct {
+	CohortID uint                       `json:"cohort_id"`
+	Enrolled int                        `json:"enrolled"`
+	Assigned int                        `json:"assigned"`
+	Failed   []*CohortEnrollmentFailure `json:"failed"`
+}
+
+type CohortEnrollmentFailure struct {
+	UserID uint   `json:"user_id"`
+	Error  string `json:"error"`
+}
@@ -1,11 +1,51 @@
 package dto
 
+import "github.com/imlargo/go-api-template/internal/enums"
+
+// CreateCourseRequest DTO for creating courses. Courses are published unless
+// is_published is sent as false.
+type CreateCourseRequest struct {
+	Title             string            `json:"title"`
+	Description       string            `json:"description"`
+	ShortDescription  string            `json:"short_description"`
+	ImageURL          string            `json:"image_url"`
+	IsPublished       *bool             `json:"is_published,omitempty"`
+	CategoryID        *uint             `json:"category_id,omitempty"`
+	Level             enums.CourseLevel `json:"level"`
+	Language          string            `json:"language"`
+	EstimatedDuration int               `json:"estimated_duration"`
+}
+
+// ReplaceCourseRequest DTO for updating courses (PUT). The publication, taxonomy and
+// language fields keep their value when omitted.
+type ReplaceCourseRequest struct {
+	Title             string             `json:"title"`
+	Description       string             `json:"description"`
+	ShortDescription  string             `json:"short_description"`
+	ImageURL          string             `json:"image_url"`
+	IsPublished       *bool              `json:"is_published,omitempty"`
+	CategoryID        *uint              `json:"category_id,omitempty"`
+	Level             *enums.CourseLevel `json:"level,omitempty"`
+	Language          *string            `json:"language,omitempty"`
+	EstimatedDuration *int               `json:"estimated_duration,omitempty"`
+}
+
 // UpdateCourseRequest DTO for updating courses (PATCH)
 type UpdateCourseRequest struct {
-	Title            *string `json:"title,omitempty"`
-	Description      *string `json:"description,omitempty"`
-	ShortDescription *string `json:"short_description,omitempty"`
-	ImageURL         *string `json:"image_url,omitempty"`
-	StudentCount     *int    `json:"student_count,omitempty"`
-	ModuleCount      *int    `json:"module_count,omitempty"`
+	Title             *string            `json:"title,omitempty"`
+	Description       *string            `json:"description,omitempty"`
+	ShortDescription  *string            `json:"short_description,omitempty"`
+	ImageURL          *string            `json:"image_url,omitempty"`
+	StudentCount      *int               `json:"student_count,omitempty"`
+	ModuleCount       *int               `json:"module_count,omitempty"`
+	IsPublished       *bool              `json:"is_published,omitempty"`
+	CategoryID        *uint              `json:"category_id,omitempty"`
+	Level             *enums.CourseLevel `json:"level,omitempty"`
+	Language          *string            `json:"language,omitempty"`
+	EstimatedDuration *int               `json:"estimated_duration,omitempty"`
+}
+
+// SetCourseTagsRequest DTO for replacing the free-form tags of a course
+type SetCourseTagsRequest struct {
+	Tags []string `json:"tags" binding:"required"`
 }
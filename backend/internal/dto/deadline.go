@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// DeadlineWindow is the effective availability of a module or an evaluation for a
+// learner once the cohort and personal overrides are applied
+type DeadlineWindow struct {
+	OpensAt *time.Time           `json:"opens_at"`
+	DueAt   *time.Time           `json:"due_at"`
+	CloseAt *time.Time           `json:"close_at"`
+	Status  enums.DeadlineStatus `json:"status"`
+}
+
+// UpcomingDeadline is a deadline of a learner in one of their courses
+type UpcomingDeadline struct {
+	TargetType  enums.DeadlineTargetType `json:"target_type"`
+	TargetID    uint                     `json:"target_id"`
+	Title       string                   `json:"title"`
+	CourseID    uint                     `json:"course_id"`
+	CourseTitle string                   `json:"course_title"`
+	ModuleID    uint                     `json:"module_id,omitempty"`
+	DeadlineWindow
+}
+
+type CreateDeadlineOverrideRequest struct {
+	UserID       uint       `json:"user_id" binding:"required"`
+	ModuleID     *uint      `json:"module_id,omitempty"`
+	EvaluationID *uint      `json:"evaluation_id,omitempty"`
+	OpensAt      *time.Time `json:"opens_at,omitempty"`
+	DueAt        *time.Time `json:"due_at,omitempty"`
+	CloseAt      *time.Time `json:"close_at,omitempty"`
+	Reason       string     `json:"reason"`
+}
+
+// UpdateDeadlineOverrideRequest DTO for updating personal deadline overrides (PATCH)
+type UpdateDeadlineOverrideRequest struct {
+	OpensAt *time.Time `json:"opens_at,omitempty"`
+	DueAt   *time.Time `json:"due_at,omitempty"`
+	CloseAt *time.Time `json:"close_at,omitempty"`
+	Reason  *string    `json:"reason,omitempty"`
+}
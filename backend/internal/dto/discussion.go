@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/imlargo/go-api-template/internal/enums"
+
+type CreateDiscussionThreadRequest struct {
+	Title     string `json:"title" binding:"required"`
+	Body      string `json:"body"`
+	ContentID *uint  `json:"content_id,omitempty"` // Hilo sobre un contenido del curso
+	Mentions  []uint `json:"mentions,omitempty"`   // IDs de los usuarios mencionados
+}
+
+// UpdateDiscussionThreadRequest DTO for editing a thread (PATCH)
+type UpdateDiscussionThreadRequest struct {
+	Title    *string `json:"title,omitempty"`
+	Body     *string `json:"body,omitempty"`
+	Mentions *[]uint `json:"mentions,omitempty"`
+}
+
+type CreateDiscussionPostRequest struct {
+	Body     string `json:"body" binding:"required"`
+	ParentID *uint  `json:"parent_id,omitempty"` // Respuesta anidada a otra respuesta
+	Mentions []uint `json:"mentions,omitempty"`
+}
+
+// UpdateDiscussionPostRequest DTO for editing a reply (PATCH)
+type UpdateDiscussionPostRequest struct {
+	Body     *string `json:"body,omitempty"`
+	Mentions *[]uint `json:"mentions,omitempty"`
+}
+
+type ModerateDiscussionRequest struct {
+	Action enums.DiscussionModerationAction `json:"action" binding:"required"`
+}
@@ -1,16 +1,26 @@
 package dto
 
-import "github.com/imlargo/go-api-template/internal/enums"
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
 
 // UpdateEvaluationRequest DTO for updating evaluations (PATCH)
 type UpdateEvaluationRequest struct {
-	Order              *int               `json:"order,omitempty"`
-	Title              *string            `json:"title,omitempty"`
-	Description        *string            `json:"description,omitempty"`
-	Type               *enums.ContentType `json:"type,omitempty"`
-	QuestionCount      *int               `json:"question_count,omitempty"`
-	AnswerOptionsCount *int               `json:"answer_options_count,omitempty"`
-	PassingScore       *float64           `json:"passing_score,omitempty"`
-	MaxAttempts        *int               `json:"max_attempts,omitempty"`
-	TimeLimit          *int               `json:"time_limit,omitempty"`
+	Order              *int                     `json:"order,omitempty"`
+	Title              *string                  `json:"title,omitempty"`
+	Description        *string                  `json:"description,omitempty"`
+	Type               *enums.ContentType       `json:"type,omitempty"`
+	QuestionCount      *int                     `json:"question_count,omitempty"`
+	AnswerOptionsCount *int                     `json:"answer_options_count,omitempty"`
+	PassingScore       *float64                 `json:"passing_score,omitempty"`
+	MaxAttempts        *int                     `json:"max_attempts,omitempty"`
+	TimeLimit          *int                     `json:"time_limit,omitempty"`
+	OpensAt            *time.Time               `json:"opens_at,omitempty"`
+	DueAt              *time.Time               `json:"due_at,omitempty"`
+	CloseAt            *time.Time               `json:"close_at,omitempty"`
+	LatePenaltyPolicy  *enums.LatePenaltyPolicy `json:"late_penalty_policy,omitempty"`
+	LatePenaltyPercent *float64                 `json:"late_penalty_percent,omitempty"`
+	ScoringPolicy      *enums.ScoringPolicy     `json:"scoring_policy,omitempty"`
 }
@@ -2,9 +2,11 @@ package dto
 
 // CourseKPIResponse DTO for course KPI dashboard metrics
 type CourseKPIResponse struct {
-	CourseID         uint    `json:"course_id"`
-	CourseTitle      string  `json:"course_title"`
-	StudentCount     int     `json:"student_count"`
-	CompletionRate   float64 `json:"completion_rate"`   // percentage 0-100
-	AverageProgress  float64 `json:"average_progress"`  // percentage 0-100
-}
\ No newline at end of file
+	CourseID        uint    `json:"course_id"`
+	CourseTitle     string  `json:"course_title"`
+	CohortID        *uint   `json:"cohort_id,omitempty"`
+	CohortName      string  `json:"cohort_name,omitempty"`
+	StudentCount    int     `json:"student_count"`
+	CompletionRate  float64 `json:"completion_rate"`  // percentage 0-100
+	AverageProgress float64 `json:"average_progress"` // percentage 0-100
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+type CreateLearningPathRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+	IsPublished bool   `json:"is_published"`
+}
+
+// UpdateLearningPathRequest DTO for updating learning paths (PATCH)
+type UpdateLearningPathRequest struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageURL    *string `json:"image_url,omitempty"`
+	IsPublished *bool   `json:"is_published,omitempty"`
+}
+
+// LearningPathStructureRequest DTO for replacing the ordered groups of a path
+type LearningPathStructureRequest struct {
+	Groups []LearningPathGroupRequest `json:"groups" binding:"required,dive"`
+}
+
+type LearningPathGroupRequest struct {
+	Title      string                      `json:"title"`
+	Kind       enums.LearningPathGroupKind `json:"kind" binding:"required"`
+	MinCourses int                         `json:"min_courses"` // Solo para grupos optativos
+	CourseIDs  []uint                      `json:"course_ids" binding:"required,min=1"`
+}
+
+// LearningPathProgress is the progress of a learner in a path, derived from the
+// progress of the enrollments in its courses
+type LearningPathProgress struct {
+	PathID      uint                         `json:"path_id"`
+	Progress    float64                      `json:"progress"` // porcentaje 0-100
+	Completed   bool                         `json:"completed"`
+	EnrolledAt  time.Time                    `json:"enrolled_at"`
+	CompletedAt *time.Time                   `json:"completed_at"`
+	Groups      []*LearningPathGroupProgress `json:"groups"`
+}
+
+type LearningPathGroupProgress struct {
+	GroupID          uint                          `json:"group_id"`
+	Title            string                        `json:"title"`
+	Kind             enums.LearningPathGroupKind   `json:"kind"`
+	RequiredCourses  int                           `json:"required_courses"`
+	CompletedCourses int                           `json:"completed_courses"`
+	Completed        bool                          `json:"completed"`
+	Courses          []*LearningPathCourseProgress `json:"courses"`
+}
+
+type LearningPathCourseProgress struct {
+	CourseID  uint    `json:"course_id"`
+	Title     string  `json:"title"`
+	Enrolled  bool    `json:"enrolled"`
+	Progress  float64 `json:"progress"`
+	Completed bool    `json:"completed"`
+}
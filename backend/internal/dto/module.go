@@ -1,8 +1,16 @@
 package dto
 
+import "time"
+
 // UpdateModuleRequest DTO for updating modules (PATCH)
 type UpdateModuleRequest struct {
-	Title       *string `json:"title,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Order       *int    `json:"order,omitempty"`
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Order       *int       `json:"order,omitempty"`
+	OpensAt     *time.Time `json:"opens_at,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	CloseAt     *time.Time `json:"close_at,omitempty"`
+
+	ReleaseAfterDays *int       `json:"release_after_days,omitempty"`
+	ReleaseAt        *time.Time `json:"release_at,omitempty"`
 }
@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/imlargo/go-api-template/internal/enums"
+
+type CreateNoteRequest struct {
+	Kind        enums.NoteKind `json:"kind" binding:"required"`
+	Body        string         `json:"body"`
+	Quote       string         `json:"quote"`
+	AnchorStart *int           `json:"anchor_start,omitempty"` // Inicio del rango de texto, en caracteres
+	AnchorEnd   *int           `json:"anchor_end,omitempty"`   // Fin del rango de texto, exclusivo
+	Timestamp   *float64       `json:"timestamp,omitempty"`    // Segundo del video
+	Color       string         `json:"color"`
+}
+
+// UpdateNoteRequest DTO for updating a note (PATCH)
+type UpdateNoteRequest struct {
+	Body        *string  `json:"body,omitempty"`
+	Quote       *string  `json:"quote,omitempty"`
+	AnchorStart *int     `json:"anchor_start,omitempty"`
+	AnchorEnd   *int     `json:"anchor_end,omitempty"`
+	Timestamp   *float64 `json:"timestamp,omitempty"`
+	Color       *string  `json:"color,omitempty"`
+}
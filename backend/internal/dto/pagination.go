@@ -0,0 +1,21 @@
+package dto
+
+// ListRequest DTO for the pagination, sorting and filtering query parameters shared by list endpoints.
+// Sort takes a field name, prefixed with "-" for descending order. When Cursor is set Offset is ignored.
+type ListRequest struct {
+	Limit   int               `form:"limit"`
+	Offset  int               `form:"offset"`
+	Cursor  string            `form:"cursor"`
+	Sort    string            `form:"sort"`
+	Filters map[string]string `form:"-"`
+}
+
+// Page is the standard envelope returned by list endpoints
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+// PlaybackHeartbeatRequest is sent periodically by the video player
+type PlaybackHeartbeatRequest struct {
+	Position  float64      `json:"position" binding:"min=0"`         // Posición actual, en segundos
+	Duration  float64      `json:"duration" binding:"required,gt=0"` // Duración del video, en segundos
+	Intervals [][2]float64 `json:"intervals"`                        // Tramos vistos desde el último heartbeat
+}
+
+// PlaybackRetentionBucket counts the viewers that watched part of a slice of the video
+type PlaybackRetentionBucket struct {
+	FromPercentage float64 `json:"from_percentage"`
+	ToPercentage   float64 `json:"to_percentage"`
+	Viewers        int     `json:"viewers"`
+}
+
+// PlaybackStats summarizes how learners watch a video
+type PlaybackStats struct {
+	ContentID                uint                      `json:"content_id"`
+	Viewers                  int                       `json:"viewers"`
+	Completions              int                       `json:"completions"`
+	CompletionRate           float64                   `json:"completion_rate"`
+	AverageWatchedPercentage float64                   `json:"average_watched_percentage"`
+	AverageTimeSpent         float64                   `json:"average_time_spent"` // en segundos
+	TotalTimeSpent           int                       `json:"total_time_spent"`   // en segundos
+	Retention                []PlaybackRetentionBucket `json:"retention"`
+}
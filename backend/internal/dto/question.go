@@ -4,11 +4,16 @@ import "github.com/imlargo/go-api-template/internal/enums"
 
 // CreateQuestionRequest DTO for creating questions
 type CreateQuestionRequest struct {
-	Text         string             `json:"text" binding:"required"`
-	Type         enums.QuestionType `json:"type" binding:"required"`
-	Explanation  string             `json:"explanation"`
-	Points       int                `json:"points" binding:"required"`
-	EvaluationID uint               `json:"evaluation_id" binding:"required"`
+	Text        string             `json:"text" binding:"required"`
+	Type        enums.QuestionType `json:"type" binding:"required"`
+	Explanation string             `json:"explanation"`
+	Points      int                `json:"points" binding:"required"`
+	// Se indica la evaluación o el banco al que pertenece la pregunta
+	EvaluationID *uint `json:"evaluation_id"`
+	BankID       *uint `json:"bank_id"`
+	// Vacía usa la política de la evaluación
+	ScoringPolicy enums.ScoringPolicy      `json:"scoring_policy"`
+	Difficulty    enums.QuestionDifficulty `json:"difficulty"`
 }
 
 // UpdateQuestionRequest DTO for updating questions (PUT)
@@ -17,4 +22,7 @@ type UpdateQuestionRequest struct {
 	Type        enums.QuestionType `json:"type"`
 	Explanation string             `json:"explanation"`
 	Points      int                `json:"points"`
+	// Vacía usa la política de la evaluación
+	ScoringPolicy enums.ScoringPolicy      `json:"scoring_policy"`
+	Difficulty    enums.QuestionDifficulty `json:"difficulty"`
 }
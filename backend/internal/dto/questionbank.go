@@ -0,0 +1,38 @@
+package dto
+
+import "github.com/imlargo/go-api-template/internal/enums"
+
+type CreateQuestionBankRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// UpdateQuestionBankRequest DTO for updating question banks (PATCH)
+type UpdateQuestionBankRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// BankQuestionFilter DTO for filtering the questions of a bank
+type BankQuestionFilter struct {
+	Tag        string                   `form:"tag"` // Nombre o slug de la etiqueta
+	Difficulty enums.QuestionDifficulty `form:"difficulty"`
+}
+
+// DrawRuleRequest describes how many questions an attempt draws from a bank
+type DrawRuleRequest struct {
+	BankID     uint                     `json:"bank_id" binding:"required"`
+	Count      int                      `json:"count" binding:"required"`
+	Tag        string                   `json:"tag"`        // Vacía admite cualquier etiqueta
+	Difficulty enums.QuestionDifficulty `json:"difficulty"` // Vacía admite cualquier dificultad
+}
+
+// SetDrawRulesRequest DTO for replacing the draw rules of an evaluation
+type SetDrawRulesRequest struct {
+	Rules []DrawRuleRequest `json:"rules" binding:"dive"`
+}
+
+// SetQuestionTagsRequest DTO for replacing the tags of a question
+type SetQuestionTagsRequest struct {
+	Tags []string `json:"tags" binding:"required"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/imlargo/go-api-template/internal/enums"
+
+type CreateReviewRequest struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment"`
+}
+
+// UpdateReviewRequest DTO for editing a review (PATCH)
+type UpdateReviewRequest struct {
+	Rating  *int    `json:"rating,omitempty"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+type ReplyReviewRequest struct {
+	Reply string `json:"reply" binding:"required"`
+}
+
+type ReportReviewRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ModerateReviewRequest struct {
+	Action enums.ReviewModerationAction `json:"action" binding:"required"`
+	Reason string                       `json:"reason"` // Motivo visible al autor cuando la reseña se oculta
+}
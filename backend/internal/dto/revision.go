@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+// RevisionFieldChange describes how a single field differs between two revisions
+type RevisionFieldChange struct {
+	Field string           `json:"field"`
+	From  interface{}      `json:"from"`
+	To    interface{}      `json:"to"`
+	Lines []utils.DiffLine `json:"lines,omitempty"` // Diff línea a línea para campos de texto
+}
+
+// RevisionDiff compares two revisions of the same entity
+type RevisionDiff struct {
+	EntityType  enums.RevisionEntityType `json:"entity_type"`
+	EntityID    uint                     `json:"entity_id"`
+	FromVersion int                      `json:"from_version"`
+	ToVersion   int                      `json:"to_version"`
+	Changes     []RevisionFieldChange    `json:"changes"`
+}
+
+type RollbackRevisionRequest struct {
+	Version int `json:"version" binding:"required,min=1"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/imlargo/go-api-template/internal/enums"
+
+// SearchRequest DTO for full-text search query parameters
+type SearchRequest struct {
+	Query    string               `form:"q" binding:"required"`
+	CourseID uint                 `form:"course_id"`
+	Language enums.SearchLanguage `form:"lang"`
+	Limit    int                  `form:"limit"`
+	Offset   int                  `form:"offset"`
+}
+
+// SearchResult DTO for a single full-text search hit
+type SearchResult struct {
+	EntityType  enums.SearchEntityType `json:"entity_type"`
+	EntityID    uint                   `json:"entity_id"`
+	CourseID    uint                   `json:"course_id"`
+	CourseTitle string                 `json:"course_title"`
+	ModuleID    uint                   `json:"module_id"`
+	Title       string                 `json:"title"`
+	Snippet     string                 `json:"snippet"`
+	Rank        float64                `json:"rank"`
+}
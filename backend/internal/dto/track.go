@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/imlargo/go-api-template/internal/enums"
+
+// UploadTrackRequest DTO for the form fields sent along with a caption or transcript file
+type UploadTrackRequest struct {
+	Kind   enums.TrackKind `form:"kind" binding:"required"`
+	Locale string          `form:"locale" binding:"required"`
+	Label  string          `form:"label"` // El idioma si se omite
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "github.com/imlargo/go-api-template/internal/enums"
+
+// RequestLocale carries the locale hints of a request: an explicit ?locale= and the
+// Accept-Language header
+type RequestLocale struct {
+	Requested      string
+	AcceptLanguage string
+}
+
+// SetTranslationsRequest maps each translatable field to its text in the locale. An empty
+// text removes the translation of the field.
+type SetTranslationsRequest struct {
+	Fields map[string]string `json:"fields" binding:"required"`
+}
+
+type SetLocaleRequest struct {
+	Locale string `json:"locale"` // Vacío vuelve a usar Accept-Language
+}
+
+type MissingTranslation struct {
+	EntityType enums.TranslationEntityType `json:"entity_type"`
+	EntityID   uint                        `json:"entity_id"`
+	Field      string                      `json:"field"`
+}
+
+type LocaleCompleteness struct {
+	Locale           string                `json:"locale"`
+	TotalFields      int                   `json:"total_fields"`
+	TranslatedFields int                   `json:"translated_fields"`
+	Percentage       float64               `json:"percentage"`
+	Missing          []*MissingTranslation `json:"missing"`
+}
+
+type CourseTranslationCompleteness struct {
+	CourseID      uint                  `json:"course_id"`
+	DefaultLocale string                `json:"default_locale"`
+	Locales       []*LocaleCompleteness `json:"locales"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// TrashItem represents a deleted item that can still be restored
+type TrashItem struct {
+	EntityType enums.TrashEntityType `json:"entity_type"`
+	ID         uint                  `json:"id"`
+	Title      string                `json:"title"`
+	CourseID   uint                  `json:"course_id"`
+	ParentID   uint                  `json:"parent_id"`
+	ParentType string                `json:"parent_type"` // Las preguntas cuelgan de una evaluación o de un banco
+	DeletedAt  time.Time             `json:"deleted_at"`
+	PurgeAt    time.Time             `json:"purge_at"`
+}
@@ -1,18 +1,23 @@
 package dto
 
+import "time"
+
 // ModuleProgressDetail represents the progress details for a specific module
 type ModuleProgressDetail struct {
-	ModuleID     uint    `json:"module_id"`
-	ModuleTitle  string  `json:"module_title"`
-	Percentage   float64 `json:"percentage"`
-	IsCompleted  bool    `json:"is_completed"`
+	ModuleID    uint       `json:"module_id"`
+	ModuleTitle string     `json:"module_title"`
+	Percentage  float64    `json:"percentage"`
+	IsCompleted bool       `json:"is_completed"`
+	Locked      bool       `json:"locked"` // Módulo aún no liberado para el usuario
+	UnlocksAt   *time.Time `json:"unlocks_at,omitempty"`
 }
 
 // CourseProgressSummary represents a comprehensive course progress summary
 type CourseProgressSummary struct {
-	CourseID         uint                   `json:"course_id"`
-	CourseTitle      string                 `json:"course_title"`
-	TotalPercentage  float64                `json:"total_percentage"`
-	IsCompleted      bool                   `json:"is_completed"`
-	ModulesProgress  []ModuleProgressDetail `json:"modules_progress"`
-}
\ No newline at end of file
+	CourseID           uint                   `json:"course_id"`
+	CourseTitle        string                 `json:"course_title"`
+	TotalPercentage    float64                `json:"total_percentage"`
+	IsCompleted        bool                   `json:"is_completed"`
+	ReleasedPercentage float64                `json:"released_percentage"` // Avance sobre los módulos ya liberados
+	ModulesProgress    []ModuleProgressDetail `json:"modules_progress"`
+}
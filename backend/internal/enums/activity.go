@@ -0,0 +1,8 @@
+package enums
+
+type ActivityItemType string
+
+const (
+	ActivityItemContent    ActivityItemType = "content"
+	ActivityItemEvaluation ActivityItemType = "evaluation"
+)
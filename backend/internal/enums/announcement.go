@@ -0,0 +1,9 @@
+package enums
+
+type AnnouncementStatus string
+
+const (
+	AnnouncementStatusScheduled  AnnouncementStatus = "scheduled"  // Esperando su fecha de publicación
+	AnnouncementStatusPublishing AnnouncementStatus = "publishing" // Notificando a los inscritos
+	AnnouncementStatusPublished  AnnouncementStatus = "published"
+)
@@ -0,0 +1,19 @@
+package enums
+
+type AttachableType string
+
+const (
+	AttachableCourse   AttachableType = "course"
+	AttachableContent  AttachableType = "content"
+	AttachableQuestion AttachableType = "question"
+	AttachableAnswer   AttachableType = "answer"
+)
+
+type AttachmentRole string
+
+const (
+	AttachmentRoleCover        AttachmentRole = "cover"        // Imagen de portada del curso
+	AttachmentRoleMedia        AttachmentRole = "media"        // Recurso principal del contenido (video, audio, documento)
+	AttachmentRoleResource     AttachmentRole = "resource"     // Material descargable complementario
+	AttachmentRoleIllustration AttachmentRole = "illustration" // Imagen que acompaña una pregunta o respuesta
+)
@@ -0,0 +1,18 @@
+package enums
+
+type CourseLevel string
+
+const (
+	CourseLevelBeginner     CourseLevel = "beginner"
+	CourseLevelIntermediate CourseLevel = "intermediate"
+	CourseLevelAdvanced     CourseLevel = "advanced"
+)
+
+type CatalogSort string
+
+const (
+	CatalogSortPopular CatalogSort = "popular"
+	CatalogSortNewest  CatalogSort = "newest"
+	CatalogSortRating  CatalogSort = "rating"
+	CatalogSortTitle   CatalogSort = "title"
+)
@@ -0,0 +1,26 @@
+package enums
+
+type LatePenaltyPolicy string
+
+const (
+	LatePenaltyNone   LatePenaltyPolicy = "none"
+	LatePenaltyFlat   LatePenaltyPolicy = "flat"    // Descuenta LatePenaltyPercent una sola vez
+	LatePenaltyPerDay LatePenaltyPolicy = "per_day" // Descuenta LatePenaltyPercent por cada día o fracción de retraso
+)
+
+type DeadlineStatus string
+
+const (
+	DeadlineStatusNotOpen DeadlineStatus = "not_open"
+	DeadlineStatusOpen    DeadlineStatus = "open"
+	DeadlineStatusLate    DeadlineStatus = "late" // Vencida, se aceptan entregas tardías hasta el cierre
+	DeadlineStatusClosed  DeadlineStatus = "closed"
+)
+
+type DeadlineTargetType string
+
+const (
+	DeadlineTargetModule     DeadlineTargetType = "module"
+	DeadlineTargetEvaluation DeadlineTargetType = "evaluation"
+	DeadlineTargetCohort     DeadlineTargetType = "cohort" // Fecha límite de cohorte sin módulo ni evaluación
+)
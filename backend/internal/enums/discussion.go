@@ -0,0 +1,20 @@
+package enums
+
+// DiscussionTargetType identifies what a discussion vote points to
+type DiscussionTargetType string
+
+const (
+	DiscussionTargetThread DiscussionTargetType = "thread"
+	DiscussionTargetPost   DiscussionTargetType = "post"
+)
+
+type DiscussionModerationAction string
+
+const (
+	DiscussionActionPin    DiscussionModerationAction = "pin"    // Solo hilos
+	DiscussionActionUnpin  DiscussionModerationAction = "unpin"  // Solo hilos
+	DiscussionActionLock   DiscussionModerationAction = "lock"   // Solo hilos: no admite nuevas respuestas
+	DiscussionActionUnlock DiscussionModerationAction = "unlock" // Solo hilos
+	DiscussionActionHide   DiscussionModerationAction = "hide"
+	DiscussionActionUnhide DiscussionModerationAction = "unhide"
+)
@@ -0,0 +1,8 @@
+package enums
+
+type LearningPathGroupKind string
+
+const (
+	LearningPathGroupRequired LearningPathGroupKind = "required" // Se deben completar todos los cursos
+	LearningPathGroupElective LearningPathGroupKind = "elective" // Se deben completar al menos MinCourses cursos
+)
@@ -0,0 +1,9 @@
+package enums
+
+type NoteKind string
+
+const (
+	NoteKindNote      NoteKind = "note"
+	NoteKindHighlight NoteKind = "highlight"
+	NoteKindBookmark  NoteKind = "bookmark"
+)
@@ -3,5 +3,9 @@ package enums
 type NotificationType string
 
 const (
-	NotificationTypeBase NotificationType = "base"
+	NotificationTypeBase         NotificationType = "base"
+	NotificationTypeReview       NotificationType = "review"
+	NotificationTypeDiscussion   NotificationType = "discussion"
+	NotificationTypeAnnouncement NotificationType = "announcement"
+	NotificationTypeRelease      NotificationType = "release"
 )
@@ -4,6 +4,21 @@ package enums
 type QuestionType string
 
 const (
-	QuestionTypeSingle   QuestionType = "single_choice"
-	QuestionTypeMultiple QuestionType = "multiple_choice"
+	QuestionTypeSingle      QuestionType = "single_choice"
+	QuestionTypeMultiple    QuestionType = "multiple_choice"
+	QuestionTypeTrueFalse   QuestionType = "true_false"
+	QuestionTypeShortAnswer QuestionType = "short_answer"
+	QuestionTypeNumeric     QuestionType = "numeric"
+	QuestionTypeOrdering    QuestionType = "ordering"
+	QuestionTypeMatching    QuestionType = "matching"
+	QuestionTypeFillBlank   QuestionType = "fill_in_blank"
+)
+
+// QuestionDifficulty clasifica las preguntas de los bancos para las reglas de sorteo
+type QuestionDifficulty string
+
+const (
+	QuestionDifficultyEasy   QuestionDifficulty = "easy"
+	QuestionDifficultyMedium QuestionDifficulty = "medium"
+	QuestionDifficultyHard   QuestionDifficulty = "hard"
 )
@@ -0,0 +1,16 @@
+package enums
+
+type ReviewStatus string
+
+const (
+	ReviewStatusVisible ReviewStatus = "visible"
+	ReviewStatusHidden  ReviewStatus = "hidden"
+)
+
+type ReviewModerationAction string
+
+const (
+	ReviewActionHide    ReviewModerationAction = "hide"    // Oculta la reseña y resuelve los reportes
+	ReviewActionUnhide  ReviewModerationAction = "unhide"  // Vuelve a publicar una reseña oculta
+	ReviewActionDismiss ReviewModerationAction = "dismiss" // Descarta los reportes y mantiene la reseña visible
+)
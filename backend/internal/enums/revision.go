@@ -0,0 +1,10 @@
+package enums
+
+type RevisionEntityType string
+
+const (
+	RevisionEntityContent    RevisionEntityType = "content"
+	RevisionEntityEvaluation RevisionEntityType = "evaluation"
+	RevisionEntityQuestion   RevisionEntityType = "question"
+	RevisionEntityAnswer     RevisionEntityType = "answer"
+)
@@ -0,0 +1,17 @@
+package enums
+
+type ScoringPolicy string
+
+const (
+	ScoringAllOrNothing    ScoringPolicy = "all_or_nothing"    // Puntaje completo solo si la respuesta es correcta
+	ScoringPartialCredit   ScoringPolicy = "partial_credit"    // Proporcional a los aciertos, nunca negativo por pregunta
+	ScoringRightMinusWrong ScoringPolicy = "right_minus_wrong" // Los errores restan; el puntaje del intento no baja de cero
+)
+
+// ReliabilityMethod indica cómo se estimó la confiabilidad de una evaluación
+type ReliabilityMethod string
+
+const (
+	ReliabilityKR20          ReliabilityMethod = "kr20"           // Todas las preguntas se puntúan como correctas o incorrectas
+	ReliabilityCronbachAlpha ReliabilityMethod = "cronbach_alpha" // Alguna pregunta otorga puntaje parcial
+)
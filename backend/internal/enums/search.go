@@ -0,0 +1,28 @@
+package enums
+
+type SearchEntityType string
+
+const (
+	SearchEntityCourse     SearchEntityType = "course"
+	SearchEntityModule     SearchEntityType = "module"
+	SearchEntityContent    SearchEntityType = "content"
+	SearchEntityQuestion   SearchEntityType = "question"
+	SearchEntityTranscript SearchEntityType = "transcript"
+)
+
+type SearchLanguage string
+
+const (
+	SearchLanguageSpanish SearchLanguage = "es"
+	SearchLanguageEnglish SearchLanguage = "en"
+)
+
+// TextSearchConfig returns the Postgres text search configuration for the language
+func (l SearchLanguage) TextSearchConfig() string {
+	switch l {
+	case SearchLanguageEnglish:
+		return "english"
+	default:
+		return "spanish"
+	}
+}
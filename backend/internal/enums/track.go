@@ -0,0 +1,8 @@
+package enums
+
+type TrackKind string
+
+const (
+	TrackKindCaptions   TrackKind = "captions"   // Subtítulos sincronizados en WebVTT
+	TrackKindTranscript TrackKind = "transcript" // Transcripción en texto plano
+)
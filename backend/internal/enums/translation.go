@@ -0,0 +1,19 @@
+package enums
+
+type TranslationEntityType string
+
+const (
+	TranslationEntityCourse     TranslationEntityType = "course"
+	TranslationEntityModule     TranslationEntityType = "module"
+	TranslationEntityContent    TranslationEntityType = "content"
+	TranslationEntityEvaluation TranslationEntityType = "evaluation"
+	TranslationEntityQuestion   TranslationEntityType = "question"
+	TranslationEntityAnswer     TranslationEntityType = "answer"
+)
+
+type Locale string
+
+const (
+	LocaleSpanish Locale = "es"
+	LocaleEnglish Locale = "en"
+)
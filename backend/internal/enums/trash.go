@@ -0,0 +1,12 @@
+package enums
+
+type TrashEntityType string
+
+const (
+	TrashEntityCourse     TrashEntityType = "course"
+	TrashEntityModule     TrashEntityType = "module"
+	TrashEntityContent    TrashEntityType = "content"
+	TrashEntityEvaluation TrashEntityType = "evaluation"
+	TrashEntityQuestion   TrashEntityType = "question"
+	TrashEntityAnswer     TrashEntityType = "answer"
+)
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type ActivityHandler struct {
+	*Handler
+	activityService services.ActivityService
+}
+
+func NewActivityHandler(handler *Handler, activityService services.ActivityService) *ActivityHandler {
+	return &ActivityHandler{
+		Handler:         handler,
+		activityService: activityService,
+	}
+}
+
+// @Summary		Send activity heartbeat
+// @Router			/api/v1/activity/heartbeat [post]
+// @Description	Record that the learner is working on a content or evaluation. Heartbeats flagged as idle, or arriving after a long gap, close the current session without adding time
+// @Tags		activity
+// @Accept		json
+// @Produce		json
+// @Param		heartbeat	body	dto.ActivityHeartbeatRequest	true	"Activity heartbeat"
+// @Success		200	{object}	models.ActivitySession	"Current session"
+// @Success		204	"Idle heartbeat without an open session"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"User not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Item not found"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *ActivityHandler) RecordHeartbeat(c *gin.Context) {
+	var request dto.ActivityHeartbeatRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	session, err := h.activityService.RecordHeartbeat(currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al registrar la actividad")
+		return
+	}
+
+	if session == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	responses.Ok(c, session)
+}
+
+// @Summary		Get time spent this week
+// @Router			/api/v1/activity/week [get]
+// @Description	Get the authenticated learner's time on task since Monday, per day and per course
+// @Tags		activity
+// @Produce		json
+// @Param		course_id	query	int	false	"Restrict to a course"
+// @Success		200	{object}	dto.WeeklyActivity	"Weekly activity"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *ActivityHandler) GetWeeklyActivity(c *gin.Context) {
+	var courseID uint64
+	if value := c.Query("course_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			responses.ErrorBadRequest(c, "ID de curso inválido")
+			return
+		}
+		courseID = parsed
+	}
+
+	weekly, err := h.activityService.GetWeeklyActivity(currentUserID(c), uint(courseID))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la actividad semanal")
+		return
+	}
+
+	responses.Ok(c, weekly)
+}
+
+// @Summary		Get time on task per item
+// @Router			/api/v1/courses/{id}/time-on-task [get]
+// @Description	Median and average time learners spend on each content and evaluation of the course, longest first. Only for administrators and the instructors of the course cohorts
+// @Tags		activity
+// @Produce		json
+// @Param		id	path	int	true	"Course ID"
+// @Success		200	{array}		dto.ItemTimeOnTask	"Time on task per item"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *ActivityHandler) GetCourseTimeOnTask(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	items, err := h.activityService.GetCourseTimeOnTask(uint(courseID), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el tiempo por elemento")
+		return
+	}
+
+	responses.Ok(c, items)
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type AnnouncementHandler struct {
+	*Handler
+	announcementService services.AnnouncementService
+}
+
+func NewAnnouncementHandler(handler *Handler, announcementService services.AnnouncementService) *AnnouncementHandler {
+	return &AnnouncementHandler{
+		Handler:             handler,
+		announcementService: announcementService,
+	}
+}
+
+// @Summary		Get course announcements
+// @Router			/api/v1/courses/{id}/announcements [get]
+// @Description	Get the announcement feed of a course with the read state of the current user. Staff also see scheduled announcements
+// @Tags		announcements
+// @Produce		json
+// @Param		id		path	int		true	"Course ID"
+// @Param		limit	query	int		false	"Page size (max 100)"
+// @Param		offset	query	int		false	"Number of items to skip"
+// @Param		cursor	query	string	false	"Cursor returned by the previous page"
+// @Param		sort	query	string	false	"Sort field (publish_at, created_at), prefix with - for descending"
+// @Param		status	query	string	false	"Filter by status (scheduled, publishing, published)"
+// @Success		200	{object}	dto.Page[models.Announcement]	"Announcements"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Security     BearerAuth
+func (h *AnnouncementHandler) ListAnnouncements(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	announcements, err := h.announcementService.ListAnnouncements(uint(courseID), currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener los anuncios")
+		return
+	}
+
+	responses.Ok(c, announcements)
+}
+
+// @Summary		Create announcement
+// @Router			/api/v1/courses/{id}/announcements [post]
+// @Description	Publish an announcement to every course enrollee through in-app, SSE and push notifications. With a future publish_at it is scheduled instead
+// @Tags		announcements
+// @Accept		json
+// @Produce		json
+// @Param		id				path	int								true	"Course ID"
+// @Param		announcement	body	dto.CreateAnnouncementRequest	true	"Announcement data"
+// @Success		201	{object}	models.Announcement	"Announcement created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Course not found"
+// @Security     BearerAuth
+func (h *AnnouncementHandler) CreateAnnouncement(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	var request dto.CreateAnnouncementRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	announcement, err := h.announcementService.CreateAnnouncement(uint(courseID), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear el anuncio")
+		return
+	}
+
+	c.JSON(http.StatusCreated, announcement)
+}
+
+// @Summary		Get announcement
+// @Router			/api/v1/announcements/{id} [get]
+// @Description	Get an announcement with the read state of the current user
+// @Tags		announcements
+// @Produce		json
+// @Param		id	path	int	true	"Announcement ID"
+// @Success		200	{object}	models.Announcement	"Announcement"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Announcement not found"
+// @Security     BearerAuth
+func (h *AnnouncementHandler) GetAnnouncement(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de anuncio inválido")
+		return
+	}
+
+	announcement, err := h.announcementService.GetAnnouncement(uint(id), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el anuncio")
+		return
+	}
+
+	responses.Ok(c, announcement)
+}
+
+// @Summary		Update announcement
+// @Router			/api/v1/announcements/{id} [patch]
+// @Description	Edit or reschedule an announcement that has not been published yet
+// @Tags		announcements
+// @Accept		json
+// @Produce		json
+// @Param		id				path	int								true	"Announcement ID"
+// @Param		announcement	body	dto.UpdateAnnouncementRequest	true	"Fields to update"
+// @Success		200	{object}	models.Announcement	"Updated announcement"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Announcement not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Already published"
+// @Security     BearerAuth
+func (h *AnnouncementHandler) UpdateAnnouncementPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de anuncio inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	announcement, err := h.announcementService.UpdateAnnouncementPatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar el anuncio")
+		return
+	}
+
+	responses.Ok(c, announcement)
+}
+
+// @Summary		Delete announcement
+// @Router			/api/v1/announcements/{id} [delete]
+// @Description	Remove an announcement from the course feed. Notifications already delivered are kept
+// @Tags		announcements
+// @Produce		json
+// @Param		id	path	int	true	"Announcement ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Announcement not found"
+// @Security     BearerAuth
+func (h *AnnouncementHandler) DeleteAnnouncement(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de anuncio inválido")
+		return
+	}
+
+	if err := h.announcementService.DeleteAnnouncement(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar el anuncio")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Mark announcement as read
+// @Router			/api/v1/announcements/{id}/read [post]
+// @Description	Mark an announcement as read by the current user
+// @Tags		announcements
+// @Produce		json
+// @Param		id	path	int	true	"Announcement ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Announcement not found"
+// @Security     BearerAuth
+func (h *AnnouncementHandler) MarkAsRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de anuncio inválido")
+		return
+	}
+
+	if err := h.announcementService.MarkAsRead(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al marcar el anuncio como leído")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
@@ -45,12 +45,14 @@ func (h *AnswerHandler) CreateAnswer(c *gin.Context) {
 		IsCorrect:  answerReq.IsCorrect,
 		Order:      answerReq.Order,
 		QuestionID: answerReq.QuestionID,
+		MatchText:  answerReq.MatchText,
+		Tolerance:  answerReq.Tolerance,
+		Blank:      answerReq.Blank,
 	}
 
-	createdAnswer, err := h.answerService.CreateAnswer(answer)
+	createdAnswer, err := h.answerService.CreateAnswer(answer, currentUserID(c))
 	if err != nil {
-		h.logger.Errorf("Failed to create answer: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al crear la respuesta")
+		h.handleError(c, err, "Failed to create answer")
 		return
 	}
 
@@ -76,8 +78,7 @@ func (h *AnswerHandler) GetAnswer(c *gin.Context) {
 
 	answer, err := h.answerService.GetAnswer(uint(id))
 	if err != nil {
-		h.logger.Errorf("Failed to get answer: %v", err)
-		responses.ErrorNotFound(c, "Respuesta")
+		h.handleError(c, err, "Failed to get answer")
 		return
 	}
 
@@ -113,12 +114,14 @@ func (h *AnswerHandler) UpdateAnswer(c *gin.Context) {
 		Text:      answerReq.Text,
 		IsCorrect: answerReq.IsCorrect,
 		Order:     answerReq.Order,
+		MatchText: answerReq.MatchText,
+		Tolerance: answerReq.Tolerance,
+		Blank:     answerReq.Blank,
 	}
 
-	updatedAnswer, err := h.answerService.UpdateAnswer(uint(id), answer)
+	updatedAnswer, err := h.answerService.UpdateAnswer(uint(id), answer, currentUserID(c))
 	if err != nil {
-		h.logger.Errorf("Failed to update answer: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al actualizar la respuesta")
+		h.handleError(c, err, "Failed to update answer")
 		return
 	}
 
@@ -157,9 +160,9 @@ func (h *AnswerHandler) UpdateAnswerPatch(c *gin.Context) {
 		return
 	}
 
-	answer, err := h.answerService.UpdateAnswerPatch(uint(answerIDInt), payload)
+	answer, err := h.answerService.UpdateAnswerPatch(uint(answerIDInt), payload, currentUserID(c))
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, err.Error())
+		h.handleError(c, err, "Error al actualizar la respuesta")
 		return
 	}
 
@@ -167,7 +170,7 @@ func (h *AnswerHandler) UpdateAnswerPatch(c *gin.Context) {
 }
 
 // @Summary Delete answer
-// @Description Delete answer by ID
+// @Description Move an answer to the trash
 // @Tags answers
 // @Param id path int true "Answer ID"
 // @Success 204
@@ -184,8 +187,7 @@ func (h *AnswerHandler) DeleteAnswer(c *gin.Context) {
 
 	err = h.answerService.DeleteAnswer(uint(id))
 	if err != nil {
-		h.logger.Errorf("Failed to delete answer: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al eliminar la respuesta")
+		h.handleError(c, err, "Failed to delete answer")
 		return
 	}
 
@@ -197,7 +199,11 @@ func (h *AnswerHandler) DeleteAnswer(c *gin.Context) {
 // @Tags answers
 // @Produce json
 // @Param questionId path int true "Question ID"
-// @Success 200 {array} models.Answer
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Success 200 {object} dto.Page[models.Answer]
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/questions/{questionId}/answers [get]
@@ -209,10 +215,15 @@ func (h *AnswerHandler) GetAnswersByQuestion(c *gin.Context) {
 		return
 	}
 
-	answers, err := h.answerService.GetAnswersByQuestion(uint(questionID))
+	request, err := bindListRequest(c)
 	if err != nil {
-		h.logger.Errorf("Failed to get answers by question: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener las respuestas")
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	answers, err := h.answerService.ListAnswersByQuestion(uint(questionID), request)
+	if err != nil {
+		h.handleError(c, err, "Failed to get answers by question")
 		return
 	}
 
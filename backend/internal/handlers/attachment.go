@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type AttachmentHandler struct {
+	*Handler
+	attachmentService services.AttachmentService
+}
+
+func NewAttachmentHandler(handler *Handler, attachmentService services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		Handler:           handler,
+		attachmentService: attachmentService,
+	}
+}
+
+// @Summary		Get attachments
+// @Router			/api/v1/attachments/{type}/{id} [get]
+// @Description	Get the files attached to a course, content, question or answer, in display order
+// @Tags		attachments
+// @Produce		json
+// @Param		type	path	string	true	"Element type (course, content, question, answer)"
+// @Param		id		path	int		true	"Element ID"
+// @Success		200	{array}		models.Attachment	"Attachments"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	attachments, err := h.attachmentService.GetAttachments(enums.AttachableType(c.Param("type")), uint(id))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener los adjuntos")
+		return
+	}
+
+	responses.Ok(c, attachments)
+}
+
+// @Summary		Attach file
+// @Router			/api/v1/attachments/{type}/{id} [post]
+// @Description	Attach an uploaded file to a course, content, question or answer. Cover and media attachments also update the course image and content media URLs
+// @Tags		attachments
+// @Accept		json
+// @Produce		json
+// @Param		type		path	string							true	"Element type (course, content, question, answer)"
+// @Param		id			path	int								true	"Element ID"
+// @Param		attachment	body	dto.CreateAttachmentRequest		true	"Attachment data"
+// @Success		201	{object}	models.Attachment	"Attachment created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Element or file not found"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *AttachmentHandler) Attach(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	var request dto.CreateAttachmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	attachment, err := h.attachmentService.Attach(enums.AttachableType(c.Param("type")), uint(id), &request, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al adjuntar el archivo")
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// @Summary		Update attachment
+// @Router			/api/v1/attachments/{id} [patch]
+// @Description	Change the role or order of an attachment
+// @Tags		attachments
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int							true	"Attachment ID"
+// @Param		attachment	body	dto.UpdateAttachmentRequest	true	"Fields to update"
+// @Success		200	{object}	models.Attachment	"Updated attachment"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Attachment not found"
+// @Security     BearerAuth
+func (h *AttachmentHandler) UpdateAttachmentPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de adjunto inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	attachment, err := h.attachmentService.UpdateAttachmentPatch(uint(id), payload, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar el adjunto")
+		return
+	}
+
+	responses.Ok(c, attachment)
+}
+
+// @Summary		Detach file
+// @Router			/api/v1/attachments/{id} [delete]
+// @Description	Remove an attachment. Files left without attachments are deleted from storage after a grace period
+// @Tags		attachments
+// @Produce		json
+// @Param		id	path	int	true	"Attachment ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Attachment not found"
+// @Security     BearerAuth
+func (h *AttachmentHandler) Detach(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de adjunto inválido")
+		return
+	}
+
+	if err := h.attachmentService.Detach(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar el adjunto")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type CategoryHandler struct {
+	*Handler
+	categoryService services.CategoryService
+}
+
+func NewCategoryHandler(handler *Handler, categoryService services.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		Handler:         handler,
+		categoryService: categoryService,
+	}
+}
+
+// @Summary		Create category
+// @Router			/api/v1/categories [post]
+// @Description	Create a catalog category, optionally nested under a parent category
+// @Tags		categories
+// @Accept		json
+// @Produce		json
+// @Param		payload	body	dto.CreateCategoryRequest	true	"Category data"
+// @Success		201	{object}	models.Category	"Category created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var request dto.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(&request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear la categoría")
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// @Summary		Get category tree
+// @Router			/api/v1/categories [get]
+// @Description	Get the root categories with their subcategories nested
+// @Tags		categories
+// @Produce		json
+// @Success		200	{array}		models.Category	"Category tree"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	categories, err := h.categoryService.GetCategoryTree()
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las categorías")
+		return
+	}
+
+	responses.Ok(c, categories)
+}
+
+// @Summary		Get category by ID
+// @Router			/api/v1/categories/{id} [get]
+// @Description	Get a category by its ID
+// @Tags		categories
+// @Produce		json
+// @Param		id	path	int	true	"Category ID"
+// @Success		200	{object}	models.Category	"Category"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Category not found"
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de categoría inválido")
+		return
+	}
+
+	category, err := h.categoryService.GetCategory(uint(id))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la categoría")
+		return
+	}
+
+	responses.Ok(c, category)
+}
+
+// @Summary		Update category
+// @Router			/api/v1/categories/{id} [patch]
+// @Description	Update a category by ID
+// @Tags		categories
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Category ID"
+// @Param		payload	body	dto.UpdateCategoryRequest	true	"Category data"
+// @Success		200	{object}	models.Category	"Category updated successfully"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+func (h *CategoryHandler) UpdateCategoryPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de categoría inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategoryPatch(uint(id), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar la categoría")
+		return
+	}
+
+	responses.Ok(c, category)
+}
+
+// @Summary		Delete category
+// @Router			/api/v1/categories/{id} [delete]
+// @Description	Delete a category; its subcategories move to its parent and its courses are left uncategorized
+// @Tags		categories
+// @Param		id	path	int	true	"Category ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de categoría inválido")
+		return
+	}
+
+	if err := h.categoryService.DeleteCategory(uint(id)); err != nil {
+		h.handleError(c, err, "Error al eliminar la categoría")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
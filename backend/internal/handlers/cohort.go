@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type CohortHandler struct {
+	*Handler
+	cohortService services.CohortService
+}
+
+func NewCohortHandler(handler *Handler, cohortService services.CohortService) *CohortHandler {
+	return &CohortHandler{
+		Handler:       handler,
+		cohortService: cohortService,
+	}
+}
+
+// @Summary		Get course cohorts
+// @Router			/api/v1/courses/{id}/cohorts [get]
+// @Description	Get the cohorts of a course with their instructors
+// @Tags		cohorts
+// @Produce		json
+// @Param		id		path	int		true	"Course ID"
+// @Param		limit	query	int		false	"Page size (max 100)"
+// @Param		offset	query	int		false	"Number of items to skip"
+// @Param		cursor	query	string	false	"Cursor returned by the previous page"
+// @Param		sort	query	string	false	"Sort field (name, start_date, created_at), prefix with - for descending"
+// @Success		200	{object}	dto.Page[models.Cohort]	"Cohorts"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Security     BearerAuth
+func (h *CohortHandler) ListCohorts(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	cohorts, err := h.cohortService.ListCohorts(uint(courseID), currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las cohortes")
+		return
+	}
+
+	responses.Ok(c, cohorts)
+}
+
+// @Summary		Create cohort
+// @Router			/api/v1/courses/{id}/cohorts [post]
+// @Description	Create a cohort of a course with its own start date, instructors and schedule
+// @Tags		cohorts
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Course ID"
+// @Param		cohort	body	dto.CreateCohortRequest		true	"Cohort data"
+// @Success		201	{object}	models.Cohort	"Cohort created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Course not found"
+// @Security     BearerAuth
+func (h *CohortHandler) CreateCohort(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	var request dto.CreateCohortRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	cohort, err := h.cohortService.CreateCohort(uint(courseID), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear la cohorte")
+		return
+	}
+
+	c.JSON(http.StatusCreated, cohort)
+}
+
+// @Summary		Get cohort
+// @Router			/api/v1/cohorts/{id} [get]
+// @Description	Get a cohort with its instructors, schedule and deadlines. Learners can only see their own cohort
+// @Tags		cohorts
+// @Produce		json
+// @Param		id	path	int	true	"Cohort ID"
+// @Success		200	{object}	models.Cohort	"Cohort"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Cohort not found"
+// @Security     BearerAuth
+func (h *CohortHandler) GetCohort(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de cohorte inválido")
+		return
+	}
+
+	cohort, err := h.cohortService.GetCohort(uint(id), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la cohorte")
+		return
+	}
+
+	responses.Ok(c, cohort)
+}
+
+// @Summary		Update cohort
+// @Router			/api/v1/cohorts/{id} [patch]
+// @Description	Update the name, dates, schedule or instructors of a cohort
+// @Tags		cohorts
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Cohort ID"
+// @Param		cohort	body	dto.UpdateCohortRequest		true	"Fields to update"
+// @Success		200	{object}	models.Cohort	"Updated cohort"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Cohort not found"
+// @Security     BearerAuth
+func (h *CohortHandler) UpdateCohortPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de cohorte inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	cohort, err := h.cohortService.UpdateCohortPatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar la cohorte")
+		return
+	}
+
+	responses.Ok(c, cohort)
+}
+
+// @Summary		Delete cohort
+// @Router			/api/v1/cohorts/{id} [delete]
+// @Description	Delete a cohort. Its learners stay enrolled in the course without a cohort
+// @Tags		cohorts
+// @Produce		json
+// @Param		id	path	int	true	"Cohort ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Cohort not found"
+// @Security     BearerAuth
+func (h *CohortHandler) DeleteCohort(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de cohorte inválido")
+		return
+	}
+
+	if err := h.cohortService.DeleteCohort(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar la cohorte")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Create cohort deadline
+// @Router			/api/v1/cohorts/{id}/deadlines [post]
+// @Description	Add a cohort-specific deadline, optionally tied to a module or an evaluation of the course. Tied deadlines override its dates for the cohort
+// @Tags		cohorts
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int									true	"Cohort ID"
+// @Param		deadline	body	dto.CreateCohortDeadlineRequest		true	"Deadline data"
+// @Success		201	{object}	models.CohortDeadline	"Deadline created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Cohort not found"
+// @Security     BearerAuth
+func (h *CohortHandler) CreateDeadline(c *gin.Context) {
+	cohortID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de cohorte inválido")
+		return
+	}
+
+	var request dto.CreateCohortDeadlineRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	deadline, err := h.cohortService.CreateDeadline(uint(cohortID), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear la fecha límite")
+		return
+	}
+
+	c.JSON(http.StatusCreated, deadline)
+}
+
+// @Summary		Update cohort deadline
+// @Router			/api/v1/cohort-deadlines/{id} [patch]
+// @Description	Update the title or dates of a cohort deadline
+// @Tags		cohorts
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int									true	"Deadline ID"
+// @Param		deadline	body	dto.UpdateCohortDeadlineRequest		true	"Fields to update"
+// @Success		200	{object}	models.CohortDeadline	"Updated deadline"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Deadline not found"
+// @Security     BearerAuth
+func (h *CohortHandler) UpdateDeadlinePatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de fecha límite inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	deadline, err := h.cohortService.UpdateDeadlinePatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar la fecha límite")
+		return
+	}
+
+	responses.Ok(c, deadline)
+}
+
+// @Summary		Delete cohort deadline
+// @Router			/api/v1/cohort-deadlines/{id} [delete]
+// @Description	Delete a cohort deadline
+// @Tags		cohorts
+// @Produce		json
+// @Param		id	path	int	true	"Deadline ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Deadline not found"
+// @Security     BearerAuth
+func (h *CohortHandler) DeleteDeadline(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de fecha límite inválido")
+		return
+	}
+
+	if err := h.cohortService.DeleteDeadline(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar la fecha límite")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
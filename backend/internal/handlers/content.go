@@ -41,10 +41,9 @@ func (h *ContentHandler) CreateContent(c *gin.Context) {
 		return
 	}
 
-	createdContent, err := h.contentService.CreateContent(&content)
+	createdContent, err := h.contentService.CreateContent(&content, currentUserID(c))
 	if err != nil {
-		h.logger.Errorf("Failed to create content: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al crear el contenido")
+		h.handleError(c, err, "Failed to create content")
 		return
 	}
 
@@ -52,12 +51,14 @@ func (h *ContentHandler) CreateContent(c *gin.Context) {
 }
 
 // @Summary Get content
-// @Description Get content by ID
+// @Description Get content by ID. Contents of a module not released for the user fail with 403 MODULE_LOCKED
 // @Tags content
 // @Produce json
 // @Param id path int true "Content ID"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
 // @Success 200 {object} models.Content
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Router /api/v1/content/{id} [get]
 func (h *ContentHandler) GetContent(c *gin.Context) {
@@ -68,10 +69,9 @@ func (h *ContentHandler) GetContent(c *gin.Context) {
 		return
 	}
 
-	content, err := h.contentService.GetContent(uint(id))
+	content, err := h.contentService.GetContent(uint(id), currentUserID(c), requestLocale(c))
 	if err != nil {
-		h.logger.Errorf("Failed to get content: %v", err)
-		responses.ErrorNotFound(c, "Contenido")
+		h.handleError(c, err, "Failed to get content")
 		return
 	}
 
@@ -103,10 +103,9 @@ func (h *ContentHandler) UpdateContent(c *gin.Context) {
 		return
 	}
 
-	updatedContent, err := h.contentService.UpdateContent(uint(id), &content)
+	updatedContent, err := h.contentService.UpdateContent(uint(id), &content, currentUserID(c))
 	if err != nil {
-		h.logger.Errorf("Error al actualizar el contenido: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al actualizar el contenido")
+		h.handleError(c, err, "Error al actualizar el contenido")
 		return
 	}
 
@@ -145,9 +144,9 @@ func (h *ContentHandler) UpdateContentPatch(c *gin.Context) {
 		return
 	}
 
-	content, err := h.contentService.UpdateContentPatch(uint(contentIDInt), payload)
+	content, err := h.contentService.UpdateContentPatch(uint(contentIDInt), payload, currentUserID(c))
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, err.Error())
+		h.handleError(c, err, "Error al actualizar el contenido")
 		return
 	}
 
@@ -155,7 +154,7 @@ func (h *ContentHandler) UpdateContentPatch(c *gin.Context) {
 }
 
 // @Summary Delete content
-// @Description Delete content by ID
+// @Description Move content to the trash
 // @Tags content
 // @Param id path int true "Content ID"
 // @Success 204
@@ -172,8 +171,7 @@ func (h *ContentHandler) DeleteContent(c *gin.Context) {
 
 	err = h.contentService.DeleteContent(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al eliminar el contenido: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al eliminar el contenido")
+		h.handleError(c, err, "Error al eliminar el contenido")
 		return
 	}
 
@@ -185,7 +183,12 @@ func (h *ContentHandler) DeleteContent(c *gin.Context) {
 // @Tags content
 // @Produce json
 // @Param moduleId path int true "Module ID"
-// @Success 200 {array} models.Content
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
+// @Success 200 {object} dto.Page[models.Content]
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/modules/{moduleId}/content [get]
@@ -197,10 +200,15 @@ func (h *ContentHandler) GetContentsByModule(c *gin.Context) {
 		return
 	}
 
-	contents, err := h.contentService.GetContentsByModule(uint(moduleID))
+	request, err := bindListRequest(c)
 	if err != nil {
-		h.logger.Errorf("Error al obtener los contenidos by module: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener los contenidos")
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	contents, err := h.contentService.ListContentsByModule(uint(moduleID), currentUserID(c), requestLocale(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener los contenidos by module")
 		return
 	}
 
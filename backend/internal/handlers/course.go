@@ -5,8 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	_ "github.com/imlargo/go-api-template/internal/dto"
-	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/internal/dto"
 	"github.com/imlargo/go-api-template/internal/responses"
 	"github.com/imlargo/go-api-template/internal/services"
 )
@@ -28,22 +27,21 @@ func NewCourseHandler(handler *Handler, courseService services.CourseService) *C
 // @Tags courses
 // @Accept json
 // @Produce json
-// @Param course body models.Course true "Course data"
+// @Param course body dto.CreateCourseRequest true "Course data"
 // @Success 201 {object} models.Course
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/courses [post]
 func (h *CourseHandler) CreateCourse(c *gin.Context) {
-	var course models.Course
-	if err := c.ShouldBindJSON(&course); err != nil {
+	var request dto.CreateCourseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
 		responses.ErrorBindJson(c, err)
 		return
 	}
 
-	createdCourse, err := h.courseService.CreateCourse(&course)
+	createdCourse, err := h.courseService.CreateCourse(&request)
 	if err != nil {
-		h.logger.Errorf("Error al crear el curso: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al crear el curso")
+		h.handleError(c, err, "Error al crear el curso")
 		return
 	}
 
@@ -55,6 +53,7 @@ func (h *CourseHandler) CreateCourse(c *gin.Context) {
 // @Tags courses
 // @Produce json
 // @Param id path int true "Course ID"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
 // @Success 200 {object} models.Course
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
@@ -67,10 +66,9 @@ func (h *CourseHandler) GetCourse(c *gin.Context) {
 		return
 	}
 
-	course, err := h.courseService.GetCourse(uint(id))
+	course, err := h.courseService.GetCourse(uint(id), currentUserID(c), requestLocale(c))
 	if err != nil {
-		h.logger.Errorf("Error al obtener el curso: %v", err)
-		responses.ErrorNotFound(c, "Curso")
+		h.handleError(c, err, "Error al obtener el curso")
 		return
 	}
 
@@ -83,7 +81,7 @@ func (h *CourseHandler) GetCourse(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Course ID"
-// @Param course body models.Course true "Course data"
+// @Param course body dto.ReplaceCourseRequest true "Course data"
 // @Success 200 {object} models.Course
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
@@ -97,16 +95,15 @@ func (h *CourseHandler) UpdateCourse(c *gin.Context) {
 		return
 	}
 
-	var course models.Course
-	if err := c.ShouldBindJSON(&course); err != nil {
+	var request dto.ReplaceCourseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
 		responses.ErrorBindJson(c, err)
 		return
 	}
 
-	updatedCourse, err := h.courseService.UpdateCourse(uint(id), &course)
+	updatedCourse, err := h.courseService.UpdateCourse(uint(id), &request)
 	if err != nil {
-		h.logger.Errorf("Error al actualizar el curso: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al actualizar el curso")
+		h.handleError(c, err, "Error al actualizar el curso")
 		return
 	}
 
@@ -147,7 +144,7 @@ func (h *CourseHandler) UpdateCoursePatch(c *gin.Context) {
 
 	course, err := h.courseService.UpdateCoursePatch(uint(courseIDInt), payload)
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, err.Error())
+		h.handleError(c, err, "Error al actualizar el curso")
 		return
 	}
 
@@ -155,7 +152,7 @@ func (h *CourseHandler) UpdateCoursePatch(c *gin.Context) {
 }
 
 // @Summary Delete course
-// @Description Delete a course by its ID
+// @Description Move a course and all its material to the trash
 // @Tags courses
 // @Param id path int true "Course ID"
 // @Success 204
@@ -173,8 +170,7 @@ func (h *CourseHandler) DeleteCourse(c *gin.Context) {
 
 	err = h.courseService.DeleteCourse(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al eliminar el curso: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al eliminar el curso")
+		h.handleError(c, err, "Error al eliminar el curso")
 		return
 	}
 
@@ -185,14 +181,24 @@ func (h *CourseHandler) DeleteCourse(c *gin.Context) {
 // @Description Get all courses
 // @Tags courses
 // @Produce json
-// @Success 200 {array} models.Course
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
+// @Success 200 {object} dto.Page[models.Course]
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/courses [get]
 func (h *CourseHandler) GetAllCourses(c *gin.Context) {
-	courses, err := h.courseService.GetAllCourses()
+	request, err := bindListRequest(c)
 	if err != nil {
-		h.logger.Errorf("Error al obtener el cursos: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener el cursos")
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	courses, err := h.courseService.ListCourses(currentUserID(c), requestLocale(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el cursos")
 		return
 	}
 
@@ -218,10 +224,78 @@ func (h *CourseHandler) GetCourseWithModules(c *gin.Context) {
 
 	course, err := h.courseService.GetCourseWithModules(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al obtener el curso with modules: %v", err)
-		responses.ErrorNotFound(c, "Curso")
+		h.handleError(c, err, "Error al obtener el curso with modules")
 		return
 	}
 
 	responses.Ok(c, course)
 }
+
+// @Summary		Get course catalog
+// @Router			/api/v1/catalog [get]
+// @Description	Get the published courses filtered by category, tags, level, language and duration, with facet counts
+// @Tags		courses
+// @Produce		json
+// @Param		q				query	string		false	"Text to match in title or short description"
+// @Param		category_id		query	int			false	"Category ID (includes subcategories)"
+// @Param		tags			query	[]string	false	"Tag slugs"
+// @Param		level			query	[]string	false	"Course levels (beginner, intermediate, advanced)"
+// @Param		language		query	[]string	false	"Course languages"
+// @Param		min_duration	query	int			false	"Minimum estimated duration in minutes"
+// @Param		max_duration	query	int			false	"Maximum estimated duration in minutes"
+// @Param		min_rating		query	number		false	"Minimum average rating (1-5)"
+// @Param		sort			query	string		false	"Sort (popular, newest, rating, title)"
+// @Param		limit			query	int			false	"Maximum number of courses"
+// @Param		offset			query	int			false	"Number of courses to skip"
+// @Success		200	{object}	dto.CatalogResponse	"Course catalog"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *CourseHandler) GetCatalog(c *gin.Context) {
+	var request dto.CatalogRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		responses.ErrorBadRequest(c, "Parámetros del catálogo inválidos: "+err.Error())
+		return
+	}
+
+	catalog, err := h.courseService.GetCatalog(&request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el catálogo")
+		return
+	}
+
+	responses.Ok(c, catalog)
+}
+
+// @Summary		Set course tags
+// @Router			/api/v1/courses/{id}/tags [put]
+// @Description	Replace the tags of a course, creating the tags that do not exist yet
+// @Tags		courses
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Course ID"
+// @Param		payload	body	dto.SetCourseTagsRequest	true	"Tag names"
+// @Success		200	{array}		models.Tag	"Course tags"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *CourseHandler) SetCourseTags(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	var request dto.SetCourseTagsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	tags, err := h.courseService.SetCourseTags(uint(id), request.Tags)
+	if err != nil {
+		h.handleError(c, err, "Error al asignar las etiquetas del curso")
+		return
+	}
+
+	responses.Ok(c, tags)
+}
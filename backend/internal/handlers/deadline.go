@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type DeadlineHandler struct {
+	*Handler
+	deadlineService services.DeadlineService
+}
+
+func NewDeadlineHandler(handler *Handler, deadlineService services.DeadlineService) *DeadlineHandler {
+	return &DeadlineHandler{
+		Handler:         handler,
+		deadlineService: deadlineService,
+	}
+}
+
+// @Summary		Get upcoming deadlines
+// @Router			/api/v1/deadlines/upcoming [get]
+// @Description	Get the modules, evaluations and cohort deadlines of the current user that are not closed yet, soonest first, with the cohort and personal overrides applied
+// @Tags		deadlines
+// @Produce		json
+// @Param		course_id	query	int	false	"Restrict to a course"
+// @Success		200	{array}		dto.UpcomingDeadline	"Upcoming deadlines"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Security     BearerAuth
+func (h *DeadlineHandler) GetUpcomingDeadlines(c *gin.Context) {
+	var courseID uint64
+	if value := c.Query("course_id"); value != "" {
+		var err error
+		courseID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			responses.ErrorBadRequest(c, "ID de curso inválido")
+			return
+		}
+	}
+
+	deadlines, err := h.deadlineService.GetUpcomingDeadlines(currentUserID(c), uint(courseID))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las fechas límite")
+		return
+	}
+
+	responses.Ok(c, deadlines)
+}
+
+// @Summary		Get evaluation window
+// @Router			/api/v1/evaluations/{id}/window [get]
+// @Description	Get the open, due and close dates of an evaluation that apply to the current user and its current status (not_open, open, late, closed)
+// @Tags		deadlines
+// @Produce		json
+// @Param		id	path	int	true	"Evaluation ID"
+// @Success		200	{object}	dto.DeadlineWindow	"Evaluation window"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Evaluation not found"
+// @Security     BearerAuth
+func (h *DeadlineHandler) GetEvaluationWindow(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de evaluación inválido")
+		return
+	}
+
+	window, err := h.deadlineService.GetUserEvaluationWindow(currentUserID(c), uint(id))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las fechas de la evaluación")
+		return
+	}
+
+	responses.Ok(c, window)
+}
+
+// @Summary		Create deadline override
+// @Router			/api/v1/deadline-overrides [post]
+// @Description	Give a learner their own open, due or close dates for a module or an evaluation, e.g. an extension
+// @Tags		deadlines
+// @Accept		json
+// @Produce		json
+// @Param		override	body	dto.CreateDeadlineOverrideRequest	true	"Override data (module_id or evaluation_id)"
+// @Success		201	{object}	models.UserDeadlineOverride	"Override created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"User, module or evaluation not found"
+// @Security     BearerAuth
+func (h *DeadlineHandler) CreateOverride(c *gin.Context) {
+	var request dto.CreateDeadlineOverrideRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	override, err := h.deadlineService.CreateOverride(currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear la excepción de fechas")
+		return
+	}
+
+	c.JSON(http.StatusCreated, override)
+}
+
+// @Summary		Update deadline override
+// @Router			/api/v1/deadline-overrides/{id} [patch]
+// @Description	Update the dates or reason of a personal deadline override. A null date is inherited again
+// @Tags		deadlines
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int									true	"Override ID"
+// @Param		override	body	dto.UpdateDeadlineOverrideRequest	true	"Fields to update"
+// @Success		200	{object}	models.UserDeadlineOverride	"Updated override"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Override not found"
+// @Security     BearerAuth
+func (h *DeadlineHandler) UpdateOverridePatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de excepción inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	override, err := h.deadlineService.UpdateOverridePatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar la excepción de fechas")
+		return
+	}
+
+	responses.Ok(c, override)
+}
+
+// @Summary		Delete deadline override
+// @Router			/api/v1/deadline-overrides/{id} [delete]
+// @Description	Delete a personal deadline override
+// @Tags		deadlines
+// @Produce		json
+// @Param		id	path	int	true	"Override ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Override not found"
+// @Security     BearerAuth
+func (h *DeadlineHandler) DeleteOverride(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de excepción inválido")
+		return
+	}
+
+	if err := h.deadlineService.DeleteOverride(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar la excepción de fechas")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Get user deadline overrides
+// @Router			/api/v1/users/{userId}/deadline-overrides [get]
+// @Description	Get the personal deadline overrides of a learner
+// @Tags		deadlines
+// @Produce		json
+// @Param		userId			path	int		true	"User ID"
+// @Param		limit			query	int		false	"Page size (max 100)"
+// @Param		offset			query	int		false	"Number of items to skip"
+// @Param		cursor			query	string	false	"Cursor returned by the previous page"
+// @Param		sort			query	string	false	"Sort field (created_at, due_at), prefix with - for descending"
+// @Param		module_id		query	int		false	"Filter by module"
+// @Param		evaluation_id	query	int		false	"Filter by evaluation"
+// @Success		200	{object}	dto.Page[models.UserDeadlineOverride]	"Overrides"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Security     BearerAuth
+func (h *DeadlineHandler) ListUserOverrides(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de usuario inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	overrides, err := h.deadlineService.ListUserOverrides(uint(userID), currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las excepciones de fechas")
+		return
+	}
+
+	responses.Ok(c, overrides)
+}
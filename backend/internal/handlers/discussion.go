@@ -0,0 +1,511 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type DiscussionHandler struct {
+	*Handler
+	discussionService services.DiscussionService
+}
+
+func NewDiscussionHandler(handler *Handler, discussionService services.DiscussionService) *DiscussionHandler {
+	return &DiscussionHandler{
+		Handler:           handler,
+		discussionService: discussionService,
+	}
+}
+
+// @Summary		Get course discussions
+// @Router			/api/v1/courses/{id}/discussions [get]
+// @Description	Get the discussion threads of a course. Only enrolled learners and course staff can read them
+// @Tags		discussions
+// @Produce		json
+// @Param		id			path	int		true	"Course ID"
+// @Param		limit		query	int		false	"Page size (max 100)"
+// @Param		offset		query	int		false	"Number of items to skip"
+// @Param		cursor		query	string	false	"Cursor returned by the previous page"
+// @Param		sort		query	string	false	"Sort field (last_activity_at, created_at, upvote_count, reply_count), prefix with - for descending"
+// @Param		content_id	query	int		false	"Filter by content"
+// @Param		is_pinned	query	bool	false	"Filter pinned threads"
+// @Param		is_answered	query	bool	false	"Filter threads with an endorsed answer"
+// @Success		200	{object}	dto.Page[models.DiscussionThread]	"Threads"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Security     BearerAuth
+func (h *DiscussionHandler) ListCourseThreads(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	threads, err := h.discussionService.ListCourseThreads(uint(courseID), currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las discusiones")
+		return
+	}
+
+	responses.Ok(c, threads)
+}
+
+// @Summary		Get content discussions
+// @Router			/api/v1/content/{id}/discussions [get]
+// @Description	Get the discussion threads about a specific content
+// @Tags		discussions
+// @Produce		json
+// @Param		id		path	int		true	"Content ID"
+// @Param		limit	query	int		false	"Page size (max 100)"
+// @Param		offset	query	int		false	"Number of items to skip"
+// @Param		cursor	query	string	false	"Cursor returned by the previous page"
+// @Param		sort	query	string	false	"Sort field (last_activity_at, created_at, upvote_count, reply_count), prefix with - for descending"
+// @Success		200	{object}	dto.Page[models.DiscussionThread]	"Threads"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Content not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) ListContentThreads(c *gin.Context) {
+	contentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de contenido inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	threads, err := h.discussionService.ListContentThreads(uint(contentID), currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las discusiones")
+		return
+	}
+
+	responses.Ok(c, threads)
+}
+
+// @Summary		Create discussion thread
+// @Router			/api/v1/courses/{id}/discussions [post]
+// @Description	Open a discussion thread in a course, optionally about one of its contents. Mentioned users are notified
+// @Tags		discussions
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int										true	"Course ID"
+// @Param		thread	body	dto.CreateDiscussionThreadRequest		true	"Thread data"
+// @Success		201	{object}	models.DiscussionThread	"Thread created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Course not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) CreateThread(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	var request dto.CreateDiscussionThreadRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	thread, err := h.discussionService.CreateThread(uint(courseID), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear el hilo")
+		return
+	}
+
+	c.JSON(http.StatusCreated, thread)
+}
+
+// @Summary		Get discussion thread
+// @Router			/api/v1/discussions/{id} [get]
+// @Description	Get a thread with its replies as a tree. Endorsed answers come first
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Thread ID"
+// @Success		200	{object}	models.DiscussionThread	"Thread"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Thread not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) GetThread(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de hilo inválido")
+		return
+	}
+
+	thread, err := h.discussionService.GetThread(uint(id), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el hilo")
+		return
+	}
+
+	responses.Ok(c, thread)
+}
+
+// @Summary		Update discussion thread
+// @Router			/api/v1/discussions/{id} [patch]
+// @Description	Edit the title, body or mentions of a thread. Newly mentioned users are notified
+// @Tags		discussions
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int										true	"Thread ID"
+// @Param		thread	body	dto.UpdateDiscussionThreadRequest		true	"Fields to update"
+// @Success		200	{object}	models.DiscussionThread	"Updated thread"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Thread not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Thread locked"
+// @Security     BearerAuth
+func (h *DiscussionHandler) UpdateThreadPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de hilo inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	thread, err := h.discussionService.UpdateThreadPatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar el hilo")
+		return
+	}
+
+	responses.Ok(c, thread)
+}
+
+// @Summary		Delete discussion thread
+// @Router			/api/v1/discussions/{id} [delete]
+// @Description	Delete a thread and all its replies. Authors delete their own threads; staff can delete any
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Thread ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Thread not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) DeleteThread(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de hilo inválido")
+		return
+	}
+
+	if err := h.discussionService.DeleteThread(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar el hilo")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Moderate discussion thread
+// @Router			/api/v1/discussions/{id}/moderate [post]
+// @Description	Pin, unpin, lock, unlock, hide or unhide a thread. Course staff only
+// @Tags		discussions
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int								true	"Thread ID"
+// @Param		moderation	body	dto.ModerateDiscussionRequest	true	"Moderation action"
+// @Success		200	{object}	models.DiscussionThread	"Thread"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Thread not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) ModerateThread(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de hilo inválido")
+		return
+	}
+
+	var request dto.ModerateDiscussionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	thread, err := h.discussionService.ModerateThread(uint(id), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al moderar el hilo")
+		return
+	}
+
+	responses.Ok(c, thread)
+}
+
+// @Summary		Reply to discussion
+// @Router			/api/v1/discussions/{id}/posts [post]
+// @Description	Reply to a thread or, with parent_id, to another reply. Participants and mentioned users are notified
+// @Tags		discussions
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int									true	"Thread ID"
+// @Param		post	body	dto.CreateDiscussionPostRequest		true	"Reply data"
+// @Success		201	{object}	models.DiscussionPost	"Reply created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Thread not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Thread locked"
+// @Security     BearerAuth
+func (h *DiscussionHandler) CreatePost(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de hilo inválido")
+		return
+	}
+
+	var request dto.CreateDiscussionPostRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	post, err := h.discussionService.CreatePost(uint(id), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear la respuesta")
+		return
+	}
+
+	c.JSON(http.StatusCreated, post)
+}
+
+// @Summary		Update discussion reply
+// @Router			/api/v1/discussion-posts/{id} [patch]
+// @Description	Edit the body or mentions of a reply
+// @Tags		discussions
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int									true	"Reply ID"
+// @Param		post	body	dto.UpdateDiscussionPostRequest		true	"Fields to update"
+// @Success		200	{object}	models.DiscussionPost	"Updated reply"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Reply not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Thread locked"
+// @Security     BearerAuth
+func (h *DiscussionHandler) UpdatePostPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de respuesta inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	post, err := h.discussionService.UpdatePostPatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar la respuesta")
+		return
+	}
+
+	responses.Ok(c, post)
+}
+
+// @Summary		Delete discussion reply
+// @Router			/api/v1/discussion-posts/{id} [delete]
+// @Description	Delete a reply and its nested replies
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Reply ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Reply not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) DeletePost(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de respuesta inválido")
+		return
+	}
+
+	if err := h.discussionService.DeletePost(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar la respuesta")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Moderate discussion reply
+// @Router			/api/v1/discussion-posts/{id}/moderate [post]
+// @Description	Hide or unhide a reply. Course staff only
+// @Tags		discussions
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int								true	"Reply ID"
+// @Param		moderation	body	dto.ModerateDiscussionRequest	true	"Moderation action (hide, unhide)"
+// @Success		200	{object}	models.DiscussionPost	"Reply"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Reply not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) ModeratePost(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de respuesta inválido")
+		return
+	}
+
+	var request dto.ModerateDiscussionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	post, err := h.discussionService.ModeratePost(uint(id), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al moderar la respuesta")
+		return
+	}
+
+	responses.Ok(c, post)
+}
+
+// @Summary		Endorse discussion reply
+// @Router			/api/v1/discussion-posts/{id}/endorse [post]
+// @Description	Mark a reply as an answer endorsed by the course staff. The author is notified
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Reply ID"
+// @Success		200	{object}	models.DiscussionPost	"Reply"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Reply not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) EndorsePost(c *gin.Context) {
+	h.setPostEndorsed(c, true)
+}
+
+// @Summary		Remove discussion reply endorsement
+// @Router			/api/v1/discussion-posts/{id}/endorse [delete]
+// @Description	Remove the staff endorsement of a reply
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Reply ID"
+// @Success		200	{object}	models.DiscussionPost	"Reply"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Reply not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) UnendorsePost(c *gin.Context) {
+	h.setPostEndorsed(c, false)
+}
+
+func (h *DiscussionHandler) setPostEndorsed(c *gin.Context, endorsed bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de respuesta inválido")
+		return
+	}
+
+	post, err := h.discussionService.SetPostEndorsed(uint(id), currentUserID(c), endorsed)
+	if err != nil {
+		h.handleError(c, err, "Error al avalar la respuesta")
+		return
+	}
+
+	responses.Ok(c, post)
+}
+
+// @Summary		Upvote discussion thread
+// @Router			/api/v1/discussions/{id}/upvote [post]
+// @Description	Upvote a thread. Repeated upvotes are ignored
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Thread ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Thread not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) UpvoteThread(c *gin.Context) {
+	h.setVote(c, enums.DiscussionTargetThread, true)
+}
+
+// @Summary		Remove discussion thread upvote
+// @Router			/api/v1/discussions/{id}/upvote [delete]
+// @Description	Withdraw the upvote of a thread
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Thread ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Thread not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) RemoveThreadUpvote(c *gin.Context) {
+	h.setVote(c, enums.DiscussionTargetThread, false)
+}
+
+// @Summary		Upvote discussion reply
+// @Router			/api/v1/discussion-posts/{id}/upvote [post]
+// @Description	Upvote a reply. Repeated upvotes are ignored
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Reply ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Reply not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) UpvotePost(c *gin.Context) {
+	h.setVote(c, enums.DiscussionTargetPost, true)
+}
+
+// @Summary		Remove discussion reply upvote
+// @Router			/api/v1/discussion-posts/{id}/upvote [delete]
+// @Description	Withdraw the upvote of a reply
+// @Tags		discussions
+// @Produce		json
+// @Param		id	path	int	true	"Reply ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Reply not found"
+// @Security     BearerAuth
+func (h *DiscussionHandler) RemovePostUpvote(c *gin.Context) {
+	h.setVote(c, enums.DiscussionTargetPost, false)
+}
+
+func (h *DiscussionHandler) setVote(c *gin.Context, targetType enums.DiscussionTargetType, upvote bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	if err := h.discussionService.SetVote(targetType, uint(id), currentUserID(c), upvote); err != nil {
+		h.handleError(c, err, "Error al registrar el voto")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
@@ -5,7 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	_ "github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/dto"
 	_ "github.com/imlargo/go-api-template/internal/models"
 
 	"github.com/imlargo/go-api-template/internal/responses"
@@ -47,12 +47,7 @@ func (h *EnrollmentHandler) CreateEnrollment(c *gin.Context) {
 
 	enrollment, err := h.enrollmentService.CreateEnrollment(enrollmentData.UserID, enrollmentData.CourseID)
 	if err != nil {
-		h.logger.Errorf("Error al crear la inscripción: %v", err)
-		if err.Error() == "el usuario ya está inscrito en este curso" {
-			responses.ErrorConflict(c, err.Error())
-			return
-		}
-		responses.ErrorInternalServerWithMessage(c, "Error al crear la inscripción")
+		h.handleError(c, err, "Error al crear la inscripción")
 		return
 	}
 
@@ -78,8 +73,7 @@ func (h *EnrollmentHandler) GetEnrollment(c *gin.Context) {
 
 	enrollment, err := h.enrollmentService.GetEnrollment(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al obtener la inscripción: %v", err)
-		responses.ErrorNotFound(c, "Inscripción")
+		h.handleError(c, err, "Error al obtener la inscripción")
 		return
 	}
 
@@ -105,8 +99,7 @@ func (h *EnrollmentHandler) GetEnrollmentWithDetails(c *gin.Context) {
 
 	enrollment, err := h.enrollmentService.GetEnrollmentWithPreloads(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al obtener la inscripción con detalles: %v", err)
-		responses.ErrorNotFound(c, "Inscripción")
+		h.handleError(c, err, "Error al obtener la inscripción con detalles")
 		return
 	}
 
@@ -147,7 +140,7 @@ func (h *EnrollmentHandler) UpdateEnrollmentPatch(c *gin.Context) {
 
 	enrollment, err := h.enrollmentService.UpdateEnrollmentPatch(uint(enrollmentIDInt), payload)
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, err.Error())
+		h.handleError(c, err, "Error al actualizar la inscripción")
 		return
 	}
 
@@ -173,8 +166,7 @@ func (h *EnrollmentHandler) DeleteEnrollment(c *gin.Context) {
 
 	err = h.enrollmentService.DeleteEnrollment(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al eliminar la inscripción: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al eliminar la inscripción")
+		h.handleError(c, err, "Error al eliminar la inscripción")
 		return
 	}
 
@@ -186,7 +178,11 @@ func (h *EnrollmentHandler) DeleteEnrollment(c *gin.Context) {
 // @Tags enrollments
 // @Produce json
 // @Param userId path int true "User ID"
-// @Success 200 {array} models.Enrollment "List of enrollments with preloaded user and course data"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Success 200 {object} dto.Page[models.Enrollment] "List of enrollments with preloaded user and course data"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/users/{userId}/enrollments [get]
@@ -198,10 +194,15 @@ func (h *EnrollmentHandler) GetUserEnrollments(c *gin.Context) {
 		return
 	}
 
-	enrollments, err := h.enrollmentService.GetUserEnrollments(uint(userID))
+	request, err := bindListRequest(c)
 	if err != nil {
-		h.logger.Errorf("Failed to get user enrollments: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener la inscripcións")
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	enrollments, err := h.enrollmentService.ListUserEnrollments(uint(userID), request)
+	if err != nil {
+		h.handleError(c, err, "Failed to get user enrollments")
 		return
 	}
 
@@ -213,7 +214,12 @@ func (h *EnrollmentHandler) GetUserEnrollments(c *gin.Context) {
 // @Tags enrollments
 // @Produce json
 // @Param id path int true "Course ID"
-// @Success 200 {array} models.Enrollment "List of enrollments with preloaded user and course data"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Param cohort_id query int false "Filter by cohort"
+// @Success 200 {object} dto.Page[models.Enrollment] "List of enrollments with preloaded user and course data"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/courses/{id}/enrollments [get]
@@ -225,10 +231,15 @@ func (h *EnrollmentHandler) GetCourseEnrollments(c *gin.Context) {
 		return
 	}
 
-	enrollments, err := h.enrollmentService.GetCourseEnrollments(uint(courseID))
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	enrollments, err := h.enrollmentService.ListCourseEnrollments(uint(courseID), request)
 	if err != nil {
-		h.logger.Errorf("Error al obtener el curso enrollments: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener la inscripcións")
+		h.handleError(c, err, "Error al obtener el curso enrollments")
 		return
 	}
 
@@ -263,8 +274,7 @@ func (h *EnrollmentHandler) GetUserCourseEnrollment(c *gin.Context) {
 
 	enrollment, err := h.enrollmentService.GetUserCourseEnrollment(uint(userID), uint(courseID))
 	if err != nil {
-		h.logger.Errorf("Failed to get user course enrollment: %v", err)
-		responses.ErrorNotFound(c, "Inscripción")
+		h.handleError(c, err, "Failed to get user course enrollment")
 		return
 	}
 
@@ -298,12 +308,7 @@ func (h *EnrollmentHandler) CompleteEnrollment(c *gin.Context) {
 
 	err = h.enrollmentService.CompleteEnrollment(uint(userID), uint(courseID))
 	if err != nil {
-		h.logger.Errorf("Failed to complete enrollment: %v", err)
-		if err.Error() == "enrollment not found" {
-			responses.ErrorNotFound(c, "Inscripción")
-			return
-		}
-		responses.ErrorInternalServerWithMessage(c, "Failed to complete enrollment")
+		h.handleError(c, err, "Failed to complete enrollment")
 		return
 	}
 
@@ -347,12 +352,7 @@ func (h *EnrollmentHandler) UpdateProgress(c *gin.Context) {
 
 	err = h.enrollmentService.UpdateProgress(uint(userID), uint(courseID), progressData.Progress)
 	if err != nil {
-		h.logger.Errorf("Error al actualizar la inscripción progress: %v", err)
-		if err.Error() == "enrollment not found" {
-			responses.ErrorNotFound(c, "Inscripción")
-			return
-		}
-		responses.ErrorInternalServerWithMessage(c, "Failed to update progress")
+		h.handleError(c, err, "Error al actualizar la inscripción progress")
 		return
 	}
 
@@ -360,10 +360,11 @@ func (h *EnrollmentHandler) UpdateProgress(c *gin.Context) {
 }
 
 // @Summary Get course KPIs for admin dashboard
-// @Description Get KPI metrics for a specific course including student count, completion rate, and average progress
+// @Description Get KPI metrics for a specific course including student count, completion rate, and average progress. Pass cohort_id to restrict them to a cohort
 // @Tags enrollments
 // @Produce json
 // @Param id path int true "Course ID"
+// @Param cohort_id query int false "Cohort ID"
 // @Success 200 {object} dto.CourseKPIResponse "Course KPI metrics"
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
@@ -377,12 +378,113 @@ func (h *EnrollmentHandler) GetCourseKPIs(c *gin.Context) {
 		return
 	}
 
-	kpis, err := h.enrollmentService.GetCourseKPIs(uint(courseID))
+	var cohortID uint64
+	if value := c.Query("cohort_id"); value != "" {
+		cohortID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			responses.ErrorBadRequest(c, "ID de cohorte inválido")
+			return
+		}
+	}
+
+	kpis, err := h.enrollmentService.GetCourseKPIs(uint(courseID), uint(cohortID))
 	if err != nil {
-		h.logger.Errorf("Error al obtener KPIs del curso: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener las métricas del curso")
+		h.handleError(c, err, "Error al obtener KPIs del curso")
 		return
 	}
 
 	responses.Ok(c, kpis)
 }
+
+// @Summary Get course KPIs by cohort
+// @Description Get the KPI metrics of every cohort of a course
+// @Tags enrollments
+// @Produce json
+// @Param id path int true "Course ID"
+// @Success 200 {array} dto.CourseKPIResponse "KPI metrics per cohort"
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/courses/{id}/kpis/cohorts [get]
+func (h *EnrollmentHandler) GetCohortKPIs(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	report, err := h.enrollmentService.GetCohortKPIs(uint(courseID))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener KPIs por cohorte")
+		return
+	}
+
+	responses.Ok(c, report)
+}
+
+// @Summary		Enroll cohort
+// @Router			/api/v1/cohorts/{id}/enrollments [post]
+// @Description	Enroll several learners in the cohort's course at once and assign them to the cohort. Learners already enrolled are moved to the cohort
+// @Tags		enrollments
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Cohort ID"
+// @Param		users	body	dto.EnrollCohortRequest		true	"Learners to enroll"
+// @Success		200	{object}	dto.CohortEnrollmentResult	"Enrollment summary"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Cohort not found"
+// @Security     BearerAuth
+func (h *EnrollmentHandler) EnrollCohort(c *gin.Context) {
+	cohortID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de cohorte inválido")
+		return
+	}
+
+	var request dto.EnrollCohortRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	result, err := h.enrollmentService.EnrollCohort(uint(cohortID), currentUserID(c), request.UserIDs)
+	if err != nil {
+		h.handleError(c, err, "Error al inscribir la cohorte")
+		return
+	}
+
+	responses.Ok(c, result)
+}
+
+// @Summary		Remove learner from cohort
+// @Router			/api/v1/cohorts/{id}/members/{userId} [delete]
+// @Description	Take a learner out of a cohort. The course enrollment is kept
+// @Tags		enrollments
+// @Produce		json
+// @Param		id		path	int	true	"Cohort ID"
+// @Param		userId	path	int	true	"User ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Cohort or member not found"
+// @Security     BearerAuth
+func (h *EnrollmentHandler) RemoveFromCohort(c *gin.Context) {
+	cohortID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de cohorte inválido")
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de usuario inválido")
+		return
+	}
+
+	if err := h.enrollmentService.RemoveFromCohort(uint(cohortID), currentUserID(c), uint(userID)); err != nil {
+		h.handleError(c, err, "Error al retirar de la cohorte")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
@@ -40,10 +40,9 @@ func (h *EvaluationHandler) CreateEvaluation(c *gin.Context) {
 		return
 	}
 
-	createdEvaluation, err := h.evaluationService.CreateEvaluation(&evaluation)
+	createdEvaluation, err := h.evaluationService.CreateEvaluation(&evaluation, currentUserID(c))
 	if err != nil {
-		h.logger.Errorf("Error al crear la evaluación: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al crear la evaluación")
+		h.handleError(c, err, "Error al crear la evaluación")
 		return
 	}
 
@@ -55,6 +54,7 @@ func (h *EvaluationHandler) CreateEvaluation(c *gin.Context) {
 // @Tags evaluations
 // @Produce json
 // @Param id path int true "Evaluation ID"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
 // @Success 200 {object} models.Evaluation
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
@@ -67,10 +67,9 @@ func (h *EvaluationHandler) GetEvaluation(c *gin.Context) {
 		return
 	}
 
-	evaluation, err := h.evaluationService.GetEvaluation(uint(id))
+	evaluation, err := h.evaluationService.GetEvaluation(uint(id), currentUserID(c), requestLocale(c))
 	if err != nil {
-		h.logger.Errorf("Error al obtener la evaluación: %v", err)
-		responses.ErrorNotFound(c, "Evaluación")
+		h.handleError(c, err, "Error al obtener la evaluación")
 		return
 	}
 
@@ -102,10 +101,9 @@ func (h *EvaluationHandler) UpdateEvaluation(c *gin.Context) {
 		return
 	}
 
-	updatedEvaluation, err := h.evaluationService.UpdateEvaluation(uint(id), &evaluation)
+	updatedEvaluation, err := h.evaluationService.UpdateEvaluation(uint(id), &evaluation, currentUserID(c))
 	if err != nil {
-		h.logger.Errorf("Error al actualizar la evaluación: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al actualizar la evaluación")
+		h.handleError(c, err, "Error al actualizar la evaluación")
 		return
 	}
 
@@ -144,9 +142,9 @@ func (h *EvaluationHandler) UpdateEvaluationPatch(c *gin.Context) {
 		return
 	}
 
-	evaluation, err := h.evaluationService.UpdateEvaluationPatch(uint(evaluationIDInt), payload)
+	evaluation, err := h.evaluationService.UpdateEvaluationPatch(uint(evaluationIDInt), payload, currentUserID(c))
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, err.Error())
+		h.handleError(c, err, "Error al actualizar la evaluación")
 		return
 	}
 
@@ -154,7 +152,7 @@ func (h *EvaluationHandler) UpdateEvaluationPatch(c *gin.Context) {
 }
 
 // @Summary Delete evaluation
-// @Description Delete evaluation by ID
+// @Description Move an evaluation with its questions to the trash
 // @Tags evaluations
 // @Param id path int true "Evaluation ID"
 // @Success 204
@@ -171,8 +169,7 @@ func (h *EvaluationHandler) DeleteEvaluation(c *gin.Context) {
 
 	err = h.evaluationService.DeleteEvaluation(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al eliminar la evaluación: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al eliminar la evaluación")
+		h.handleError(c, err, "Error al eliminar la evaluación")
 		return
 	}
 
@@ -184,7 +181,12 @@ func (h *EvaluationHandler) DeleteEvaluation(c *gin.Context) {
 // @Tags evaluations
 // @Produce json
 // @Param moduleId path int true "Module ID"
-// @Success 200 {array} models.Evaluation
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
+// @Success 200 {object} dto.Page[models.Evaluation]
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/modules/{moduleId}/evaluations [get]
@@ -196,10 +198,15 @@ func (h *EvaluationHandler) GetEvaluationsByModule(c *gin.Context) {
 		return
 	}
 
-	evaluations, err := h.evaluationService.GetEvaluationsByModule(uint(moduleID))
+	request, err := bindListRequest(c)
 	if err != nil {
-		h.logger.Errorf("Error al obtener la evaluacións by module: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener la evaluacións")
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	evaluations, err := h.evaluationService.ListEvaluationsByModule(uint(moduleID), currentUserID(c), requestLocale(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la evaluacións by module")
 		return
 	}
 
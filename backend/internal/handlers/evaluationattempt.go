@@ -45,15 +45,9 @@ func (h *EvaluationAttemptHandler) StartAttempt(c *gin.Context) {
 		return
 	}
 
-	attempt, err := h.evaluationAttemptService.StartAttempt(attemptData.UserID, attemptData.EvaluationID)
+	attempt, err := h.evaluationAttemptService.StartAttempt(attemptData.UserID, attemptData.EvaluationID, requestLocale(c))
 	if err != nil {
-		h.logger.Errorf("Failed to start attempt: %v", err)
-		if err.Error() == "cannot start attempt: maximum attempts reached" ||
-			err.Error() == "cannot start attempt: attempt already in progress" {
-			responses.ErrorConflict(c, err.Error())
-			return
-		}
-		responses.ErrorInternalServerWithMessage(c, "Failed to start attempt:"+err.Error())
+		h.handleError(c, err, "Failed to start attempt")
 		return
 	}
 
@@ -61,7 +55,7 @@ func (h *EvaluationAttemptHandler) StartAttempt(c *gin.Context) {
 }
 
 // @Summary Submit evaluation attempt
-// @Description Submit answers for an evaluation attempt
+// @Description Submit an evaluation attempt. It is scored with its autosaved answers, replaced by the answers in the payload for the questions they include; answers may be left out to submit the saved ones as they are
 // @Tags evaluation-attempts
 // @Accept json
 // @Produce json
@@ -80,7 +74,7 @@ func (h *EvaluationAttemptHandler) SubmitAttempt(c *gin.Context) {
 	}
 
 	var submissionData struct {
-		Answers []models.AttemptAnswer `json:"answers" binding:"required"`
+		Answers []models.AttemptAnswer `json:"answers"`
 	}
 
 	if err := c.ShouldBindJSON(&submissionData); err != nil {
@@ -90,20 +84,57 @@ func (h *EvaluationAttemptHandler) SubmitAttempt(c *gin.Context) {
 
 	attempt, err := h.evaluationAttemptService.SubmitAttempt(uint(id), submissionData.Answers)
 	if err != nil {
-		h.logger.Errorf("Failed to submit attempt: %v", err)
-		if err.Error() == "el intento ya fue enviado" || err.Error() == "tiempo límite excedido" {
-			responses.ErrorConflict(c, err.Error())
-			return
-		}
-		responses.ErrorInternalServerWithMessage(c, "Error al enviar el intento")
+		h.handleError(c, err, "Failed to submit attempt")
 		return
 	}
 
 	responses.Ok(c, attempt)
 }
 
+// @Summary Save attempt answer
+// @Description Autosave the answer to one question of an attempt in progress. Send the version of the answer last received, 0 for a question never saved; if another tab saved it since, the request fails with 409 ANSWER_VERSION_CONFLICT and the current answer in details
+// @Tags evaluation-attempts
+// @Accept json
+// @Produce json
+// @Param id path int true "Attempt ID"
+// @Param questionId path int true "Attempt question ID"
+// @Param answer body models.AttemptAnswer true "Answer with the version last received"
+// @Success 200 {object} models.AttemptAnswer
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/evaluation-attempts/{id}/answers/{questionId} [put]
+func (h *EvaluationAttemptHandler) SaveAnswer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Invalid attempt ID")
+		return
+	}
+
+	questionID, err := strconv.ParseUint(c.Param("questionId"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Invalid question ID")
+		return
+	}
+
+	var answer models.AttemptAnswer
+	if err := c.ShouldBindJSON(&answer); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+	answer.AttemptQuestionID = uint(questionID)
+
+	saved, err := h.evaluationAttemptService.SaveAnswer(uint(id), answer)
+	if err != nil {
+		h.handleError(c, err, "Failed to save answer")
+		return
+	}
+
+	responses.Ok(c, saved)
+}
+
 // @Summary Get evaluation attempt
-// @Description Get an evaluation attempt by its ID
+// @Description Get an evaluation attempt by its ID. Attempts in progress include their autosaved answers and remaining_seconds of their time limit; one past the limit and grace period is submitted with its saved answers first
 // @Tags evaluation-attempts
 // @Produce json
 // @Param id path int true "Attempt ID"
@@ -121,8 +152,7 @@ func (h *EvaluationAttemptHandler) GetAttempt(c *gin.Context) {
 
 	attempt, err := h.evaluationAttemptService.GetAttempt(uint(id))
 	if err != nil {
-		h.logger.Errorf("Failed to get attempt: %v", err)
-		responses.ErrorNotFound(c, "Attempt")
+		h.handleError(c, err, "Failed to get attempt")
 		return
 	}
 
@@ -163,7 +193,7 @@ func (h *EvaluationAttemptHandler) UpdateEvaluationAttemptPatch(c *gin.Context)
 
 	attempt, err := h.evaluationAttemptService.UpdateEvaluationAttemptPatch(uint(attemptIDInt), payload)
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, err.Error())
+		h.handleError(c, err, "Error al actualizar el intento")
 		return
 	}
 
@@ -176,7 +206,11 @@ func (h *EvaluationAttemptHandler) UpdateEvaluationAttemptPatch(c *gin.Context)
 // @Produce json
 // @Param userId path int true "User ID"
 // @Param evaluationId path int true "Evaluation ID"
-// @Success 200 {array} models.EvaluationAttempt
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Success 200 {object} dto.Page[models.EvaluationAttempt]
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/users/{userId}/evaluations/{evaluationId}/attempts [get]
@@ -195,10 +229,15 @@ func (h *EvaluationAttemptHandler) GetUserAttempts(c *gin.Context) {
 		return
 	}
 
-	attempts, err := h.evaluationAttemptService.GetUserAttempts(uint(userID), uint(evaluationID))
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	attempts, err := h.evaluationAttemptService.ListUserAttempts(uint(userID), uint(evaluationID), request)
 	if err != nil {
-		h.logger.Errorf("Failed to get user attempts: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Failed to get attempts")
+		h.handleError(c, err, "Failed to get user attempts")
 		return
 	}
 
@@ -231,8 +270,7 @@ func (h *EvaluationAttemptHandler) CanUserAttempt(c *gin.Context) {
 
 	canAttempt, reason, err := h.evaluationAttemptService.CanUserAttempt(uint(userID), uint(evaluationID))
 	if err != nil {
-		h.logger.Errorf("Failed to check if user can attempt: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Failed to check attempt eligibility")
+		h.handleError(c, err, "Failed to check if user can attempt")
 		return
 	}
 
@@ -261,8 +299,7 @@ func (h *EvaluationAttemptHandler) ScoreAttempt(c *gin.Context) {
 
 	attempt, err := h.evaluationAttemptService.ScoreAttempt(uint(id))
 	if err != nil {
-		h.logger.Errorf("Failed to score attempt: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Failed to score attempt")
+		h.handleError(c, err, "Failed to score attempt")
 		return
 	}
 
@@ -39,12 +39,13 @@ func NewFileHandler(handler *Handler, fileService services.FileService) *FileHan
 func (h *FileHandler) UploadFile(c *gin.Context) {
 	file, err := c.FormFile("file")
 	if err != nil {
-		responses.ErrorBadRequest(c, "Invalid file: "+err.Error())
+		responses.ErrorBadRequest(c, "Archivo inválido: "+err.Error())
+		return
 	}
 
-	result, err := h.fileService.UploadFromMultipart(file)
+	result, err := h.fileService.UploadFromMultipart(file, currentUserID(c))
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, "Error al subir el archivo: "+err.Error())
+		h.handleError(c, err, "Error al subir el archivo")
 		return
 	}
 
@@ -60,6 +61,7 @@ func (h *FileHandler) UploadFile(c *gin.Context) {
 // @Produce		json
 // @Success		200	{object}	models.File	"File retrieved successfully"
 // @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
 // @Failure		404	{object}	responses.ErrorResponse	"File Not Found"
 // @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
 // @Security     BearerAuth
@@ -73,7 +75,7 @@ func (h *FileHandler) GetFile(c *gin.Context) {
 
 	file, err := h.fileService.GetFile(uint(id))
 	if err != nil {
-		responses.ErrorNotFound(c, err.Error())
+		h.handleError(c, err, "Error al obtener el archivo")
 		return
 	}
 
@@ -82,12 +84,14 @@ func (h *FileHandler) GetFile(c *gin.Context) {
 
 // @Summary		Delete file
 // @Router			/api/v1/files/{id} [delete]
-// @Description	 Delete a file by its ID
+// @Description	 Delete a file by its ID. Only the uploader or an administrator can delete it, and only once it has no attachments
 // @Tags			files
-// @Param			fileID	path	int				true	"File ID"
+// @Param			id	path	int				true	"File ID"
 // @Accept			json
 // @Produce		json
 // @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		409	{object}	responses.ErrorResponse	"File is still attached"
 // @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
 // @Security     BearerAuth
 func (h *FileHandler) DeleteFile(c *gin.Context) {
@@ -98,8 +102,13 @@ func (h *FileHandler) DeleteFile(c *gin.Context) {
 		return
 	}
 
+	if err := h.fileService.CanManageFile(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al verificar el archivo")
+		return
+	}
+
 	if err := h.fileService.DeleteFile(uint(id)); err != nil {
-		responses.ErrorInternalServerWithMessage(c, "Error al eliminar el archivo: "+err.Error())
+		h.handleError(c, err, "Error al eliminar el archivo")
 		return
 	}
 
@@ -107,14 +116,15 @@ func (h *FileHandler) DeleteFile(c *gin.Context) {
 }
 
 // @Summary		Get presigned URL for file upload
-// @Router			/api/v1/files/presigned-url [post]
+// @Router			/api/v1/files/{id}/presigned-url [post]
 // @Description	 Get a presigned URL for uploading a file
 // @Tags			files
-// @Param			fileID	path	int				true	"File ID"
+// @Param			id	path	int				true	"File ID"
 // @Accept			json
 // @Produce		json
 // @Param		payload	body	dto.CreatePresignedUrl				true	"Expiry time in minutes for the presigned URL"
 // @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
 // @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
 // @Security     BearerAuth
 func (h *FileHandler) GetPresignedURL(c *gin.Context) {
@@ -132,9 +142,14 @@ func (h *FileHandler) GetPresignedURL(c *gin.Context) {
 		return
 	}
 
+	if err := h.fileService.CanAccessFile(uint(fileID), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al verificar el archivo")
+		return
+	}
+
 	result, err := h.fileService.GetPresignedURL(uint(fileID), payload.ExpiryMins)
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, "Failed to get presigned URL: "+err.Error())
+		h.handleError(c, err, "Error al obtener la URL firmada")
 		return
 	}
 
@@ -150,6 +165,7 @@ func (h *FileHandler) GetPresignedURL(c *gin.Context) {
 // @Produce		octet-stream
 // @Success		200	{file}	file	"File downloaded successfully"
 // @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
 // @Failure		404	{object}	responses.ErrorResponse	"File Not Found
 // @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
 // @Security     BearerAuth
@@ -161,9 +177,14 @@ func (h *FileHandler) DownloadFile(c *gin.Context) {
 		return
 	}
 
+	if err := h.fileService.CanAccessFile(uint(fileID), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al verificar el archivo")
+		return
+	}
+
 	file, downloadData, err := h.fileService.DownloadFile(uint(fileID))
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, "Error al descargar el archivo: "+err.Error())
+		h.handleError(c, err, "Error al descargar el archivo")
 		return
 	}
 
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
 	"go.uber.org/zap"
 )
 
@@ -15,3 +19,30 @@ func NewHandler(
 		logger: logger,
 	}
 }
+
+// currentUserID returns the authenticated user, or 0 when the request carries no valid token
+func currentUserID(c *gin.Context) uint {
+	if userID, exists := c.Get("userID"); exists {
+		if id, ok := userID.(uint); ok {
+			return id
+		}
+	}
+	return 0
+}
+
+// requestLocale collects the locale hints of the request: ?locale= and Accept-Language
+func requestLocale(c *gin.Context) dto.RequestLocale {
+	return dto.RequestLocale{
+		Requested:      c.Query("locale"),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+	}
+}
+
+// handleError answers a service error through the error catalog. Errors without a domain
+// code are unexpected, so they are logged and answered as internal errors.
+func (h *Handler) handleError(c *gin.Context, err error, message string) {
+	if _, ok := apperrors.From(err); !ok {
+		h.logger.Errorf("%s: %v", message, err)
+	}
+	responses.Error(c, err)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type ItemAnalysisHandler struct {
+	*Handler
+	itemAnalysisService services.ItemAnalysisService
+}
+
+func NewItemAnalysisHandler(handler *Handler, itemAnalysisService services.ItemAnalysisService) *ItemAnalysisHandler {
+	return &ItemAnalysisHandler{
+		Handler:             handler,
+		itemAnalysisService: itemAnalysisService,
+	}
+}
+
+// @Summary		Get evaluation item analysis
+// @Router			/api/v1/evaluations/{id}/item-analysis [get]
+// @Description	Get the statistics of each question from the first submitted attempt of every learner: difficulty (p-value), point-biserial discrimination, average time and how often each option is chosen, plus the KR-20 or Cronbach's alpha reliability of the evaluation. A background job recomputes them after new submissions; computed_at tells when
+// @Tags		evaluations
+// @Produce		json
+// @Param		id	path	int	true	"Evaluation ID"
+// @Success		200	{object}	models.EvaluationItemAnalysis	"Item analysis"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Evaluation not found"
+// @Security     BearerAuth
+func (h *ItemAnalysisHandler) GetItemAnalysis(c *gin.Context) {
+	evaluationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de evaluación inválido")
+		return
+	}
+
+	analysis, err := h.itemAnalysisService.GetItemAnalysis(uint(evaluationID), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el análisis de la evaluación")
+		return
+	}
+
+	responses.Ok(c, analysis)
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type LearningPathHandler struct {
+	*Handler
+	learningPathService services.LearningPathService
+}
+
+func NewLearningPathHandler(handler *Handler, learningPathService services.LearningPathService) *LearningPathHandler {
+	return &LearningPathHandler{
+		Handler:             handler,
+		learningPathService: learningPathService,
+	}
+}
+
+// @Summary		Get learning paths
+// @Router			/api/v1/learning-paths [get]
+// @Description	Get the learning paths. Learners only see published paths
+// @Tags		learning-paths
+// @Produce		json
+// @Param		limit			query	int		false	"Page size (max 100)"
+// @Param		offset			query	int		false	"Number of items to skip"
+// @Param		cursor			query	string	false	"Cursor returned by the previous page"
+// @Param		sort			query	string	false	"Sort field (title, created_at, student_count), prefix with - for descending"
+// @Param		is_published	query	bool	false	"Filter by published state"
+// @Success		200	{object}	dto.Page[models.LearningPath]	"Learning paths"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+func (h *LearningPathHandler) ListPaths(c *gin.Context) {
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	paths, err := h.learningPathService.ListPaths(currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las rutas de aprendizaje")
+		return
+	}
+
+	responses.Ok(c, paths)
+}
+
+// @Summary		Create learning path
+// @Router			/api/v1/learning-paths [post]
+// @Description	Create a learning path. Courses are added by setting its structure
+// @Tags		learning-paths
+// @Accept		json
+// @Produce		json
+// @Param		path	body	dto.CreateLearningPathRequest	true	"Learning path data"
+// @Success		201	{object}	models.LearningPath	"Learning path created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Security     BearerAuth
+func (h *LearningPathHandler) CreatePath(c *gin.Context) {
+	var request dto.CreateLearningPathRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	path, err := h.learningPathService.CreatePath(currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear la ruta de aprendizaje")
+		return
+	}
+
+	c.JSON(http.StatusCreated, path)
+}
+
+// @Summary		Get learning path
+// @Router			/api/v1/learning-paths/{id} [get]
+// @Description	Get a learning path with its ordered groups and courses
+// @Tags		learning-paths
+// @Produce		json
+// @Param		id	path	int	true	"Learning path ID"
+// @Success		200	{object}	models.LearningPath	"Learning path"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Learning path not found"
+func (h *LearningPathHandler) GetPath(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de ruta inválido")
+		return
+	}
+
+	path, err := h.learningPathService.GetPath(uint(id), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la ruta de aprendizaje")
+		return
+	}
+
+	responses.Ok(c, path)
+}
+
+// @Summary		Update learning path
+// @Router			/api/v1/learning-paths/{id} [patch]
+// @Description	Update the title, description, image or published state of a learning path
+// @Tags		learning-paths
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int								true	"Learning path ID"
+// @Param		path	body	dto.UpdateLearningPathRequest	true	"Fields to update"
+// @Success		200	{object}	models.LearningPath	"Updated learning path"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Learning path not found"
+// @Security     BearerAuth
+func (h *LearningPathHandler) UpdatePathPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de ruta inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	path, err := h.learningPathService.UpdatePathPatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar la ruta de aprendizaje")
+		return
+	}
+
+	responses.Ok(c, path)
+}
+
+// @Summary		Delete learning path
+// @Router			/api/v1/learning-paths/{id} [delete]
+// @Description	Delete a learning path. Course enrollments created through it are kept
+// @Tags		learning-paths
+// @Produce		json
+// @Param		id	path	int	true	"Learning path ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Learning path not found"
+// @Security     BearerAuth
+func (h *LearningPathHandler) DeletePath(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de ruta inválido")
+		return
+	}
+
+	if err := h.learningPathService.DeletePath(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar la ruta de aprendizaje")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Set learning path structure
+// @Router			/api/v1/learning-paths/{id}/structure [put]
+// @Description	Replace the ordered groups of a learning path. Required groups need every course completed; elective groups need min_courses of them
+// @Tags		learning-paths
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int									true	"Learning path ID"
+// @Param		structure	body	dto.LearningPathStructureRequest	true	"Ordered groups (kind: required, elective)"
+// @Success		200	{object}	models.LearningPath	"Updated learning path"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Learning path or course not found"
+// @Security     BearerAuth
+func (h *LearningPathHandler) SetStructure(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de ruta inválido")
+		return
+	}
+
+	var request dto.LearningPathStructureRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	path, err := h.learningPathService.SetStructure(uint(id), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al guardar la estructura de la ruta")
+		return
+	}
+
+	responses.Ok(c, path)
+}
+
+// @Summary		Enroll in learning path
+// @Router			/api/v1/learning-paths/{id}/enroll [post]
+// @Description	Enroll the current user in a learning path and in every course of the path
+// @Tags		learning-paths
+// @Produce		json
+// @Param		id	path	int	true	"Learning path ID"
+// @Success		201	{object}	dto.LearningPathProgress	"Path progress"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Learning path not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Already enrolled"
+// @Security     BearerAuth
+func (h *LearningPathHandler) Enroll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de ruta inválido")
+		return
+	}
+
+	progress, err := h.learningPathService.Enroll(uint(id), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al inscribir en la ruta de aprendizaje")
+		return
+	}
+
+	c.JSON(http.StatusCreated, progress)
+}
+
+// @Summary		Get learning path progress
+// @Router			/api/v1/learning-paths/{id}/progress [get]
+// @Description	Get the current user's progress in a learning path, derived from the progress of its courses
+// @Tags		learning-paths
+// @Produce		json
+// @Param		id	path	int	true	"Learning path ID"
+// @Success		200	{object}	dto.LearningPathProgress	"Path progress"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Learning path not found"
+// @Security     BearerAuth
+func (h *LearningPathHandler) GetProgress(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de ruta inválido")
+		return
+	}
+
+	progress, err := h.learningPathService.GetProgress(uint(id), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el progreso de la ruta")
+		return
+	}
+
+	responses.Ok(c, progress)
+}
+
+// @Summary		Get my learning paths
+// @Router			/api/v1/learning-paths/enrolled [get]
+// @Description	Get the learning paths the current user is enrolled in
+// @Tags		learning-paths
+// @Produce		json
+// @Param		limit	query	int		false	"Page size (max 100)"
+// @Param		offset	query	int		false	"Number of items to skip"
+// @Param		cursor	query	string	false	"Cursor returned by the previous page"
+// @Param		sort	query	string	false	"Sort field (enrolled_at, completed_at), prefix with - for descending"
+// @Success		200	{object}	dto.Page[models.LearningPathEnrollment]	"Path enrollments"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Security     BearerAuth
+func (h *LearningPathHandler) ListUserPaths(c *gin.Context) {
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	enrollments, err := h.learningPathService.ListUserPaths(currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las rutas de aprendizaje")
+		return
+	}
+
+	responses.Ok(c, enrollments)
+}
@@ -42,8 +42,7 @@ func (h *ModuleHandler) CreateModule(c *gin.Context) {
 
 	createdModule, err := h.moduleService.CreateModule(&module)
 	if err != nil {
-		h.logger.Errorf("Error al crear el módulo: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al crear el módulo")
+		h.handleError(c, err, "Error al crear el módulo")
 		return
 	}
 
@@ -55,6 +54,7 @@ func (h *ModuleHandler) CreateModule(c *gin.Context) {
 // @Tags modules
 // @Produce json
 // @Param id path int true "Module ID"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
 // @Success 200 {object} models.Module
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
@@ -67,10 +67,9 @@ func (h *ModuleHandler) GetModule(c *gin.Context) {
 		return
 	}
 
-	module, err := h.moduleService.GetModule(uint(id))
+	module, err := h.moduleService.GetModule(uint(id), currentUserID(c), requestLocale(c))
 	if err != nil {
-		h.logger.Errorf("Error al obtener el módulo: %v", err)
-		responses.ErrorNotFound(c, "Módulo")
+		h.handleError(c, err, "Error al obtener el módulo")
 		return
 	}
 
@@ -105,8 +104,7 @@ func (h *ModuleHandler) UpdateModule(c *gin.Context) {
 
 	updatedModule, err := h.moduleService.UpdateModule(uint(id), &module)
 	if err != nil {
-		h.logger.Errorf("Error al actualizar el módulo: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al actualizar el módulo")
+		h.handleError(c, err, "Error al actualizar el módulo")
 		return
 	}
 
@@ -147,7 +145,7 @@ func (h *ModuleHandler) UpdateModulePatch(c *gin.Context) {
 
 	module, err := h.moduleService.UpdateModulePatch(uint(moduleIDInt), payload)
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, err.Error())
+		h.handleError(c, err, "Error al actualizar el módulo")
 		return
 	}
 
@@ -155,7 +153,7 @@ func (h *ModuleHandler) UpdateModulePatch(c *gin.Context) {
 }
 
 // @Summary Delete module
-// @Description Delete a module by its ID
+// @Description Move a module with its contents and evaluations to the trash
 // @Tags modules
 // @Param id path int true "Module ID"
 // @Success 204
@@ -173,8 +171,7 @@ func (h *ModuleHandler) DeleteModule(c *gin.Context) {
 
 	err = h.moduleService.DeleteModule(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al eliminar el módulo: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al eliminar el módulo")
+		h.handleError(c, err, "Error al eliminar el módulo")
 		return
 	}
 
@@ -182,11 +179,16 @@ func (h *ModuleHandler) DeleteModule(c *gin.Context) {
 }
 
 // @Summary Get modules by course
-// @Description Get all modules for a specific course
+// @Description Get all modules for a specific course. With a session each module carries its drip release state for the user (locked, unlocks_at)
 // @Tags modules
 // @Produce json
 // @Param courseId path int true "Course ID"
-// @Success 200 {array} models.Module
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
+// @Success 200 {object} dto.Page[models.Module]
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/courses/{courseId}/modules [get]
@@ -198,10 +200,15 @@ func (h *ModuleHandler) GetModulesByCourse(c *gin.Context) {
 		return
 	}
 
-	modules, err := h.moduleService.GetModulesByCourse(uint(courseID))
+	request, err := bindListRequest(c)
 	if err != nil {
-		h.logger.Errorf("Error al obtener el módulos by course: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener el módulos")
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	modules, err := h.moduleService.ListModulesByCourse(uint(courseID), currentUserID(c), requestLocale(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el módulos by course")
 		return
 	}
 
@@ -213,6 +220,7 @@ func (h *ModuleHandler) GetModulesByCourse(c *gin.Context) {
 // @Tags modules
 // @Produce json
 // @Param id path int true "Module ID"
+// @Param locale query string false "Locale (es, en); defaults to the user preference, then Accept-Language"
 // @Success 200 {object} models.Module
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
@@ -225,10 +233,9 @@ func (h *ModuleHandler) GetModuleWithContent(c *gin.Context) {
 		return
 	}
 
-	module, err := h.moduleService.GetModuleWithContent(uint(id))
+	module, err := h.moduleService.GetModuleWithContent(uint(id), currentUserID(c), requestLocale(c))
 	if err != nil {
-		h.logger.Errorf("Error al obtener el módulo with content: %v", err)
-		responses.ErrorNotFound(c, "Módulo")
+		h.handleError(c, err, "Error al obtener el módulo with content")
 		return
 	}
 
@@ -280,8 +287,7 @@ func (h *ModuleHandler) ReorderModules(c *gin.Context) {
 
 	err = h.moduleService.ReorderModules(uint(courseID), convertedOrders)
 	if err != nil {
-		h.logger.Errorf("Failed to reorder modules: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Failed to reorder modules")
+		h.handleError(c, err, "Failed to reorder modules")
 		return
 	}
 
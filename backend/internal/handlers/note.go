@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type NoteHandler struct {
+	*Handler
+	noteService services.NoteService
+}
+
+func NewNoteHandler(handler *Handler, noteService services.NoteService) *NoteHandler {
+	return &NoteHandler{
+		Handler:     handler,
+		noteService: noteService,
+	}
+}
+
+// @Summary		Create note
+// @Router			/api/v1/content/{id}/notes [post]
+// @Description	Save a private note, highlight or bookmark on a content, optionally anchored to a text range or a video timestamp
+// @Tags		notes
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int						true	"Content ID"
+// @Param		note	body	dto.CreateNoteRequest	true	"Note data (kind: note, highlight, bookmark)"
+// @Success		201	{object}	models.Note	"Note created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Content not found"
+// @Security     BearerAuth
+func (h *NoteHandler) CreateNote(c *gin.Context) {
+	contentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de contenido inválido")
+		return
+	}
+
+	var request dto.CreateNoteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	note, err := h.noteService.CreateNote(uint(contentID), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear la nota")
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// @Summary		Get content notes
+// @Router			/api/v1/content/{id}/notes [get]
+// @Description	Get the current user's notes on a content
+// @Tags		notes
+// @Produce		json
+// @Param		id		path	int		true	"Content ID"
+// @Param		limit	query	int		false	"Page size (max 100)"
+// @Param		offset	query	int		false	"Number of items to skip"
+// @Param		cursor	query	string	false	"Cursor returned by the previous page"
+// @Param		sort	query	string	false	"Sort field (created_at, updated_at), prefix with - for descending"
+// @Param		kind	query	string	false	"Filter by kind (note, highlight, bookmark)"
+// @Success		200	{object}	dto.Page[models.Note]	"Notes"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Security     BearerAuth
+func (h *NoteHandler) GetContentNotes(c *gin.Context) {
+	contentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de contenido inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	notes, err := h.noteService.GetContentNotes(uint(contentID), currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las notas")
+		return
+	}
+
+	responses.Ok(c, notes)
+}
+
+// @Summary		Get course notes
+// @Router			/api/v1/courses/{id}/notes [get]
+// @Description	Get the current user's notes, highlights and bookmarks in a course
+// @Tags		notes
+// @Produce		json
+// @Param		id			path	int		true	"Course ID"
+// @Param		limit		query	int		false	"Page size (max 100)"
+// @Param		offset		query	int		false	"Number of items to skip"
+// @Param		cursor		query	string	false	"Cursor returned by the previous page"
+// @Param		sort		query	string	false	"Sort field (created_at, updated_at), prefix with - for descending"
+// @Param		content_id	query	int		false	"Filter by content"
+// @Param		kind		query	string	false	"Filter by kind (note, highlight, bookmark)"
+// @Success		200	{object}	dto.Page[models.Note]	"Notes"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Security     BearerAuth
+func (h *NoteHandler) GetCourseNotes(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	notes, err := h.noteService.GetCourseNotes(uint(courseID), currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las notas")
+		return
+	}
+
+	responses.Ok(c, notes)
+}
+
+// @Summary		Export course notes
+// @Router			/api/v1/courses/{id}/notes/export [get]
+// @Description	Download the current user's notes of a course as a Markdown document ordered by the course outline
+// @Tags		notes
+// @Produce		plain
+// @Param		id	path	int	true	"Course ID"
+// @Success		200	{file}	file	"Markdown document"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Course not found"
+// @Security     BearerAuth
+func (h *NoteHandler) ExportCourseNotes(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	filename, document, err := h.noteService.ExportCourseNotes(uint(courseID), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al exportar las notas")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", document)
+}
+
+// @Summary		Search notes
+// @Router			/api/v1/notes/search [get]
+// @Description	Search the text and highlighted quotes of the current user's notes
+// @Tags		notes
+// @Produce		json
+// @Param		q			query	string	true	"Text to search"
+// @Param		limit		query	int		false	"Page size (max 100)"
+// @Param		offset		query	int		false	"Number of items to skip"
+// @Param		cursor		query	string	false	"Cursor returned by the previous page"
+// @Param		course_id	query	int		false	"Filter by course"
+// @Param		kind		query	string	false	"Filter by kind (note, highlight, bookmark)"
+// @Success		200	{object}	dto.Page[models.Note]	"Notes"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Security     BearerAuth
+func (h *NoteHandler) SearchNotes(c *gin.Context) {
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	notes, err := h.noteService.SearchNotes(currentUserID(c), c.Query("q"), request)
+	if err != nil {
+		h.handleError(c, err, "Error al buscar las notas")
+		return
+	}
+
+	responses.Ok(c, notes)
+}
+
+// @Summary		Update note
+// @Router			/api/v1/notes/{id} [patch]
+// @Description	Update the text, quote, anchor or color of a note
+// @Tags		notes
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int						true	"Note ID"
+// @Param		note	body	dto.UpdateNoteRequest	true	"Fields to update"
+// @Success		200	{object}	models.Note	"Updated note"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Note not found"
+// @Security     BearerAuth
+func (h *NoteHandler) UpdateNotePatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de nota inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	note, err := h.noteService.UpdateNotePatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar la nota")
+		return
+	}
+
+	responses.Ok(c, note)
+}
+
+// @Summary		Delete note
+// @Router			/api/v1/notes/{id} [delete]
+// @Description	Delete a note of the current user
+// @Tags		notes
+// @Produce		json
+// @Param		id	path	int	true	"Note ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Note not found"
+// @Security     BearerAuth
+func (h *NoteHandler) DeleteNote(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de nota inválido")
+		return
+	}
+
+	if err := h.noteService.DeleteNote(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar la nota")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
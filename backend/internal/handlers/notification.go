@@ -277,6 +277,11 @@ func (h *NotificationHandler) GetSSESubscriptions(c *gin.Context) {
 // @Accept			json
 // @Produce		json
 // @Param			user_id query	int	true	"User ID"
+// @Param			limit	query	int		false	"Page size (default 20, max 100)"
+// @Param			offset	query	int		false	"Number of items to skip"
+// @Param			cursor	query	string	false	"Cursor returned as next_cursor by the previous page"
+// @Param			sort	query	string	false	"Sort field, prefixed with - for descending order"
+// @Success		200	{object}	dto.Page[models.Notification]	"Paginated notifications"
 // @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
 // @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error
 // @Security     BearerAuth
@@ -293,9 +298,15 @@ func (h *NotificationHandler) GetUserNotifications(c *gin.Context) {
 		return
 	}
 
-	notifications, err := h.notificationService.GetUserNotifications(uint(userID))
+	request, err := bindListRequest(c)
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, fmt.Sprintf("error fetching notifications: %v", err))
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	notifications, err := h.notificationService.ListUserNotifications(uint(userID), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las notificaciones")
 		return
 	}
 
@@ -327,7 +338,7 @@ func (h *NotificationHandler) MarkNotificationsAsRead(c *gin.Context) {
 
 	err = h.notificationService.MarkNotificationsAsRead(uint(userID))
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, fmt.Sprintf("error marking notifications as read: %v", err))
+		h.handleError(c, err, "Error al marcar las notificaciones como leídas")
 		return
 	}
 
@@ -362,7 +373,7 @@ func (h *NotificationHandler) GetPushSubscription(c *gin.Context) {
 
 	subscription, err := h.notificationService.GetPushSubscription(uint(subscriptionID))
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, fmt.Sprintf("error fetching subscription: %v", err))
+		h.handleError(c, err, "Error al obtener la suscripción")
 		return
 	}
 
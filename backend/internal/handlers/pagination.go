@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+)
+
+// bindListRequest reads the shared list parameters (limit, offset, cursor, sort).
+// The remaining query parameters are kept as filters; each list only applies the ones it allows.
+func bindListRequest(c *gin.Context) (*dto.ListRequest, error) {
+	var request dto.ListRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		return nil, err
+	}
+
+	request.Filters = make(map[string]string)
+	for key, values := range c.Request.URL.Query() {
+		if len(values) > 0 {
+			request.Filters[key] = values[0]
+		}
+	}
+
+	return &request, nil
+}
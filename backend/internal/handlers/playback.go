@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type PlaybackHandler struct {
+	*Handler
+	playbackService services.PlaybackService
+}
+
+func NewPlaybackHandler(handler *Handler, playbackService services.PlaybackService) *PlaybackHandler {
+	return &PlaybackHandler{
+		Handler:         handler,
+		playbackService: playbackService,
+	}
+}
+
+// @Summary		Send playback heartbeat
+// @Router			/api/v1/content/{id}/playback [post]
+// @Description	Record the current playback position and the intervals watched since the previous heartbeat. The video is completed once enough distinct seconds are watched
+// @Tags		playback
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int								true	"Content ID"
+// @Param		heartbeat	body	dto.PlaybackHeartbeatRequest	true	"Playback state"
+// @Success		200	{object}	models.PlaybackProgress	"Playback progress"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"User not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Content not found"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *PlaybackHandler) RecordHeartbeat(c *gin.Context) {
+	contentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de contenido inválido")
+		return
+	}
+
+	var request dto.PlaybackHeartbeatRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	progress, err := h.playbackService.RecordHeartbeat(currentUserID(c), uint(contentID), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al registrar la reproducción")
+		return
+	}
+
+	responses.Ok(c, progress)
+}
+
+// @Summary		Get playback progress
+// @Router			/api/v1/content/{id}/playback [get]
+// @Description	Get the position where the authenticated user left the video and how much of it was watched
+// @Tags		playback
+// @Produce		json
+// @Param		id	path	int	true	"Content ID"
+// @Success		200	{object}	models.PlaybackProgress	"Playback progress"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *PlaybackHandler) GetPlayback(c *gin.Context) {
+	contentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de contenido inválido")
+		return
+	}
+
+	progress, err := h.playbackService.GetPlayback(currentUserID(c), uint(contentID))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la reproducción")
+		return
+	}
+
+	responses.Ok(c, progress)
+}
+
+// @Summary		Get video engagement stats
+// @Router			/api/v1/content/{id}/playback/stats [get]
+// @Description	Viewers, completions, watch time and audience retention of a video. Only for instructors and administrators
+// @Tags		playback
+// @Produce		json
+// @Param		id	path	int	true	"Content ID"
+// @Success		200	{object}	dto.PlaybackStats	"Engagement stats"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Content not found"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *PlaybackHandler) GetPlaybackStats(c *gin.Context) {
+	contentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de contenido inválido")
+		return
+	}
+
+	stats, err := h.playbackService.GetPlaybackStats(uint(contentID), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las estadísticas del video")
+		return
+	}
+
+	responses.Ok(c, stats)
+}
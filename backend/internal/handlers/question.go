@@ -41,17 +41,19 @@ func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
 	}
 
 	question := &models.Question{
-		Text:         questionReq.Text,
-		Type:         questionReq.Type,
-		Explanation:  questionReq.Explanation,
-		Points:       questionReq.Points,
-		EvaluationID: questionReq.EvaluationID,
+		Text:          questionReq.Text,
+		Type:          questionReq.Type,
+		Explanation:   questionReq.Explanation,
+		Points:        questionReq.Points,
+		EvaluationID:  questionReq.EvaluationID,
+		BankID:        questionReq.BankID,
+		ScoringPolicy: questionReq.ScoringPolicy,
+		Difficulty:    questionReq.Difficulty,
 	}
 
-	createdQuestion, err := h.questionService.CreateQuestion(question)
+	createdQuestion, err := h.questionService.CreateQuestion(question, currentUserID(c))
 	if err != nil {
-		h.logger.Errorf("Error al crear la pregunta: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al crear la pregunta")
+		h.handleError(c, err, "Error al crear la pregunta")
 		return
 	}
 
@@ -77,8 +79,7 @@ func (h *QuestionHandler) GetQuestion(c *gin.Context) {
 
 	question, err := h.questionService.GetQuestion(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al obtener la pregunta: %v", err)
-		responses.ErrorNotFound(c, "Pregunta")
+		h.handleError(c, err, "Error al obtener la pregunta")
 		return
 	}
 
@@ -111,16 +112,17 @@ func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
 	}
 
 	question := &models.Question{
-		Text:        questionReq.Text,
-		Type:        questionReq.Type,
-		Explanation: questionReq.Explanation,
-		Points:      questionReq.Points,
+		Text:          questionReq.Text,
+		Type:          questionReq.Type,
+		Explanation:   questionReq.Explanation,
+		Points:        questionReq.Points,
+		ScoringPolicy: questionReq.ScoringPolicy,
+		Difficulty:    questionReq.Difficulty,
 	}
 
-	updatedQuestion, err := h.questionService.UpdateQuestion(uint(id), question)
+	updatedQuestion, err := h.questionService.UpdateQuestion(uint(id), question, currentUserID(c))
 	if err != nil {
-		h.logger.Errorf("Error al actualizar la pregunta: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al actualizar la pregunta")
+		h.handleError(c, err, "Error al actualizar la pregunta")
 		return
 	}
 
@@ -159,9 +161,9 @@ func (h *QuestionHandler) UpdateQuestionPatch(c *gin.Context) {
 		return
 	}
 
-	question, err := h.questionService.UpdateQuestionPatch(uint(questionIDInt), payload)
+	question, err := h.questionService.UpdateQuestionPatch(uint(questionIDInt), payload, currentUserID(c))
 	if err != nil {
-		responses.ErrorInternalServerWithMessage(c, err.Error())
+		h.handleError(c, err, "Error al actualizar la pregunta")
 		return
 	}
 
@@ -169,7 +171,7 @@ func (h *QuestionHandler) UpdateQuestionPatch(c *gin.Context) {
 }
 
 // @Summary Delete question
-// @Description Delete question by ID
+// @Description Move a question with its answers to the trash
 // @Tags questions
 // @Param id path int true "Question ID"
 // @Success 204
@@ -186,8 +188,7 @@ func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 
 	err = h.questionService.DeleteQuestion(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al eliminar la pregunta: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al eliminar la pregunta")
+		h.handleError(c, err, "Error al eliminar la pregunta")
 		return
 	}
 
@@ -199,7 +200,11 @@ func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 // @Tags questions
 // @Produce json
 // @Param evaluationId path int true "Evaluation ID"
-// @Success 200 {array} models.Question
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "Cursor returned as next_cursor by the previous page"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Success 200 {object} dto.Page[models.Question]
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/evaluations/{evaluationId}/questions [get]
@@ -211,10 +216,15 @@ func (h *QuestionHandler) GetQuestionsByEvaluation(c *gin.Context) {
 		return
 	}
 
-	questions, err := h.questionService.GetQuestionsByEvaluation(uint(evaluationID))
+	request, err := bindListRequest(c)
 	if err != nil {
-		h.logger.Errorf("Error al obtener la preguntas by evaluation: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "Error al obtener la preguntas")
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	questions, err := h.questionService.ListQuestionsByEvaluation(uint(evaluationID), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la preguntas by evaluation")
 		return
 	}
 
@@ -240,10 +250,45 @@ func (h *QuestionHandler) GetQuestionWithAnswers(c *gin.Context) {
 
 	question, err := h.questionService.GetQuestionWithAnswers(uint(id))
 	if err != nil {
-		h.logger.Errorf("Error al obtener la pregunta with answers: %v", err)
-		responses.ErrorNotFound(c, "Pregunta")
+		h.handleError(c, err, "Error al obtener la pregunta with answers")
 		return
 	}
 
 	responses.Ok(c, question)
 }
+
+// @Summary Set question tags
+// @Description Replace the tags of a question, creating the tags that do not exist yet. Draw rules use them to pick bank questions
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Param payload body dto.SetQuestionTagsRequest true "Tag names"
+// @Success 200 {array} models.Tag
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /api/v1/questions/{id}/tags [put]
+func (h *QuestionHandler) SetQuestionTags(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de pregunta inválido")
+		return
+	}
+
+	var request dto.SetQuestionTagsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	tags, err := h.questionService.SetQuestionTags(uint(id), currentUserID(c), request.Tags)
+	if err != nil {
+		h.handleError(c, err, "Error al asignar las etiquetas de la pregunta")
+		return
+	}
+
+	responses.Ok(c, tags)
+}
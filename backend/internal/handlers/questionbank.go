@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type QuestionBankHandler struct {
+	*Handler
+	questionBankService services.QuestionBankService
+}
+
+func NewQuestionBankHandler(handler *Handler, questionBankService services.QuestionBankService) *QuestionBankHandler {
+	return &QuestionBankHandler{
+		Handler:             handler,
+		questionBankService: questionBankService,
+	}
+}
+
+// @Summary		Get course question banks
+// @Router			/api/v1/courses/{id}/question-banks [get]
+// @Description	Get the question banks of a course with how many questions each one holds
+// @Tags		question-banks
+// @Produce		json
+// @Param		id	path	int	true	"Course ID"
+// @Success		200	{array}		models.QuestionBank	"Question banks"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Course not found"
+// @Security     BearerAuth
+func (h *QuestionBankHandler) ListBanks(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	banks, err := h.questionBankService.ListBanks(uint(courseID), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener los bancos de preguntas")
+		return
+	}
+
+	responses.Ok(c, banks)
+}
+
+// @Summary		Create question bank
+// @Router			/api/v1/courses/{id}/question-banks [post]
+// @Description	Create a bank of reusable questions for a course
+// @Tags		question-banks
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int								true	"Course ID"
+// @Param		bank	body	dto.CreateQuestionBankRequest	true	"Bank data"
+// @Success		201	{object}	models.QuestionBank	"Question bank created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Course not found"
+// @Security     BearerAuth
+func (h *QuestionBankHandler) CreateBank(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	var request dto.CreateQuestionBankRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	bank, err := h.questionBankService.CreateBank(uint(courseID), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear el banco de preguntas")
+		return
+	}
+
+	c.JSON(http.StatusCreated, bank)
+}
+
+// @Summary		Get question bank
+// @Router			/api/v1/question-banks/{id} [get]
+// @Description	Get a question bank with how many questions it holds
+// @Tags		question-banks
+// @Produce		json
+// @Param		id	path	int	true	"Question bank ID"
+// @Success		200	{object}	models.QuestionBank	"Question bank"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Question bank not found"
+// @Security     BearerAuth
+func (h *QuestionBankHandler) GetBank(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de banco de preguntas inválido")
+		return
+	}
+
+	bank, err := h.questionBankService.GetBank(uint(id), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el banco de preguntas")
+		return
+	}
+
+	responses.Ok(c, bank)
+}
+
+// @Summary		Update question bank
+// @Router			/api/v1/question-banks/{id} [patch]
+// @Description	Update the name or description of a question bank
+// @Tags		question-banks
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int								true	"Question bank ID"
+// @Param		bank	body	dto.UpdateQuestionBankRequest	true	"Fields to update"
+// @Success		200	{object}	models.QuestionBank	"Updated question bank"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Question bank not found"
+// @Security     BearerAuth
+func (h *QuestionBankHandler) UpdateBankPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de banco de preguntas inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	bank, err := h.questionBankService.UpdateBankPatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar el banco de preguntas")
+		return
+	}
+
+	responses.Ok(c, bank)
+}
+
+// @Summary		Delete question bank
+// @Router			/api/v1/question-banks/{id} [delete]
+// @Description	Delete a question bank with its questions and the draw rules that use it. Past attempts keep their questions
+// @Tags		question-banks
+// @Produce		json
+// @Param		id	path	int	true	"Question bank ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Question bank not found"
+// @Security     BearerAuth
+func (h *QuestionBankHandler) DeleteBank(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de banco de preguntas inválido")
+		return
+	}
+
+	if err := h.questionBankService.DeleteBank(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar el banco de preguntas")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Get bank questions
+// @Router			/api/v1/question-banks/{id}/questions [get]
+// @Description	Get the questions of a bank with their answers and tags, optionally only those a draw rule with the same tag and difficulty would pick
+// @Tags		question-banks
+// @Produce		json
+// @Param		id			path	int		true	"Question bank ID"
+// @Param		tag			query	string	false	"Tag name or slug"
+// @Param		difficulty	query	string	false	"Difficulty (easy, medium, hard)"
+// @Success		200	{array}		models.Question	"Bank questions"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Question bank not found"
+// @Security     BearerAuth
+func (h *QuestionBankHandler) GetBankQuestions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de banco de preguntas inválido")
+		return
+	}
+
+	var filter dto.BankQuestionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		responses.ErrorBadRequest(c, "Filtros inválidos: "+err.Error())
+		return
+	}
+
+	questions, err := h.questionBankService.GetBankQuestions(uint(id), currentUserID(c), &filter)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las preguntas del banco")
+		return
+	}
+
+	responses.Ok(c, questions)
+}
+
+// @Summary		Get evaluation draw rules
+// @Router			/api/v1/evaluations/{id}/draw-rules [get]
+// @Description	Get the rules that draw questions from the course banks for each attempt of the evaluation
+// @Tags		question-banks
+// @Produce		json
+// @Param		id	path	int	true	"Evaluation ID"
+// @Success		200	{array}		models.EvaluationDrawRule	"Draw rules"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Evaluation not found"
+// @Security     BearerAuth
+func (h *QuestionBankHandler) GetDrawRules(c *gin.Context) {
+	evaluationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de evaluación inválido")
+		return
+	}
+
+	rules, err := h.questionBankService.GetDrawRules(uint(evaluationID), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las reglas de sorteo")
+		return
+	}
+
+	responses.Ok(c, rules)
+}
+
+// @Summary		Set evaluation draw rules
+// @Router			/api/v1/evaluations/{id}/draw-rules [put]
+// @Description	Replace the draw rules of an evaluation, e.g. 3 questions tagged "loops" from one bank and 2 hard ones from another. Each attempt draws them on top of the evaluation's own question_count
+// @Tags		question-banks
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Evaluation ID"
+// @Param		payload	body	dto.SetDrawRulesRequest		true	"Draw rules"
+// @Success		200	{array}		models.EvaluationDrawRule	"Draw rules"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Evaluation or question bank not found"
+// @Security     BearerAuth
+func (h *QuestionBankHandler) SetDrawRules(c *gin.Context) {
+	evaluationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de evaluación inválido")
+		return
+	}
+
+	var request dto.SetDrawRulesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	rules, err := h.questionBankService.SetDrawRules(uint(evaluationID), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al guardar las reglas de sorteo")
+		return
+	}
+
+	responses.Ok(c, rules)
+}
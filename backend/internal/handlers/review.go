@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type ReviewHandler struct {
+	*Handler
+	reviewService services.ReviewService
+}
+
+func NewReviewHandler(handler *Handler, reviewService services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		Handler:       handler,
+		reviewService: reviewService,
+	}
+}
+
+// @Summary		Get course reviews
+// @Router			/api/v1/courses/{id}/reviews [get]
+// @Description	Get the visible reviews of a course with their instructor replies
+// @Tags		reviews
+// @Produce		json
+// @Param		id		path	int		true	"Course ID"
+// @Param		limit	query	int		false	"Page size (max 100)"
+// @Param		offset	query	int		false	"Number of items to skip"
+// @Param		cursor	query	string	false	"Cursor returned by the previous page"
+// @Param		sort	query	string	false	"Sort field (created_at, rating), prefix with - for descending"
+// @Param		rating	query	int		false	"Filter by rating (1-5)"
+// @Success		200	{object}	dto.Page[models.Review]	"Reviews"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *ReviewHandler) GetCourseReviews(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	reviews, err := h.reviewService.GetCourseReviews(uint(courseID), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las reseñas")
+		return
+	}
+
+	responses.Ok(c, reviews)
+}
+
+// @Summary		Get my course review
+// @Router			/api/v1/courses/{id}/reviews/me [get]
+// @Description	Get the review the current user wrote for a course, including hidden ones
+// @Tags		reviews
+// @Produce		json
+// @Param		id	path	int	true	"Course ID"
+// @Success		200	{object}	models.Review	"Review"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Review not found"
+// @Security     BearerAuth
+func (h *ReviewHandler) GetMyCourseReview(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	review, err := h.reviewService.GetUserCourseReview(currentUserID(c), uint(courseID))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la reseña")
+		return
+	}
+
+	responses.Ok(c, review)
+}
+
+// @Summary		Create review
+// @Router			/api/v1/courses/{id}/reviews [post]
+// @Description	Rate a course from 1 to 5 stars with an optional comment. Only enrolled learners can review, once per course
+// @Tags		reviews
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Course ID"
+// @Param		review	body	dto.CreateReviewRequest		true	"Review data"
+// @Success		201	{object}	models.Review	"Review created"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Not enrolled"
+// @Failure		404	{object}	responses.ErrorResponse	"Course not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Review already exists"
+// @Security     BearerAuth
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	var request dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(uint(courseID), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al crear la reseña")
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
+}
+
+// @Summary		Update review
+// @Router			/api/v1/reviews/{id} [patch]
+// @Description	Edit the rating or comment of the current user's review
+// @Tags		reviews
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Review ID"
+// @Param		review	body	dto.UpdateReviewRequest		true	"Fields to update"
+// @Success		200	{object}	models.Review	"Updated review"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Review not found"
+// @Security     BearerAuth
+func (h *ReviewHandler) UpdateReviewPatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de reseña inválido")
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	review, err := h.reviewService.UpdateReviewPatch(uint(id), currentUserID(c), payload)
+	if err != nil {
+		h.handleError(c, err, "Error al actualizar la reseña")
+		return
+	}
+
+	responses.Ok(c, review)
+}
+
+// @Summary		Delete review
+// @Router			/api/v1/reviews/{id} [delete]
+// @Description	Delete a review. Authors delete their own reviews; instructors and admins can delete any
+// @Tags		reviews
+// @Produce		json
+// @Param		id	path	int	true	"Review ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Review not found"
+// @Security     BearerAuth
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de reseña inválido")
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar la reseña")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Reply to review
+// @Router			/api/v1/reviews/{id}/reply [post]
+// @Description	Publish or replace the instructor reply to a review. The author is notified
+// @Tags		reviews
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Review ID"
+// @Param		reply	body	dto.ReplyReviewRequest		true	"Reply"
+// @Success		200	{object}	models.Review	"Review"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Review not found"
+// @Security     BearerAuth
+func (h *ReviewHandler) ReplyReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de reseña inválido")
+		return
+	}
+
+	var request dto.ReplyReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	review, err := h.reviewService.ReplyReview(uint(id), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al responder la reseña")
+		return
+	}
+
+	responses.Ok(c, review)
+}
+
+// @Summary		Report review
+// @Router			/api/v1/reviews/{id}/report [post]
+// @Description	Report an inappropriate review to the moderation queue
+// @Tags		reviews
+// @Accept		json
+// @Produce		json
+// @Param		id		path	int							true	"Review ID"
+// @Param		report	body	dto.ReportReviewRequest		false	"Report reason"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Review not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Already reported"
+// @Security     BearerAuth
+func (h *ReviewHandler) ReportReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de reseña inválido")
+		return
+	}
+
+	var request dto.ReportReviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			responses.ErrorBindJson(c, err)
+			return
+		}
+	}
+
+	if err := h.reviewService.ReportReview(uint(id), currentUserID(c), &request); err != nil {
+		h.handleError(c, err, "Error al reportar la reseña")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Get review moderation queue
+// @Router			/api/v1/reviews/moderation [get]
+// @Description	Get the reviews with pending reports and their reasons. Instructors and admins only
+// @Tags		reviews
+// @Produce		json
+// @Param		limit		query	int		false	"Page size (max 100)"
+// @Param		offset		query	int		false	"Number of items to skip"
+// @Param		cursor		query	string	false	"Cursor returned by the previous page"
+// @Param		sort		query	string	false	"Sort field (created_at, rating, report_count), prefix with - for descending"
+// @Param		course_id	query	int		false	"Filter by course"
+// @Param		status		query	string	false	"Filter by status (visible, hidden)"
+// @Success		200	{object}	dto.Page[models.Review]	"Reported reviews"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Security     BearerAuth
+func (h *ReviewHandler) GetModerationQueue(c *gin.Context) {
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	reviews, err := h.reviewService.GetModerationQueue(currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la cola de moderación")
+		return
+	}
+
+	responses.Ok(c, reviews)
+}
+
+// @Summary		Moderate review
+// @Router			/api/v1/reviews/{id}/moderate [post]
+// @Description	Hide, unhide or dismiss the reports of a review. Hidden reviews do not count in the course rating
+// @Tags		reviews
+// @Accept		json
+// @Produce		json
+// @Param		id			path	int								true	"Review ID"
+// @Param		moderation	body	dto.ModerateReviewRequest		true	"Moderation action (hide, unhide, dismiss)"
+// @Success		200	{object}	models.Review	"Review"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Review not found"
+// @Security     BearerAuth
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de reseña inválido")
+		return
+	}
+
+	var request dto.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	review, err := h.reviewService.ModerateReview(uint(id), currentUserID(c), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al moderar la reseña")
+		return
+	}
+
+	responses.Ok(c, review)
+}
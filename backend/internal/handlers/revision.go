@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type RevisionHandler struct {
+	*Handler
+	revisionService services.RevisionService
+}
+
+func NewRevisionHandler(handler *Handler, revisionService services.RevisionService) *RevisionHandler {
+	return &RevisionHandler{
+		Handler:         handler,
+		revisionService: revisionService,
+	}
+}
+
+// @Summary		List revisions
+// @Router			/api/v1/revisions/{type}/{id} [get]
+// @Description	Get the revision history of a content, evaluation, question or answer, newest first
+// @Tags		revisions
+// @Produce		json
+// @Param		type	path	string	true	"Entity type (content, evaluation, question, answer)"
+// @Param		id		path	int		true	"Entity ID"
+// @Param		limit	query	int		false	"Page size (max 100)"
+// @Param		offset	query	int		false	"Number of items to skip"
+// @Param		cursor	query	string	false	"Cursor returned by the previous page"
+// @Param		sort	query	string	false	"Sort field (version, created_at), prefix with - for descending"
+// @Success		200	{object}	dto.Page[models.Revision]	"Revisions"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *RevisionHandler) ListRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	request, err := bindListRequest(c)
+	if err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de paginación inválidos: "+err.Error())
+		return
+	}
+
+	revisions, err := h.revisionService.ListRevisions(enums.RevisionEntityType(c.Param("type")), uint(id), currentUserID(c), request)
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las revisiones")
+		return
+	}
+
+	responses.Ok(c, revisions)
+}
+
+// @Summary		Get revision
+// @Router			/api/v1/revisions/{type}/{id}/versions/{version} [get]
+// @Description	Get a single revision with the full snapshot of the tracked fields
+// @Tags		revisions
+// @Produce		json
+// @Param		type	path	string	true	"Entity type (content, evaluation, question, answer)"
+// @Param		id		path	int		true	"Entity ID"
+// @Param		version	path	int		true	"Revision version"
+// @Success		200	{object}	models.Revision	"Revision"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Revision not found"
+// @Security     BearerAuth
+func (h *RevisionHandler) GetRevision(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		responses.ErrorBadRequest(c, "Versión inválida")
+		return
+	}
+
+	revision, err := h.revisionService.GetRevision(enums.RevisionEntityType(c.Param("type")), uint(id), version, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la revisión")
+		return
+	}
+
+	responses.Ok(c, revision)
+}
+
+// @Summary		Diff revisions
+// @Router			/api/v1/revisions/{type}/{id}/diff [get]
+// @Description	Compare two revisions field by field, with a line diff for multiline text
+// @Tags		revisions
+// @Produce		json
+// @Param		type	path	string	true	"Entity type (content, evaluation, question, answer)"
+// @Param		id		path	int		true	"Entity ID"
+// @Param		from	query	int		true	"Base revision version"
+// @Param		to		query	int		true	"Compared revision version"
+// @Success		200	{object}	dto.RevisionDiff	"Differences"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Revision not found"
+// @Security     BearerAuth
+func (h *RevisionHandler) DiffRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		responses.ErrorBadRequest(c, "Los parámetros from y to deben ser versiones válidas")
+		return
+	}
+
+	diff, err := h.revisionService.Diff(enums.RevisionEntityType(c.Param("type")), uint(id), from, to, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al comparar las revisiones")
+		return
+	}
+
+	responses.Ok(c, diff)
+}
+
+// @Summary		Roll back to a revision
+// @Router			/api/v1/revisions/{type}/{id}/rollback [post]
+// @Description	Restore the fields stored in a previous revision; the rollback is recorded as a new revision
+// @Tags		revisions
+// @Accept		json
+// @Produce		json
+// @Param		type	path	string	true	"Entity type (content, evaluation, question, answer)"
+// @Param		id		path	int		true	"Entity ID"
+// @Param		request	body	dto.RollbackRevisionRequest	true	"Version to restore"
+// @Success		200	{object}	models.Revision	"New revision"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Revision not found"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *RevisionHandler) Rollback(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	var request dto.RollbackRevisionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	revision, err := h.revisionService.Rollback(enums.RevisionEntityType(c.Param("type")), uint(id), request.Version, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al restaurar la revisión")
+		return
+	}
+
+	responses.Ok(c, revision)
+}
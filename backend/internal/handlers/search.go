@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type SearchHandler struct {
+	*Handler
+	searchService services.SearchService
+}
+
+func NewSearchHandler(handler *Handler, searchService services.SearchService) *SearchHandler {
+	return &SearchHandler{
+		Handler:       handler,
+		searchService: searchService,
+	}
+}
+
+// @Summary		Search course material
+// @Router			/api/v1/search [get]
+// @Description	Full-text search over courses, modules, contents, questions and video transcripts with ranking and highlighted snippets
+// @Tags		search
+// @Produce		json
+// @Param		q			query	string	true	"Search terms"
+// @Param		course_id	query	int		false	"Restrict results to a course"
+// @Param		lang		query	string	false	"Search language (es, en)"
+// @Param		locale		query	string	false	"Locale (es, en); defaults to the user preference, then Accept-Language"
+// @Param		limit		query	int		false	"Maximum number of results"
+// @Param		offset		query	int		false	"Number of results to skip"
+// @Success		200	{array}		dto.SearchResult	"Search results"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		401	{object}	responses.ErrorResponse	"No autorizado"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *SearchHandler) Search(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		responses.ErrorUnauthorized(c, "Usuario no autenticado")
+		return
+	}
+
+	var request dto.SearchRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		responses.ErrorBadRequest(c, "Parámetros de búsqueda inválidos: "+err.Error())
+		return
+	}
+
+	results, err := h.searchService.Search(userID.(uint), &request, requestLocale(c))
+	if err != nil {
+		h.handleError(c, err, "Error al realizar la búsqueda")
+		return
+	}
+
+	responses.Ok(c, results)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type TagHandler struct {
+	*Handler
+	tagService services.TagService
+}
+
+func NewTagHandler(handler *Handler, tagService services.TagService) *TagHandler {
+	return &TagHandler{
+		Handler:    handler,
+		tagService: tagService,
+	}
+}
+
+// @Summary		Get all tags
+// @Router			/api/v1/tags [get]
+// @Description	Get every course tag
+// @Tags		tags
+// @Produce		json
+// @Success		200	{array}		models.Tag	"Tags"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *TagHandler) GetAllTags(c *gin.Context) {
+	tags, err := h.tagService.GetAllTags()
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las etiquetas")
+		return
+	}
+
+	responses.Ok(c, tags)
+}
+
+// @Summary		Delete tag
+// @Router			/api/v1/tags/{id} [delete]
+// @Description	Delete a tag and remove it from every course
+// @Tags		tags
+// @Param		id	path	int	true	"Tag ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de etiqueta inválido")
+		return
+	}
+
+	if err := h.tagService.DeleteTag(uint(id)); err != nil {
+		h.logger.Errorf("Error al eliminar la etiqueta: %v", err)
+		responses.ErrorInternalServerWithMessage(c, "Error al eliminar la etiqueta")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
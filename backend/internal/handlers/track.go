@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type TrackHandler struct {
+	*Handler
+	trackService services.TrackService
+}
+
+func NewTrackHandler(handler *Handler, trackService services.TrackService) *TrackHandler {
+	return &TrackHandler{
+		Handler:      handler,
+		trackService: trackService,
+	}
+}
+
+// @Summary		Get content tracks
+// @Router			/api/v1/content/{id}/tracks [get]
+// @Description	Get the caption and transcript tracks of a video or audio content
+// @Tags		tracks
+// @Produce		json
+// @Param		id	path	int	true	"Content ID"
+// @Success		200	{array}		models.ContentTrack	"Tracks"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		404	{object}	responses.ErrorResponse	"Content not found"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+func (h *TrackHandler) GetTracks(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de contenido inválido")
+		return
+	}
+
+	tracks, err := h.trackService.GetTracks(uint(id))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las pistas")
+		return
+	}
+
+	responses.Ok(c, tracks)
+}
+
+// @Summary		Upload content track
+// @Router			/api/v1/content/{id}/tracks [post]
+// @Description	Upload a WebVTT or SRT caption file, or a plain text transcript, for a video or audio content. Captions are validated and stored as WebVTT; a new upload replaces the track of the same kind and language
+// @Tags		tracks
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param		id		path		int		true	"Content ID"
+// @Param		file	formData	file	true	"Caption or transcript file"
+// @Param		kind	formData	string	true	"Track kind (captions, transcript)"
+// @Param		locale	formData	string	true	"Language tag, e.g. es or en-US"
+// @Param		label	formData	string	false	"Label shown in the player"
+// @Success		201	{object}	models.ContentTrack	"Track uploaded"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Content not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Content has no media"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *TrackHandler) UploadTrack(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de contenido inválido")
+		return
+	}
+
+	var request dto.UploadTrackRequest
+	if err := c.ShouldBind(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		responses.ErrorBadRequest(c, "Archivo inválido: "+err.Error())
+		return
+	}
+
+	track, err := h.trackService.UploadTrack(uint(id), &request, file, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al subir la pista")
+		return
+	}
+
+	c.JSON(http.StatusCreated, track)
+}
+
+// @Summary		Delete content track
+// @Router			/api/v1/tracks/{id} [delete]
+// @Description	Delete a caption or transcript track. Its file is deleted from storage after a grace period
+// @Tags		tracks
+// @Produce		json
+// @Param		id	path	int	true	"Track ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Track not found"
+// @Security     BearerAuth
+func (h *TrackHandler) DeleteTrack(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de pista inválido")
+		return
+	}
+
+	if err := h.trackService.DeleteTrack(uint(id), currentUserID(c)); err != nil {
+		h.handleError(c, err, "Error al eliminar la pista")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type TranslationHandler struct {
+	*Handler
+	translationService services.TranslationService
+}
+
+func NewTranslationHandler(handler *Handler, translationService services.TranslationService) *TranslationHandler {
+	return &TranslationHandler{
+		Handler:            handler,
+		translationService: translationService,
+	}
+}
+
+// @Summary		Get translations
+// @Router			/api/v1/translations/{type}/{id} [get]
+// @Description	Get the translated fields of a course, module, content, evaluation, question or answer in every locale
+// @Tags		translations
+// @Produce		json
+// @Param		type	path	string	true	"Entity type (course, module, content, evaluation, question, answer)"
+// @Param		id		path	int		true	"Entity ID"
+// @Success		200	{array}		models.Translation	"Translations"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+func (h *TranslationHandler) GetTranslations(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	translations, err := h.translationService.GetTranslations(enums.TranslationEntityType(c.Param("type")), uint(id))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener las traducciones")
+		return
+	}
+
+	responses.Ok(c, translations)
+}
+
+// @Summary		Set translations
+// @Router			/api/v1/translations/{type}/{id}/{locale} [put]
+// @Description	Set the text of translatable fields of an entity in a locale. Fields sent empty lose their translation and fall back to the course language
+// @Tags		translations
+// @Accept		json
+// @Produce		json
+// @Param		type			path	string						true	"Entity type (course, module, content, evaluation, question, answer)"
+// @Param		id				path	int							true	"Entity ID"
+// @Param		locale			path	string						true	"Locale (es, en)"
+// @Param		translations	body	dto.SetTranslationsRequest	true	"Translated fields"
+// @Success		200	{array}		models.Translation	"Translations of the entity"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Entity not found"
+// @Security     BearerAuth
+func (h *TranslationHandler) SetTranslations(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	var request dto.SetTranslationsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	translations, err := h.translationService.SetTranslations(currentUserID(c), enums.TranslationEntityType(c.Param("type")), uint(id), c.Param("locale"), &request)
+	if err != nil {
+		h.handleError(c, err, "Error al guardar las traducciones")
+		return
+	}
+
+	responses.Ok(c, translations)
+}
+
+// @Summary		Delete translations
+// @Router			/api/v1/translations/{type}/{id}/{locale} [delete]
+// @Description	Delete every translated field of an entity in a locale
+// @Tags		translations
+// @Produce		json
+// @Param		type	path	string	true	"Entity type (course, module, content, evaluation, question, answer)"
+// @Param		id		path	int		true	"Entity ID"
+// @Param		locale	path	string	true	"Locale (es, en)"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Security     BearerAuth
+func (h *TranslationHandler) DeleteTranslations(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	if err := h.translationService.DeleteTranslations(currentUserID(c), enums.TranslationEntityType(c.Param("type")), uint(id), c.Param("locale")); err != nil {
+		h.handleError(c, err, "Error al eliminar las traducciones")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
+
+// @Summary		Get course translation completeness
+// @Router			/api/v1/courses/{id}/translations/completeness [get]
+// @Description	Report, for every locale other than the course language, how many text fields of the course, its modules, contents, evaluations, questions and answers are translated and which are missing
+// @Tags		translations
+// @Produce		json
+// @Param		id	path	int	true	"Course ID"
+// @Success		200	{object}	dto.CourseTranslationCompleteness	"Completeness report"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Course not found"
+// @Security     BearerAuth
+func (h *TranslationHandler) GetCourseCompleteness(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	report, err := h.translationService.GetCourseCompleteness(currentUserID(c), uint(courseID))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener el estado de las traducciones")
+		return
+	}
+
+	responses.Ok(c, report)
+}
+
+// @Summary		Set preferred locale
+// @Router			/auth/me/locale [put]
+// @Description	Set the locale the current user sees courses in. An empty locale goes back to Accept-Language
+// @Tags		translations
+// @Accept		json
+// @Produce		json
+// @Param		locale	body	dto.SetLocaleRequest	true	"Locale (es, en)"
+// @Success		200	{object}	models.User	"Updated user"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Security     BearerAuth
+func (h *TranslationHandler) SetMyLocale(c *gin.Context) {
+	var request dto.SetLocaleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.ErrorBindJson(c, err)
+		return
+	}
+
+	user, err := h.translationService.SetUserLocale(currentUserID(c), request.Locale)
+	if err != nil {
+		h.handleError(c, err, "Error al guardar el idioma")
+		return
+	}
+
+	responses.Ok(c, user)
+}
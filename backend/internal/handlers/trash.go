@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/responses"
+	"github.com/imlargo/go-api-template/internal/services"
+)
+
+type TrashHandler struct {
+	*Handler
+	trashService services.TrashService
+}
+
+func NewTrashHandler(handler *Handler, trashService services.TrashService) *TrashHandler {
+	return &TrashHandler{
+		Handler:      handler,
+		trashService: trashService,
+	}
+}
+
+// @Summary		Get deleted courses
+// @Router			/api/v1/trash/courses [get]
+// @Description	Get the courses in the trash with the date they will be permanently purged
+// @Tags		trash
+// @Produce		json
+// @Success		200	{array}		dto.TrashItem	"Deleted courses"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *TrashHandler) GetDeletedCourses(c *gin.Context) {
+	items, err := h.trashService.GetDeletedCourses(currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la papelera")
+		return
+	}
+
+	responses.Ok(c, items)
+}
+
+// @Summary		Get course trash
+// @Router			/api/v1/courses/{id}/trash [get]
+// @Description	Get the deleted modules, contents, evaluations, questions and answers of a course
+// @Tags		trash
+// @Produce		json
+// @Param		id	path	int	true	"Course ID"
+// @Success		200	{array}		dto.TrashItem	"Deleted items"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		500	{object}	responses.ErrorResponse	"Internal Server Error"
+// @Security     BearerAuth
+func (h *TrashHandler) GetCourseTrash(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID de curso inválido")
+		return
+	}
+
+	items, err := h.trashService.GetCourseTrash(uint(courseID), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al obtener la papelera del curso")
+		return
+	}
+
+	responses.Ok(c, items)
+}
+
+// @Summary		Restore deleted item
+// @Router			/api/v1/trash/{type}/{id}/restore [post]
+// @Description	Restore a deleted item together with everything that was deleted with it
+// @Tags		trash
+// @Produce		json
+// @Param		type	path	string	true	"Item type (course, module, content, evaluation, question, answer)"
+// @Param		id		path	int		true	"Item ID"
+// @Success		200	{string}	string	"ok"
+// @Failure		400	{object}	responses.ErrorResponse	"Bad Request"
+// @Failure		403	{object}	responses.ErrorResponse	"Forbidden"
+// @Failure		404	{object}	responses.ErrorResponse	"Item not found"
+// @Failure		409	{object}	responses.ErrorResponse	"Parent item is in the trash"
+// @Security     BearerAuth
+func (h *TrashHandler) Restore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		responses.ErrorBadRequest(c, "ID inválido")
+		return
+	}
+
+	err = h.trashService.Restore(enums.TrashEntityType(c.Param("type")), uint(id), currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Error al restaurar el elemento")
+		return
+	}
+
+	responses.Ok(c, "ok")
+}
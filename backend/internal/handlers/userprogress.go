@@ -31,6 +31,7 @@ func NewUserProgressHandler(handler *Handler, userProgressService services.UserP
 // @Param data body object true "Content completion data"
 // @Success 201 {object} object
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{} "Module not released yet"
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/user-progress/complete [post]
 func (h *UserProgressHandler) MarkContentComplete(c *gin.Context) {
@@ -48,8 +49,7 @@ func (h *UserProgressHandler) MarkContentComplete(c *gin.Context) {
 
 	progress, err := h.userProgressService.MarkContentComplete(req.UserID, req.CourseID, req.ModuleID, req.ContentID)
 	if err != nil {
-		h.logger.Errorf("Error al marcar contenido como completado: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo marcar el contenido como completado")
+		h.handleError(c, err, "Error al marcar contenido como completado")
 		return
 	}
 
@@ -81,8 +81,7 @@ func (h *UserProgressHandler) MarkContentIncomplete(c *gin.Context) {
 
 	err := h.userProgressService.MarkContentIncomplete(req.UserID, req.CourseID, req.ModuleID, req.ContentID)
 	if err != nil {
-		h.logger.Errorf("Error al marcar contenido como incompleto: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo marcar el contenido como incompleto")
+		h.handleError(c, err, "Error al marcar contenido como incompleto")
 		return
 	}
 
@@ -114,8 +113,7 @@ func (h *UserProgressHandler) GetUserCourseProgress(c *gin.Context) {
 
 	progress, err := h.userProgressService.GetUserProgress(uint(userID), uint(courseID))
 	if err != nil {
-		h.logger.Errorf("Error al obtener progreso del curso: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo obtener el progreso del curso")
+		h.handleError(c, err, "Error al obtener progreso del curso")
 		return
 	}
 
@@ -147,8 +145,7 @@ func (h *UserProgressHandler) GetUserModuleProgress(c *gin.Context) {
 
 	progress, err := h.userProgressService.GetUserModuleProgress(uint(userID), uint(moduleID))
 	if err != nil {
-		h.logger.Errorf("Error al obtener progreso del módulo: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo obtener el progreso del módulo")
+		h.handleError(c, err, "Error al obtener progreso del módulo")
 		return
 	}
 
@@ -180,8 +177,7 @@ func (h *UserProgressHandler) CalculateCourseProgress(c *gin.Context) {
 
 	progressPercentage, err := h.userProgressService.CalculateCourseProgress(uint(userID), uint(courseID))
 	if err != nil {
-		h.logger.Errorf("Error al calcular progreso del curso: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo calcular el progreso del curso")
+		h.handleError(c, err, "Error al calcular progreso del curso")
 		return
 	}
 
@@ -213,8 +209,7 @@ func (h *UserProgressHandler) CalculateModuleProgress(c *gin.Context) {
 
 	progressPercentage, err := h.userProgressService.CalculateModuleProgress(uint(userID), uint(moduleID))
 	if err != nil {
-		h.logger.Errorf("Error al calcular progreso del módulo: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo calcular el progreso del módulo")
+		h.handleError(c, err, "Error al calcular progreso del módulo")
 		return
 	}
 
@@ -247,8 +242,7 @@ func (h *UserProgressHandler) GetUserContentProgress(c *gin.Context) {
 
 	progress, err := h.userProgressService.GetUserProgressForContent(uint(userID), uint(contentID))
 	if err != nil {
-		h.logger.Errorf("Error al obtener progreso del contenido: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo obtener el progreso del contenido")
+		h.handleError(c, err, "Error al obtener progreso del contenido")
 		return
 	}
 
@@ -280,8 +274,7 @@ func (h *UserProgressHandler) CheckEvaluationPassed(c *gin.Context) {
 
 	hasPassed, err := h.userProgressService.HasUserPassedEvaluation(uint(userID), uint(evaluationID))
 	if err != nil {
-		h.logger.Errorf("Error al verificar si el usuario pasó la evaluación: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo verificar el estado de la evaluación")
+		h.handleError(c, err, "Error al verificar si el usuario pasó la evaluación")
 		return
 	}
 
@@ -315,8 +308,7 @@ func (h *UserProgressHandler) UpdateUserProgressPatch(c *gin.Context) {
 
 	progress, err := h.userProgressService.UpdateUserProgressPatch(uint(id), updateData)
 	if err != nil {
-		h.logger.Errorf("Error al actualizar progreso: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo actualizar el progreso")
+		h.handleError(c, err, "Error al actualizar progreso")
 		return
 	}
 
@@ -348,8 +340,7 @@ func (h *UserProgressHandler) GetComprehensiveCourseProgress(c *gin.Context) {
 
 	progressSummary, err := h.userProgressService.GetComprehensiveCourseProgress(uint(userID), uint(courseID))
 	if err != nil {
-		h.logger.Errorf("Error al obtener resumen de progreso del curso: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo obtener el resumen de progreso del curso")
+		h.handleError(c, err, "Error al obtener resumen de progreso del curso")
 		return
 	}
 
@@ -381,8 +372,7 @@ func (h *UserProgressHandler) GetModuleContentProgress(c *gin.Context) {
 
 	contentProgress, err := h.userProgressService.GetModuleContentProgress(uint(userID), uint(moduleID))
 	if err != nil {
-		h.logger.Errorf("Error al obtener progreso de contenidos del módulo: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo obtener el progreso de contenidos del módulo")
+		h.handleError(c, err, "Error al obtener progreso de contenidos del módulo")
 		return
 	}
 
@@ -407,8 +397,7 @@ func (h *UserProgressHandler) GetRecentUserProgress(c *gin.Context) {
 
 	progress, err := h.userProgressService.GetRecentUserProgress(uint(userID))
 	if err != nil {
-		h.logger.Errorf("Error al obtener progreso reciente del usuario: %v", err)
-		responses.ErrorInternalServerWithMessage(c, "No se pudo obtener el progreso reciente del usuario")
+		h.handleError(c, err, "Error al obtener progreso reciente del usuario")
 		return
 	}
 
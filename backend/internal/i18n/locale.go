@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// DefaultLocale is the language of the platform and of untranslated texts
+const DefaultLocale = enums.LocaleSpanish
+
+// SupportedLocales are the locales courses can be taught in and translated to
+var SupportedLocales = []enums.Locale{enums.LocaleSpanish, enums.LocaleEnglish}
+
+// NormalizeLocale reduces a language tag such as "en-US" to a supported locale
+func NormalizeLocale(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, locale := range SupportedLocales {
+		if tag == string(locale) {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+// ParseAcceptLanguage returns the supported locale with the highest weight in an
+// Accept-Language header, e.g. "en-US,en;q=0.9,es;q=0.8"
+func ParseAcceptLanguage(header string) (string, bool) {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+	var candidates []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale, ok := NormalizeLocale(tag)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, weighted{locale, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale, true
+}
@@ -44,3 +44,18 @@ func AuthTokenMiddleware(jwtAuthenticator *jwt.JWT) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// OptionalAuthTokenMiddleware identifies the user when a valid bearer token is sent,
+// without rejecting anonymous requests
+func OptionalAuthTokenMiddleware(jwtAuthenticator *jwt.JWT) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		parts := strings.SplitN(ctx.GetHeader("Authorization"), " ", 2)
+		if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" && parts[1] != "" {
+			if tokenData, err := jwtAuthenticator.ParseToken(parts[1]); err == nil {
+				ctx.Set("userID", tokenData.UserID)
+			}
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// ActivitySession - periodo continuo de actividad de un estudiante sobre un contenido o evaluación
+type ActivitySession struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID         uint                   `json:"user_id" gorm:"not null;index:idx_activity_sessions_user_item,priority:1"`
+	CourseID       uint                   `json:"course_id" gorm:"not null;index"`
+	ModuleID       uint                   `json:"module_id" gorm:"not null;index"`
+	ItemType       enums.ActivityItemType `json:"item_type" gorm:"not null;index:idx_activity_sessions_user_item,priority:2"`
+	ItemID         uint                   `json:"item_id" gorm:"not null;index:idx_activity_sessions_user_item,priority:3"`
+	StartedAt      time.Time              `json:"started_at" gorm:"not null"`
+	LastActivityAt time.Time              `json:"last_activity_at" gorm:"not null;index"`
+	EndedAt        *time.Time             `json:"ended_at" gorm:"index"`
+	ActiveSeconds  int                    `json:"active_seconds" gorm:"not null;default:0"`
+
+	// Relaciones
+	User   *User   `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Course *Course `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+}
+
+func (ActivitySession) TableName() string {
+	return "activity_sessions"
+}
+
+// DailyActivity - tiempo activo acumulado por estudiante, módulo y día
+type DailyActivity struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID        uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_daily_activity_user_module_day,priority:1"`
+	CourseID      uint      `json:"course_id" gorm:"not null;index"`
+	ModuleID      uint      `json:"module_id" gorm:"not null;uniqueIndex:idx_daily_activity_user_module_day,priority:2"`
+	Day           time.Time `json:"day" gorm:"type:date;not null;uniqueIndex:idx_daily_activity_user_module_day,priority:3"`
+	ActiveSeconds int       `json:"active_seconds" gorm:"not null;default:0"`
+
+	// Relaciones
+	User   *User   `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Course *Course `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+}
+
+func (DailyActivity) TableName() string {
+	return "daily_activity"
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// Announcement - anuncio de un instructor para todos los inscritos de un curso
+type Announcement struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	CourseID    uint                     `json:"course_id" gorm:"not null;index"`
+	AuthorID    uint                     `json:"author_id" gorm:"not null"`
+	Title       string                   `json:"title" gorm:"not null"`
+	Body        string                   `json:"body" gorm:"type:text"`
+	Status      enums.AnnouncementStatus `json:"status" gorm:"not null;default:'scheduled';index"`
+	PublishAt   time.Time                `json:"publish_at" gorm:"not null;index"`
+	PublishedAt *time.Time               `json:"published_at"`
+
+	// Avance del envío: permite retomar un envío interrumpido desde el último destinatario
+	LastRecipientID uint `json:"-" gorm:"not null;default:0"`
+	RecipientCount  int  `json:"recipient_count" gorm:"not null;default:0"`
+	ReadCount       int  `json:"read_count" gorm:"not null;default:0"`
+
+	// Calculado por usuario
+	Read bool `json:"read" gorm:"-"`
+
+	// Relaciones
+	Course *Course `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Author *User   `json:"author,omitempty" gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE"`
+}
+
+func (Announcement) TableName() string {
+	return "announcements"
+}
+
+// AnnouncementRead - registro de lectura de un anuncio por un usuario
+type AnnouncementRead struct {
+	ID     uint      `json:"id" gorm:"primarykey"`
+	ReadAt time.Time `json:"read_at" gorm:"not null"`
+
+	AnnouncementID uint `json:"announcement_id" gorm:"not null;uniqueIndex:idx_announcement_reads_user,priority:1"`
+	UserID         uint `json:"user_id" gorm:"not null;uniqueIndex:idx_announcement_reads_user,priority:2"`
+
+	// Relaciones
+	Announcement *Announcement `json:"-" gorm:"foreignKey:AnnouncementID;constraint:OnDelete:CASCADE"`
+	User         *User         `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (AnnouncementRead) TableName() string {
+	return "announcement_reads"
+}
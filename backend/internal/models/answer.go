@@ -1,18 +1,34 @@
 package models
 
-import "time"
+import (
+	"time"
 
-// Answer - modelo de respuesta para las opciones de pregunta
+	"gorm.io/gorm"
+)
+
+// Answer - modelo de respuesta para las opciones de pregunta. Cada tipo de pregunta la usa
+// como clave de respuesta de una forma distinta:
+//   - single_choice, multiple_choice, true_false: opción marcada con IsCorrect
+//   - short_answer: texto aceptado
+//   - numeric: número aceptado en Text, con margen Tolerance
+//   - ordering: elemento cuya posición correcta es Order
+//   - matching: Text se empareja con MatchText
+//   - fill_in_blank: texto aceptado para el espacio número Blank
 type Answer struct {
-	ID        uint      `json:"id" gorm:"primarykey"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint           `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	Text       string `json:"text" gorm:"type:text;not null"`
 	IsCorrect  bool   `json:"is_correct" gorm:"column:is_correct;not null;default:false"`
 	Order      int    `json:"order" gorm:"not null;index:idx_answers_question_order,priority:2"`
 	QuestionID uint   `json:"question_id" gorm:"not null;index;index:idx_answers_question_order,priority:1"`
 
+	MatchText string  `json:"match_text,omitempty" gorm:"type:text"`
+	Tolerance float64 `json:"tolerance" gorm:"not null;default:0"`
+	Blank     int     `json:"blank" gorm:"not null;default:0"`
+
 	// Relaciones
 	Question *Question `json:"question" gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE"`
 }
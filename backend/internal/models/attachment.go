@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// Attachment - relación entre un archivo y un curso, contenido, pregunta o respuesta
+type Attachment struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	FileID         uint                 `json:"file_id" gorm:"not null;index"`
+	AttachableType enums.AttachableType `json:"attachable_type" gorm:"not null;index:idx_attachments_attachable,priority:1"`
+	AttachableID   uint                 `json:"attachable_id" gorm:"not null;index:idx_attachments_attachable,priority:2"`
+	Role           enums.AttachmentRole `json:"role" gorm:"not null;default:'resource'"`
+	Order          int                  `json:"order" gorm:"not null;default:0;index:idx_attachments_attachable,priority:3"`
+	CreatedByID    *uint                `json:"created_by_id"`
+
+	// Relaciones
+	File *File `json:"file" gorm:"foreignKey:FileID;constraint:OnDelete:CASCADE"`
+}
+
+func (Attachment) TableName() string {
+	return "attachments"
+}
@@ -11,20 +11,36 @@ import (
 
 // AttemptAnswerOption - opción de respuesta generada para una pregunta del intento
 type AttemptAnswerOption struct {
-	ID        uint   `json:"id"`
-	Text      string `json:"text"`
-	IsCorrect bool   `json:"is_correct"`
+	ID         uint   `json:"id"`
+	OriginalID uint   `json:"original_id,omitempty"` // ID de la respuesta original
+	Text       string `json:"text"`
+	IsCorrect  bool   `json:"is_correct"`
+	Position   int    `json:"position,omitempty"` // Posición correcta en preguntas de ordenamiento
+	MatchID    uint   `json:"match_id,omitempty"` // Pareja correcta en preguntas de emparejamiento
+}
+
+// AttemptAcceptedAnswer - respuesta aceptada en preguntas de texto, numéricas y de completar
+type AttemptAcceptedAnswer struct {
+	Text      string  `json:"text"`
+	Tolerance float64 `json:"tolerance,omitempty"`
+	Blank     int     `json:"blank,omitempty"`
 }
 
 // AttemptQuestion - pregunta generada para un intento específico
 type AttemptQuestion struct {
-	ID            uint                  `json:"id"`
-	Text          string                `json:"text"`
-	Type          enums.QuestionType    `json:"type"`
-	Explanation   string                `json:"explanation"`
-	Points        int                   `json:"points"`
-	OriginalID    uint                  `json:"original_id"`    // ID de la pregunta original
-	AnswerOptions []AttemptAnswerOption `json:"answer_options"` // Opciones de respuesta generadas
+	ID              uint                  `json:"id"`
+	Text            string                `json:"text"`
+	Type            enums.QuestionType    `json:"type"`
+	Explanation     string                `json:"explanation"`
+	Points          int                   `json:"points"`
+	OriginalID      uint                  `json:"original_id"`              // ID de la pregunta original
+	RevisionVersion int                   `json:"revision_version"`         // Versión de la pregunta mostrada al estudiante
+	AnswerOptions   []AttemptAnswerOption `json:"answer_options"`           // Opciones de respuesta generadas
+	ScoringPolicy   enums.ScoringPolicy   `json:"scoring_policy,omitempty"` // Política propia de la pregunta; vacía usa la de la evaluación
+
+	MatchOptions    []AttemptAnswerOption   `json:"match_options,omitempty"`    // Columna derecha de las preguntas de emparejamiento
+	BlankCount      int                     `json:"blank_count,omitempty"`      // Espacios a completar
+	AcceptedAnswers []AttemptAcceptedAnswer `json:"accepted_answers,omitempty"` // Clave de las preguntas sin opciones
 }
 
 // AttemptQuestions - slice personalizado para manejar JSON
@@ -49,12 +65,29 @@ func (aq *AttemptQuestions) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, aq)
 }
 
-// AttemptAnswer - estructura para las respuestas de un intento
+// AttemptMatch - pareja elegida en una pregunta de emparejamiento
+type AttemptMatch struct {
+	OptionID uint `json:"option_id"` // ID de la opción de answer_options
+	MatchID  uint `json:"match_id"`  // ID de la opción de match_options
+}
+
+// AttemptAnswer - estructura para las respuestas de un intento. Cada tipo de pregunta usa
+// un campo: selected_option_ids en las de opciones y verdadero/falso, text en las de
+// respuesta corta y numéricas, ordered_option_ids, matches y blanks en las demás.
 type AttemptAnswer struct {
-	AttemptQuestionID uint   `json:"attempt_question_id"` // ID de la pregunta generada del intento
-	SelectedOptionIDs []uint `json:"selected_option_ids"` // IDs de las opciones seleccionadas
-	IsCorrect         bool   `json:"is_correct"`
-	Points            int    `json:"points"`
+	AttemptQuestionID uint           `json:"attempt_question_id"`          // ID de la pregunta generada del intento
+	SelectedOptionIDs []uint         `json:"selected_option_ids"`          // IDs de las opciones seleccionadas
+	Text              string         `json:"text,omitempty"`               // Respuesta escrita
+	OrderedOptionIDs  []uint         `json:"ordered_option_ids,omitempty"` // IDs de las opciones en el orden elegido
+	Matches           []AttemptMatch `json:"matches,omitempty"`            // Parejas elegidas
+	Blanks            []string       `json:"blanks,omitempty"`             // Texto de cada espacio, en orden
+	IsCorrect         bool           `json:"is_correct"`
+	Points            float64        `json:"points"`               // Puede ser fraccionario, o negativo con right_minus_wrong
+	TimeSpent         int            `json:"time_spent,omitempty"` // Segundos dedicados a la pregunta, según el cliente
+	// Versión del guardado automático; el cliente envía la última que conoce para no pisar
+	// lo guardado desde otra pestaña
+	Version int        `json:"version,omitempty"`
+	SavedAt *time.Time `json:"saved_at,omitempty"` // Último guardado automático
 }
 
 // AttemptAnswers - slice personalizado para manejar JSON
@@ -87,14 +120,24 @@ type EvaluationAttempt struct {
 
 	UserID       uint             `json:"user_id" gorm:"not null;index;index:idx_eval_attempts_user_eval,priority:1"`
 	EvaluationID uint             `json:"evaluation_id" gorm:"not null;index;index:idx_eval_attempts_user_eval,priority:2"`
-	Questions    AttemptQuestions `json:"questions" gorm:"type:json"` // Preguntas generadas para este intento
-	Answers      AttemptAnswers   `json:"answers" gorm:"type:json"`   // Respuestas del usuario
-	Score        int              `json:"score" gorm:"not null;default:0"`
-	TotalPoints  int              `json:"total_points" gorm:"not null"`
+	Locale       string           `json:"locale" gorm:"not null;default:''"` // Idioma en que se generaron las preguntas
+	Questions    AttemptQuestions `json:"questions" gorm:"type:json"`        // Preguntas generadas para este intento
+	Answers      AttemptAnswers   `json:"answers" gorm:"type:json"`          // Respuestas del usuario
+	Score        float64          `json:"score" gorm:"not null;default:0"`
+	RawScore     float64          `json:"raw_score" gorm:"not null;default:0"` // Puntaje antes de la penalización por entrega tardía
+	IsLate       bool             `json:"is_late" gorm:"not null;default:false"`
+	LatePenalty  float64          `json:"late_penalty" gorm:"not null;default:0"` // Porcentaje descontado
+	TotalPoints  float64          `json:"total_points" gorm:"not null"`
 	Passed       bool             `json:"passed" gorm:"not null;default:false"`
 	StartedAt    time.Time        `json:"started_at" gorm:"not null"`
 	SubmittedAt  *time.Time       `json:"submitted_at" gorm:"default:null"`
 	TimeSpent    int              `json:"time_spent"` // en minutos
+	// Fin del tiempo límite; vacío en evaluaciones sin límite
+	ExpiresAt *time.Time `json:"expires_at" gorm:"index"`
+	// Enviado por el sistema con las respuestas guardadas al vencer el tiempo
+	AutoSubmitted bool `json:"auto_submitted" gorm:"not null;default:false"`
+	// Segundos restantes de un intento en curso; calculado al consultarlo
+	RemainingSeconds *int `json:"remaining_seconds,omitempty" gorm:"-"`
 
 	// Relaciones
 	User       *User       `json:"user" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Category - modelo de categoría jerárquica del catálogo
+type Category struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Name        string `json:"name" gorm:"not null"`
+	Slug        string `json:"slug" gorm:"not null;uniqueIndex"`
+	Description string `json:"description" gorm:"type:text"`
+	Order       int    `json:"order" gorm:"not null;default:0"`
+	ParentID    *uint  `json:"parent_id" gorm:"index"`
+
+	// Relaciones
+	Parent   *Category   `json:"parent,omitempty" gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL"`
+	Children []*Category `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+}
+
+func (Category) TableName() string {
+	return "categories"
+}
+
+// Tag - modelo de etiqueta libre para cursos
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Name string `json:"name" gorm:"not null"`
+	Slug string `json:"slug" gorm:"not null;uniqueIndex"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
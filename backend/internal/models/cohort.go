@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Cohort - grupo de estudiantes que cursa un curso con fecha de inicio, instructores y horario propios
+type Cohort struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	CourseID    uint       `json:"course_id" gorm:"not null;index"`
+	Name        string     `json:"name" gorm:"not null"`
+	Description string     `json:"description" gorm:"type:text"`
+	StartDate   time.Time  `json:"start_date" gorm:"not null"`
+	EndDate     *time.Time `json:"end_date"`                  // Fecha límite para completar el curso
+	Schedule    string     `json:"schedule" gorm:"type:text"` // Horario de las sesiones, p. ej. "Martes y jueves 18:00-20:00"
+
+	// Relaciones
+	Course      *Course           `json:"course,omitempty" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Instructors []*User           `json:"instructors" gorm:"many2many:cohort_instructors;constraint:OnDelete:CASCADE"`
+	Deadlines   []*CohortDeadline `json:"deadlines,omitempty" gorm:"foreignKey:CohortID;constraint:OnDelete:CASCADE"`
+}
+
+func (Cohort) TableName() string {
+	return "cohorts"
+}
+
+// CohortDeadline - fecha límite propia de una cohorte, opcionalmente ligada a un módulo o evaluación
+type CohortDeadline struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	CohortID     uint       `json:"cohort_id" gorm:"not null;index"`
+	Title        string     `json:"title" gorm:"not null"`
+	OpensAt      *time.Time `json:"opens_at"` // Solo aplica a módulos y evaluaciones
+	DueAt        time.Time  `json:"due_at" gorm:"not null"`
+	CloseAt      *time.Time `json:"close_at"` // Solo aplica a módulos y evaluaciones
+	ModuleID     *uint      `json:"module_id" gorm:"index"`
+	EvaluationID *uint      `json:"evaluation_id" gorm:"index"`
+}
+
+func (CohortDeadline) TableName() string {
+	return "cohort_deadlines"
+}
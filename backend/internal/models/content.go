@@ -4,25 +4,29 @@ import (
 	"time"
 
 	"github.com/imlargo/go-api-template/internal/enums"
+	"gorm.io/gorm"
 )
 
 // Content - modelo de contenido (lecciones, videos, lecturas)
 type Content struct {
-	ID        uint      `json:"id" gorm:"primarykey"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint           `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Order       int               `json:"order" gorm:"not null;index:idx_contents_module_order,priority:2"`
-	Title       string            `json:"title" gorm:"not null"`
-	Description string            `json:"description" gorm:"type:text"`
-	Type        enums.ContentType `json:"type" gorm:"not null;default:'content'"`
-	Body        string            `json:"body" gorm:"type:text;not null"`
-	MediaURL    string            `json:"media_url" gorm:"column:media_url"`
-	ModuleID    uint              `json:"module_id" gorm:"not null;index;index:idx_contents_module_order,priority:1"`
+	Order         int               `json:"order" gorm:"not null;index:idx_contents_module_order,priority:2"`
+	Title         string            `json:"title" gorm:"not null"`
+	Description   string            `json:"description" gorm:"type:text"`
+	Type          enums.ContentType `json:"type" gorm:"not null;default:'content'"`
+	Body          string            `json:"body" gorm:"type:text;not null"`
+	MediaURL      string            `json:"media_url" gorm:"column:media_url"`
+	MediaDuration float64           `json:"media_duration" gorm:"not null;default:0"` // Duración del video en segundos; 0 si no se conoce
+	ModuleID      uint              `json:"module_id" gorm:"not null;index;index:idx_contents_module_order,priority:1"`
 
 	// Relaciones
 	Module       *Module         `json:"module" gorm:"foreignKey:ModuleID"`
 	UserProgress []*UserProgress `json:"user_progress" gorm:"foreignKey:ContentID"`
+	Tracks       []*ContentTrack `json:"tracks,omitempty" gorm:"foreignKey:ContentID"`
 }
 
 func (Content) TableName() string {
@@ -1,23 +1,40 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+	"gorm.io/gorm"
+)
 
 // Course - modelo de curso
 type Course struct {
-	ID        uint      `json:"id" gorm:"primarykey"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint           `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Title            string `json:"title" gorm:"not null"`
-	Description      string `json:"description"`
-	ShortDescription string `json:"short_description"`
-	ImageURL         string `json:"image_url"`
-	StudentCount     int    `json:"student_count"`
-	ModuleCount      int    `json:"module_count"`
+	Title             string            `json:"title" gorm:"not null"`
+	Description       string            `json:"description"`
+	ShortDescription  string            `json:"short_description"`
+	ImageURL          string            `json:"image_url"`
+	StudentCount      int               `json:"student_count" gorm:"index"`
+	ModuleCount       int               `json:"module_count"`
+	IsPublished       bool              `json:"is_published" gorm:"not null;default:true"`
+	CategoryID        *uint             `json:"category_id" gorm:"index"`
+	Level             enums.CourseLevel `json:"level" gorm:"not null;default:'beginner';index"`
+	Language          string            `json:"language" gorm:"not null;default:'es';index"`
+	EstimatedDuration int               `json:"estimated_duration" gorm:"not null;default:0"` // en minutos
+	RatingAverage     float64           `json:"rating_average" gorm:"not null;default:0"`
+	RatingCount       int               `json:"rating_count" gorm:"not null;default:0"`
+	RatingHistogram   RatingHistogram   `json:"rating_histogram" gorm:"type:json"`
 
 	// Relaciones
-	Modules     []*Module     `json:"modules" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
-	Enrollments []*Enrollment `json:"enrollments" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Category      *Category       `json:"category,omitempty" gorm:"foreignKey:CategoryID;constraint:OnDelete:SET NULL"`
+	Tags          []*Tag          `json:"tags" gorm:"many2many:course_tags;constraint:OnDelete:CASCADE"`
+	Modules       []*Module       `json:"modules" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Enrollments   []*Enrollment   `json:"enrollments" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	QuestionBanks []*QuestionBank `json:"question_banks,omitempty" gorm:"foreignKey:CourseID"`
 }
 
 func (Course) TableName() string {
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// UserDeadlineOverride - fechas propias de un estudiante para un módulo o una evaluación
+type UserDeadlineOverride struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID       uint       `json:"user_id" gorm:"not null;index"`
+	ModuleID     *uint      `json:"module_id" gorm:"index"`
+	EvaluationID *uint      `json:"evaluation_id" gorm:"index"`
+	OpensAt      *time.Time `json:"opens_at"`
+	DueAt        *time.Time `json:"due_at"`
+	CloseAt      *time.Time `json:"close_at"`
+	Reason       string     `json:"reason"`
+	CreatedByID  uint       `json:"created_by_id"`
+
+	// Relaciones
+	User *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (UserDeadlineOverride) TableName() string {
+	return "user_deadline_overrides"
+}
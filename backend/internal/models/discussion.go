@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// DiscussionThread - hilo de discusión de un curso o de un contenido específico
+type DiscussionThread struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	CourseID       uint       `json:"course_id" gorm:"not null;index"`
+	ContentID      *uint      `json:"content_id" gorm:"index"` // nil para hilos generales del curso
+	AuthorID       uint       `json:"author_id" gorm:"not null;index"`
+	Title          string     `json:"title" gorm:"not null"`
+	Body           string     `json:"body" gorm:"type:text"`
+	IsPinned       bool       `json:"is_pinned" gorm:"not null;default:false"`
+	IsLocked       bool       `json:"is_locked" gorm:"not null;default:false"`
+	IsHidden       bool       `json:"is_hidden" gorm:"not null;default:false"`
+	IsAnswered     bool       `json:"is_answered" gorm:"not null;default:false"` // Tiene una respuesta avalada
+	UpvoteCount    int        `json:"upvote_count" gorm:"not null;default:0"`
+	ReplyCount     int        `json:"reply_count" gorm:"not null;default:0"`
+	LastActivityAt time.Time  `json:"last_activity_at" gorm:"not null;index"`
+	EditedAt       *time.Time `json:"edited_at"`
+
+	// Calculado por usuario
+	Upvoted bool `json:"upvoted" gorm:"-"`
+
+	// Relaciones
+	Course   *Course           `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Content  *Content          `json:"-" gorm:"foreignKey:ContentID;constraint:OnDelete:CASCADE"`
+	Author   *User             `json:"author,omitempty" gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE"`
+	Mentions []*User           `json:"mentions,omitempty" gorm:"many2many:discussion_thread_mentions;constraint:OnDelete:CASCADE"`
+	Posts    []*DiscussionPost `json:"posts,omitempty" gorm:"foreignKey:ThreadID;constraint:OnDelete:CASCADE"`
+}
+
+func (DiscussionThread) TableName() string {
+	return "discussion_threads"
+}
+
+// DiscussionPost - respuesta dentro de un hilo, opcionalmente a otra respuesta
+type DiscussionPost struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ThreadID     uint       `json:"thread_id" gorm:"not null;index"`
+	ParentID     *uint      `json:"parent_id" gorm:"index"`
+	AuthorID     uint       `json:"author_id" gorm:"not null;index"`
+	Body         string     `json:"body" gorm:"type:text;not null"`
+	UpvoteCount  int        `json:"upvote_count" gorm:"not null;default:0"`
+	IsEndorsed   bool       `json:"is_endorsed" gorm:"not null;default:false"`
+	EndorsedByID *uint      `json:"endorsed_by_id"`
+	EndorsedAt   *time.Time `json:"endorsed_at"`
+	IsHidden     bool       `json:"is_hidden" gorm:"not null;default:false"`
+	EditedAt     *time.Time `json:"edited_at"`
+
+	// Calculado por usuario y al armar el árbol de respuestas
+	Upvoted bool              `json:"upvoted" gorm:"-"`
+	Replies []*DiscussionPost `json:"replies" gorm:"-"`
+
+	// Relaciones
+	Thread   *DiscussionThread `json:"-" gorm:"foreignKey:ThreadID;constraint:OnDelete:CASCADE"`
+	Parent   *DiscussionPost   `json:"-" gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
+	Author   *User             `json:"author,omitempty" gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE"`
+	Mentions []*User           `json:"mentions,omitempty" gorm:"many2many:discussion_post_mentions;constraint:OnDelete:CASCADE"`
+}
+
+func (DiscussionPost) TableName() string {
+	return "discussion_posts"
+}
+
+// DiscussionVote - voto positivo de un usuario sobre un hilo o una respuesta
+type DiscussionVote struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	UserID     uint                       `json:"user_id" gorm:"not null;uniqueIndex:idx_discussion_votes_user_target,priority:1"`
+	TargetType enums.DiscussionTargetType `json:"target_type" gorm:"not null;uniqueIndex:idx_discussion_votes_user_target,priority:2"`
+	TargetID   uint                       `json:"target_id" gorm:"not null;uniqueIndex:idx_discussion_votes_user_target,priority:3"`
+}
+
+func (DiscussionVote) TableName() string {
+	return "discussion_votes"
+}
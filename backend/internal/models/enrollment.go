@@ -13,10 +13,12 @@ type Enrollment struct {
 	EnrolledAt  time.Time `json:"enrolled_at" gorm:"not null"`
 	CompletedAt time.Time `json:"completed_at"`
 	Progress    float64   `json:"progress" gorm:"not null;default:0.0"` // porcentaje 0-100
+	CohortID    *uint     `json:"cohort_id" gorm:"index"`
 
 	// Relaciones
 	User   *User   `json:"user" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 	Course *Course `json:"course" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Cohort *Cohort `json:"cohort,omitempty" gorm:"foreignKey:CohortID;constraint:OnDelete:SET NULL"`
 }
 
 func (Enrollment) TableName() string {
@@ -4,29 +4,38 @@ import (
 	"time"
 
 	"github.com/imlargo/go-api-template/internal/enums"
+	"gorm.io/gorm"
 )
 
 // Evaluation - modelo de evaluación (quizzes, exámenes)
 type Evaluation struct {
-	ID        uint      `json:"id" gorm:"primarykey"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint           `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Order              int               `json:"order" gorm:"not null;index:idx_evaluations_module_order,priority:2"`
-	Title              string            `json:"title" gorm:"not null"`
-	Description        string            `json:"description" gorm:"type:text"`
-	Type               enums.ContentType `json:"type" gorm:"not null;default:'evaluation'"`
-	QuestionCount      int               `json:"question_count" gorm:"not null"`
-	AnswerOptionsCount int               `json:"answer_options_count" gorm:"not null;default:4"`
-	PassingScore       int               `json:"passing_score" gorm:"not null"`
-	MaxAttempts        int               `json:"max_attempts"`
-	TimeLimit          int               `json:"time_limit"` // en minutos
-	ModuleID           uint              `json:"module_id" gorm:"not null;index;index:idx_evaluations_module_order,priority:1"`
+	Order              int                     `json:"order" gorm:"not null;index:idx_evaluations_module_order,priority:2"`
+	Title              string                  `json:"title" gorm:"not null"`
+	Description        string                  `json:"description" gorm:"type:text"`
+	Type               enums.ContentType       `json:"type" gorm:"not null;default:'evaluation'"`
+	QuestionCount      int                     `json:"question_count" gorm:"not null"`
+	AnswerOptionsCount int                     `json:"answer_options_count" gorm:"not null;default:4"`
+	PassingScore       int                     `json:"passing_score" gorm:"not null"`
+	MaxAttempts        int                     `json:"max_attempts"`
+	TimeLimit          int                     `json:"time_limit"` // en minutos
+	OpensAt            *time.Time              `json:"opens_at"`
+	DueAt              *time.Time              `json:"due_at"`
+	CloseAt            *time.Time              `json:"close_at"` // Cierre de entregas tardías; sin valor cierra en DueAt
+	LatePenaltyPolicy  enums.LatePenaltyPolicy `json:"late_penalty_policy" gorm:"not null;default:'none'"`
+	LatePenaltyPercent float64                 `json:"late_penalty_percent" gorm:"not null;default:0"`
+	ScoringPolicy      enums.ScoringPolicy     `json:"scoring_policy" gorm:"not null;default:'all_or_nothing'"`
+	ModuleID           uint                    `json:"module_id" gorm:"not null;index;index:idx_evaluations_module_order,priority:1"`
 
 	// Relaciones
-	Module             *Module              `json:"module" gorm:"foreignKey:ModuleID;constraint:OnDelete:CASCADE"`
-	Questions          []*Question          `json:"questions"`
-	EvaluationAttempts []*EvaluationAttempt `json:"evaluation_attempts"`
+	Module             *Module               `json:"module" gorm:"foreignKey:ModuleID;constraint:OnDelete:CASCADE"`
+	Questions          []*Question           `json:"questions"`
+	DrawRules          []*EvaluationDrawRule `json:"draw_rules,omitempty" gorm:"foreignKey:EvaluationID"`
+	EvaluationAttempts []*EvaluationAttempt  `json:"evaluation_attempts"`
 }
 
 func (Evaluation) TableName() string {
@@ -15,4 +15,8 @@ type File struct {
 
 	Path string `json:"path" gorm:"not null"`
 	Url  string `json:"url"  gorm:"not null"`
+	Name string `json:"name"`
+
+	UploadedByID *uint      `json:"uploaded_by_id" gorm:"index"`
+	ReleasedAt   *time.Time `json:"released_at" gorm:"index"` // Set when the file lost its last attachment
 }
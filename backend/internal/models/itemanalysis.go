@@ -0,0 +1,76 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// OptionStatistic - frecuencia con la que se eligió una opción de respuesta
+type OptionStatistic struct {
+	AnswerID   uint    `json:"answer_id,omitempty"` // Respuesta original; vacía en intentos anteriores al análisis
+	Text       string  `json:"text"`
+	IsCorrect  bool    `json:"is_correct"`
+	Shown      int     `json:"shown"`       // Intentos en los que apareció
+	Chosen     int     `json:"chosen"`      // Intentos en los que se eligió
+	ChosenRate float64 `json:"chosen_rate"` // Chosen / Shown
+}
+
+// ItemStatistic - estadísticas psicométricas de una pregunta
+type ItemStatistic struct {
+	QuestionID    uint               `json:"question_id"`
+	Text          string             `json:"text"` // Texto mostrado en el intento más reciente
+	Type          enums.QuestionType `json:"type"`
+	Responses     int                `json:"responses"`         // Intentos en los que apareció
+	Omitted       int                `json:"omitted"`           // Intentos en los que quedó sin responder
+	PValue        float64            `json:"p_value"`           // Proporción media del puntaje obtenido; 1 es la pregunta más fácil
+	PointBiserial *float64           `json:"point_biserial"`    // Correlación con el resto del intento; vacía sin varianza
+	AverageTime   *float64           `json:"average_time"`      // Segundos, según lo reportado por el cliente
+	Options       []OptionStatistic  `json:"options,omitempty"` // Opciones de las preguntas de selección
+}
+
+// ItemStatistics - slice personalizado para manejar JSON
+type ItemStatistics []ItemStatistic
+
+// Implementar driver.Valuer para poder guardar en la base de datos
+func (is ItemStatistics) Value() (driver.Value, error) {
+	return json.Marshal(is)
+}
+
+// Implementar sql.Scanner para poder leer desde la base de datos
+func (is *ItemStatistics) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("no se puede escanear datos que no sean []byte en ItemStatistics")
+	}
+
+	return json.Unmarshal(bytes, is)
+}
+
+// EvaluationItemAnalysis - análisis de las preguntas de una evaluación, recalculado en segundo plano
+type EvaluationItemAnalysis struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	EvaluationID      uint                    `json:"evaluation_id" gorm:"not null;uniqueIndex"`
+	AttemptCount      int                     `json:"attempt_count" gorm:"not null;default:0"` // Primeros intentos enviados de cada estudiante
+	Reliability       *float64                `json:"reliability"`                             // Vacía con menos de dos preguntas o intentos
+	ReliabilityMethod enums.ReliabilityMethod `json:"reliability_method" gorm:"not null;default:''"`
+	Items             ItemStatistics          `json:"items" gorm:"type:json"`
+	ComputedAt        time.Time               `json:"computed_at" gorm:"not null"`
+
+	// Relaciones
+	Evaluation *Evaluation `json:"-" gorm:"foreignKey:EvaluationID;constraint:OnDelete:CASCADE"`
+}
+
+func (EvaluationItemAnalysis) TableName() string {
+	return "evaluation_item_analyses"
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// LearningPath - programa que agrupa varios cursos en un orden sugerido
+type LearningPath struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Title        string `json:"title" gorm:"not null"`
+	Description  string `json:"description" gorm:"type:text"`
+	ImageURL     string `json:"image_url"`
+	IsPublished  bool   `json:"is_published" gorm:"not null;default:false;index"`
+	CourseCount  int    `json:"course_count" gorm:"not null;default:0"`
+	StudentCount int    `json:"student_count" gorm:"not null;default:0"`
+
+	// Relaciones
+	Groups []*LearningPathGroup `json:"groups,omitempty" gorm:"foreignKey:PathID;constraint:OnDelete:CASCADE"`
+}
+
+func (LearningPath) TableName() string {
+	return "learning_paths"
+}
+
+// LearningPathGroup - grupo de cursos obligatorios u optativos dentro de una ruta
+type LearningPathGroup struct {
+	ID uint `json:"id" gorm:"primarykey"`
+
+	PathID     uint                        `json:"path_id" gorm:"not null;index"`
+	Title      string                      `json:"title"`
+	Kind       enums.LearningPathGroupKind `json:"kind" gorm:"not null;default:'required'"`
+	Order      int                         `json:"order" gorm:"not null"`
+	MinCourses int                         `json:"min_courses" gorm:"not null;default:0"` // Cursos a completar en grupos optativos
+
+	// Relaciones
+	Courses []*LearningPathCourse `json:"courses" gorm:"foreignKey:GroupID;constraint:OnDelete:CASCADE"`
+}
+
+func (LearningPathGroup) TableName() string {
+	return "learning_path_groups"
+}
+
+// LearningPathCourse - curso dentro de un grupo de una ruta
+type LearningPathCourse struct {
+	ID uint `json:"id" gorm:"primarykey"`
+
+	PathID   uint `json:"path_id" gorm:"not null;uniqueIndex:idx_learning_path_courses_path_course,priority:1"`
+	GroupID  uint `json:"group_id" gorm:"not null;index"`
+	CourseID uint `json:"course_id" gorm:"not null;uniqueIndex:idx_learning_path_courses_path_course,priority:2;index"`
+	Order    int  `json:"order" gorm:"not null"`
+
+	// Relaciones
+	Path   *LearningPath `json:"-" gorm:"foreignKey:PathID;constraint:OnDelete:CASCADE"`
+	Course *Course       `json:"course,omitempty" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+}
+
+func (LearningPathCourse) TableName() string {
+	return "learning_path_courses"
+}
+
+// LearningPathEnrollment - inscripción de un usuario a una ruta de aprendizaje
+type LearningPathEnrollment struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	PathID      uint       `json:"path_id" gorm:"not null;uniqueIndex:idx_learning_path_enrollments_path_user,priority:1"`
+	UserID      uint       `json:"user_id" gorm:"not null;uniqueIndex:idx_learning_path_enrollments_path_user,priority:2;index"`
+	EnrolledAt  time.Time  `json:"enrolled_at" gorm:"not null"`
+	CompletedAt *time.Time `json:"completed_at"`
+
+	// Relaciones
+	Path *LearningPath `json:"path,omitempty" gorm:"foreignKey:PathID;constraint:OnDelete:CASCADE"`
+	User *User         `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (LearningPathEnrollment) TableName() string {
+	return "learning_path_enrollments"
+}
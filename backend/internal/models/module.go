@@ -1,17 +1,34 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Module - modelo de módulo
 type Module struct {
-	ID        uint      `json:"id" gorm:"primarykey"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-
-	Title       string `json:"title" gorm:"not null"`
-	Description string `json:"description"`
-	Order       int    `json:"order" gorm:"not null;index:idx_modules_course_order,priority:2"`
-	CourseID    uint   `json:"course_id" gorm:"not null;index;index:idx_modules_course_order,priority:1"`
+	ID        uint           `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Title       string     `json:"title" gorm:"not null"`
+	Description string     `json:"description"`
+	Order       int        `json:"order" gorm:"not null;index:idx_modules_course_order,priority:2"`
+	CourseID    uint       `json:"course_id" gorm:"not null;index;index:idx_modules_course_order,priority:1"`
+	OpensAt     *time.Time `json:"opens_at"`
+	DueAt       *time.Time `json:"due_at"`
+	CloseAt     *time.Time `json:"close_at"` // Cierre de entregas tardías; sin valor cierra en DueAt
+
+	// Liberación progresiva: días tras la inscripción (o el inicio de la cohorte) y/o fecha fija.
+	// Con ambos valores el módulo se libera en la fecha más tardía
+	ReleaseAfterDays *int       `json:"release_after_days"`
+	ReleaseAt        *time.Time `json:"release_at"`
+
+	// Estado de liberación para el usuario actual
+	Locked    bool       `json:"locked" gorm:"-"`
+	UnlocksAt *time.Time `json:"unlocks_at,omitempty" gorm:"-"`
 
 	// Relaciones
 	Course      *Course       `json:"course" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
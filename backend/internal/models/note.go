@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// Note - nota, resaltado o marcador privado de un estudiante sobre un contenido
+type Note struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID    uint           `json:"user_id" gorm:"not null;index:idx_notes_user_course,priority:1"`
+	CourseID  uint           `json:"course_id" gorm:"not null;index:idx_notes_user_course,priority:2"`
+	ContentID uint           `json:"content_id" gorm:"not null;index"`
+	Kind      enums.NoteKind `json:"kind" gorm:"not null;default:'note'"`
+	Body      string         `json:"body" gorm:"type:text"`
+	Quote     string         `json:"quote" gorm:"type:text"` // Texto resaltado
+
+	// Anclaje opcional: rango de caracteres del cuerpo del contenido o segundo del video
+	AnchorStart *int     `json:"anchor_start"`
+	AnchorEnd   *int     `json:"anchor_end"`
+	Timestamp   *float64 `json:"timestamp"`
+	Color       string   `json:"color"`
+
+	// Relaciones
+	User    *User    `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Course  *Course  `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Content *Content `json:"content,omitempty" gorm:"foreignKey:ContentID;constraint:OnDelete:CASCADE"`
+}
+
+func (Note) TableName() string {
+	return "notes"
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// PlaybackIntervals - tramos [inicio, fin] vistos del video, en segundos, ordenados y sin solaparse
+type PlaybackIntervals [][2]float64
+
+// Implementar driver.Valuer para poder guardar en la base de datos
+func (pi PlaybackIntervals) Value() (driver.Value, error) {
+	return json.Marshal(pi)
+}
+
+// Implementar sql.Scanner para poder leer desde la base de datos
+func (pi *PlaybackIntervals) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("no se puede escanear datos que no sean []byte en PlaybackIntervals")
+	}
+
+	return json.Unmarshal(bytes, pi)
+}
+
+// PlaybackProgress - estado de reproducción de un video por usuario
+type PlaybackProgress struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID         uint              `json:"user_id" gorm:"not null;uniqueIndex:idx_playback_user_content,priority:1"`
+	ContentID      uint              `json:"content_id" gorm:"not null;index;uniqueIndex:idx_playback_user_content,priority:2"`
+	Position       float64           `json:"position" gorm:"not null;default:0"` // Última posición reportada, en segundos
+	Duration       float64           `json:"duration" gorm:"not null;default:0"` // Duración del video, en segundos
+	Intervals      PlaybackIntervals `json:"intervals" gorm:"type:json"`
+	WatchedSeconds float64           `json:"watched_seconds" gorm:"not null;default:0"` // Segundos distintos vistos
+	Percentage     float64           `json:"percentage" gorm:"not null;default:0"`
+	TimeSpent      int               `json:"time_spent" gorm:"not null;default:0"` // Tiempo total de reproducción, en segundos
+	LastWatchedAt  time.Time         `json:"last_watched_at"`
+	CompletedAt    *time.Time        `json:"completed_at"`
+
+	// Relaciones
+	User    *User    `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Content *Content `json:"-" gorm:"foreignKey:ContentID;constraint:OnDelete:CASCADE"`
+}
+
+func (PlaybackProgress) TableName() string {
+	return "playback_progress"
+}
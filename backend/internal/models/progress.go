@@ -15,6 +15,8 @@ type UserProgress struct {
 	CompletedAt time.Time `json:"completed_at"`
 	Score       int       `json:"score"`
 	Attempts    int       `json:"attempts" gorm:"not null;default:0"`
+	Progress    float64   `json:"progress" gorm:"not null;default:0"`   // Porcentaje visto del contenido
+	TimeSpent   int       `json:"time_spent" gorm:"not null;default:0"` // en segundos
 
 	// Relaciones
 	User    *User    `json:"user" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
@@ -4,23 +4,33 @@ import (
 	"time"
 
 	"github.com/imlargo/go-api-template/internal/enums"
+	"gorm.io/gorm"
 )
 
 // Question - modelo de pregunta
 type Question struct {
-	ID        uint      `json:"id" gorm:"primarykey"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint           `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Text         string             `json:"text" gorm:"type:text;not null"`
-	Type         enums.QuestionType `json:"type" gorm:"not null"`
-	Explanation  string             `json:"explanation" gorm:"type:text"`
-	Points       int                `json:"points" gorm:"not null;default:1"`
-	EvaluationID uint               `json:"evaluation_id" gorm:"not null;index"`
+	Text        string             `json:"text" gorm:"type:text;not null"`
+	Type        enums.QuestionType `json:"type" gorm:"not null"`
+	Explanation string             `json:"explanation" gorm:"type:text"`
+	Points      int                `json:"points" gorm:"not null;default:1"`
+	// Política de puntuación propia; vacía usa la de la evaluación
+	ScoringPolicy enums.ScoringPolicy `json:"scoring_policy" gorm:"not null;default:''"`
+	// Vacía en las preguntas sin clasificar
+	Difficulty enums.QuestionDifficulty `json:"difficulty" gorm:"not null;default:''"`
+	// Una pregunta pertenece a una evaluación o a un banco del curso, nunca a ambos
+	EvaluationID *uint `json:"evaluation_id" gorm:"index"`
+	BankID       *uint `json:"bank_id" gorm:"index"`
 
 	// Relaciones
-	Evaluation *Evaluation `json:"evaluation" gorm:"foreignKey:EvaluationID;constraint:OnDelete:CASCADE"`
-	Answers    []*Answer   `json:"answers" gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE"`
+	Evaluation *Evaluation   `json:"evaluation" gorm:"foreignKey:EvaluationID;constraint:OnDelete:CASCADE"`
+	Bank       *QuestionBank `json:"-" gorm:"foreignKey:BankID;constraint:OnDelete:CASCADE"`
+	Answers    []*Answer     `json:"answers" gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE"`
+	Tags       []*Tag        `json:"tags,omitempty" gorm:"many2many:question_tags;constraint:OnDelete:CASCADE"`
 }
 
 func (Question) TableName() string {
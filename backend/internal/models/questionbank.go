@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// QuestionBank - banco de preguntas reutilizables de un curso
+type QuestionBank struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	CourseID    uint   `json:"course_id" gorm:"not null;index"`
+	Name        string `json:"name" gorm:"not null"`
+	Description string `json:"description" gorm:"type:text"`
+	CreatedByID *uint  `json:"created_by_id"`
+
+	// Calculado al listar, no se guarda
+	QuestionCount int `json:"question_count" gorm:"-"`
+
+	// Relaciones
+	Course    *Course     `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Questions []*Question `json:"questions,omitempty" gorm:"foreignKey:BankID"`
+}
+
+func (QuestionBank) TableName() string {
+	return "question_banks"
+}
+
+// EvaluationDrawRule - regla que sortea preguntas de un banco en cada intento de la evaluación
+type EvaluationDrawRule struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	EvaluationID uint `json:"evaluation_id" gorm:"not null;index"`
+	BankID       uint `json:"bank_id" gorm:"not null;index"`
+	Count        int  `json:"count" gorm:"not null"`
+	// Slug de la etiqueta; vacío admite cualquier pregunta del banco
+	Tag string `json:"tag" gorm:"not null;default:''"`
+	// Vacía admite cualquier dificultad
+	Difficulty enums.QuestionDifficulty `json:"difficulty" gorm:"not null;default:''"`
+	Order      int                      `json:"order" gorm:"not null;default:0"`
+
+	// Relaciones
+	Evaluation *Evaluation   `json:"-" gorm:"foreignKey:EvaluationID;constraint:OnDelete:CASCADE"`
+	Bank       *QuestionBank `json:"bank,omitempty" gorm:"foreignKey:BankID;constraint:OnDelete:CASCADE"`
+}
+
+func (EvaluationDrawRule) TableName() string {
+	return "evaluation_draw_rules"
+}
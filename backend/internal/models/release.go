@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// ModuleReleaseNotice - registro de la notificación de liberación de un módulo a un estudiante
+type ModuleReleaseNotice struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ModuleID   uint      `json:"module_id" gorm:"not null;uniqueIndex:idx_module_release_notice,priority:1"`
+	UserID     uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_module_release_notice,priority:2"`
+	UnlockedAt time.Time `json:"unlocked_at" gorm:"not null"`
+
+	// Relaciones
+	Module *Module `json:"-" gorm:"foreignKey:ModuleID;constraint:OnDelete:CASCADE"`
+	User   *User   `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (ModuleReleaseNotice) TableName() string {
+	return "module_release_notices"
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// RatingHistogram - número de reseñas visibles con 1 a 5 estrellas
+type RatingHistogram [5]int
+
+// Implementar driver.Valuer para poder guardar en la base de datos
+func (rh RatingHistogram) Value() (driver.Value, error) {
+	return json.Marshal(rh)
+}
+
+// Implementar sql.Scanner para poder leer desde la base de datos
+func (rh *RatingHistogram) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("no se puede escanear datos que no sean []byte en RatingHistogram")
+	}
+
+	return json.Unmarshal(bytes, rh)
+}
+
+// Review - reseña y calificación de un curso por un estudiante inscrito
+type Review struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	CourseID     uint               `json:"course_id" gorm:"not null;index;uniqueIndex:idx_reviews_course_user,priority:1"`
+	UserID       uint               `json:"user_id" gorm:"not null;uniqueIndex:idx_reviews_course_user,priority:2"`
+	Rating       int                `json:"rating" gorm:"not null"`
+	Comment      string             `json:"comment" gorm:"type:text"`
+	Status       enums.ReviewStatus `json:"status" gorm:"not null;default:'visible';index"`
+	EditedAt     *time.Time         `json:"edited_at"`
+	Reply        string             `json:"reply" gorm:"type:text"` // Respuesta del instructor
+	RepliedAt    *time.Time         `json:"replied_at"`
+	RepliedByID  *uint              `json:"replied_by_id"`
+	ReportCount  int                `json:"report_count" gorm:"not null;default:0"` // Reportes sin resolver
+	HiddenReason string             `json:"hidden_reason,omitempty"`
+
+	// Relaciones
+	Course  *Course         `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	User    *User           `json:"user,omitempty" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Reports []*ReviewReport `json:"reports,omitempty" gorm:"foreignKey:ReviewID;constraint:OnDelete:CASCADE"`
+}
+
+func (Review) TableName() string {
+	return "reviews"
+}
+
+// ReviewReport - reporte de una reseña inapropiada
+type ReviewReport struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ReviewID   uint       `json:"review_id" gorm:"not null;uniqueIndex:idx_review_reports_review_user,priority:1"`
+	UserID     uint       `json:"user_id" gorm:"not null;uniqueIndex:idx_review_reports_review_user,priority:2"`
+	Reason     string     `json:"reason" gorm:"type:text"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+
+	// Relaciones
+	User *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (ReviewReport) TableName() string {
+	return "review_reports"
+}
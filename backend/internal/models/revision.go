@@ -0,0 +1,52 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// RevisionSnapshot - valores de los campos versionados de la entidad, indexados por columna
+type RevisionSnapshot map[string]interface{}
+
+// Implementar driver.Valuer para poder guardar en la base de datos
+func (rs RevisionSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(rs)
+}
+
+// Implementar sql.Scanner para poder leer desde la base de datos
+func (rs *RevisionSnapshot) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("no se puede escanear datos que no sean []byte en RevisionSnapshot")
+	}
+
+	return json.Unmarshal(bytes, rs)
+}
+
+// Revision - versión guardada de un contenido, evaluación, pregunta o respuesta
+type Revision struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	EntityType enums.RevisionEntityType `json:"entity_type" gorm:"not null;uniqueIndex:idx_revisions_entity_version,priority:1"`
+	EntityID   uint                     `json:"entity_id" gorm:"not null;uniqueIndex:idx_revisions_entity_version,priority:2"`
+	Version    int                      `json:"version" gorm:"not null;uniqueIndex:idx_revisions_entity_version,priority:3"`
+	AuthorID   *uint                    `json:"author_id" gorm:"index"`
+	Summary    string                   `json:"summary" gorm:"type:text"`
+	Snapshot   RevisionSnapshot         `json:"snapshot" gorm:"type:json"`
+
+	// Relaciones
+	Author *User `json:"author,omitempty" gorm:"foreignKey:AuthorID;constraint:OnDelete:SET NULL"`
+}
+
+func (Revision) TableName() string {
+	return "revisions"
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// ContentTrack - pista de subtítulos o transcripción de un contenido multimedia en un idioma.
+// Los subtítulos se guardan normalizados a WebVTT; la transcripción guarda además su texto
+// para la búsqueda
+type ContentTrack struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ContentID   uint            `json:"content_id" gorm:"not null;uniqueIndex:idx_content_track,priority:1"`
+	Kind        enums.TrackKind `json:"kind" gorm:"not null;uniqueIndex:idx_content_track,priority:2"`
+	Locale      string          `json:"locale" gorm:"size:35;not null;uniqueIndex:idx_content_track,priority:3"`
+	Label       string          `json:"label" gorm:"not null"`
+	FileID      uint            `json:"file_id" gorm:"not null;index"`
+	Text        string          `json:"text,omitempty" gorm:"type:text"`
+	CreatedByID *uint           `json:"created_by_id"`
+
+	// Relaciones
+	File    *File    `json:"file" gorm:"foreignKey:FileID"`
+	Content *Content `json:"-" gorm:"foreignKey:ContentID;constraint:OnDelete:CASCADE"`
+}
+
+func (ContentTrack) TableName() string {
+	return "content_tracks"
+}
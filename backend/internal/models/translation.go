@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// Translation - traducción de un campo de texto de una entidad del curso a un idioma.
+// Los campos originales están en el idioma del curso, que sirve de respaldo
+type Translation struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	EntityType enums.TranslationEntityType `json:"entity_type" gorm:"not null;uniqueIndex:idx_translation_field,priority:1"`
+	EntityID   uint                        `json:"entity_id" gorm:"not null;uniqueIndex:idx_translation_field,priority:2"`
+	Locale     string                      `json:"locale" gorm:"not null;uniqueIndex:idx_translation_field,priority:3"`
+	Field      string                      `json:"field" gorm:"not null;uniqueIndex:idx_translation_field,priority:4"`
+	Value      string                      `json:"value" gorm:"type:text;not null"`
+	CourseID   uint                        `json:"course_id" gorm:"not null;index"`
+
+	// Relaciones
+	Course *Course `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+}
+
+func (Translation) TableName() string {
+	return "translations"
+}
@@ -15,6 +15,7 @@ type User struct {
 	Fullname  string         `json:"fullname" gorm:"not null"`
 	AvatarUrl string         `json:"avatar_url" gorm:"not null"`
 	Role      enums.UserRole `json:"role" gorm:"not null;default:'student'"`
+	Locale    string         `json:"locale" gorm:"not null;default:''"` // Idioma preferido; vacío usa Accept-Language
 
 	// Relaciones
 	Enrollments []*Enrollment `json:"enrollments" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DailyCourseActivity is a row of the daily rollup aggregated per course
+type DailyCourseActivity struct {
+	Day         time.Time
+	CourseID    uint
+	CourseTitle string
+	Seconds     int
+}
+
+type ActivityRepository interface {
+	GetOpenSession(userID uint, itemType enums.ActivityItemType, itemID uint) (*models.ActivitySession, error)
+	SaveSession(session *models.ActivitySession) error
+	AddDailyActivity(userID, courseID, moduleID uint, day time.Time, seconds int) error
+	CloseIdleSessions(lastActivityBefore time.Time) (int64, error)
+	GetDailyByUser(userID uint, from, to time.Time, courseID uint) ([]*DailyCourseActivity, error)
+	GetItemTimeOnTask(courseID uint) ([]*dto.ItemTimeOnTask, error)
+}
+
+type activityRepository struct {
+	*Repository
+}
+
+func NewActivityRepository(r *Repository) ActivityRepository {
+	return &activityRepository{
+		Repository: r,
+	}
+}
+
+func (r *activityRepository) GetOpenSession(userID uint, itemType enums.ActivityItemType, itemID uint) (*models.ActivitySession, error) {
+	var session models.ActivitySession
+	if err := r.db.
+		Where("user_id = ? AND item_type = ? AND item_id = ? AND ended_at IS NULL", userID, itemType, itemID).
+		Order("last_activity_at DESC").
+		First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *activityRepository) SaveSession(session *models.ActivitySession) error {
+	return r.db.Save(session).Error
+}
+
+// AddDailyActivity increments the rollup of the day, creating the row on the first heartbeat
+func (r *activityRepository) AddDailyActivity(userID, courseID, moduleID uint, day time.Time, seconds int) error {
+	activity := &models.DailyActivity{
+		UserID:        userID,
+		CourseID:      courseID,
+		ModuleID:      moduleID,
+		Day:           day,
+		ActiveSeconds: seconds,
+	}
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "module_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"active_seconds": gorm.Expr("daily_activity.active_seconds + EXCLUDED.active_seconds"),
+			"updated_at":     gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}).Create(activity).Error
+}
+
+// CloseIdleSessions ends the sessions that received no heartbeat since the given time
+func (r *activityRepository) CloseIdleSessions(lastActivityBefore time.Time) (int64, error) {
+	result := r.db.Model(&models.ActivitySession{}).
+		Where("ended_at IS NULL AND last_activity_at < ?", lastActivityBefore).
+		Update("ended_at", gorm.Expr("last_activity_at"))
+	return result.RowsAffected, result.Error
+}
+
+func (r *activityRepository) GetDailyByUser(userID uint, from, to time.Time, courseID uint) ([]*DailyCourseActivity, error) {
+	query := r.db.Table("daily_activity AS da").
+		Select("da.day, da.course_id, c.title AS course_title, SUM(da.active_seconds) AS seconds").
+		Joins("INNER JOIN courses c ON c.id = da.course_id").
+		Where("da.user_id = ? AND da.day >= ? AND da.day < ?", userID, from, to).
+		Group("da.day, da.course_id, c.title").
+		Order("da.day ASC")
+
+	if courseID != 0 {
+		query = query.Where("da.course_id = ?", courseID)
+	}
+
+	var rows []*DailyCourseActivity
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetItemTimeOnTask computes, per item of the course, the median and average time each learner spent on it
+func (r *activityRepository) GetItemTimeOnTask(courseID uint) ([]*dto.ItemTimeOnTask, error) {
+	query := `
+	WITH per_learner AS (
+		SELECT item_type, item_id, module_id, user_id, SUM(active_seconds) AS seconds
+		FROM activity_sessions
+		WHERE course_id = ?
+		GROUP BY item_type, item_id, module_id, user_id
+	)
+	SELECT
+		p.item_type,
+		p.item_id,
+		p.module_id,
+		COALESCE(ct.title, ev.title) AS title,
+		COUNT(*) AS learners,
+		percentile_cont(0.5) WITHIN GROUP (ORDER BY p.seconds) AS median_seconds,
+		AVG(p.seconds) AS average_seconds
+	FROM per_learner p
+	LEFT JOIN contents ct ON p.item_type = 'content' AND ct.id = p.item_id AND ct.deleted_at IS NULL
+	LEFT JOIN evaluations ev ON p.item_type = 'evaluation' AND ev.id = p.item_id AND ev.deleted_at IS NULL
+	WHERE ct.id IS NOT NULL OR ev.id IS NOT NULL
+	GROUP BY p.item_type, p.item_id, p.module_id, ct.title, ev.title
+	ORDER BY median_seconds DESC
+	`
+
+	var items []*dto.ItemTimeOnTask
+	if err := r.db.Raw(query, courseID).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnnouncementRepository interface {
+	Create(announcement *models.Announcement) error
+	Get(id uint) (*models.Announcement, error)
+	Patch(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	ListByCourse(courseID uint, includeScheduled bool, request *dto.ListRequest) (*dto.Page[*models.Announcement], error)
+	ClaimDue(now time.Time, staleBefore time.Time) ([]*models.Announcement, error)
+	SaveProgress(id uint, lastRecipientID uint, recipients int) error
+	MarkPublished(id uint, publishedAt time.Time) error
+	MarkRead(announcementID, userID uint) error
+	GetReadIDs(userID uint, announcementIDs []uint) (map[uint]bool, error)
+}
+
+type announcementRepository struct {
+	*Repository
+}
+
+func NewAnnouncementRepository(r *Repository) AnnouncementRepository {
+	return &announcementRepository{
+		Repository: r,
+	}
+}
+
+func (r *announcementRepository) Create(announcement *models.Announcement) error {
+	return r.db.Create(announcement).Error
+}
+
+func (r *announcementRepository) Get(id uint) (*models.Announcement, error) {
+	var announcement models.Announcement
+	if err := r.db.Preload("Author").First(&announcement, id).Error; err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+func (r *announcementRepository) Patch(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.Announcement{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *announcementRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Announcement{}, id).Error
+}
+
+var announcementListSpec = ListSpec{
+	Sorts: map[string]string{
+		"publish_at": "publish_at",
+		"created_at": "created_at",
+	},
+	Filters: map[string]string{
+		"status": "status",
+	},
+	DefaultSort: "-publish_at",
+	Preloads:    []string{"Author"},
+}
+
+func (r *announcementRepository) ListByCourse(courseID uint, includeScheduled bool, request *dto.ListRequest) (*dto.Page[*models.Announcement], error) {
+	query := r.db.Where("course_id = ?", courseID)
+	if !includeScheduled {
+		query = query.Where("status <> ?", enums.AnnouncementStatusScheduled)
+	}
+	return paginate[models.Announcement](query, request, announcementListSpec)
+}
+
+// ClaimDue moves the announcements whose publish date has arrived to publishing and returns them.
+// Fan-outs that stopped reporting progress before staleBefore are claimed again so they resume.
+// Locked rows are skipped, so concurrent workers never claim the same announcement.
+func (r *announcementRepository) ClaimDue(now time.Time, staleBefore time.Time) ([]*models.Announcement, error) {
+	var announcements []*models.Announcement
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND publish_at <= ?) OR (status = ? AND updated_at < ?)",
+				enums.AnnouncementStatusScheduled, now, enums.AnnouncementStatusPublishing, staleBefore).
+			Find(&announcements).Error; err != nil {
+			return err
+		}
+		if len(announcements) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(announcements))
+		for i, announcement := range announcements {
+			ids[i] = announcement.ID
+			announcement.Status = enums.AnnouncementStatusPublishing
+		}
+		return tx.Model(&models.Announcement{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":     enums.AnnouncementStatusPublishing,
+			"updated_at": now,
+		}).Error
+	})
+	return announcements, err
+}
+
+// SaveProgress records the last notified recipient after each batch; it also refreshes
+// updated_at, which tells other workers that the fan-out is still alive
+func (r *announcementRepository) SaveProgress(id uint, lastRecipientID uint, recipients int) error {
+	return r.db.Model(&models.Announcement{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_recipient_id": lastRecipientID,
+		"recipient_count":   gorm.Expr("recipient_count + ?", recipients),
+	}).Error
+}
+
+func (r *announcementRepository) MarkPublished(id uint, publishedAt time.Time) error {
+	return r.db.Model(&models.Announcement{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       enums.AnnouncementStatusPublished,
+		"published_at": publishedAt,
+	}).Error
+}
+
+// MarkRead stores the first read of the user and increments the read counter
+func (r *announcementRepository) MarkRead(announcementID, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		read := &models.AnnouncementRead{
+			AnnouncementID: announcementID,
+			UserID:         userID,
+			ReadAt:         time.Now(),
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(read)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&models.Announcement{}).Where("id = ?", announcementID).
+			Update("read_count", gorm.Expr("read_count + 1")).Error
+	})
+}
+
+func (r *announcementRepository) GetReadIDs(userID uint, announcementIDs []uint) (map[uint]bool, error) {
+	read := make(map[uint]bool)
+	if len(announcementIDs) == 0 {
+		return read, nil
+	}
+
+	var ids []uint
+	if err := r.db.Model(&models.AnnouncementRead{}).
+		Where("user_id = ? AND announcement_id IN ?", userID, announcementIDs).
+		Pluck("announcement_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		read[id] = true
+	}
+	return read, nil
+}
@@ -1,6 +1,10 @@
 package repositories
 
 import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"gorm.io/gorm/clause"
 )
@@ -13,6 +17,7 @@ type AnswerRepository interface {
 	Delete(id uint) error
 	GetAll() ([]*models.Answer, error)
 	GetByQuestionID(questionID uint) ([]*models.Answer, error)
+	ListByQuestionID(questionID uint, request *dto.ListRequest) (*dto.Page[*models.Answer], error)
 }
 
 type answerRepository struct {
@@ -46,9 +51,7 @@ func (r *answerRepository) Patch(id uint, data map[string]interface{}) error {
 }
 
 func (r *answerRepository) Delete(id uint) error {
-	var answer models.Answer
-	answer.ID = id
-	return r.db.Delete(&answer).Error
+	return softDeleteTree(r.db, enums.TrashEntityAnswer, []uint{id}, time.Now())
 }
 
 func (r *answerRepository) GetAll() ([]*models.Answer, error) {
@@ -66,3 +69,18 @@ func (r *answerRepository) GetByQuestionID(questionID uint) ([]*models.Answer, e
 	}
 	return answers, nil
 }
+
+var answerListSpec = ListSpec{
+	Sorts: map[string]string{
+		"order":      "order",
+		"created_at": "created_at",
+	},
+	Filters: map[string]string{
+		"is_correct": "is_correct",
+	},
+	DefaultSort: "order",
+}
+
+func (r *answerRepository) ListByQuestionID(questionID uint, request *dto.ListRequest) (*dto.Page[*models.Answer], error) {
+	return paginate[models.Answer](r.db.Where("question_id = ?", questionID), request, answerListSpec)
+}
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+// attachableTrashTypes maps the trash entities that can own attachments to their attachable type
+var attachableTrashTypes = map[enums.TrashEntityType]enums.AttachableType{
+	enums.TrashEntityCourse:   enums.AttachableCourse,
+	enums.TrashEntityContent:  enums.AttachableContent,
+	enums.TrashEntityQuestion: enums.AttachableQuestion,
+	enums.TrashEntityAnswer:   enums.AttachableAnswer,
+}
+
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment) error
+	Get(id uint) (*models.Attachment, error)
+	Patch(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	GetByAttachable(attachableType enums.AttachableType, attachableID uint) ([]*models.Attachment, error)
+	GetNextOrder(attachableType enums.AttachableType, attachableID uint) (int, error)
+	CountByFile(fileID uint) (int64, error)
+}
+
+type attachmentRepository struct {
+	*Repository
+}
+
+func NewAttachmentRepository(r *Repository) AttachmentRepository {
+	return &attachmentRepository{
+		Repository: r,
+	}
+}
+
+// Create stores the attachment and takes its file out of the release queue
+func (r *attachmentRepository) Create(attachment *models.Attachment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attachment).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.File{}).Where("id = ?", attachment.FileID).Update("released_at", nil).Error
+	})
+}
+
+func (r *attachmentRepository) Get(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := r.db.Preload("File").First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) Patch(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.Attachment{}).Where("id = ?", id).Updates(data).Error
+}
+
+// Delete removes the attachment and releases its file when nothing else references it
+func (r *attachmentRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var attachment models.Attachment
+		if err := tx.First(&attachment, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&attachment).Error; err != nil {
+			return err
+		}
+		return releaseFiles(tx, []uint{attachment.FileID}, time.Now())
+	})
+}
+
+func (r *attachmentRepository) GetByAttachable(attachableType enums.AttachableType, attachableID uint) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	if err := r.db.Preload("File").
+		Where("attachable_type = ? AND attachable_id = ?", attachableType, attachableID).
+		Order(`"order" ASC, id ASC`).
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) GetNextOrder(attachableType enums.AttachableType, attachableID uint) (int, error) {
+	var next int
+	if err := r.db.Model(&models.Attachment{}).
+		Where("attachable_type = ? AND attachable_id = ?", attachableType, attachableID).
+		Select(`COALESCE(MAX("order") + 1, 0)`).
+		Scan(&next).Error; err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+func (r *attachmentRepository) CountByFile(fileID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Attachment{}).Where("file_id = ?", fileID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// releaseAttachments drops the attachments of permanently deleted entities
+func releaseAttachments(tx *gorm.DB, attachableType enums.AttachableType, attachableIDs []uint, at time.Time) error {
+	var fileIDs []uint
+	if err := tx.Model(&models.Attachment{}).
+		Where("attachable_type = ? AND attachable_id IN ?", attachableType, attachableIDs).
+		Pluck("file_id", &fileIDs).Error; err != nil {
+		return err
+	}
+	if len(fileIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("attachable_type = ? AND attachable_id IN ?", attachableType, attachableIDs).
+		Delete(&models.Attachment{}).Error; err != nil {
+		return err
+	}
+
+	return releaseFiles(tx, fileIDs, at)
+}
+
+// releaseFiles marks the files left without attachments or tracks so the cleanup job can delete them
+func releaseFiles(tx *gorm.DB, fileIDs []uint, at time.Time) error {
+	return tx.Model(&models.File{}).
+		Where("id IN ? AND released_at IS NULL", fileIDs).
+		Where("NOT EXISTS (SELECT 1 FROM attachments WHERE attachments.file_id = files.id)").
+		Where("NOT EXISTS (SELECT 1 FROM content_tracks WHERE content_tracks.file_id = files.id)").
+		Update("released_at", at).Error
+}
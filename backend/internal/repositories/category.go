@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CategoryRepository interface {
+	Get(id uint) (*models.Category, error)
+	Create(category *models.Category) error
+	Update(category *models.Category) error
+	Patch(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	GetAll() ([]*models.Category, error)
+	GetBySlug(slug string) (*models.Category, error)
+}
+
+type categoryRepository struct {
+	*Repository
+}
+
+func NewCategoryRepository(r *Repository) CategoryRepository {
+	return &categoryRepository{
+		Repository: r,
+	}
+}
+
+func (r *categoryRepository) Create(category *models.Category) error {
+	return r.db.Create(category).Error
+}
+
+func (r *categoryRepository) Get(id uint) (*models.Category, error) {
+	var category models.Category
+	if err := r.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) Update(category *models.Category) error {
+	return r.db.Model(category).Clauses(clause.Returning{}).Updates(category).Error
+}
+
+func (r *categoryRepository) Patch(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.Category{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *categoryRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		if err := tx.First(&category, id).Error; err != nil {
+			return err
+		}
+
+		// Move child categories up to the parent of the deleted category
+		if err := tx.Model(&models.Category{}).Where("parent_id = ?", id).Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
+
+		// Courses in this category are left without category
+		if err := tx.Model(&models.Course{}).Where("category_id = ?", id).Update("category_id", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&category).Error
+	})
+}
+
+func (r *categoryRepository) GetAll() ([]*models.Category, error) {
+	var categories []*models.Category
+	if err := r.db.Order("\"order\" ASC, name ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *categoryRepository) GetBySlug(slug string) (*models.Category, error) {
+	var category models.Category
+	if err := r.db.Where("slug = ?", slug).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+type CohortRepository interface {
+	Create(cohort *models.Cohort) error
+	Get(id uint) (*models.Cohort, error)
+	Patch(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	ListByCourse(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Cohort], error)
+	GetAllByCourse(courseID uint) ([]*models.Cohort, error)
+	ReplaceInstructors(cohortID uint, instructors []*models.User) error
+	IsCourseInstructor(courseID, userID uint) (bool, error)
+	CreateDeadline(deadline *models.CohortDeadline) error
+	GetDeadline(id uint) (*models.CohortDeadline, error)
+	PatchDeadline(id uint, data map[string]interface{}) error
+	DeleteDeadline(id uint) error
+}
+
+type cohortRepository struct {
+	*Repository
+}
+
+func NewCohortRepository(r *Repository) CohortRepository {
+	return &cohortRepository{
+		Repository: r,
+	}
+}
+
+// Create stores the cohort and links the existing instructors without upserting them
+func (r *cohortRepository) Create(cohort *models.Cohort) error {
+	return r.db.Omit("Instructors.*").Create(cohort).Error
+}
+
+func (r *cohortRepository) Get(id uint) (*models.Cohort, error) {
+	var cohort models.Cohort
+	err := r.db.
+		Preload("Instructors").
+		Preload("Deadlines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("due_at ASC, id ASC")
+		}).
+		First(&cohort, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cohort, nil
+}
+
+func (r *cohortRepository) Patch(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.Cohort{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *cohortRepository) Delete(id uint) error {
+	return r.db.Select("Instructors").Delete(&models.Cohort{ID: id}).Error
+}
+
+var cohortListSpec = ListSpec{
+	Sorts: map[string]string{
+		"name":       "name",
+		"start_date": "start_date",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-start_date",
+	Preloads:    []string{"Instructors"},
+}
+
+func (r *cohortRepository) ListByCourse(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Cohort], error) {
+	return paginate[models.Cohort](r.db.Where("course_id = ?", courseID), request, cohortListSpec)
+}
+
+func (r *cohortRepository) GetAllByCourse(courseID uint) ([]*models.Cohort, error) {
+	var cohorts []*models.Cohort
+	if err := r.db.Where("course_id = ?", courseID).Order("start_date ASC, id ASC").Find(&cohorts).Error; err != nil {
+		return nil, err
+	}
+	return cohorts, nil
+}
+
+func (r *cohortRepository) ReplaceInstructors(cohortID uint, instructors []*models.User) error {
+	cohort := models.Cohort{ID: cohortID}
+	return r.db.Model(&cohort).Omit("Instructors.*").Association("Instructors").Replace(instructors)
+}
+
+// IsCourseInstructor reports whether the user teaches at least one cohort of the course
+func (r *cohortRepository) IsCourseInstructor(courseID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Table("cohort_instructors").
+		Joins("JOIN cohorts ON cohorts.id = cohort_instructors.cohort_id").
+		Where("cohorts.course_id = ? AND cohort_instructors.user_id = ?", courseID, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *cohortRepository) CreateDeadline(deadline *models.CohortDeadline) error {
+	return r.db.Create(deadline).Error
+}
+
+func (r *cohortRepository) GetDeadline(id uint) (*models.CohortDeadline, error) {
+	var deadline models.CohortDeadline
+	if err := r.db.First(&deadline, id).Error; err != nil {
+		return nil, err
+	}
+	return &deadline, nil
+}
+
+func (r *cohortRepository) PatchDeadline(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.CohortDeadline{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *cohortRepository) DeleteDeadline(id uint) error {
+	return r.db.Delete(&models.CohortDeadline{}, id).Error
+}
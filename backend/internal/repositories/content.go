@@ -1,6 +1,10 @@
 package repositories
 
 import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -22,6 +26,7 @@ type ContentRepository interface {
 	GetAll() ([]*models.Content, error)
 	GetByModuleID(moduleID uint) ([]*models.Content, error)
 	GetContentProgressByModule(userID, moduleID uint) ([]*ContentProgressResult, error)
+	ListByModuleID(moduleID uint, request *dto.ListRequest) (*dto.Page[*models.Content], error)
 }
 
 type contentRepository struct {
@@ -70,16 +75,9 @@ func (r *contentRepository) Patch(id uint, data map[string]interface{}) error {
 	return r.db.Model(&models.Content{}).Where("id = ?", id).Updates(data).Error
 }
 
+// Delete moves the content to the trash. Learner progress is kept.
 func (r *contentRepository) Delete(id uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Delete user progress for this content
-		if err := tx.Where(&models.UserProgress{ContentID: id}).Delete(&models.UserProgress{}).Error; err != nil {
-			return err
-		}
-
-		// Delete the content itself
-		return tx.Delete(&models.Content{ID: id}).Error
-	})
+	return softDeleteTree(r.db, enums.TrashEntityContent, []uint{id}, time.Now())
 }
 
 func (r *contentRepository) GetAll() ([]*models.Content, error) {
@@ -116,7 +114,7 @@ func (r *contentRepository) GetContentProgressByModule(userID, moduleID uint) ([
 			AND up.user_id = ? 
 			AND up.completed_at IS NOT NULL
 		)
-		WHERE c.module_id = ?
+		WHERE c.module_id = ? AND c.deleted_at IS NULL
 		ORDER BY c."order" ASC
 	`
 
@@ -126,3 +124,19 @@ func (r *contentRepository) GetContentProgressByModule(userID, moduleID uint) ([
 
 	return results, nil
 }
+
+var contentListSpec = ListSpec{
+	Sorts: map[string]string{
+		"order":      "order",
+		"title":      "title",
+		"created_at": "created_at",
+	},
+	Filters: map[string]string{
+		"type": "type",
+	},
+	DefaultSort: "order",
+}
+
+func (r *contentRepository) ListByModuleID(moduleID uint, request *dto.ListRequest) (*dto.Page[*models.Content], error) {
+	return paginate[models.Content](r.db.Where("module_id = ?", moduleID), request, contentListSpec)
+}
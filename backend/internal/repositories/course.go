@@ -1,6 +1,11 @@
 package repositories
 
 import (
+	"strings"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -17,6 +22,11 @@ type CourseRepository interface {
 	DecrementStudentCount(courseID uint) error
 	IncrementModuleCount(courseID uint) error
 	DecrementModuleCount(courseID uint) error
+	GetCatalog(filter *dto.CatalogRequest) ([]*models.Course, int64, error)
+	GetCatalogFacets(filter *dto.CatalogRequest) (*dto.CatalogFacets, error)
+	GetCatalogPaths(filter *dto.CatalogRequest) ([]*models.LearningPath, error)
+	ReplaceTags(courseID uint, tags []*models.Tag) error
+	List(request *dto.ListRequest) (*dto.Page[*models.Course], error)
 }
 
 type courseRepository struct {
@@ -29,13 +39,25 @@ func NewCourseRepository(r *Repository) CourseRepository {
 	}
 }
 
+// Create inserts the course. GORM replaces a false is_published with the column default,
+// so drafts are written explicitly after the insert.
 func (r *courseRepository) Create(course *models.Course) error {
-	return r.db.Create(course).Error
+	published := course.IsPublished
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(course).Error; err != nil {
+			return err
+		}
+		if published {
+			return nil
+		}
+		course.IsPublished = false
+		return tx.Model(course).Update("is_published", false).Error
+	})
 }
 
 func (r *courseRepository) Get(id uint) (*models.Course, error) {
 	var course models.Course
-	if err := r.db.First(&course, id).Error; err != nil {
+	if err := r.db.Preload("Category").Preload("Tags").First(&course, id).Error; err != nil {
 		return nil, err
 	}
 	return &course, nil
@@ -49,75 +71,10 @@ func (r *courseRepository) Patch(id uint, data map[string]interface{}) error {
 	return r.db.Model(&models.Course{}).Where("id = ?", id).Updates(data).Error
 }
 
+// Delete moves the course and its modules, contents and evaluations to the trash. Learner records are kept.
 func (r *courseRepository) Delete(id uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Get all modules for this course
-		var modules []*models.Module
-		if err := tx.Where("course_id = ?", id).Find(&modules).Error; err != nil {
-			return err
-		}
-		
-		// For each module, delete its contents and evaluations with cascade
-		for _, module := range modules {
-			// Get all evaluations for this module and cascade delete them
-			var evaluations []*models.Evaluation
-			if err := tx.Where("module_id = ?", module.ID).Find(&evaluations).Error; err != nil {
-				return err
-			}
-			
-			for _, evaluation := range evaluations {
-				// Delete answers for all questions in this evaluation
-				var questions []*models.Question
-				if err := tx.Where("evaluation_id = ?", evaluation.ID).Find(&questions).Error; err != nil {
-					return err
-				}
-				for _, question := range questions {
-					if err := tx.Where("question_id = ?", question.ID).Delete(&models.Answer{}).Error; err != nil {
-						return err
-					}
-				}
-				
-				// Delete questions for this evaluation
-				if err := tx.Where("evaluation_id = ?", evaluation.ID).Delete(&models.Question{}).Error; err != nil {
-					return err
-				}
-				
-				// Delete evaluation attempts for this evaluation
-				if err := tx.Where("evaluation_id = ?", evaluation.ID).Delete(&models.EvaluationAttempt{}).Error; err != nil {
-					return err
-				}
-			}
-			
-			// Delete all evaluations for this module
-			if err := tx.Where("module_id = ?", module.ID).Delete(&models.Evaluation{}).Error; err != nil {
-				return err
-			}
-			
-			// Delete all contents for this module
-			if err := tx.Where("module_id = ?", module.ID).Delete(&models.Content{}).Error; err != nil {
-				return err
-			}
-		}
-		
-		// Delete all modules for this course
-		if err := tx.Where("course_id = ?", id).Delete(&models.Module{}).Error; err != nil {
-			return err
-		}
-		
-		// Delete user progress for this course
-		if err := tx.Where("course_id = ?", id).Delete(&models.UserProgress{}).Error; err != nil {
-			return err
-		}
-		
-		// Delete enrollments for this course
-		if err := tx.Where("course_id = ?", id).Delete(&models.Enrollment{}).Error; err != nil {
-			return err
-		}
-		
-		// Finally delete the course itself
-		var course models.Course
-		course.ID = id
-		return tx.Delete(&course).Error
+		return softDeleteTree(tx, enums.TrashEntityCourse, []uint{id}, time.Now())
 	})
 }
 
@@ -148,3 +105,219 @@ func (r *courseRepository) DecrementModuleCount(courseID uint) error {
 	return r.db.Model(&models.Course{}).Where("id = ?", courseID).
 		Update("module_count", r.db.Raw("GREATEST(0, module_count - 1)")).Error
 }
+
+// Catalog facets, used to skip the facet's own filter when counting it
+const (
+	catalogFacetCategory = "category"
+	catalogFacetTags     = "tags"
+	catalogFacetLevel    = "level"
+	catalogFacetLanguage = "language"
+	catalogFacetRating   = "rating"
+)
+
+// likeEscaper escapes the LIKE wildcards, using the backslash Postgres takes as the default escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// containsPattern builds an ILIKE pattern matching the text literally anywhere in the value
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
+// catalogQuery builds the published courses query with every catalog filter
+// applied except the one belonging to the skipped facet
+func (r *courseRepository) catalogQuery(filter *dto.CatalogRequest, skip string) *gorm.DB {
+	query := r.db.Model(&models.Course{}).Where("courses.is_published = ?", true)
+
+	if filter.Query != "" {
+		like := containsPattern(filter.Query)
+		query = query.Where("(courses.title ILIKE ? OR courses.short_description ILIKE ?)", like, like)
+	}
+
+	if skip != catalogFacetCategory && filter.CategoryID != 0 {
+		// A category includes the courses of all its subcategories
+		query = query.Where(`courses.category_id IN (
+			WITH RECURSIVE category_tree AS (
+				SELECT id FROM categories WHERE id = ?
+				UNION ALL
+				SELECT c.id FROM categories c INNER JOIN category_tree t ON c.parent_id = t.id
+			)
+			SELECT id FROM category_tree
+		)`, filter.CategoryID)
+	}
+
+	if skip != catalogFacetTags && len(filter.Tags) > 0 {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM course_tags ct
+			INNER JOIN tags t ON t.id = ct.tag_id
+			WHERE ct.course_id = courses.id AND t.slug IN ?
+		)`, filter.Tags)
+	}
+
+	if skip != catalogFacetLevel && len(filter.Levels) > 0 {
+		query = query.Where("courses.level IN ?", filter.Levels)
+	}
+
+	if skip != catalogFacetLanguage && len(filter.Languages) > 0 {
+		query = query.Where("courses.language IN ?", filter.Languages)
+	}
+
+	if skip != catalogFacetRating && filter.MinRating > 0 {
+		query = query.Where("courses.rating_average >= ?", filter.MinRating)
+	}
+
+	if filter.MinDuration > 0 {
+		query = query.Where("courses.estimated_duration >= ?", filter.MinDuration)
+	}
+
+	if filter.MaxDuration > 0 {
+		query = query.Where("courses.estimated_duration <= ?", filter.MaxDuration)
+	}
+
+	return query
+}
+
+func catalogOrder(sort enums.CatalogSort) string {
+	switch sort {
+	case enums.CatalogSortNewest:
+		return "courses.created_at DESC, courses.id DESC"
+	case enums.CatalogSortRating:
+		return "courses.rating_average DESC, courses.rating_count DESC, courses.id DESC"
+	case enums.CatalogSortTitle:
+		return "courses.title ASC, courses.id ASC"
+	default:
+		return "courses.student_count DESC, courses.id DESC"
+	}
+}
+
+// GetCatalog returns a page of published courses matching the filter and the total count.
+// A limit lower or equal to zero returns every matching course.
+func (r *courseRepository) GetCatalog(filter *dto.CatalogRequest) ([]*models.Course, int64, error) {
+	var total int64
+	if err := r.catalogQuery(filter, "").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := r.catalogQuery(filter, "").
+		Preload("Category").
+		Preload("Tags").
+		Order(catalogOrder(filter.Sort))
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var courses []*models.Course
+	if err := query.Find(&courses).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return courses, total, nil
+}
+
+// GetCatalogFacets counts the catalog courses per facet value. Each facet ignores
+// its own filter so the client can show how many courses every option would yield.
+func (r *courseRepository) GetCatalogFacets(filter *dto.CatalogRequest) (*dto.CatalogFacets, error) {
+	facets := &dto.CatalogFacets{
+		Categories: []*dto.FacetCount{},
+		Tags:       []*dto.FacetCount{},
+		Levels:     []*dto.FacetCount{},
+		Languages:  []*dto.FacetCount{},
+		Ratings:    []*dto.FacetCount{},
+	}
+
+	if err := r.catalogQuery(filter, catalogFacetCategory).
+		Select("CAST(categories.id AS TEXT) AS value, categories.name AS label, COUNT(*) AS count").
+		Joins("INNER JOIN categories ON categories.id = courses.category_id").
+		Group("categories.id, categories.name").
+		Order("count DESC, label ASC").
+		Scan(&facets.Categories).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.catalogQuery(filter, catalogFacetTags).
+		Select("tags.slug AS value, tags.name AS label, COUNT(*) AS count").
+		Joins("INNER JOIN course_tags ON course_tags.course_id = courses.id").
+		Joins("INNER JOIN tags ON tags.id = course_tags.tag_id").
+		Group("tags.slug, tags.name").
+		Order("count DESC, label ASC").
+		Scan(&facets.Tags).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.catalogQuery(filter, catalogFacetLevel).
+		Select("courses.level AS value, courses.level AS label, COUNT(*) AS count").
+		Group("courses.level").
+		Order("count DESC, label ASC").
+		Scan(&facets.Levels).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.catalogQuery(filter, catalogFacetLanguage).
+		Select("courses.language AS value, courses.language AS label, COUNT(*) AS count").
+		Group("courses.language").
+		Order("count DESC, label ASC").
+		Scan(&facets.Languages).Error; err != nil {
+		return nil, err
+	}
+
+	// Rating thresholds are cumulative: a 4.5 course counts for 4+, 3+, 2+ and 1+
+	if err := r.catalogQuery(filter, catalogFacetRating).
+		Select("CAST(thresholds.value AS TEXT) AS value, CAST(thresholds.value AS TEXT) || '+' AS label, COUNT(*) AS count").
+		Joins("INNER JOIN (VALUES (4), (3), (2), (1)) AS thresholds(value) ON courses.rating_average >= thresholds.value").
+		Group("thresholds.value").
+		Order("thresholds.value DESC").
+		Scan(&facets.Ratings).Error; err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// GetCatalogPaths returns the published learning paths that bundle at least one course
+// matching the catalog filter, plus the paths whose title matches the search text
+func (r *courseRepository) GetCatalogPaths(filter *dto.CatalogRequest) ([]*models.LearningPath, error) {
+	courses := r.catalogQuery(filter, "").Select("courses.id")
+	matching := r.db.Where(`EXISTS (
+		SELECT 1 FROM learning_path_courses lpc
+		WHERE lpc.path_id = learning_paths.id AND lpc.course_id IN (?)
+	)`, courses)
+	if filter.Query != "" {
+		matching = matching.Or("learning_paths.title ILIKE ?", containsPattern(filter.Query))
+	}
+
+	var paths []*models.LearningPath
+	err := r.db.Where("learning_paths.is_published = ?", true).
+		Where(matching).
+		Order("learning_paths.student_count DESC, learning_paths.id DESC").
+		Find(&paths).Error
+	return paths, err
+}
+
+func (r *courseRepository) ReplaceTags(courseID uint, tags []*models.Tag) error {
+	course := models.Course{ID: courseID}
+	return r.db.Model(&course).Association("Tags").Replace(tags)
+}
+
+var courseListSpec = ListSpec{
+	Sorts: map[string]string{
+		"title":         "title",
+		"created_at":    "created_at",
+		"student_count": "student_count",
+		"module_count":  "module_count",
+		"rating":        "rating_average",
+	},
+	Filters: map[string]string{
+		"is_published": "is_published",
+		"category_id":  "category_id",
+		"level":        "level",
+		"language":     "language",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *courseRepository) List(request *dto.ListRequest) (*dto.Page[*models.Course], error) {
+	return paginate[models.Course](r.db, request, courseListSpec)
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeadlineRepository interface {
+	CreateOverride(override *models.UserDeadlineOverride) error
+	GetOverride(id uint) (*models.UserDeadlineOverride, error)
+	PatchOverride(id uint, data map[string]interface{}) error
+	DeleteOverride(id uint) error
+	ListOverridesByUser(userID uint, request *dto.ListRequest) (*dto.Page[*models.UserDeadlineOverride], error)
+	GetUserOverrides(userID uint) ([]*models.UserDeadlineOverride, error)
+	GetCourseSchedule(courseIDs []uint) ([]*models.Module, error)
+}
+
+type deadlineRepository struct {
+	*Repository
+}
+
+func NewDeadlineRepository(r *Repository) DeadlineRepository {
+	return &deadlineRepository{
+		Repository: r,
+	}
+}
+
+func (r *deadlineRepository) CreateOverride(override *models.UserDeadlineOverride) error {
+	return r.db.Create(override).Error
+}
+
+func (r *deadlineRepository) GetOverride(id uint) (*models.UserDeadlineOverride, error) {
+	var override models.UserDeadlineOverride
+	if err := r.db.First(&override, id).Error; err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+func (r *deadlineRepository) PatchOverride(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.UserDeadlineOverride{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *deadlineRepository) DeleteOverride(id uint) error {
+	return r.db.Delete(&models.UserDeadlineOverride{}, id).Error
+}
+
+var deadlineOverrideListSpec = ListSpec{
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"due_at":     "due_at",
+	},
+	Filters: map[string]string{
+		"module_id":     "module_id",
+		"evaluation_id": "evaluation_id",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *deadlineRepository) ListOverridesByUser(userID uint, request *dto.ListRequest) (*dto.Page[*models.UserDeadlineOverride], error) {
+	return paginate[models.UserDeadlineOverride](r.db.Where("user_id = ?", userID), request, deadlineOverrideListSpec)
+}
+
+func (r *deadlineRepository) GetUserOverrides(userID uint) ([]*models.UserDeadlineOverride, error) {
+	var overrides []*models.UserDeadlineOverride
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// GetCourseSchedule returns the modules of the courses in outline order with their
+// evaluations, which is everything needed to resolve their deadlines
+func (r *deadlineRepository) GetCourseSchedule(courseIDs []uint) ([]*models.Module, error) {
+	var modules []*models.Module
+	if len(courseIDs) == 0 {
+		return modules, nil
+	}
+	err := r.db.
+		Preload("Evaluations", func(db *gorm.DB) *gorm.DB {
+			return db.Order(`"order" ASC, id ASC`)
+		}).
+		Where("course_id IN ?", courseIDs).
+		Order(`course_id ASC, "order" ASC, id ASC`).
+		Find(&modules).Error
+	return modules, err
+}
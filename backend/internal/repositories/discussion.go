@@ -0,0 +1,302 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DiscussionRepository interface {
+	CreateThread(thread *models.DiscussionThread) error
+	GetThread(id uint) (*models.DiscussionThread, error)
+	PatchThread(id uint, data map[string]interface{}) error
+	DeleteThread(id uint) error
+	ListThreadsByCourse(courseID uint, includeHidden bool, request *dto.ListRequest) (*dto.Page[*models.DiscussionThread], error)
+	ListThreadsByContent(contentID uint, includeHidden bool, request *dto.ListRequest) (*dto.Page[*models.DiscussionThread], error)
+	ReplaceThreadMentions(threadID uint, users []*models.User) error
+
+	CreatePost(post *models.DiscussionPost) error
+	GetPost(id uint) (*models.DiscussionPost, error)
+	PatchPost(id uint, data map[string]interface{}) error
+	DeletePost(id uint) error
+	GetPostsByThread(threadID uint) ([]*models.DiscussionPost, error)
+	ReplacePostMentions(postID uint, users []*models.User) error
+	SetEndorsed(postID uint, endorsed bool, endorsedByID uint) error
+
+	AddVote(vote *models.DiscussionVote) error
+	RemoveVote(userID uint, targetType enums.DiscussionTargetType, targetID uint) error
+	GetVotedTargets(userID uint, targetType enums.DiscussionTargetType, targetIDs []uint) (map[uint]bool, error)
+
+	GetCourseParticipants(courseID uint, userIDs []uint) ([]*models.User, error)
+	GetThreadParticipantIDs(threadID uint) ([]uint, error)
+}
+
+type discussionRepository struct {
+	*Repository
+}
+
+func NewDiscussionRepository(r *Repository) DiscussionRepository {
+	return &discussionRepository{
+		Repository: r,
+	}
+}
+
+// discussionTargetTables maps the vote targets to the table holding their upvote counter
+var discussionTargetTables = map[enums.DiscussionTargetType]string{
+	enums.DiscussionTargetThread: "discussion_threads",
+	enums.DiscussionTargetPost:   "discussion_posts",
+}
+
+// CreateThread stores the thread and links its mentions without touching the mentioned users
+func (r *discussionRepository) CreateThread(thread *models.DiscussionThread) error {
+	return r.db.Omit("Mentions.*").Create(thread).Error
+}
+
+func (r *discussionRepository) GetThread(id uint) (*models.DiscussionThread, error) {
+	var thread models.DiscussionThread
+	if err := r.db.Preload("Author").Preload("Mentions").First(&thread, id).Error; err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+func (r *discussionRepository) PatchThread(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.DiscussionThread{}).Where("id = ?", id).Updates(data).Error
+}
+
+// DeleteThread removes the thread with its replies and their votes
+func (r *discussionRepository) DeleteThread(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("target_type = ? AND target_id IN (?)", enums.DiscussionTargetPost,
+			tx.Model(&models.DiscussionPost{}).Select("id").Where("thread_id = ?", id),
+		).Delete(&models.DiscussionVote{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("target_type = ? AND target_id = ?", enums.DiscussionTargetThread, id).
+			Delete(&models.DiscussionVote{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.DiscussionThread{}, id).Error
+	})
+}
+
+var discussionThreadListSpec = ListSpec{
+	Sorts: map[string]string{
+		"last_activity_at": "last_activity_at",
+		"created_at":       "created_at",
+		"upvote_count":     "upvote_count",
+		"reply_count":      "reply_count",
+	},
+	Filters: map[string]string{
+		"content_id":  "content_id",
+		"author_id":   "author_id",
+		"is_pinned":   "is_pinned",
+		"is_locked":   "is_locked",
+		"is_answered": "is_answered",
+	},
+	DefaultSort: "-last_activity_at",
+	Preloads:    []string{"Author"},
+}
+
+func (r *discussionRepository) ListThreadsByCourse(courseID uint, includeHidden bool, request *dto.ListRequest) (*dto.Page[*models.DiscussionThread], error) {
+	query := r.db.Where("course_id = ?", courseID)
+	if !includeHidden {
+		query = query.Where("is_hidden = ?", false)
+	}
+	return paginate[models.DiscussionThread](query, request, discussionThreadListSpec)
+}
+
+func (r *discussionRepository) ListThreadsByContent(contentID uint, includeHidden bool, request *dto.ListRequest) (*dto.Page[*models.DiscussionThread], error) {
+	query := r.db.Where("content_id = ?", contentID)
+	if !includeHidden {
+		query = query.Where("is_hidden = ?", false)
+	}
+	return paginate[models.DiscussionThread](query, request, discussionThreadListSpec)
+}
+
+func (r *discussionRepository) ReplaceThreadMentions(threadID uint, users []*models.User) error {
+	thread := models.DiscussionThread{ID: threadID}
+	return r.db.Omit("Mentions.*").Model(&thread).Association("Mentions").Replace(users)
+}
+
+// CreatePost stores the reply and bumps the reply counter and activity of its thread
+func (r *discussionRepository) CreatePost(post *models.DiscussionPost) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Mentions.*").Create(post).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.DiscussionThread{}).Where("id = ?", post.ThreadID).Updates(map[string]interface{}{
+			"reply_count":      gorm.Expr("reply_count + 1"),
+			"last_activity_at": post.CreatedAt,
+		}).Error
+	})
+}
+
+func (r *discussionRepository) GetPost(id uint) (*models.DiscussionPost, error) {
+	var post models.DiscussionPost
+	if err := r.db.Preload("Author").Preload("Mentions").First(&post, id).Error; err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func (r *discussionRepository) PatchPost(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.DiscussionPost{}).Where("id = ?", id).Updates(data).Error
+}
+
+// DeletePost removes the reply together with its nested replies and recomputes the thread counters
+func (r *discussionRepository) DeletePost(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var post models.DiscussionPost
+		if err := tx.First(&post, id).Error; err != nil {
+			return err
+		}
+
+		var subtree []uint
+		if err := tx.Raw(`
+			WITH RECURSIVE post_tree AS (
+				SELECT id FROM discussion_posts WHERE id = ?
+				UNION ALL
+				SELECT p.id FROM discussion_posts p INNER JOIN post_tree t ON p.parent_id = t.id
+			)
+			SELECT id FROM post_tree`, id).Scan(&subtree).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("target_type = ? AND target_id IN ?", enums.DiscussionTargetPost, subtree).
+			Delete(&models.DiscussionVote{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.DiscussionPost{}, subtree).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE discussion_threads SET
+				reply_count = (SELECT COUNT(*) FROM discussion_posts WHERE thread_id = ?),
+				is_answered = EXISTS (SELECT 1 FROM discussion_posts WHERE thread_id = ? AND is_endorsed)
+			WHERE id = ?`, post.ThreadID, post.ThreadID, post.ThreadID).Error
+	})
+}
+
+func (r *discussionRepository) GetPostsByThread(threadID uint) ([]*models.DiscussionPost, error) {
+	var posts []*models.DiscussionPost
+	if err := r.db.Preload("Author").Preload("Mentions").
+		Where("thread_id = ?", threadID).
+		Order("created_at ASC, id ASC").
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func (r *discussionRepository) ReplacePostMentions(postID uint, users []*models.User) error {
+	post := models.DiscussionPost{ID: postID}
+	return r.db.Omit("Mentions.*").Model(&post).Association("Mentions").Replace(users)
+}
+
+// SetEndorsed marks or unmarks a reply as endorsed answer and keeps the thread answered flag in sync
+func (r *discussionRepository) SetEndorsed(postID uint, endorsed bool, endorsedByID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var post models.DiscussionPost
+		if err := tx.First(&post, postID).Error; err != nil {
+			return err
+		}
+
+		data := map[string]interface{}{
+			"is_endorsed":    endorsed,
+			"endorsed_by_id": nil,
+			"endorsed_at":    nil,
+		}
+		if endorsed {
+			data["endorsed_by_id"] = endorsedByID
+			data["endorsed_at"] = time.Now()
+		}
+		if err := tx.Model(&models.DiscussionPost{}).Where("id = ?", postID).Updates(data).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE discussion_threads SET
+				is_answered = EXISTS (SELECT 1 FROM discussion_posts WHERE thread_id = ? AND is_endorsed)
+			WHERE id = ?`, post.ThreadID, post.ThreadID).Error
+	})
+}
+
+// AddVote registers the upvote and increments the target counter; repeated votes are ignored
+func (r *discussionRepository) AddVote(vote *models.DiscussionVote) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(vote)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Table(discussionTargetTables[vote.TargetType]).Where("id = ?", vote.TargetID).
+			Update("upvote_count", gorm.Expr("upvote_count + 1")).Error
+	})
+}
+
+// RemoveVote withdraws the upvote and decrements the target counter when there was one
+func (r *discussionRepository) RemoveVote(userID uint, targetType enums.DiscussionTargetType, targetID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+			Delete(&models.DiscussionVote{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Table(discussionTargetTables[targetType]).Where("id = ?", targetID).
+			Update("upvote_count", gorm.Expr("GREATEST(0, upvote_count - 1)")).Error
+	})
+}
+
+func (r *discussionRepository) GetVotedTargets(userID uint, targetType enums.DiscussionTargetType, targetIDs []uint) (map[uint]bool, error) {
+	voted := make(map[uint]bool)
+	if len(targetIDs) == 0 {
+		return voted, nil
+	}
+
+	var ids []uint
+	if err := r.db.Model(&models.DiscussionVote{}).
+		Where("user_id = ? AND target_type = ? AND target_id IN ?", userID, targetType, targetIDs).
+		Pluck("target_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		voted[id] = true
+	}
+	return voted, nil
+}
+
+// GetCourseParticipants returns the given users that can take part in the course discussions:
+// enrolled learners, instructors and admins
+func (r *discussionRepository) GetCourseParticipants(courseID uint, userIDs []uint) ([]*models.User, error) {
+	var users []*models.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	if err := r.db.Where("id IN ?", userIDs).
+		Where("(role IN ? OR EXISTS (SELECT 1 FROM enrollments e WHERE e.user_id = users.id AND e.course_id = ?))",
+			[]enums.UserRole{enums.UserRoleInstructor, enums.UserRoleAdmin}, courseID).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetThreadParticipantIDs returns the thread author and every user that replied in it
+func (r *discussionRepository) GetThreadParticipantIDs(threadID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Raw(`
+		SELECT author_id FROM discussion_threads WHERE id = ?
+		UNION
+		SELECT author_id FROM discussion_posts WHERE thread_id = ?`, threadID, threadID).
+		Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -1,7 +1,9 @@
 package repositories
 
 import (
+	"github.com/imlargo/go-api-template/internal/dto"
 	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -16,7 +18,12 @@ type EnrollmentRepository interface {
 	GetAll() ([]*models.Enrollment, error)
 	GetByUserID(userID uint) ([]*models.Enrollment, error)
 	GetByCourseID(courseID uint) ([]*models.Enrollment, error)
-	GetCourseKPIs(courseID uint) (int, float64, float64, string, error)
+	GetByUserAndCourses(userID uint, courseIDs []uint) ([]*models.Enrollment, error)
+	GetUserIDsByCourseAfter(courseID uint, afterUserID uint, limit int) ([]uint, error)
+	GetCourseKPIs(courseID uint, cohortID uint) (int, float64, float64, string, error)
+	SetCohort(courseID uint, userIDs []uint, cohortID *uint) error
+	ListByUserID(userID uint, request *dto.ListRequest) (*dto.Page[*models.Enrollment], error)
+	ListByCourseID(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Enrollment], error)
 }
 
 type enrollmentRepository struct {
@@ -87,6 +94,17 @@ func (r *enrollmentRepository) GetByUserID(userID uint) ([]*models.Enrollment, e
 	return enrollments, nil
 }
 
+func (r *enrollmentRepository) GetByUserAndCourses(userID uint, courseIDs []uint) ([]*models.Enrollment, error) {
+	var enrollments []*models.Enrollment
+	if len(courseIDs) == 0 {
+		return enrollments, nil
+	}
+	if err := r.db.Where("user_id = ? AND course_id IN ?", userID, courseIDs).Find(&enrollments).Error; err != nil {
+		return nil, err
+	}
+	return enrollments, nil
+}
+
 func (r *enrollmentRepository) GetByCourseID(courseID uint) ([]*models.Enrollment, error) {
 	var enrollments []*models.Enrollment
 	if err := r.db.Preload("User").Preload("Course").Where("course_id = ?", courseID).Find(&enrollments).Error; err != nil {
@@ -95,16 +113,39 @@ func (r *enrollmentRepository) GetByCourseID(courseID uint) ([]*models.Enrollmen
 	return enrollments, nil
 }
 
-func (r *enrollmentRepository) GetCourseKPIs(courseID uint) (int, float64, float64, string, error) {
+// GetUserIDsByCourseAfter returns the next batch of enrolled user IDs, in ascending order, after the given user
+func (r *enrollmentRepository) GetUserIDsByCourseAfter(courseID uint, afterUserID uint, limit int) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&models.Enrollment{}).
+		Where("course_id = ? AND user_id > ?", courseID, afterUserID).
+		Order("user_id ASC").
+		Limit(limit).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetCourseKPIs computes the course metrics. A non-zero cohortID restricts them to the
+// enrollments of that cohort.
+func (r *enrollmentRepository) GetCourseKPIs(courseID uint, cohortID uint) (int, float64, float64, string, error) {
 	// Get course title
 	var course models.Course
 	if err := r.db.First(&course, courseID).Error; err != nil {
 		return 0, 0, 0, "", err
 	}
 
+	scope := func() *gorm.DB {
+		query := r.db.Model(&models.Enrollment{}).Where("course_id = ?", courseID)
+		if cohortID != 0 {
+			query = query.Where("cohort_id = ?", cohortID)
+		}
+		return query
+	}
+
 	// Count total enrollments for this course
 	var totalEnrollments int64
-	if err := r.db.Model(&models.Enrollment{}).Where("course_id = ?", courseID).Count(&totalEnrollments).Error; err != nil {
+	if err := scope().Count(&totalEnrollments).Error; err != nil {
 		return 0, 0, 0, course.Title, err
 	}
 
@@ -114,15 +155,14 @@ func (r *enrollmentRepository) GetCourseKPIs(courseID uint) (int, float64, float
 
 	// Count completed enrollments (progress = 100)
 	var completedEnrollments int64
-	if err := r.db.Model(&models.Enrollment{}).Where("course_id = ? AND progress = ?", courseID, 100.0).Count(&completedEnrollments).Error; err != nil {
+	if err := scope().Where("progress = ?", 100.0).Count(&completedEnrollments).Error; err != nil {
 		return int(totalEnrollments), 0, 0, course.Title, err
 	}
 
 	// Calculate average progress - scan directly into float64
 	var avgProgress float64
-	if err := r.db.Model(&models.Enrollment{}).
+	if err := scope().
 		Select("AVG(progress)").
-		Where("course_id = ?", courseID).
 		Row().Scan(&avgProgress); err != nil {
 		return int(totalEnrollments), 0, 0, course.Title, err
 	}
@@ -132,3 +172,35 @@ func (r *enrollmentRepository) GetCourseKPIs(courseID uint) (int, float64, float
 
 	return int(totalEnrollments), completionRate, avgProgress, course.Title, nil
 }
+
+// SetCohort assigns the course enrollments of the given users to a cohort; a nil cohort
+// removes them from their cohort
+func (r *enrollmentRepository) SetCohort(courseID uint, userIDs []uint, cohortID *uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Enrollment{}).
+		Where("course_id = ? AND user_id IN ?", courseID, userIDs).
+		Update("cohort_id", cohortID).Error
+}
+
+var enrollmentListSpec = ListSpec{
+	Sorts: map[string]string{
+		"enrolled_at":  "enrolled_at",
+		"completed_at": "completed_at",
+		"progress":     "progress",
+	},
+	Filters: map[string]string{
+		"cohort_id": "cohort_id",
+	},
+	DefaultSort: "-enrolled_at",
+	Preloads:    []string{"User", "Course"},
+}
+
+func (r *enrollmentRepository) ListByUserID(userID uint, request *dto.ListRequest) (*dto.Page[*models.Enrollment], error) {
+	return paginate[models.Enrollment](r.db.Where("user_id = ?", userID), request, enrollmentListSpec)
+}
+
+func (r *enrollmentRepository) ListByCourseID(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Enrollment], error) {
+	return paginate[models.Enrollment](r.db.Where("course_id = ?", courseID), request, enrollmentListSpec)
+}
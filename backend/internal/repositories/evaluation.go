@@ -1,6 +1,10 @@
 package repositories
 
 import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -15,6 +19,7 @@ type EvaluationRepository interface {
 	GetAll() ([]*models.Evaluation, error)
 	GetByModuleID(moduleID uint) ([]*models.Evaluation, error)
 	GetWithQuestions(id uint) (*models.Evaluation, error)
+	ListByModuleID(moduleID uint, request *dto.ListRequest) (*dto.Page[*models.Evaluation], error)
 }
 
 type evaluationRepository struct {
@@ -47,20 +52,10 @@ func (r *evaluationRepository) Patch(id uint, data map[string]interface{}) error
 	return r.db.Model(&models.Evaluation{}).Where("id = ?", id).Updates(data).Error
 }
 
+// Delete moves the evaluation and its questions to the trash. Learner records are kept.
 func (r *evaluationRepository) Delete(id uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Delete all questions for this evaluation (answers will be deleted in cascade)
-		if err := tx.Where(&models.Question{EvaluationID: id}).Delete(&models.Question{}).Error; err != nil {
-			return err
-		}
-
-		// Delete all evaluation attempts for this evaluation
-		if err := tx.Where(&models.EvaluationAttempt{EvaluationID: id}).Delete(&models.EvaluationAttempt{}).Error; err != nil {
-			return err
-		}
-
-		// Finally delete the evaluation itself
-		return tx.Delete(&models.Evaluation{}, id).Error
+		return softDeleteTree(tx, enums.TrashEntityEvaluation, []uint{id}, time.Now())
 	})
 }
 
@@ -87,3 +82,16 @@ func (r *evaluationRepository) GetWithQuestions(id uint) (*models.Evaluation, er
 	}
 	return &evaluation, nil
 }
+
+var evaluationListSpec = ListSpec{
+	Sorts: map[string]string{
+		"order":      "order",
+		"title":      "title",
+		"created_at": "created_at",
+	},
+	DefaultSort: "order",
+}
+
+func (r *evaluationRepository) ListByModuleID(moduleID uint, request *dto.ListRequest) (*dto.Page[*models.Evaluation], error) {
+	return paginate[models.Evaluation](r.db.Where("module_id = ?", moduleID), request, evaluationListSpec)
+}
@@ -1,7 +1,11 @@
 package repositories
 
 import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
 	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -9,12 +13,18 @@ type EvaluationAttemptRepository interface {
 	Get(id uint) (*models.EvaluationAttempt, error)
 	Create(evaluationattempt *models.EvaluationAttempt) error
 	Update(evaluationattempt *models.EvaluationAttempt) error
+	Submit(id uint, change func(evaluationattempt *models.EvaluationAttempt) error) (*models.EvaluationAttempt, bool, error)
+	UpdateAnswers(id uint, change func(evaluationattempt *models.EvaluationAttempt) error) (*models.EvaluationAttempt, error)
 	Patch(id uint, data map[string]interface{}) error
 	Delete(id uint) error
 	GetAll() ([]*models.EvaluationAttempt, error)
 	GetByUserAndEvaluation(userID, evaluationID uint) ([]*models.EvaluationAttempt, error)
 	CountCompletedAttempts(userID, evaluationID uint) (int64, error)
 	GetInProgressAttempt(userID, evaluationID uint) (*models.EvaluationAttempt, error)
+	ListByUserAndEvaluation(userID, evaluationID uint, request *dto.ListRequest) (*dto.Page[*models.EvaluationAttempt], error)
+	GetPassedEvaluationIDs(userID uint, evaluationIDs []uint) (map[uint]bool, error)
+	GetFirstSubmittedByEvaluation(evaluationID uint) ([]*models.EvaluationAttempt, error)
+	GetExpiredInProgress(cutoff time.Time, limit int) ([]*models.EvaluationAttempt, error)
 }
 
 type evaluationattemptRepository struct {
@@ -39,8 +49,54 @@ func (r *evaluationattemptRepository) Get(id uint) (*models.EvaluationAttempt, e
 	return &evaluationattempt, nil
 }
 
+// Update writes every column so rescoring can bring the score down to zero or unset passed
 func (r *evaluationattemptRepository) Update(evaluationattempt *models.EvaluationAttempt) error {
-	return r.db.Model(evaluationattempt).Clauses(clause.Returning{}).Updates(evaluationattempt).Error
+	return r.db.Model(evaluationattempt).Clauses(clause.Returning{}).Select("*").Omit("created_at").Updates(evaluationattempt).Error
+}
+
+// Submit locks the attempt, lets change fill in its answers and score, and writes every
+// column back while it is still in progress. Under the lock a learner, the sweeper and
+// autosaves cannot overwrite each other. It reports whether this call submitted it.
+func (r *evaluationattemptRepository) Submit(id uint, change func(evaluationattempt *models.EvaluationAttempt) error) (*models.EvaluationAttempt, bool, error) {
+	var evaluationattempt models.EvaluationAttempt
+	submitted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&evaluationattempt, id).Error; err != nil {
+			return err
+		}
+		if evaluationattempt.SubmittedAt != nil {
+			return nil
+		}
+		if err := change(&evaluationattempt); err != nil {
+			return err
+		}
+		submitted = true
+		return tx.Model(&evaluationattempt).Select("*").Omit("created_at").Updates(&evaluationattempt).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &evaluationattempt, submitted, nil
+}
+
+// UpdateAnswers locks the attempt while change edits its answers and then writes them back,
+// so saves of different questions made at the same time do not overwrite each other. An
+// error from change rolls the transaction back and is returned as is.
+func (r *evaluationattemptRepository) UpdateAnswers(id uint, change func(evaluationattempt *models.EvaluationAttempt) error) (*models.EvaluationAttempt, error) {
+	var evaluationattempt models.EvaluationAttempt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&evaluationattempt, id).Error; err != nil {
+			return err
+		}
+		if err := change(&evaluationattempt); err != nil {
+			return err
+		}
+		return tx.Model(&evaluationattempt).Update("answers", evaluationattempt.Answers).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &evaluationattempt, nil
 }
 
 func (r *evaluationattemptRepository) Patch(id uint, data map[string]interface{}) error {
@@ -88,3 +144,71 @@ func (r *evaluationattemptRepository) GetInProgressAttempt(userID, evaluationID
 	}
 	return &attempt, nil
 }
+
+var evaluationAttemptListSpec = ListSpec{
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"score":      "score",
+	},
+	Filters: map[string]string{
+		"passed": "passed",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *evaluationattemptRepository) ListByUserAndEvaluation(userID, evaluationID uint, request *dto.ListRequest) (*dto.Page[*models.EvaluationAttempt], error) {
+	query := r.db.Where("user_id = ? AND evaluation_id = ?", userID, evaluationID)
+	return paginate[models.EvaluationAttempt](query, request, evaluationAttemptListSpec)
+}
+
+// GetPassedEvaluationIDs reports which of the evaluations the user already passed
+func (r *evaluationattemptRepository) GetPassedEvaluationIDs(userID uint, evaluationIDs []uint) (map[uint]bool, error) {
+	passed := make(map[uint]bool)
+	if len(evaluationIDs) == 0 {
+		return passed, nil
+	}
+
+	var ids []uint
+	if err := r.db.Model(&models.EvaluationAttempt{}).
+		Where("user_id = ? AND evaluation_id IN ? AND passed = ?", userID, evaluationIDs, true).
+		Distinct().Pluck("evaluation_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		passed[id] = true
+	}
+	return passed, nil
+}
+
+// GetFirstSubmittedByEvaluation returns the first submitted attempt of each learner
+func (r *evaluationattemptRepository) GetFirstSubmittedByEvaluation(evaluationID uint) ([]*models.EvaluationAttempt, error) {
+	var attempts []*models.EvaluationAttempt
+	err := r.db.
+		Raw(`SELECT DISTINCT ON (user_id) * FROM evaluation_attempts
+			WHERE evaluation_id = ? AND submitted_at IS NOT NULL
+			ORDER BY user_id, submitted_at ASC, id ASC`, evaluationID).
+		Scan(&attempts).Error
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// GetExpiredInProgress returns in-progress attempts whose time limit ended before the cutoff.
+// Attempts started before expires_at existed use the time limit of their evaluation.
+func (r *evaluationattemptRepository) GetExpiredInProgress(cutoff time.Time, limit int) ([]*models.EvaluationAttempt, error) {
+	var attempts []*models.EvaluationAttempt
+	err := r.db.
+		Joins("INNER JOIN evaluations e ON e.id = evaluation_attempts.evaluation_id AND e.deleted_at IS NULL").
+		Where("evaluation_attempts.submitted_at IS NULL").
+		Where(`COALESCE(evaluation_attempts.expires_at,
+			CASE WHEN e.time_limit > 0 THEN evaluation_attempts.started_at + e.time_limit * INTERVAL '1 minute' END) < ?`, cutoff).
+		Order("evaluation_attempts.id ASC").
+		Limit(limit).
+		Find(&attempts).Error
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/imlargo/go-api-template/internal/models"
 	"gorm.io/gorm/clause"
 )
@@ -14,6 +16,7 @@ type FileRepository interface {
 	GetFiles(fileIDs []uint) ([]*models.File, error)
 	GetFilesKeys(fileIDs []uint) ([]string, error)
 	DeleteFiles(fileIDs []uint) error
+	GetReleasedBefore(cutoff time.Time) ([]uint, error)
 }
 
 type fileRepository struct {
@@ -91,3 +94,15 @@ func (r *fileRepository) GetFilesKeys(fileIDs []uint) ([]string, error) {
 
 	return keys, nil
 }
+
+func (r *fileRepository) GetReleasedBefore(cutoff time.Time) ([]uint, error) {
+	var fileIDs []uint
+	if err := r.db.Model(&models.File{}).
+		Where("released_at IS NOT NULL AND released_at < ?", cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM attachments WHERE attachments.file_id = files.id)").
+		Where("NOT EXISTS (SELECT 1 FROM content_tracks WHERE content_tracks.file_id = files.id)").
+		Pluck("id", &fileIDs).Error; err != nil {
+		return nil, err
+	}
+	return fileIDs, nil
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+type ItemAnalysisRepository interface {
+	GetByEvaluation(evaluationID uint) (*models.EvaluationItemAnalysis, error)
+	Save(analysis *models.EvaluationItemAnalysis) error
+	GetStaleEvaluationIDs() ([]uint, error)
+}
+
+type itemAnalysisRepository struct {
+	*Repository
+}
+
+func NewItemAnalysisRepository(r *Repository) ItemAnalysisRepository {
+	return &itemAnalysisRepository{
+		Repository: r,
+	}
+}
+
+func (r *itemAnalysisRepository) GetByEvaluation(evaluationID uint) (*models.EvaluationItemAnalysis, error) {
+	var analysis models.EvaluationItemAnalysis
+	if err := r.db.Where("evaluation_id = ?", evaluationID).First(&analysis).Error; err != nil {
+		return nil, err
+	}
+	return &analysis, nil
+}
+
+// Save stores the analysis, replacing the previous one of the evaluation
+func (r *itemAnalysisRepository) Save(analysis *models.EvaluationItemAnalysis) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "evaluation_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"attempt_count", "reliability", "reliability_method", "items", "computed_at", "updated_at"}),
+	}).Create(analysis).Error
+}
+
+// GetStaleEvaluationIDs returns the live evaluations with attempts submitted or rescored
+// after their last analysis, including those never analysed
+func (r *itemAnalysisRepository) GetStaleEvaluationIDs() ([]uint, error) {
+	var ids []uint
+	err := r.db.Raw(`
+		SELECT a.evaluation_id
+		FROM evaluation_attempts a
+		INNER JOIN evaluations e ON e.id = a.evaluation_id AND e.deleted_at IS NULL
+		LEFT JOIN evaluation_item_analyses ia ON ia.evaluation_id = a.evaluation_id
+		WHERE a.submitted_at IS NOT NULL
+		GROUP BY a.evaluation_id, ia.computed_at
+		HAVING ia.computed_at IS NULL OR MAX(a.updated_at) > ia.computed_at
+		ORDER BY a.evaluation_id
+	`).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+type LearningPathRepository interface {
+	Create(path *models.LearningPath) error
+	Get(id uint) (*models.LearningPath, error)
+	Patch(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	List(publishedOnly bool, request *dto.ListRequest) (*dto.Page[*models.LearningPath], error)
+	ReplaceStructure(pathID uint, groups []*models.LearningPathGroup) error
+	CreateEnrollment(enrollment *models.LearningPathEnrollment) error
+	GetEnrollment(pathID, userID uint) (*models.LearningPathEnrollment, error)
+	ListEnrollmentsByUser(userID uint, request *dto.ListRequest) (*dto.Page[*models.LearningPathEnrollment], error)
+	MarkEnrollmentCompleted(id uint, completedAt time.Time) error
+}
+
+type learningPathRepository struct {
+	*Repository
+}
+
+func NewLearningPathRepository(r *Repository) LearningPathRepository {
+	return &learningPathRepository{
+		Repository: r,
+	}
+}
+
+func (r *learningPathRepository) Create(path *models.LearningPath) error {
+	return r.db.Create(path).Error
+}
+
+// Get loads the path with its groups and courses in their configured order
+func (r *learningPathRepository) Get(id uint) (*models.LearningPath, error) {
+	var path models.LearningPath
+	err := r.db.
+		Preload("Groups", func(db *gorm.DB) *gorm.DB {
+			return db.Order(`"order" ASC, id ASC`)
+		}).
+		Preload("Groups.Courses", func(db *gorm.DB) *gorm.DB {
+			return db.Order(`"order" ASC, id ASC`)
+		}).
+		Preload("Groups.Courses.Course").
+		First(&path, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &path, nil
+}
+
+func (r *learningPathRepository) Patch(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.LearningPath{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *learningPathRepository) Delete(id uint) error {
+	return r.db.Delete(&models.LearningPath{}, id).Error
+}
+
+var learningPathListSpec = ListSpec{
+	Sorts: map[string]string{
+		"title":         "title",
+		"created_at":    "created_at",
+		"student_count": "student_count",
+	},
+	Filters: map[string]string{
+		"is_published": "is_published",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *learningPathRepository) List(publishedOnly bool, request *dto.ListRequest) (*dto.Page[*models.LearningPath], error) {
+	query := r.db.Model(&models.LearningPath{})
+	if publishedOnly {
+		query = query.Where("is_published = ?", true)
+	}
+	return paginate[models.LearningPath](query, request, learningPathListSpec)
+}
+
+// ReplaceStructure swaps the groups and courses of the path for the given ones and
+// refreshes the course count in the same transaction
+func (r *learningPathRepository) ReplaceStructure(pathID uint, groups []*models.LearningPathGroup) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("path_id = ?", pathID).Delete(&models.LearningPathCourse{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("path_id = ?", pathID).Delete(&models.LearningPathGroup{}).Error; err != nil {
+			return err
+		}
+
+		courseCount := 0
+		for _, group := range groups {
+			group.PathID = pathID
+			for _, course := range group.Courses {
+				course.PathID = pathID
+			}
+			courseCount += len(group.Courses)
+		}
+
+		if len(groups) > 0 {
+			if err := tx.Create(&groups).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.LearningPath{}).Where("id = ?", pathID).
+			Update("course_count", courseCount).Error
+	})
+}
+
+// CreateEnrollment stores the path enrollment and increments the path student count
+func (r *learningPathRepository) CreateEnrollment(enrollment *models.LearningPathEnrollment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(enrollment).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.LearningPath{}).Where("id = ?", enrollment.PathID).
+			Update("student_count", gorm.Expr("student_count + 1")).Error
+	})
+}
+
+func (r *learningPathRepository) GetEnrollment(pathID, userID uint) (*models.LearningPathEnrollment, error) {
+	var enrollment models.LearningPathEnrollment
+	if err := r.db.Where("path_id = ? AND user_id = ?", pathID, userID).First(&enrollment).Error; err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+var learningPathEnrollmentListSpec = ListSpec{
+	Sorts: map[string]string{
+		"enrolled_at":  "enrolled_at",
+		"completed_at": "completed_at",
+	},
+	DefaultSort: "-enrolled_at",
+	Preloads:    []string{"Path"},
+}
+
+func (r *learningPathRepository) ListEnrollmentsByUser(userID uint, request *dto.ListRequest) (*dto.Page[*models.LearningPathEnrollment], error) {
+	query := r.db.Where("user_id = ?", userID)
+	return paginate[models.LearningPathEnrollment](query, request, learningPathEnrollmentListSpec)
+}
+
+func (r *learningPathRepository) MarkEnrollmentCompleted(id uint, completedAt time.Time) error {
+	return r.db.Model(&models.LearningPathEnrollment{}).
+		Where("id = ? AND completed_at IS NULL", id).
+		Update("completed_at", completedAt).Error
+}
@@ -1,6 +1,10 @@
 package repositories
 
 import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -16,6 +20,7 @@ type ModuleRepository interface {
 	GetByCourseID(courseID uint) ([]*models.Module, error)
 	GetWithContent(id uint) (*models.Module, error)
 	GetMaxOrderByCourseID(courseID uint) (int, error)
+	ListByCourseID(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Module], error)
 }
 
 type moduleRepository struct {
@@ -62,56 +67,10 @@ func (r *moduleRepository) Patch(id uint, data map[string]interface{}) error {
 	return r.db.Model(&models.Module{}).Where("id = ?", id).Updates(data).Error
 }
 
+// Delete moves the module and its contents and evaluations to the trash. Learner records are kept.
 func (r *moduleRepository) Delete(id uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Get all evaluations for this module and cascade delete them
-		var evaluations []*models.Evaluation
-		if err := tx.Where("module_id = ?", id).Find(&evaluations).Error; err != nil {
-			return err
-		}
-		
-		for _, evaluation := range evaluations {
-			// Delete answers for all questions in this evaluation
-			var questions []*models.Question
-			if err := tx.Where("evaluation_id = ?", evaluation.ID).Find(&questions).Error; err != nil {
-				return err
-			}
-			for _, question := range questions {
-				if err := tx.Where("question_id = ?", question.ID).Delete(&models.Answer{}).Error; err != nil {
-					return err
-				}
-			}
-			
-			// Delete questions for this evaluation
-			if err := tx.Where("evaluation_id = ?", evaluation.ID).Delete(&models.Question{}).Error; err != nil {
-				return err
-			}
-			
-			// Delete evaluation attempts for this evaluation
-			if err := tx.Where("evaluation_id = ?", evaluation.ID).Delete(&models.EvaluationAttempt{}).Error; err != nil {
-				return err
-			}
-		}
-		
-		// Delete all evaluations for this module
-		if err := tx.Where("module_id = ?", id).Delete(&models.Evaluation{}).Error; err != nil {
-			return err
-		}
-		
-		// Delete all contents for this module
-		if err := tx.Where("module_id = ?", id).Delete(&models.Content{}).Error; err != nil {
-			return err
-		}
-		
-		// Delete user progress for this module
-		if err := tx.Where("module_id = ?", id).Delete(&models.UserProgress{}).Error; err != nil {
-			return err
-		}
-		
-		// Finally delete the module itself
-		var module models.Module
-		module.ID = id
-		return tx.Delete(&module).Error
+		return softDeleteTree(tx, enums.TrashEntityModule, []uint{id}, time.Now())
 	})
 }
 
@@ -147,3 +106,17 @@ func (r *moduleRepository) GetMaxOrderByCourseID(courseID uint) (int, error) {
 		Scan(&maxOrder).Error
 	return maxOrder, err
 }
+
+var moduleListSpec = ListSpec{
+	Sorts: map[string]string{
+		"order":      "order",
+		"title":      "title",
+		"created_at": "created_at",
+	},
+	DefaultSort: "order",
+	Preloads:    []string{"Contents"},
+}
+
+func (r *moduleRepository) ListByCourseID(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Module], error) {
+	return paginate[models.Module](r.db.Where("course_id = ?", courseID), request, moduleListSpec)
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+type NoteRepository interface {
+	Create(note *models.Note) error
+	Get(id uint) (*models.Note, error)
+	Patch(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	ListByUserAndCourse(userID, courseID uint, request *dto.ListRequest) (*dto.Page[*models.Note], error)
+	ListByUserAndContent(userID, contentID uint, request *dto.ListRequest) (*dto.Page[*models.Note], error)
+	Search(userID uint, query string, request *dto.ListRequest) (*dto.Page[*models.Note], error)
+	GetCourseNotesInOrder(userID, courseID uint) ([]*models.Note, error)
+}
+
+type noteRepository struct {
+	*Repository
+}
+
+func NewNoteRepository(r *Repository) NoteRepository {
+	return &noteRepository{
+		Repository: r,
+	}
+}
+
+func (r *noteRepository) Create(note *models.Note) error {
+	return r.db.Create(note).Error
+}
+
+func (r *noteRepository) Get(id uint) (*models.Note, error) {
+	var note models.Note
+	if err := r.db.First(&note, id).Error; err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (r *noteRepository) Patch(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.Note{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *noteRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Note{}, id).Error
+}
+
+var noteListSpec = ListSpec{
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Filters: map[string]string{
+		"content_id": "content_id",
+		"course_id":  "course_id",
+		"kind":       "kind",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *noteRepository) ListByUserAndCourse(userID, courseID uint, request *dto.ListRequest) (*dto.Page[*models.Note], error) {
+	query := r.db.Where("user_id = ? AND course_id = ?", userID, courseID)
+	return paginate[models.Note](query, request, noteListSpec)
+}
+
+func (r *noteRepository) ListByUserAndContent(userID, contentID uint, request *dto.ListRequest) (*dto.Page[*models.Note], error) {
+	query := r.db.Where("user_id = ? AND content_id = ?", userID, contentID)
+	return paginate[models.Note](query, request, noteListSpec)
+}
+
+// Search matches the text of the user's own notes and highlights
+func (r *noteRepository) Search(userID uint, query string, request *dto.ListRequest) (*dto.Page[*models.Note], error) {
+	like := "%" + query + "%"
+	filtered := r.db.Where("user_id = ?", userID).
+		Where("(body ILIKE ? OR quote ILIKE ?)", like, like)
+	return paginate[models.Note](filtered, request, noteListSpec)
+}
+
+// GetCourseNotesInOrder returns every note of the user in the course following the course
+// outline: module order, content order, then the position of the anchor
+func (r *noteRepository) GetCourseNotesInOrder(userID, courseID uint) ([]*models.Note, error) {
+	var notes []*models.Note
+	if err := r.db.Preload("Content").Preload("Content.Module").
+		Joins("INNER JOIN contents ON contents.id = notes.content_id").
+		Joins("INNER JOIN modules ON modules.id = contents.module_id").
+		Where("notes.user_id = ? AND notes.course_id = ?", userID, courseID).
+		Where("contents.deleted_at IS NULL AND modules.deleted_at IS NULL").
+		Order(`modules."order" ASC, contents."order" ASC, notes.timestamp ASC NULLS LAST, notes.anchor_start ASC NULLS LAST, notes.created_at ASC`).
+		Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
@@ -3,13 +3,16 @@ package repositories
 import (
 	"time"
 
+	"github.com/imlargo/go-api-template/internal/dto"
 	"github.com/imlargo/go-api-template/internal/models"
 )
 
 type NotificationRepository interface {
 	Create(notification *models.Notification) error
+	CreateBatch(notifications []*models.Notification) error
 	GetByUser(id uint) ([]*models.Notification, error)
 	MarkAsRead(userID uint, since time.Time) error
+	ListByUser(userID uint, request *dto.ListRequest) (*dto.Page[*models.Notification], error)
 }
 
 type notificationRepositoryImpl struct {
@@ -26,6 +29,14 @@ func (r *notificationRepositoryImpl) Create(notification *models.Notification) e
 	return r.db.Create(notification).Error
 }
 
+// CreateBatch stores many notifications in a single insert
+func (r *notificationRepositoryImpl) CreateBatch(notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.Create(&notifications).Error
+}
+
 func (r *notificationRepositoryImpl) GetByUser(userID uint) ([]*models.Notification, error) {
 	var notifications []*models.Notification
 	if err := r.db.Order("created_at desc").Where(&models.Notification{UserID: userID}).Limit(100).Find(&notifications).Error; err != nil {
@@ -45,3 +56,18 @@ func (r *notificationRepositoryImpl) MarkAsRead(userID uint, since time.Time) er
 
 	return nil
 }
+
+var notificationListSpec = ListSpec{
+	Sorts: map[string]string{
+		"created_at": "created_at",
+	},
+	Filters: map[string]string{
+		"read":     "read",
+		"category": "category",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *notificationRepositoryImpl) ListByUser(userID uint, request *dto.ListRequest) (*dto.Page[*models.Notification], error) {
+	return paginate[models.Notification](r.db.Where("user_id = ?", userID), request, notificationListSpec)
+}
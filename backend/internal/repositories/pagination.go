@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+var (
+	ErrInvalidSort   = apperrors.New("INVALID_SORT", apperrors.KindInvalid, "campo de ordenamiento inválido", "invalid sort field")
+	ErrInvalidCursor = apperrors.New("INVALID_CURSOR", apperrors.KindInvalid, "cursor de paginación inválido", "invalid pagination cursor")
+)
+
+// ListSpec describes the sort fields and filters accepted by a list query.
+// Sorts and Filters map the public parameter name to the table column.
+type ListSpec struct {
+	Sorts       map[string]string
+	Filters     map[string]string
+	DefaultSort string
+	Preloads    []string
+}
+
+// listCursor points to the last item of a page: its sort value and its ID. Null marks a
+// NULL sort value, which sorts after every other value in both directions.
+type listCursor struct {
+	Value string `json:"v"`
+	Null  bool   `json:"n,omitempty"`
+	ID    uint   `json:"id"`
+}
+
+// paginate applies the shared pagination contract to a query over T. Results are ordered by
+// the requested sort field with the primary key as tie-breaker, so cursors are stable.
+// Nullable sort fields list their NULL rows last.
+func paginate[T any](query *gorm.DB, request *dto.ListRequest, spec ListSpec) (*dto.Page[*T], error) {
+	if request == nil {
+		request = &dto.ListRequest{}
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	offset := request.Offset
+	if offset < 0 || request.Cursor != "" {
+		offset = 0
+	}
+
+	sort := request.Sort
+	if sort == "" {
+		sort = spec.DefaultSort
+	}
+	desc := strings.HasPrefix(sort, "-")
+	column, ok := spec.Sorts[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		return nil, ErrInvalidSort.WithDetails(map[string]interface{}{"field": strings.TrimPrefix(sort, "-")})
+	}
+
+	query = query.Model(new(T))
+	for param, filterColumn := range spec.Filters {
+		if value, ok := request.Filters[param]; ok && value != "" {
+			query = query.Where("? = ?", clause.Column{Name: filterColumn}, value)
+		}
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	if request.Cursor != "" {
+		cursor, err := decodeListCursor(request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		operator := ">"
+		if desc {
+			operator = "<"
+		}
+		if cursor.Null {
+			query = query.Where(
+				fmt.Sprintf("(? IS NULL AND ? %s ?)", operator),
+				clause.Column{Name: column}, clause.Column{Name: "id"}, cursor.ID,
+			)
+		} else {
+			query = query.Where(
+				fmt.Sprintf("(? %s ? OR (? = ? AND ? %s ?) OR ? IS NULL)", operator, operator),
+				clause.Column{Name: column}, cursor.Value,
+				clause.Column{Name: column}, cursor.Value,
+				clause.Column{Name: "id"}, cursor.ID,
+				clause.Column{Name: column},
+			)
+		}
+	}
+
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+
+	for _, preload := range spec.Preloads {
+		query = query.Preload(preload)
+	}
+
+	var items []*T
+	if err := query.
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  fmt.Sprintf("? %s NULLS LAST, ? %s", direction, direction),
+			Vars: []interface{}{clause.Column{Name: column}, clause.Column{Name: "id"}},
+		}}).
+		Limit(limit + 1).
+		Offset(offset).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	page := &dto.Page[*T]{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+
+		cursor, err := encodeListCursor(query, page.Items[limit-1], column)
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+	}
+
+	return page, nil
+}
+
+// listSchemas caches the parsed model schemas used to build cursors
+var listSchemas sync.Map
+
+func encodeListCursor[T any](query *gorm.DB, item *T, column string) (string, error) {
+	modelSchema, err := schema.Parse(item, &listSchemas, query.NamingStrategy)
+	if err != nil {
+		return "", err
+	}
+
+	sortField := modelSchema.LookUpField(column)
+	if sortField == nil || modelSchema.PrioritizedPrimaryField == nil {
+		return "", ErrInvalidSort
+	}
+
+	ctx := context.Background()
+	itemValue := reflect.ValueOf(item).Elem()
+	value, _ := sortField.ValueOf(ctx, itemValue)
+	id, _ := modelSchema.PrioritizedPrimaryField.ValueOf(ctx, itemValue)
+
+	cursor := listCursor{}
+	cursor.Value, cursor.Null = formatCursorValue(value)
+	if idValue, ok := id.(uint); ok {
+		cursor.ID = idValue
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeListCursor(encoded string) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor listCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// formatCursorValue returns the sort value as text, or reports that it is NULL
+func formatCursorValue(value interface{}) (string, bool) {
+	if value == nil {
+		return "", true
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "", true
+		}
+		value = rv.Elem().Interface()
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano), false
+	default:
+		return fmt.Sprint(v), false
+	}
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+type PlaybackRepository interface {
+	GetByUserAndContent(userID, contentID uint) (*models.PlaybackProgress, error)
+	GetByContent(contentID uint) ([]*models.PlaybackProgress, error)
+	Save(progress *models.PlaybackProgress) error
+}
+
+type playbackRepository struct {
+	*Repository
+}
+
+func NewPlaybackRepository(r *Repository) PlaybackRepository {
+	return &playbackRepository{
+		Repository: r,
+	}
+}
+
+func (r *playbackRepository) GetByUserAndContent(userID, contentID uint) (*models.PlaybackProgress, error) {
+	var progress models.PlaybackProgress
+	if err := r.db.Where("user_id = ? AND content_id = ?", userID, contentID).First(&progress).Error; err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+func (r *playbackRepository) GetByContent(contentID uint) ([]*models.PlaybackProgress, error) {
+	var progress []*models.PlaybackProgress
+	if err := r.db.Where("content_id = ?", contentID).Find(&progress).Error; err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// Save creates the playback state on the first heartbeat and overwrites it afterwards
+func (r *playbackRepository) Save(progress *models.PlaybackProgress) error {
+	return r.db.Save(progress).Error
+}
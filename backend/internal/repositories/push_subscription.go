@@ -7,6 +7,7 @@ import (
 type PushNotificationSubscriptionRepository interface {
 	Create(subscription *models.PushNotificationSubscription) error
 	GetSubscriptionsByUser(id uint) ([]*models.PushNotificationSubscription, error)
+	GetSubscriptionsByUsers(ids []uint) ([]*models.PushNotificationSubscription, error)
 	Delete(id uint) error
 	GetByID(id uint) (*models.PushNotificationSubscription, error)
 }
@@ -33,6 +34,17 @@ func (r *pushSubscriptionRepositoryImpl) GetSubscriptionsByUser(id uint) ([]*mod
 	return subscriptions, nil
 }
 
+func (r *pushSubscriptionRepositoryImpl) GetSubscriptionsByUsers(ids []uint) ([]*models.PushNotificationSubscription, error) {
+	var subscriptions []*models.PushNotificationSubscription
+	if len(ids) == 0 {
+		return subscriptions, nil
+	}
+	if err := r.db.Where("user_id IN ?", ids).Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
 func (r *pushSubscriptionRepositoryImpl) Delete(id uint) error {
 	if err := r.db.Where("id = ?", id).Delete(&models.PushNotificationSubscription{}).Error; err != nil {
 		return err
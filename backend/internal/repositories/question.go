@@ -1,6 +1,10 @@
 package repositories
 
 import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -15,6 +19,8 @@ type QuestionRepository interface {
 	GetAll() ([]*models.Question, error)
 	GetByEvaluationID(evaluationID uint) ([]*models.Question, error)
 	GetWithAnswers(id uint) (*models.Question, error)
+	ListByEvaluationID(evaluationID uint, request *dto.ListRequest) (*dto.Page[*models.Question], error)
+	ReplaceTags(questionID uint, tags []*models.Tag) error
 }
 
 type questionRepository struct {
@@ -33,7 +39,7 @@ func (r *questionRepository) Create(question *models.Question) error {
 
 func (r *questionRepository) Get(id uint) (*models.Question, error) {
 	var question models.Question
-	if err := r.db.Preload("Answers").First(&question, id).Error; err != nil {
+	if err := r.db.Preload("Answers").Preload("Tags").First(&question, id).Error; err != nil {
 		return nil, err
 	}
 	return &question, nil
@@ -47,17 +53,10 @@ func (r *questionRepository) Patch(id uint, data map[string]interface{}) error {
 	return r.db.Model(&models.Question{}).Where("id = ?", id).Updates(data).Error
 }
 
+// Delete moves the question and its answers to the trash. Learner records are kept.
 func (r *questionRepository) Delete(id uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// First delete all answers related to this question
-		if err := tx.Where("question_id = ?", id).Delete(&models.Answer{}).Error; err != nil {
-			return err
-		}
-
-		// Then delete the question
-		var question models.Question
-		question.ID = id
-		return tx.Delete(&question).Error
+		return softDeleteTree(tx, enums.TrashEntityQuestion, []uint{id}, time.Now())
 	})
 }
 
@@ -79,8 +78,29 @@ func (r *questionRepository) GetByEvaluationID(evaluationID uint) ([]*models.Que
 
 func (r *questionRepository) GetWithAnswers(id uint) (*models.Question, error) {
 	var question models.Question
-	if err := r.db.Preload("Answers").First(&question, id).Error; err != nil {
+	if err := r.db.Preload("Answers").Preload("Tags").First(&question, id).Error; err != nil {
 		return nil, err
 	}
 	return &question, nil
 }
+
+var questionListSpec = ListSpec{
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"points":     "points",
+	},
+	Filters: map[string]string{
+		"type": "type",
+	},
+	DefaultSort: "created_at",
+	Preloads:    []string{"Answers"},
+}
+
+func (r *questionRepository) ListByEvaluationID(evaluationID uint, request *dto.ListRequest) (*dto.Page[*models.Question], error) {
+	return paginate[models.Question](r.db.Where("evaluation_id = ?", evaluationID), request, questionListSpec)
+}
+
+func (r *questionRepository) ReplaceTags(questionID uint, tags []*models.Tag) error {
+	question := models.Question{ID: questionID}
+	return r.db.Model(&question).Association("Tags").Replace(tags)
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+type QuestionBankRepository interface {
+	Create(bank *models.QuestionBank) error
+	Get(id uint) (*models.QuestionBank, error)
+	Patch(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	GetByCourse(courseID uint) ([]*models.QuestionBank, error)
+	CountQuestions(bankIDs []uint) (map[uint]int, error)
+	GetQuestions(bankID uint, tag string, difficulty enums.QuestionDifficulty) ([]*models.Question, error)
+	GetDrawRules(evaluationID uint) ([]*models.EvaluationDrawRule, error)
+	ReplaceDrawRules(evaluationID uint, rules []*models.EvaluationDrawRule) error
+}
+
+type questionBankRepository struct {
+	*Repository
+}
+
+func NewQuestionBankRepository(r *Repository) QuestionBankRepository {
+	return &questionBankRepository{
+		Repository: r,
+	}
+}
+
+func (r *questionBankRepository) Create(bank *models.QuestionBank) error {
+	return r.db.Create(bank).Error
+}
+
+func (r *questionBankRepository) Get(id uint) (*models.QuestionBank, error) {
+	var bank models.QuestionBank
+	if err := r.db.First(&bank, id).Error; err != nil {
+		return nil, err
+	}
+	return &bank, nil
+}
+
+func (r *questionBankRepository) Patch(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.QuestionBank{}).Where("id = ?", id).Updates(data).Error
+}
+
+// Delete removes the bank for good. Its questions, their answers and the draw rules that
+// use it go with it through the foreign keys; attempts keep their own copy of the questions.
+func (r *questionBankRepository) Delete(id uint) error {
+	return r.db.Delete(&models.QuestionBank{}, id).Error
+}
+
+func (r *questionBankRepository) GetByCourse(courseID uint) ([]*models.QuestionBank, error) {
+	var banks []*models.QuestionBank
+	if err := r.db.Where("course_id = ?", courseID).Order("name ASC, id ASC").Find(&banks).Error; err != nil {
+		return nil, err
+	}
+	return banks, nil
+}
+
+// CountQuestions returns how many live questions each bank holds
+func (r *questionBankRepository) CountQuestions(bankIDs []uint) (map[uint]int, error) {
+	counts := make(map[uint]int, len(bankIDs))
+	if len(bankIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		BankID uint
+		Total  int
+	}
+	err := r.db.Model(&models.Question{}).
+		Select("bank_id, COUNT(*) AS total").
+		Where("bank_id IN ?", bankIDs).
+		Group("bank_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.BankID] = row.Total
+	}
+	return counts, nil
+}
+
+// GetQuestions returns the questions of the bank with the given tag slug and difficulty;
+// empty values match every question
+func (r *questionBankRepository) GetQuestions(bankID uint, tag string, difficulty enums.QuestionDifficulty) ([]*models.Question, error) {
+	query := r.db.Preload("Answers").Preload("Tags").Where("bank_id = ?", bankID)
+	if tag != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM question_tags qt INNER JOIN tags t ON t.id = qt.tag_id WHERE qt.question_id = questions.id AND t.slug = ?)",
+			tag,
+		)
+	}
+	if difficulty != "" {
+		query = query.Where("difficulty = ?", difficulty)
+	}
+
+	var questions []*models.Question
+	if err := query.Order("id ASC").Find(&questions).Error; err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (r *questionBankRepository) GetDrawRules(evaluationID uint) ([]*models.EvaluationDrawRule, error) {
+	var rules []*models.EvaluationDrawRule
+	err := r.db.Preload("Bank").
+		Where("evaluation_id = ?", evaluationID).
+		Order(`"order" ASC, id ASC`).
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceDrawRules swaps every draw rule of the evaluation for the given ones
+func (r *questionBankRepository) ReplaceDrawRules(evaluationID uint, rules []*models.EvaluationDrawRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("evaluation_id = ?", evaluationID).Delete(&models.EvaluationDrawRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Omit("Bank", "Evaluation").Create(&rules).Error
+	})
+}
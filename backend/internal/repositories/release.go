@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// PendingModuleRelease is a module that unlocked for an enrolled learner who has not been
+// notified yet
+type PendingModuleRelease struct {
+	ModuleID    uint      `json:"module_id"`
+	ModuleTitle string    `json:"module_title"`
+	CourseID    uint      `json:"course_id"`
+	CourseTitle string    `json:"course_title"`
+	UserID      uint      `json:"user_id"`
+	UnlockedAt  time.Time `json:"unlocked_at"`
+}
+
+type ReleaseRepository interface {
+	GetPendingReleases(now, since time.Time, limit int) ([]*PendingModuleRelease, error)
+	CreateNotices(notices []*models.ModuleReleaseNotice) error
+}
+
+type releaseRepository struct {
+	*Repository
+}
+
+func NewReleaseRepository(r *Repository) ReleaseRepository {
+	return &releaseRepository{
+		Repository: r,
+	}
+}
+
+// GetPendingReleases finds the modules with a release rule that unlocked between since and
+// now for each enrollee without a notice. Modules already unlocked when the learner enrolled
+// are skipped. Relative releases count from the cohort start, or the enrollment date
+// without a cohort, matching releaseBase.
+func (r *releaseRepository) GetPendingReleases(now, since time.Time, limit int) ([]*PendingModuleRelease, error) {
+	query := `
+	SELECT m.id AS module_id, m.title AS module_title, c.id AS course_id, c.title AS course_title,
+		e.user_id, r.unlocked_at
+	FROM enrollments e
+	INNER JOIN courses c ON c.id = e.course_id AND c.deleted_at IS NULL
+	INNER JOIN modules m ON m.course_id = e.course_id AND m.deleted_at IS NULL
+	LEFT JOIN cohorts co ON co.id = e.cohort_id
+	CROSS JOIN LATERAL (
+		SELECT GREATEST(m.release_at, COALESCE(co.start_date, e.enrolled_at) + m.release_after_days * INTERVAL '1 day') AS unlocked_at
+	) r
+	WHERE (m.release_at IS NOT NULL OR m.release_after_days IS NOT NULL)
+	AND r.unlocked_at <= ? AND r.unlocked_at > ? AND r.unlocked_at > e.enrolled_at
+	AND NOT EXISTS (
+		SELECT 1 FROM module_release_notices n WHERE n.module_id = m.id AND n.user_id = e.user_id
+	)
+	ORDER BY m.id, e.user_id
+	LIMIT ?
+	`
+
+	var releases []*PendingModuleRelease
+	if err := r.db.Raw(query, now, since, limit).Scan(&releases).Error; err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+func (r *releaseRepository) CreateNotices(notices []*models.ModuleReleaseNotice) error {
+	if len(notices) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(notices, 100).Error
+}
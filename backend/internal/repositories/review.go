@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+type ReviewRepository interface {
+	Create(review *models.Review) error
+	Get(id uint) (*models.Review, error)
+	Patch(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	GetByUserAndCourse(userID, courseID uint) (*models.Review, error)
+	ListVisibleByCourse(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Review], error)
+	ListReported(request *dto.ListRequest) (*dto.Page[*models.Review], error)
+	HasReport(reviewID, userID uint) (bool, error)
+	CreateReport(report *models.ReviewReport) error
+	ResolveReports(reviewID uint) error
+	RefreshCourseRating(courseID uint) error
+}
+
+type reviewRepository struct {
+	*Repository
+}
+
+func NewReviewRepository(r *Repository) ReviewRepository {
+	return &reviewRepository{
+		Repository: r,
+	}
+}
+
+func (r *reviewRepository) Create(review *models.Review) error {
+	return r.db.Create(review).Error
+}
+
+func (r *reviewRepository) Get(id uint) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.Preload("User").First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepository) Patch(id uint, data map[string]interface{}) error {
+	return r.db.Model(&models.Review{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *reviewRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Review{}, id).Error
+}
+
+func (r *reviewRepository) GetByUserAndCourse(userID, courseID uint) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.Where("user_id = ? AND course_id = ?", userID, courseID).First(&review).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+var reviewListSpec = ListSpec{
+	Sorts: map[string]string{
+		"created_at":   "created_at",
+		"rating":       "rating",
+		"report_count": "report_count",
+	},
+	Filters: map[string]string{
+		"rating":    "rating",
+		"course_id": "course_id",
+		"status":    "status",
+	},
+	DefaultSort: "-created_at",
+	Preloads:    []string{"User"},
+}
+
+func (r *reviewRepository) ListVisibleByCourse(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Review], error) {
+	query := r.db.Where("course_id = ? AND status = ?", courseID, enums.ReviewStatusVisible)
+	return paginate[models.Review](query, request, reviewListSpec)
+}
+
+// ListReported returns the moderation queue: reviews with unresolved reports
+func (r *reviewRepository) ListReported(request *dto.ListRequest) (*dto.Page[*models.Review], error) {
+	query := r.db.Where("report_count > 0").
+		Preload("Reports", "resolved_at IS NULL")
+	return paginate[models.Review](query, request, reviewListSpec)
+}
+
+func (r *reviewRepository) HasReport(reviewID, userID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.ReviewReport{}).
+		Where("review_id = ? AND user_id = ?", reviewID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateReport stores the report and increments the unresolved reports counter of the review
+func (r *reviewRepository) CreateReport(report *models.ReviewReport) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(report).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Review{}).Where("id = ?", report.ReviewID).
+			Update("report_count", gorm.Expr("report_count + 1")).Error
+	})
+}
+
+func (r *reviewRepository) ResolveReports(reviewID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ReviewReport{}).
+			Where("review_id = ? AND resolved_at IS NULL", reviewID).
+			Update("resolved_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Review{}).Where("id = ?", reviewID).Update("report_count", 0).Error
+	})
+}
+
+// RefreshCourseRating recomputes the course average, count and histogram from its visible reviews
+func (r *reviewRepository) RefreshCourseRating(courseID uint) error {
+	return r.db.Exec(`
+		UPDATE courses SET
+			rating_average = stats.average,
+			rating_count = stats.count,
+			rating_histogram = stats.histogram
+		FROM (
+			SELECT
+				COALESCE(ROUND(AVG(rating)::numeric, 2), 0) AS average,
+				COUNT(*) AS count,
+				json_build_array(
+					COUNT(*) FILTER (WHERE rating = 1),
+					COUNT(*) FILTER (WHERE rating = 2),
+					COUNT(*) FILTER (WHERE rating = 3),
+					COUNT(*) FILTER (WHERE rating = 4),
+					COUNT(*) FILTER (WHERE rating = 5)
+				) AS histogram
+			FROM reviews
+			WHERE course_id = ? AND status = ?
+		) AS stats
+		WHERE courses.id = ?`, courseID, enums.ReviewStatusVisible, courseID).Error
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+type RevisionRepository interface {
+	Create(revision *models.Revision) error
+	GetVersion(entityType enums.RevisionEntityType, entityID uint, version int) (*models.Revision, error)
+	GetLatest(entityType enums.RevisionEntityType, entityID uint) (*models.Revision, error)
+	GetLatestVersions(entityType enums.RevisionEntityType, entityIDs []uint) (map[uint]int, error)
+	ListByEntity(entityType enums.RevisionEntityType, entityID uint, request *dto.ListRequest) (*dto.Page[*models.Revision], error)
+}
+
+type revisionRepository struct {
+	*Repository
+}
+
+func NewRevisionRepository(r *Repository) RevisionRepository {
+	return &revisionRepository{
+		Repository: r,
+	}
+}
+
+// Create assigns the next version number of the entity and stores the revision. Concurrent
+// edits of the same entity are serialized with an advisory lock, since its first revision
+// has no row to lock yet.
+func (r *revisionRepository) Create(revision *models.Revision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?), ?)", revision.EntityType, int32(revision.EntityID)).Error; err != nil {
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&models.Revision{}).
+			Where("entity_type = ? AND entity_id = ?", revision.EntityType, revision.EntityID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		revision.Version = latest + 1
+		return tx.Create(revision).Error
+	})
+}
+
+func (r *revisionRepository) GetVersion(entityType enums.RevisionEntityType, entityID uint, version int) (*models.Revision, error) {
+	var revision models.Revision
+	if err := r.db.Preload("Author").
+		Where("entity_type = ? AND entity_id = ? AND version = ?", entityType, entityID, version).
+		First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// GetLatest returns nil without error when the entity has no revisions yet
+func (r *revisionRepository) GetLatest(entityType enums.RevisionEntityType, entityID uint) (*models.Revision, error) {
+	var revision models.Revision
+	err := r.db.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("version DESC").
+		First(&revision).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *revisionRepository) GetLatestVersions(entityType enums.RevisionEntityType, entityIDs []uint) (map[uint]int, error) {
+	versions := make(map[uint]int)
+	if len(entityIDs) == 0 {
+		return versions, nil
+	}
+
+	var rows []struct {
+		EntityID uint
+		Version  int
+	}
+	if err := r.db.Model(&models.Revision{}).
+		Select("entity_id, MAX(version) AS version").
+		Where("entity_type = ? AND entity_id IN ?", entityType, entityIDs).
+		Group("entity_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		versions[row.EntityID] = row.Version
+	}
+	return versions, nil
+}
+
+var revisionListSpec = ListSpec{
+	Sorts: map[string]string{
+		"version":    "version",
+		"created_at": "created_at",
+	},
+	Filters: map[string]string{
+		"author_id": "author_id",
+	},
+	DefaultSort: "-version",
+	Preloads:    []string{"Author"},
+}
+
+func (r *revisionRepository) ListByEntity(entityType enums.RevisionEntityType, entityID uint, request *dto.ListRequest) (*dto.Page[*models.Revision], error) {
+	query := r.db.Where("entity_type = ? AND entity_id = ?", entityType, entityID)
+	return paginate[models.Revision](query, request, revisionListSpec)
+}
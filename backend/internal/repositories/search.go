@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+// SearchFilter represents the filters applied to a full-text search
+type SearchFilter struct {
+	Query    string
+	Language enums.SearchLanguage
+	CourseID uint
+	// UserID and Restricted limit results to published courses or courses the user is enrolled in,
+	// and hide the material of modules not yet released to the user
+	UserID     uint
+	Restricted bool
+	Limit      int
+	Offset     int
+}
+
+type SearchRepository interface {
+	Search(filter SearchFilter) ([]*dto.SearchResult, error)
+}
+
+type searchRepository struct {
+	*Repository
+}
+
+func NewSearchRepository(r *Repository) SearchRepository {
+	return &searchRepository{
+		Repository: r,
+	}
+}
+
+// Columns indexed per language, the search query must build the exact same expressions
+var (
+	courseSearchColumns     = []string{"title", "short_description", "description"}
+	moduleSearchColumns     = []string{"title", "description"}
+	contentSearchColumns    = []string{"title", "description", "body"}
+	questionSearchColumns   = []string{"text"}
+	transcriptSearchColumns = []string{"text"}
+)
+
+var searchLanguages = []enums.SearchLanguage{enums.SearchLanguageSpanish, enums.SearchLanguageEnglish}
+
+// searchVector builds the to_tsvector expression for the given columns, optionally qualified by a table alias
+func searchVector(cfg string, alias string, columns []string) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		if alias != "" {
+			column = alias + "." + column
+		}
+		parts[i] = fmt.Sprintf("coalesce(%s, '')", column)
+	}
+	return fmt.Sprintf("to_tsvector('%s', %s)", cfg, strings.Join(parts, " || ' ' || "))
+}
+
+// SearchIndexes returns the GIN expression indexes backing the full-text search
+func SearchIndexes() []string {
+	var statements []string
+	for _, language := range searchLanguages {
+		cfg := language.TextSearchConfig()
+		statements = append(statements,
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_courses_fts_%s ON courses USING GIN (%s)", cfg, searchVector(cfg, "", courseSearchColumns)),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_modules_fts_%s ON modules USING GIN (%s)", cfg, searchVector(cfg, "", moduleSearchColumns)),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_contents_fts_%s ON contents USING GIN (%s)", cfg, searchVector(cfg, "", contentSearchColumns)),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_questions_fts_%s ON questions USING GIN (%s)", cfg, searchVector(cfg, "", questionSearchColumns)),
+			// Only transcripts carry text, caption tracks are served as files
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_content_tracks_fts_%s ON content_tracks USING GIN (%s) WHERE kind = '%s'", cfg, searchVector(cfg, "", transcriptSearchColumns), enums.TrackKindTranscript),
+		)
+	}
+	return statements
+}
+
+func (r *searchRepository) Search(filter SearchFilter) ([]*dto.SearchResult, error) {
+	var results []*dto.SearchResult
+
+	// The text search configuration comes from a fixed whitelist, so it is safe to inline it
+	// and lets Postgres match the expression indexes created by SearchIndexes
+	cfg := filter.Language.TextSearchConfig()
+
+	query := fmt.Sprintf(`
+	WITH search_query AS (
+		SELECT websearch_to_tsquery('%[1]s', @query) AS q
+	),
+	documents AS (
+		-- Courses: title weighs more than descriptions
+		SELECT
+			'course' AS entity_type,
+			c.id AS entity_id,
+			c.id AS course_id,
+			c.title AS course_title,
+			0 AS module_id,
+			c.title AS title,
+			coalesce(c.short_description, '') || ' ' || coalesce(c.description, '') AS body,
+			setweight(to_tsvector('%[1]s', coalesce(c.title, '')), 'A') ||
+			setweight(to_tsvector('%[1]s', coalesce(c.short_description, '') || ' ' || coalesce(c.description, '')), 'B') AS document
+		FROM courses c, search_query sq
+		WHERE c.deleted_at IS NULL AND %[2]s @@ sq.q
+
+		UNION ALL
+
+		-- Modules
+		SELECT
+			'module',
+			m.id,
+			c.id,
+			c.title,
+			m.id,
+			m.title,
+			coalesce(m.description, ''),
+			setweight(to_tsvector('%[1]s', coalesce(m.title, '')), 'A') ||
+			setweight(to_tsvector('%[1]s', coalesce(m.description, '')), 'C')
+		FROM modules m
+		INNER JOIN courses c ON c.id = m.course_id, search_query sq
+		WHERE m.deleted_at IS NULL AND %[3]s @@ sq.q
+
+		UNION ALL
+
+		-- Contents
+		SELECT
+			'content',
+			ct.id,
+			c.id,
+			c.title,
+			m.id,
+			ct.title,
+			coalesce(ct.description, '') || ' ' || coalesce(ct.body, ''),
+			setweight(to_tsvector('%[1]s', coalesce(ct.title, '')), 'A') ||
+			setweight(to_tsvector('%[1]s', coalesce(ct.description, '')), 'B') ||
+			setweight(to_tsvector('%[1]s', coalesce(ct.body, '')), 'C')
+		FROM contents ct
+		INNER JOIN modules m ON m.id = ct.module_id
+		INNER JOIN courses c ON c.id = m.course_id, search_query sq
+		WHERE ct.deleted_at IS NULL AND %[4]s @@ sq.q
+
+		UNION ALL
+
+		-- Questions
+		SELECT
+			'question',
+			qu.id,
+			c.id,
+			c.title,
+			m.id,
+			e.title,
+			coalesce(qu.text, ''),
+			setweight(to_tsvector('%[1]s', coalesce(qu.text, '')), 'D')
+		FROM questions qu
+		INNER JOIN evaluations e ON e.id = qu.evaluation_id
+		INNER JOIN modules m ON m.id = e.module_id
+		INNER JOIN courses c ON c.id = m.course_id, search_query sq
+		WHERE qu.deleted_at IS NULL AND %[5]s @@ sq.q
+
+		UNION ALL
+
+		-- Transcripts: results point to the content they transcribe
+		SELECT
+			'transcript',
+			ct.id,
+			c.id,
+			c.title,
+			m.id,
+			ct.title,
+			coalesce(tr.text, ''),
+			setweight(to_tsvector('%[1]s', coalesce(tr.text, '')), 'D')
+		FROM content_tracks tr
+		INNER JOIN contents ct ON ct.id = tr.content_id
+		INNER JOIN modules m ON m.id = ct.module_id
+		INNER JOIN courses c ON c.id = m.course_id, search_query sq
+		WHERE tr.kind = '%[7]s' AND ct.deleted_at IS NULL AND %[6]s @@ sq.q
+	)
+	SELECT
+		d.entity_type,
+		d.entity_id,
+		d.course_id,
+		d.course_title,
+		d.module_id,
+		d.title,
+		ts_headline('%[1]s', d.body, sq.q, 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2') AS snippet,
+		ts_rank(d.document, sq.q) AS rank
+	FROM documents d
+	INNER JOIN courses c ON c.id = d.course_id, search_query sq
+	WHERE (@course_id = 0 OR d.course_id = @course_id)
+	AND (
+		@restricted = false
+		OR c.is_published = true
+		OR EXISTS (
+			SELECT 1 FROM enrollments en
+			WHERE en.course_id = c.id AND en.user_id = @user_id
+		)
+	)
+	-- Material of modules not yet released to the learner stays hidden; the module itself
+	-- is part of the course outline. Mirrors the release rule of the modules service.
+	AND (
+		@restricted = false
+		OR d.entity_type NOT IN ('content', 'question', 'transcript')
+		OR NOT EXISTS (
+			SELECT 1 FROM modules rm
+			LEFT JOIN enrollments re ON re.course_id = rm.course_id AND re.user_id = @user_id
+			LEFT JOIN cohorts rc ON rc.id = re.cohort_id
+			WHERE rm.id = d.module_id
+			AND (
+				rm.release_at > NOW()
+				OR (
+					rm.release_after_days IS NOT NULL AND re.id IS NOT NULL
+					AND COALESCE(rc.start_date, re.enrolled_at) + rm.release_after_days * INTERVAL '1 day' > NOW()
+				)
+			)
+		)
+	)
+	ORDER BY rank DESC, d.entity_type, d.entity_id
+	LIMIT @limit OFFSET @offset
+	`,
+		cfg,
+		searchVector(cfg, "c", courseSearchColumns),
+		searchVector(cfg, "m", moduleSearchColumns),
+		searchVector(cfg, "ct", contentSearchColumns),
+		searchVector(cfg, "qu", questionSearchColumns),
+		searchVector(cfg, "tr", transcriptSearchColumns),
+		enums.TrackKindTranscript,
+	)
+
+	params := map[string]interface{}{
+		"query":      filter.Query,
+		"course_id":  filter.CourseID,
+		"restricted": filter.Restricted,
+		"user_id":    filter.UserID,
+		"limit":      filter.Limit,
+		"offset":     filter.Offset,
+	}
+
+	if err := r.db.Raw(query, params).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository interface {
+	GetAll() ([]*models.Tag, error)
+	GetOrCreate(tags []*models.Tag) ([]*models.Tag, error)
+	Delete(id uint) error
+}
+
+type tagRepository struct {
+	*Repository
+}
+
+func NewTagRepository(r *Repository) TagRepository {
+	return &tagRepository{
+		Repository: r,
+	}
+}
+
+func (r *tagRepository) GetAll() ([]*models.Tag, error) {
+	var tags []*models.Tag
+	if err := r.db.Order("name ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetOrCreate inserts the tags whose slug does not exist yet and returns all of them
+func (r *tagRepository) GetOrCreate(tags []*models.Tag) ([]*models.Tag, error) {
+	if len(tags) == 0 {
+		return []*models.Tag{}, nil
+	}
+
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slug"}},
+		DoNothing: true,
+	}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+
+	var existing []*models.Tag
+	if err := r.db.Where("slug IN ?", slugs).Order("name ASC").Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *tagRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Remove the tag from every course and bank question
+		if err := tx.Exec("DELETE FROM course_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM question_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		var tag models.Tag
+		tag.ID = id
+		return tx.Delete(&tag).Error
+	})
+}
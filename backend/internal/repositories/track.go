@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ContentTrackRepository interface {
+	Save(track *models.ContentTrack) error
+	Get(id uint) (*models.ContentTrack, error)
+	GetByContent(contentID uint) ([]*models.ContentTrack, error)
+	Delete(id uint) error
+	CountByFile(fileID uint) (int64, error)
+}
+
+type contentTrackRepository struct {
+	*Repository
+}
+
+func NewContentTrackRepository(r *Repository) ContentTrackRepository {
+	return &contentTrackRepository{
+		Repository: r,
+	}
+}
+
+// Save creates the track or replaces the one with the same content, kind and locale,
+// releasing the file of the replaced track
+func (r *contentTrackRepository) Save(track *models.ContentTrack) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing models.ContentTrack
+		err := tx.Where("content_id = ? AND kind = ? AND locale = ?", track.ContentID, track.Kind, track.Locale).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Omit(clause.Associations).Create(track).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		track.ID = existing.ID
+		track.CreatedAt = existing.CreatedAt
+		if err := tx.Omit(clause.Associations).Save(track).Error; err != nil {
+			return err
+		}
+		if existing.FileID == track.FileID {
+			return nil
+		}
+		return releaseFiles(tx, []uint{existing.FileID}, time.Now())
+	})
+}
+
+func (r *contentTrackRepository) Get(id uint) (*models.ContentTrack, error) {
+	var track models.ContentTrack
+	if err := r.db.Preload("File").First(&track, id).Error; err != nil {
+		return nil, err
+	}
+	return &track, nil
+}
+
+func (r *contentTrackRepository) GetByContent(contentID uint) ([]*models.ContentTrack, error) {
+	var tracks []*models.ContentTrack
+	if err := r.db.Preload("File").
+		Where("content_id = ?", contentID).
+		Order("kind ASC, locale ASC").
+		Find(&tracks).Error; err != nil {
+		return nil, err
+	}
+	return tracks, nil
+}
+
+// Delete removes the track and releases its file
+func (r *contentTrackRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var track models.ContentTrack
+		if err := tx.First(&track, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&track).Error; err != nil {
+			return err
+		}
+		return releaseFiles(tx, []uint{track.FileID}, time.Now())
+	})
+}
+
+func (r *contentTrackRepository) CountByFile(fileID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ContentTrack{}).Where("file_id = ?", fileID).Count(&count).Error
+	return count, err
+}
+
+// releaseContentTracks deletes the tracks of the given contents and releases their files
+func releaseContentTracks(tx *gorm.DB, contentIDs []uint, at time.Time) error {
+	var fileIDs []uint
+	if err := tx.Model(&models.ContentTrack{}).
+		Where("content_id IN ?", contentIDs).
+		Pluck("file_id", &fileIDs).Error; err != nil {
+		return err
+	}
+	if len(fileIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("content_id IN ?", contentIDs).Delete(&models.ContentTrack{}).Error; err != nil {
+		return err
+	}
+
+	return releaseFiles(tx, fileIDs, at)
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// translatableTrashTypes maps the trash entities that can be translated to their translation entity type
+var translatableTrashTypes = map[enums.TrashEntityType]enums.TranslationEntityType{
+	enums.TrashEntityCourse:     enums.TranslationEntityCourse,
+	enums.TrashEntityModule:     enums.TranslationEntityModule,
+	enums.TrashEntityContent:    enums.TranslationEntityContent,
+	enums.TrashEntityEvaluation: enums.TranslationEntityEvaluation,
+	enums.TrashEntityQuestion:   enums.TranslationEntityQuestion,
+	enums.TrashEntityAnswer:     enums.TranslationEntityAnswer,
+}
+
+type TranslationRepository interface {
+	Upsert(translations []*models.Translation) error
+	DeleteFields(entityType enums.TranslationEntityType, entityID uint, locale string, fields []string) error
+	DeleteLocale(entityType enums.TranslationEntityType, entityID uint, locale string) error
+	GetByEntity(entityType enums.TranslationEntityType, entityID uint) ([]*models.Translation, error)
+	GetForEntities(entityType enums.TranslationEntityType, entityIDs []uint, locale string) ([]*models.Translation, error)
+	GetByCourse(courseID uint) ([]*models.Translation, error)
+	GetCourseTree(courseID uint) (*models.Course, error)
+}
+
+type translationRepository struct {
+	*Repository
+}
+
+func NewTranslationRepository(r *Repository) TranslationRepository {
+	return &translationRepository{
+		Repository: r,
+	}
+}
+
+// Upsert stores the translations, replacing the text of the fields already translated
+func (r *translationRepository) Upsert(translations []*models.Translation) error {
+	if len(translations) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "entity_id"}, {Name: "locale"}, {Name: "field"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "course_id", "updated_at"}),
+	}).Create(translations).Error
+}
+
+func (r *translationRepository) DeleteFields(entityType enums.TranslationEntityType, entityID uint, locale string, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return r.db.Where("entity_type = ? AND entity_id = ? AND locale = ? AND field IN ?", entityType, entityID, locale, fields).
+		Delete(&models.Translation{}).Error
+}
+
+func (r *translationRepository) DeleteLocale(entityType enums.TranslationEntityType, entityID uint, locale string) error {
+	return r.db.Where("entity_type = ? AND entity_id = ? AND locale = ?", entityType, entityID, locale).
+		Delete(&models.Translation{}).Error
+}
+
+func (r *translationRepository) GetByEntity(entityType enums.TranslationEntityType, entityID uint) ([]*models.Translation, error) {
+	var translations []*models.Translation
+	if err := r.db.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("locale ASC, field ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (r *translationRepository) GetForEntities(entityType enums.TranslationEntityType, entityIDs []uint, locale string) ([]*models.Translation, error) {
+	var translations []*models.Translation
+	if len(entityIDs) == 0 {
+		return translations, nil
+	}
+	if err := r.db.Where("entity_type = ? AND entity_id IN ? AND locale = ?", entityType, entityIDs, locale).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (r *translationRepository) GetByCourse(courseID uint) ([]*models.Translation, error) {
+	var translations []*models.Translation
+	if err := r.db.Where("course_id = ?", courseID).Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetCourseTree loads the course with every translatable entity below it, in outline order
+func (r *translationRepository) GetCourseTree(courseID uint) (*models.Course, error) {
+	var course models.Course
+	if err := r.db.
+		Preload("Modules", func(db *gorm.DB) *gorm.DB { return db.Order("\"order\" ASC, id ASC") }).
+		Preload("Modules.Contents", func(db *gorm.DB) *gorm.DB { return db.Order("\"order\" ASC, id ASC") }).
+		Preload("Modules.Evaluations", func(db *gorm.DB) *gorm.DB { return db.Order("\"order\" ASC, id ASC") }).
+		Preload("Modules.Evaluations.Questions", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Modules.Evaluations.Questions.Answers", func(db *gorm.DB) *gorm.DB { return db.Order("\"order\" ASC, id ASC") }).
+		Preload("QuestionBanks", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC, id ASC") }).
+		Preload("QuestionBanks.Questions", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("QuestionBanks.Questions.Answers", func(db *gorm.DB) *gorm.DB { return db.Order("\"order\" ASC, id ASC") }).
+		First(&course, courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+// deleteTranslations removes the translations of the given entities. The table is
+// polymorphic, so no foreign key removes them with the entity.
+func deleteTranslations(tx *gorm.DB, entityType enums.TranslationEntityType, entityIDs []uint) error {
+	return tx.Where("entity_type = ? AND entity_id IN ?", entityType, entityIDs).
+		Delete(&models.Translation{}).Error
+}
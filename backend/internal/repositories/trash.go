@@ -0,0 +1,367 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotInTrash    = apperrors.New("NOT_IN_TRASH", apperrors.KindConflict, "el elemento no está en la papelera", "the item is not in the trash")
+	ErrParentInTrash = apperrors.New("PARENT_IN_TRASH", apperrors.KindConflict, "el elemento padre está en la papelera, restáuralo primero", "the parent item is in the trash, restore it first")
+)
+
+// trashNode describes how an authored entity hangs from its parent and which
+// learner records point to it. Referenced rows are never purged, so every table of
+// learner records must list its reference here or the purge cascades it away.
+type trashNode struct {
+	table        string
+	parent       enums.TrashEntityType
+	parentColumn string
+	children     []enums.TrashEntityType
+	learnerRefs  []string
+}
+
+var trashTree = map[enums.TrashEntityType]trashNode{
+	enums.TrashEntityCourse: {
+		table:    "courses",
+		children: []enums.TrashEntityType{enums.TrashEntityModule},
+		learnerRefs: []string{
+			"enrollments.course_id", "user_progress.course_id", "activity_sessions.course_id", "daily_activity.course_id",
+			"reviews.course_id", "discussion_threads.course_id", "notes.course_id",
+		},
+	},
+	enums.TrashEntityModule: {
+		table:        "modules",
+		parent:       enums.TrashEntityCourse,
+		parentColumn: "course_id",
+		children:     []enums.TrashEntityType{enums.TrashEntityContent, enums.TrashEntityEvaluation},
+		learnerRefs:  []string{"user_progress.module_id", "activity_sessions.module_id", "daily_activity.module_id"},
+	},
+	enums.TrashEntityContent: {
+		table:        "contents",
+		parent:       enums.TrashEntityModule,
+		parentColumn: "module_id",
+		learnerRefs: []string{
+			"user_progress.content_id", "playback_progress.content_id", "discussion_threads.content_id", "notes.content_id",
+		},
+	},
+	enums.TrashEntityEvaluation: {
+		table:        "evaluations",
+		parent:       enums.TrashEntityModule,
+		parentColumn: "module_id",
+		children:     []enums.TrashEntityType{enums.TrashEntityQuestion},
+		learnerRefs:  []string{"evaluation_attempts.evaluation_id"},
+	},
+	enums.TrashEntityQuestion: {
+		table:        "questions",
+		parent:       enums.TrashEntityEvaluation,
+		parentColumn: "evaluation_id",
+		children:     []enums.TrashEntityType{enums.TrashEntityAnswer},
+	},
+	enums.TrashEntityAnswer: {
+		table:        "answers",
+		parent:       enums.TrashEntityQuestion,
+		parentColumn: "question_id",
+	},
+}
+
+// purgeOrder lists the entities from the top of the tree down so parents are purged with their subtree
+var purgeOrder = []enums.TrashEntityType{
+	enums.TrashEntityCourse,
+	enums.TrashEntityModule,
+	enums.TrashEntityContent,
+	enums.TrashEntityEvaluation,
+	enums.TrashEntityQuestion,
+	enums.TrashEntityAnswer,
+}
+
+type TrashRepository interface {
+	GetDeletedCourses() ([]*dto.TrashItem, error)
+	GetCourseTrash(courseID uint) ([]*dto.TrashItem, error)
+	Restore(entity enums.TrashEntityType, id uint) error
+	PurgeDeletedBefore(cutoff time.Time) (int, error)
+}
+
+type trashRepository struct {
+	*Repository
+}
+
+func NewTrashRepository(r *Repository) TrashRepository {
+	return &trashRepository{
+		Repository: r,
+	}
+}
+
+func (r *trashRepository) GetDeletedCourses() ([]*dto.TrashItem, error) {
+	var items []*dto.TrashItem
+
+	query := `
+		SELECT 'course' AS entity_type, c.id, c.title, c.id AS course_id, 0 AS parent_id, '' AS parent_type, c.deleted_at
+		FROM courses c
+		WHERE c.deleted_at IS NOT NULL
+		ORDER BY c.deleted_at DESC
+	`
+
+	if err := r.db.Raw(query).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetCourseTrash lists the deleted items of a course. Items deleted together with
+// their parent are restored with it, so only the top of each deleted subtree is listed.
+// Bank questions have no evaluation and are reached through their bank's course.
+func (r *trashRepository) GetCourseTrash(courseID uint) ([]*dto.TrashItem, error) {
+	var items []*dto.TrashItem
+
+	query := `
+		SELECT 'module' AS entity_type, m.id, m.title, m.course_id, m.course_id AS parent_id, 'course' AS parent_type, m.deleted_at
+		FROM modules m
+		WHERE m.course_id = @course_id AND m.deleted_at IS NOT NULL
+
+		UNION ALL
+
+		SELECT 'content', ct.id, ct.title, m.course_id, ct.module_id, 'module', ct.deleted_at
+		FROM contents ct
+		INNER JOIN modules m ON m.id = ct.module_id
+		WHERE m.course_id = @course_id AND ct.deleted_at IS NOT NULL
+		AND m.deleted_at IS DISTINCT FROM ct.deleted_at
+
+		UNION ALL
+
+		SELECT 'evaluation', e.id, e.title, m.course_id, e.module_id, 'module', e.deleted_at
+		FROM evaluations e
+		INNER JOIN modules m ON m.id = e.module_id
+		WHERE m.course_id = @course_id AND e.deleted_at IS NOT NULL
+		AND m.deleted_at IS DISTINCT FROM e.deleted_at
+
+		UNION ALL
+
+		SELECT 'question', q.id, LEFT(q.text, 120), coalesce(m.course_id, b.course_id), coalesce(q.evaluation_id, q.bank_id),
+			CASE WHEN q.evaluation_id IS NULL THEN 'question_bank' ELSE 'evaluation' END, q.deleted_at
+		FROM questions q
+		LEFT JOIN evaluations e ON e.id = q.evaluation_id
+		LEFT JOIN modules m ON m.id = e.module_id
+		LEFT JOIN question_banks b ON b.id = q.bank_id
+		WHERE coalesce(m.course_id, b.course_id) = @course_id AND q.deleted_at IS NOT NULL
+		AND e.deleted_at IS DISTINCT FROM q.deleted_at
+
+		UNION ALL
+
+		SELECT 'answer', a.id, LEFT(a.text, 120), coalesce(m.course_id, b.course_id), a.question_id, 'question', a.deleted_at
+		FROM answers a
+		INNER JOIN questions q ON q.id = a.question_id
+		LEFT JOIN evaluations e ON e.id = q.evaluation_id
+		LEFT JOIN modules m ON m.id = e.module_id
+		LEFT JOIN question_banks b ON b.id = q.bank_id
+		WHERE coalesce(m.course_id, b.course_id) = @course_id AND a.deleted_at IS NOT NULL
+		AND q.deleted_at IS DISTINCT FROM a.deleted_at
+
+		ORDER BY deleted_at DESC
+	`
+
+	if err := r.db.Raw(query, map[string]interface{}{"course_id": courseID}).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Restore brings back a deleted item together with everything deleted along with it
+func (r *trashRepository) Restore(entity enums.TrashEntityType, id uint) error {
+	node, ok := trashTree[entity]
+	if !ok {
+		return fmt.Errorf("tipo de elemento inválido: %s", entity)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var item struct {
+			DeletedAt *time.Time
+		}
+		result := tx.Table(node.table).Select("deleted_at").Where("id = ?", id).Scan(&item)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if item.DeletedAt == nil {
+			return ErrNotInTrash
+		}
+
+		if node.parent != "" {
+			parent := trashTree[node.parent]
+			var parentDeleted bool
+			query := fmt.Sprintf(
+				"SELECT EXISTS (SELECT 1 FROM %s p INNER JOIN %s t ON t.%s = p.id WHERE t.id = ? AND p.deleted_at IS NOT NULL)",
+				parent.table, node.table, node.parentColumn,
+			)
+			if err := tx.Raw(query, id).Scan(&parentDeleted).Error; err != nil {
+				return err
+			}
+			if parentDeleted {
+				return ErrParentInTrash
+			}
+		}
+
+		return restoreTree(tx, entity, []uint{id}, *item.DeletedAt)
+	})
+}
+
+// PurgeDeletedBefore permanently removes the items deleted before the cutoff and returns how many
+// rows were removed. Items still referenced by learner records stay in the trash.
+func (r *trashRepository) PurgeDeletedBefore(cutoff time.Time) (int, error) {
+	purged := 0
+
+	for _, entity := range purgeOrder {
+		var ids []uint
+		if err := r.db.Table(trashTree[entity].table).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Pluck("id", &ids).Error; err != nil {
+			return purged, err
+		}
+
+		for _, id := range ids {
+			err := r.db.Transaction(func(tx *gorm.DB) error {
+				count, _, err := purgeTree(tx, entity, id)
+				purged += count
+				return err
+			})
+			if err != nil {
+				return purged, err
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+// softDeleteTree marks the items and their live descendants as deleted using the same timestamp,
+// which is what later identifies the subtree to restore
+func softDeleteTree(tx *gorm.DB, entity enums.TrashEntityType, ids []uint, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	node := trashTree[entity]
+	for _, child := range node.children {
+		childNode := trashTree[child]
+
+		var childIDs []uint
+		if err := tx.Table(childNode.table).
+			Where(childNode.parentColumn+" IN ? AND deleted_at IS NULL", ids).
+			Pluck("id", &childIDs).Error; err != nil {
+			return err
+		}
+
+		if err := softDeleteTree(tx, child, childIDs, at); err != nil {
+			return err
+		}
+	}
+
+	return tx.Table(node.table).
+		Where("id IN ? AND deleted_at IS NULL", ids).
+		Update("deleted_at", at).Error
+}
+
+func restoreTree(tx *gorm.DB, entity enums.TrashEntityType, ids []uint, deletedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	node := trashTree[entity]
+	if err := tx.Table(node.table).
+		Where("id IN ? AND deleted_at = ?", ids, deletedAt).
+		Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+
+	for _, child := range node.children {
+		childNode := trashTree[child]
+
+		var childIDs []uint
+		if err := tx.Table(childNode.table).
+			Where(childNode.parentColumn+" IN ? AND deleted_at = ?", ids, deletedAt).
+			Pluck("id", &childIDs).Error; err != nil {
+			return err
+		}
+
+		if err := restoreTree(tx, child, childIDs, deletedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// purgeTree hard-deletes an item and its subtree. It returns the number of removed rows and
+// whether the item was kept because it, or one of its descendants, is referenced by learner records.
+func purgeTree(tx *gorm.DB, entity enums.TrashEntityType, id uint) (int, bool, error) {
+	node := trashTree[entity]
+	purged := 0
+	kept := false
+
+	for _, child := range node.children {
+		childNode := trashTree[child]
+
+		var childIDs []uint
+		if err := tx.Table(childNode.table).Where(childNode.parentColumn+" = ?", id).Pluck("id", &childIDs).Error; err != nil {
+			return purged, false, err
+		}
+
+		for _, childID := range childIDs {
+			count, childKept, err := purgeTree(tx, child, childID)
+			if err != nil {
+				return purged, false, err
+			}
+			purged += count
+			kept = kept || childKept
+		}
+	}
+
+	if !kept {
+		for _, ref := range node.learnerRefs {
+			var referenced bool
+			table, _, _ := strings.Cut(ref, ".")
+			query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s = ?)", table, ref)
+			if err := tx.Raw(query, id).Scan(&referenced).Error; err != nil {
+				return purged, false, err
+			}
+			if referenced {
+				kept = true
+				break
+			}
+		}
+	}
+
+	if kept {
+		return purged, true, nil
+	}
+
+	if attachableType, ok := attachableTrashTypes[entity]; ok {
+		if err := releaseAttachments(tx, attachableType, []uint{id}, time.Now()); err != nil {
+			return purged, false, err
+		}
+	}
+	if entity == enums.TrashEntityContent {
+		if err := releaseContentTracks(tx, []uint{id}, time.Now()); err != nil {
+			return purged, false, err
+		}
+	}
+	if entityType, ok := translatableTrashTypes[entity]; ok {
+		if err := deleteTranslations(tx, entityType, []uint{id}); err != nil {
+			return purged, false, err
+		}
+	}
+
+	if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", node.table), id).Error; err != nil {
+		return purged, false, err
+	}
+
+	return purged + 1, false, nil
+}
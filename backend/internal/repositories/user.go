@@ -13,6 +13,7 @@ type UserRepository interface {
 	GetByID(id uint) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	Update(user *models.User) error
+	SetLocale(userID uint, locale string) error
 	Delete(id uint) error
 }
 
@@ -63,6 +64,16 @@ func (r *userRepository) Update(user *models.User) error {
 	return nil
 }
 
+func (r *userRepository) SetLocale(userID uint, locale string) error {
+	if err := r.db.Model(&models.User{}).Where("id = ?", userID).Update("locale", locale).Error; err != nil {
+		return err
+	}
+
+	r.invalidateCache(userID)
+
+	return nil
+}
+
 func (r *userRepository) Delete(id uint) error {
 	if err := r.db.Delete(&models.User{}, id).Error; err != nil {
 		return err
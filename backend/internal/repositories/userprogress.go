@@ -94,7 +94,8 @@ func (r *userprogressRepository) GetByUserAndContent(userID, contentID uint) (*m
 func (r *userprogressRepository) CountCompletedByUserAndCourse(userID, courseID uint) (int64, error) {
 	var count int64
 	if err := r.db.Model(&models.UserProgress{}).
-		Where("user_id = ? AND course_id = ? AND completed_at IS NOT NULL", userID, courseID).
+		Joins("INNER JOIN contents ON contents.id = user_progress.content_id AND contents.deleted_at IS NULL").
+		Where("user_progress.user_id = ? AND user_progress.course_id = ? AND user_progress.completed_at IS NOT NULL", userID, courseID).
 		Count(&count).Error; err != nil {
 		return 0, err
 	}
@@ -104,7 +105,8 @@ func (r *userprogressRepository) CountCompletedByUserAndCourse(userID, courseID
 func (r *userprogressRepository) CountCompletedByUserAndModule(userID, moduleID uint) (int64, error) {
 	var count int64
 	if err := r.db.Model(&models.UserProgress{}).
-		Where("user_id = ? AND module_id = ? AND completed_at IS NOT NULL", userID, moduleID).
+		Joins("INNER JOIN contents ON contents.id = user_progress.content_id AND contents.deleted_at IS NULL").
+		Where("user_progress.user_id = ? AND user_progress.module_id = ? AND user_progress.completed_at IS NOT NULL", userID, moduleID).
 		Count(&count).Error; err != nil {
 		return 0, err
 	}
@@ -149,14 +151,16 @@ func (r *userprogressRepository) GetCourseProgressSummary(userID, courseID uint)
 		LEFT JOIN (
 			SELECT module_id, COUNT(*) as content_count
 			FROM contents
+			WHERE deleted_at IS NULL
 			GROUP BY module_id
 		) c ON c.module_id = m.id
 		LEFT JOIN (
 			SELECT module_id, COUNT(*) as evaluation_count
 			FROM evaluations
+			WHERE deleted_at IS NULL
 			GROUP BY module_id
 		) e ON e.module_id = m.id
-		WHERE m.course_id = ?
+		WHERE m.course_id = ? AND m.deleted_at IS NULL
 	),
 	user_content_progress AS (
 		-- Get completed content for this user and course
@@ -164,6 +168,7 @@ func (r *userprogressRepository) GetCourseProgressSummary(userID, courseID uint)
 			up.module_id,
 			COUNT(*) as completed_contents
 		FROM user_progress up
+		INNER JOIN contents ct ON ct.id = up.content_id AND ct.deleted_at IS NULL
 		WHERE up.user_id = ? 
 		AND up.course_id = ?
 		AND up.completed_at IS NOT NULL
@@ -175,7 +180,7 @@ func (r *userprogressRepository) GetCourseProgressSummary(userID, courseID uint)
 			e.module_id,
 			COUNT(*) as passed_evaluations
 		FROM evaluation_attempts ea
-		INNER JOIN evaluations e ON ea.evaluation_id = e.id
+		INNER JOIN evaluations e ON ea.evaluation_id = e.id AND e.deleted_at IS NULL
 		WHERE ea.user_id = ?
 		AND e.module_id IN (SELECT id FROM modules WHERE course_id = ?)
 		AND ea.passed = true
@@ -195,7 +200,7 @@ func (r *userprogressRepository) GetCourseProgressSummary(userID, courseID uint)
 	INNER JOIN module_stats ms ON ms.course_id = c.id
 	LEFT JOIN user_content_progress ucp ON ucp.module_id = ms.module_id
 	LEFT JOIN user_evaluation_progress uep ON uep.module_id = ms.module_id
-	WHERE c.id = ?
+	WHERE c.id = ? AND c.deleted_at IS NULL
 	ORDER BY ms.module_id
 	`
 
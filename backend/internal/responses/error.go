@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/i18n"
 )
 
 type ErrorResponse struct {
@@ -44,6 +46,52 @@ func ErrorConflict(c *gin.Context, message string) {
 	NewErrorResponse(c, http.StatusConflict, message, errConflict, nil)
 }
 
+func ErrorForbidden(c *gin.Context, message string) {
+	NewErrorResponse(c, http.StatusForbidden, message, errForbidden, nil)
+}
+
+// Error writes the response of a service error. Domain errors carry their code, their
+// status, their message in the caller's language and their details; any other error is
+// reported as an internal error without leaking its text.
+func Error(c *gin.Context, err error) {
+	appErr, ok := apperrors.From(err)
+	if !ok {
+		appErr = apperrors.ErrInternal
+	}
+	NewErrorResponse(c, Status(appErr.Kind), appErr.Message(errorLocale(c)), appErr.Code, appErr.Details())
+}
+
+// Status returns the HTTP status of a kind of domain error
+func Status(kind apperrors.Kind) int {
+	switch kind {
+	case apperrors.KindInvalid:
+		return http.StatusBadRequest
+	case apperrors.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperrors.KindForbidden:
+		return http.StatusForbidden
+	case apperrors.KindNotFound:
+		return http.StatusNotFound
+	case apperrors.KindConflict:
+		return http.StatusConflict
+	case apperrors.KindTooManyRequests:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorLocale picks the language of error messages from ?locale= or Accept-Language
+func errorLocale(c *gin.Context) string {
+	if locale, ok := i18n.NormalizeLocale(c.Query("locale")); ok {
+		return locale
+	}
+	if locale, ok := i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language")); ok {
+		return locale
+	}
+	return string(i18n.DefaultLocale)
+}
+
 func NewErrorResponse(c *gin.Context, httpStatusCode int, message string, code string, payload map[string]interface{}) {
 	c.JSON(httpStatusCode, ErrorResponse{
 		Code:    code,
@@ -8,4 +8,5 @@ const (
 	errTooManyRequests = "TOO_MANY_REQUESTS"
 	errUnauthorized    = "UNAUTHORIZED"
 	errConflict        = "CONFLICT"
+	errForbidden       = "FORBIDDEN"
 )
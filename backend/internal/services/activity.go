@@ -0,0 +1,217 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidActivityItem = apperrors.New("INVALID_ACTIVITY_ITEM", apperrors.KindInvalid, "tipo de actividad inválido", "invalid activity type")
+
+type ActivityService interface {
+	RecordHeartbeat(userID uint, request *dto.ActivityHeartbeatRequest) (*models.ActivitySession, error)
+	GetWeeklyActivity(userID, courseID uint) (*dto.WeeklyActivity, error)
+	GetCourseTimeOnTask(courseID, userID uint) ([]*dto.ItemTimeOnTask, error)
+	CloseIdleSessions() error
+}
+
+type activityService struct {
+	*Service
+	enrollmentService EnrollmentService
+}
+
+func NewActivityService(service *Service, enrollmentService EnrollmentService) ActivityService {
+	return &activityService{
+		Service:           service,
+		enrollmentService: enrollmentService,
+	}
+}
+
+// RecordHeartbeat credits the time elapsed since the previous heartbeat to the open session.
+// Gaps longer than the idle timeout, or heartbeats flagged as idle, are not counted.
+func (s *activityService) RecordHeartbeat(userID uint, request *dto.ActivityHeartbeatRequest) (*models.ActivitySession, error) {
+	module, err := s.resolveModule(request.ItemType, request.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.enrollmentService.GetUserCourseEnrollment(userID, module.CourseID); err != nil {
+		return nil, ErrNotEnrolled
+	}
+
+	now := time.Now()
+	idleTimeout := s.config.Activity.IdleTimeout
+
+	session, err := s.store.Activity.GetOpenSession(userID, request.ItemType, request.ItemID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error al obtener la sesión de actividad: %w", err)
+	}
+
+	// A session that went quiet for too long ends at its last heartbeat
+	if session != nil && (request.Idle || now.Sub(session.LastActivityAt) > idleTimeout) {
+		if err := s.closeSession(session); err != nil {
+			return nil, err
+		}
+		if request.Idle {
+			return session, nil
+		}
+		session = nil
+	}
+
+	if request.Idle {
+		return nil, nil
+	}
+
+	credited := 0
+	if session == nil {
+		session = &models.ActivitySession{
+			UserID:    userID,
+			CourseID:  module.CourseID,
+			ModuleID:  module.ID,
+			ItemType:  request.ItemType,
+			ItemID:    request.ItemID,
+			StartedAt: now,
+		}
+	} else {
+		credited = int(now.Sub(session.LastActivityAt).Seconds())
+	}
+
+	session.LastActivityAt = now
+	session.ActiveSeconds += credited
+
+	if err := s.store.Activity.SaveSession(session); err != nil {
+		return nil, fmt.Errorf("error al guardar la sesión de actividad: %w", err)
+	}
+
+	if credited > 0 {
+		if err := s.store.Activity.AddDailyActivity(userID, module.CourseID, module.ID, startOfDay(now), credited); err != nil {
+			s.logger.Warnf("Failed to update daily activity for user %d: %v", userID, err)
+		}
+	}
+
+	return session, nil
+}
+
+// GetWeeklyActivity returns the time spent since Monday, per day and per course
+func (s *activityService) GetWeeklyActivity(userID, courseID uint) (*dto.WeeklyActivity, error) {
+	weekStart := startOfWeek(time.Now())
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	rows, err := s.store.Activity.GetDailyByUser(userID, weekStart, weekEnd, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la actividad semanal: %w", err)
+	}
+
+	weekly := &dto.WeeklyActivity{
+		WeekStart: weekStart,
+		Days:      make([]dto.DailyActivitySummary, 7),
+		Courses:   []dto.CourseActivitySummary{},
+	}
+
+	for i := range weekly.Days {
+		weekly.Days[i].Day = weekStart.AddDate(0, 0, i).Format(time.DateOnly)
+	}
+
+	courseIndex := make(map[uint]int)
+	for _, row := range rows {
+		day := int(startOfDay(row.Day).Sub(weekStart).Hours() / 24)
+		if day >= 0 && day < len(weekly.Days) {
+			weekly.Days[day].Seconds += row.Seconds
+		}
+		weekly.TotalSeconds += row.Seconds
+
+		index, ok := courseIndex[row.CourseID]
+		if !ok {
+			index = len(weekly.Courses)
+			courseIndex[row.CourseID] = index
+			weekly.Courses = append(weekly.Courses, dto.CourseActivitySummary{
+				CourseID:    row.CourseID,
+				CourseTitle: row.CourseTitle,
+			})
+		}
+		weekly.Courses[index].Seconds += row.Seconds
+	}
+
+	return weekly, nil
+}
+
+// GetCourseTimeOnTask returns the time spent on each item of the course; only the staff of the course can read it
+func (s *activityService) GetCourseTimeOnTask(courseID, userID uint) ([]*dto.ItemTimeOnTask, error) {
+	if err := s.requireCourseStaff(userID, courseID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.store.Activity.GetItemTimeOnTask(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener el tiempo por elemento: %w", err)
+	}
+	return items, nil
+}
+
+// CloseIdleSessions ends the sessions whose learner stopped sending heartbeats
+func (s *activityService) CloseIdleSessions() error {
+	closed, err := s.store.Activity.CloseIdleSessions(time.Now().Add(-s.config.Activity.IdleTimeout))
+	if err != nil {
+		return fmt.Errorf("error al cerrar las sesiones inactivas: %w", err)
+	}
+
+	if closed > 0 {
+		s.logger.Infof("Closed %d idle activity sessions", closed)
+	}
+
+	return nil
+}
+
+func (s *activityService) closeSession(session *models.ActivitySession) error {
+	endedAt := session.LastActivityAt
+	session.EndedAt = &endedAt
+	if err := s.store.Activity.SaveSession(session); err != nil {
+		return fmt.Errorf("error al cerrar la sesión de actividad: %w", err)
+	}
+	return nil
+}
+
+func (s *activityService) resolveModule(itemType enums.ActivityItemType, itemID uint) (*models.Module, error) {
+	var moduleID uint
+	switch itemType {
+	case enums.ActivityItemContent:
+		content, err := s.store.Contents.Get(itemID)
+		if err != nil {
+			return nil, notFound(ErrContentNotFound, err)
+		}
+		moduleID = content.ModuleID
+	case enums.ActivityItemEvaluation:
+		evaluation, err := s.store.Evaluations.Get(itemID)
+		if err != nil {
+			return nil, notFound(ErrEvaluationNotFound, err)
+		}
+		moduleID = evaluation.ModuleID
+	default:
+		return nil, ErrInvalidActivityItem
+	}
+
+	module, err := s.store.Modules.Get(moduleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+	return module, nil
+}
+
+// startOfDay truncates to midnight UTC, the boundary used by the daily rollup
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// startOfWeek returns Monday at midnight UTC of the week containing t
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// announcementStaleAfter is how long a fan-out can go without reporting progress before
+// another worker resumes it
+const announcementStaleAfter = 5 * time.Minute
+
+var ErrAnnouncementPublished = apperrors.New("ANNOUNCEMENT_PUBLISHED", apperrors.KindConflict, "el anuncio ya fue publicado y no se puede modificar", "the announcement was already published and cannot be modified")
+
+type AnnouncementService interface {
+	CreateAnnouncement(courseID, userID uint, request *dto.CreateAnnouncementRequest) (*models.Announcement, error)
+	ListAnnouncements(courseID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Announcement], error)
+	GetAnnouncement(id, userID uint) (*models.Announcement, error)
+	UpdateAnnouncementPatch(id, userID uint, data map[string]interface{}) (*models.Announcement, error)
+	DeleteAnnouncement(id, userID uint) error
+	MarkAsRead(id, userID uint) error
+	PublishDueAnnouncements() error
+}
+
+type announcementService struct {
+	*Service
+	enrollmentService   EnrollmentService
+	notificationService NotificationService
+}
+
+func NewAnnouncementService(service *Service, enrollmentService EnrollmentService, notificationService NotificationService) AnnouncementService {
+	return &announcementService{
+		Service:             service,
+		enrollmentService:   enrollmentService,
+		notificationService: notificationService,
+	}
+}
+
+// CreateAnnouncement stores the announcement in the course feed. Announcements without a
+// future publish date are fanned out right away in the background.
+func (s *announcementService) CreateAnnouncement(courseID, userID uint, request *dto.CreateAnnouncementRequest) (*models.Announcement, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Courses.Get(courseID); err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	now := time.Now()
+	publishAt := now
+	if request.PublishAt != nil && request.PublishAt.After(now) {
+		publishAt = *request.PublishAt
+	}
+
+	announcement := &models.Announcement{
+		CourseID:  courseID,
+		AuthorID:  userID,
+		Title:     strings.TrimSpace(request.Title),
+		Body:      strings.TrimSpace(request.Body),
+		Status:    enums.AnnouncementStatusScheduled,
+		PublishAt: publishAt,
+	}
+
+	if err := s.store.Announcements.Create(announcement); err != nil {
+		return nil, fmt.Errorf("error al crear el anuncio: %w", err)
+	}
+
+	if !publishAt.After(now) {
+		go func() {
+			if err := s.PublishDueAnnouncements(); err != nil {
+				s.logger.Errorf("Failed to publish announcement %d: %v", announcement.ID, err)
+			}
+		}()
+	}
+
+	return s.store.Announcements.Get(announcement.ID)
+}
+
+// ListAnnouncements returns the course feed. Staff also see the scheduled announcements;
+// learners get the read flag of each announcement.
+func (s *announcementService) ListAnnouncements(courseID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Announcement], error) {
+	isStaff, err := s.authorize(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.store.Announcements.ListByCourse(courseID, isStaff, request)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(page.Items))
+	for i, announcement := range page.Items {
+		ids[i] = announcement.ID
+	}
+
+	read, err := s.store.Announcements.GetReadIDs(userID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las lecturas: %w", err)
+	}
+	for _, announcement := range page.Items {
+		announcement.Read = read[announcement.ID]
+	}
+
+	return page, nil
+}
+
+func (s *announcementService) GetAnnouncement(id, userID uint) (*models.Announcement, error) {
+	announcement, _, err := s.getAccessibleAnnouncement(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	read, err := s.store.Announcements.GetReadIDs(userID, []uint{id})
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las lecturas: %w", err)
+	}
+	announcement.Read = read[id]
+
+	return announcement, nil
+}
+
+// UpdateAnnouncementPatch edits an announcement that has not been published yet
+func (s *announcementService) UpdateAnnouncementPatch(id, userID uint, data map[string]interface{}) (*models.Announcement, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	var request dto.UpdateAnnouncementRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	announcement, err := s.store.Announcements.Get(id)
+	if err != nil {
+		return nil, notFound(ErrAnnouncementNotFound, err)
+	}
+
+	if announcement.Status != enums.AnnouncementStatusScheduled {
+		return nil, ErrAnnouncementPublished
+	}
+
+	changes := map[string]interface{}{}
+	if request.Title != nil {
+		changes["title"] = strings.TrimSpace(*request.Title)
+	}
+	if request.Body != nil {
+		changes["body"] = strings.TrimSpace(*request.Body)
+	}
+	if request.PublishAt != nil {
+		changes["publish_at"] = *request.PublishAt
+	}
+
+	if len(changes) > 0 {
+		if err := s.store.Announcements.Patch(id, changes); err != nil {
+			return nil, fmt.Errorf("error al actualizar el anuncio: %w", err)
+		}
+	}
+
+	return s.store.Announcements.Get(id)
+}
+
+func (s *announcementService) DeleteAnnouncement(id, userID uint) error {
+	if err := s.requireStaff(userID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.Announcements.Get(id); err != nil {
+		return notFound(ErrAnnouncementNotFound, err)
+	}
+
+	if err := s.store.Announcements.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar el anuncio: %w", err)
+	}
+	return nil
+}
+
+func (s *announcementService) MarkAsRead(id, userID uint) error {
+	if _, _, err := s.getAccessibleAnnouncement(id, userID); err != nil {
+		return err
+	}
+
+	if err := s.store.Announcements.MarkRead(id, userID); err != nil {
+		return fmt.Errorf("error al marcar el anuncio como leído: %w", err)
+	}
+	return nil
+}
+
+// PublishDueAnnouncements claims the announcements whose publish date has arrived and
+// notifies the course enrollees in batches
+func (s *announcementService) PublishDueAnnouncements() error {
+	now := time.Now()
+	announcements, err := s.store.Announcements.ClaimDue(now, now.Add(-announcementStaleAfter))
+	if err != nil {
+		return fmt.Errorf("error al obtener los anuncios pendientes: %w", err)
+	}
+
+	for _, announcement := range announcements {
+		if err := s.fanOut(announcement); err != nil {
+			// The announcement stays in publishing and is resumed once it becomes stale
+			s.logger.Errorf("Failed to fan out announcement %d: %v", announcement.ID, err)
+		}
+	}
+	return nil
+}
+
+// fanOut notifies every enrollee after the last recipient of the previous run, saving
+// the progress after each batch so an interrupted fan-out resumes without duplicates
+func (s *announcementService) fanOut(announcement *models.Announcement) error {
+	course, err := s.store.Courses.Get(announcement.CourseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.store.Announcements.MarkPublished(announcement.ID, time.Now())
+		}
+		return err
+	}
+
+	title := fmt.Sprintf("Nuevo anuncio en %s", course.Title)
+	batchSize := s.config.Announcements.BatchSize
+	if batchSize <= 0 {
+		batchSize = 200
+	}
+
+	lastRecipientID := announcement.LastRecipientID
+	for {
+		userIDs, err := s.store.Enrollments.GetUserIDsByCourseAfter(announcement.CourseID, lastRecipientID, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+
+		if err := s.notificationService.DispatchNotificationBatch(userIDs, title, announcement.Title, string(enums.NotificationTypeAnnouncement)); err != nil {
+			return err
+		}
+
+		lastRecipientID = userIDs[len(userIDs)-1]
+		if err := s.store.Announcements.SaveProgress(announcement.ID, lastRecipientID, len(userIDs)); err != nil {
+			return err
+		}
+
+		if len(userIDs) < batchSize {
+			break
+		}
+	}
+
+	return s.store.Announcements.MarkPublished(announcement.ID, time.Now())
+}
+
+// authorize checks that the user can read the course feed: staff or enrolled learners
+func (s *announcementService) authorize(userID, courseID uint) (bool, error) {
+	isStaff, err := s.userHasRole(userID, enums.UserRoleInstructor, enums.UserRoleAdmin)
+	if err != nil {
+		return false, err
+	}
+	if isStaff {
+		return true, nil
+	}
+
+	if _, err := s.enrollmentService.GetUserCourseEnrollment(userID, courseID); err != nil {
+		return false, ErrNotEnrolled
+	}
+	return false, nil
+}
+
+// getAccessibleAnnouncement loads the announcement for a feed reader; scheduled
+// announcements are only visible to the staff
+func (s *announcementService) getAccessibleAnnouncement(id, userID uint) (*models.Announcement, bool, error) {
+	announcement, err := s.store.Announcements.Get(id)
+	if err != nil {
+		return nil, false, notFound(ErrAnnouncementNotFound, err)
+	}
+
+	isStaff, err := s.authorize(userID, announcement.CourseID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if announcement.Status == enums.AnnouncementStatusScheduled && !isStaff {
+		return nil, false, notFound(ErrAnnouncementNotFound, gorm.ErrRecordNotFound)
+	}
+
+	return announcement, isStaff, nil
+}
@@ -1,81 +1,125 @@
 package services
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"github.com/imlargo/go-api-template/pkg/utils"
 )
 
 type AnswerService interface {
-	CreateAnswer(answer *models.Answer) (*models.Answer, error)
+	CreateAnswer(answer *models.Answer, authorID uint) (*models.Answer, error)
 	GetAnswer(id uint) (*models.Answer, error)
-	UpdateAnswer(id uint, answer *models.Answer) (*models.Answer, error)
-	UpdateAnswerPatch(id uint, data map[string]interface{}) (*models.Answer, error)
+	UpdateAnswer(id uint, answer *models.Answer, authorID uint) (*models.Answer, error)
+	UpdateAnswerPatch(id uint, data map[string]interface{}, authorID uint) (*models.Answer, error)
 	DeleteAnswer(id uint) error
 	GetAnswersByQuestion(questionID uint) ([]*models.Answer, error)
+	ListAnswersByQuestion(questionID uint, request *dto.ListRequest) (*dto.Page[*models.Answer], error)
 	ValidateAnswers(questionID uint, selectedAnswerIDs []uint) (bool, int, error)
 }
 
 type answerService struct {
 	*Service
+	revisionService RevisionService
 }
 
-func NewAnswerService(service *Service) AnswerService {
+func NewAnswerService(service *Service, revisionService RevisionService) AnswerService {
 	return &answerService{
-		Service: service,
+		Service:         service,
+		revisionService: revisionService,
 	}
 }
 
-func (s *answerService) CreateAnswer(answer *models.Answer) (*models.Answer, error) {
+func (s *answerService) CreateAnswer(answer *models.Answer, authorID uint) (*models.Answer, error) {
 	// Verify question exists
-	_, err := s.store.Questions.Get(answer.QuestionID)
+	question, err := s.store.Questions.Get(answer.QuestionID)
 	if err != nil {
-		return nil, fmt.Errorf("pregunta no encontrada: %w", err)
+		return nil, notFound(ErrQuestionNotFound, err)
+	}
+
+	if err := validateAnswerKey(question.Type, answer); err != nil {
+		return nil, err
 	}
 
 	if err := s.store.Answers.Create(answer); err != nil {
 		return nil, fmt.Errorf("error al crear la respuesta: %w", err)
 	}
+
+	s.recordRevision(answer.ID, authorID, nil, answerSnapshot(answer))
 	return answer, nil
 }
 
 func (s *answerService) GetAnswer(id uint) (*models.Answer, error) {
 	answer, err := s.store.Answers.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("respuesta no encontrada: %w", err)
+		return nil, notFound(ErrAnswerNotFound, err)
 	}
 	return answer, nil
 }
 
-func (s *answerService) UpdateAnswer(id uint, answerData *models.Answer) (*models.Answer, error) {
+func (s *answerService) UpdateAnswer(id uint, answerData *models.Answer, authorID uint) (*models.Answer, error) {
 	existingAnswer, err := s.store.Answers.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("respuesta no encontrada: %w", err)
+		return nil, notFound(ErrAnswerNotFound, err)
 	}
 
+	question, err := s.store.Questions.Get(existingAnswer.QuestionID)
+	if err != nil {
+		return nil, notFound(ErrQuestionNotFound, err)
+	}
+
+	before := answerSnapshot(existingAnswer)
+
 	// Update fields
 	existingAnswer.Text = answerData.Text
 	existingAnswer.IsCorrect = answerData.IsCorrect
 	existingAnswer.Order = answerData.Order
+	existingAnswer.MatchText = answerData.MatchText
+	existingAnswer.Tolerance = answerData.Tolerance
+	existingAnswer.Blank = answerData.Blank
+
+	if err := validateAnswerKey(question.Type, existingAnswer); err != nil {
+		return nil, err
+	}
 
 	if err := s.store.Answers.Update(existingAnswer); err != nil {
 		return nil, fmt.Errorf("error al actualizar la respuesta: %w", err)
 	}
 
+	s.recordRevision(id, authorID, before, answerSnapshot(existingAnswer))
+
 	return existingAnswer, nil
 }
 
-func (s *answerService) UpdateAnswerPatch(answerID uint, data map[string]interface{}) (*models.Answer, error) {
+func (s *answerService) UpdateAnswerPatch(answerID uint, data map[string]interface{}, authorID uint) (*models.Answer, error) {
 	if answerID == 0 {
-		return nil, errors.New("answer ID cannot be zero")
+		return nil, ErrInvalidID
 	}
 
 	var answer dto.UpdateAnswerRequest
 	if err := utils.MapToStructStrict(data, &answer); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
+	}
+
+	existing, err := s.store.Answers.Get(answerID)
+	if err != nil {
+		return nil, notFound(ErrAnswerNotFound, err)
+	}
+	before := answerSnapshot(existing)
+
+	// Check the key as it will be after the patch
+	question, err := s.store.Questions.Get(existing.QuestionID)
+	if err != nil {
+		return nil, notFound(ErrQuestionNotFound, err)
+	}
+	patched := *existing
+	if err := utils.MapToStruct(data, &patched); err != nil {
+		return nil, invalidData(err)
+	}
+	if err := validateAnswerKey(question.Type, &patched); err != nil {
+		return nil, err
 	}
 
 	if err := s.store.Answers.Patch(answerID, data); err != nil {
@@ -84,9 +128,11 @@ func (s *answerService) UpdateAnswerPatch(answerID uint, data map[string]interfa
 
 	updated, err := s.store.Answers.Get(answerID)
 	if err != nil {
-		return nil, errors.New("respuesta no encontrada")
+		return nil, notFound(ErrAnswerNotFound, err)
 	}
 
+	s.recordRevision(answerID, authorID, before, answerSnapshot(updated))
+
 	return updated, nil
 }
 
@@ -107,11 +153,19 @@ func (s *answerService) GetAnswersByQuestion(questionID uint) ([]*models.Answer,
 	return answers, nil
 }
 
+func (s *answerService) ListAnswersByQuestion(questionID uint, request *dto.ListRequest) (*dto.Page[*models.Answer], error) {
+	page, err := s.store.Answers.ListByQuestionID(questionID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las respuestas: %w", err)
+	}
+	return page, nil
+}
+
 func (s *answerService) ValidateAnswers(questionID uint, selectedAnswerIDs []uint) (bool, int, error) {
 	// Get question to determine points
 	question, err := s.store.Questions.Get(questionID)
 	if err != nil {
-		return false, 0, fmt.Errorf("pregunta no encontrada: %w", err)
+		return false, 0, notFound(ErrQuestionNotFound, err)
 	}
 
 	// Get all answers for the question
@@ -165,3 +219,10 @@ func (s *answerService) ValidateAnswers(questionID uint, selectedAnswerIDs []uin
 
 	return isCorrect, points, nil
 }
+
+// recordRevision saves the change in the revision history; a failure never undoes the edit
+func (s *answerService) recordRevision(id, authorID uint, before, after models.RevisionSnapshot) {
+	if err := s.revisionService.Record(enums.RevisionEntityAnswer, id, authorID, before, after); err != nil {
+		s.logger.Warnf("failed to record revision for answer %d: %v", id, err)
+	}
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+var (
+	ErrInvalidAttachable     = apperrors.New("INVALID_ATTACHABLE", apperrors.KindInvalid, "tipo de elemento inválido", "invalid item type")
+	ErrInvalidAttachmentRole = apperrors.New("INVALID_ATTACHMENT_ROLE", apperrors.KindInvalid, "el rol no es válido para el tipo de elemento", "the role is not valid for the item type")
+)
+
+// attachmentRoles lists the roles each kind of element accepts
+var attachmentRoles = map[enums.AttachableType][]enums.AttachmentRole{
+	enums.AttachableCourse:   {enums.AttachmentRoleCover, enums.AttachmentRoleResource},
+	enums.AttachableContent:  {enums.AttachmentRoleMedia, enums.AttachmentRoleResource},
+	enums.AttachableQuestion: {enums.AttachmentRoleIllustration, enums.AttachmentRoleResource},
+	enums.AttachableAnswer:   {enums.AttachmentRoleIllustration},
+}
+
+type AttachmentService interface {
+	Attach(attachableType enums.AttachableType, attachableID uint, request *dto.CreateAttachmentRequest, userID uint) (*models.Attachment, error)
+	GetAttachments(attachableType enums.AttachableType, attachableID uint) ([]*models.Attachment, error)
+	UpdateAttachmentPatch(id uint, data map[string]interface{}, userID uint) (*models.Attachment, error)
+	Detach(id, userID uint) error
+}
+
+type attachmentService struct {
+	*Service
+	revisionService RevisionService
+	fileService     FileService
+}
+
+func NewAttachmentService(service *Service, revisionService RevisionService, fileService FileService) AttachmentService {
+	return &attachmentService{
+		Service:         service,
+		revisionService: revisionService,
+		fileService:     fileService,
+	}
+}
+
+func (s *attachmentService) Attach(attachableType enums.AttachableType, attachableID uint, request *dto.CreateAttachmentRequest, userID uint) (*models.Attachment, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateRole(attachableType, request.Role); err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyAttachable(attachableType, attachableID); err != nil {
+		return nil, err
+	}
+
+	// Attaching publishes the file to every user, so only its uploader or an admin can do it
+	if err := s.fileService.CanManageFile(request.FileID, userID); err != nil {
+		return nil, err
+	}
+	file, err := s.store.Files.GetByID(request.FileID)
+	if err != nil {
+		return nil, notFound(ErrFileNotFound, err)
+	}
+
+	attachment := &models.Attachment{
+		FileID:         file.ID,
+		AttachableType: attachableType,
+		AttachableID:   attachableID,
+		Role:           request.Role,
+		CreatedByID:    &userID,
+	}
+
+	if request.Order != nil {
+		attachment.Order = *request.Order
+	} else {
+		attachment.Order, err = s.store.Attachments.GetNextOrder(attachableType, attachableID)
+		if err != nil {
+			return nil, fmt.Errorf("error al calcular el orden del adjunto: %w", err)
+		}
+	}
+
+	if err := s.store.Attachments.Create(attachment); err != nil {
+		return nil, fmt.Errorf("error al crear el adjunto: %w", err)
+	}
+	attachment.File = file
+
+	s.syncPrimaryURL(attachment, file.Url, userID)
+
+	return attachment, nil
+}
+
+func (s *attachmentService) GetAttachments(attachableType enums.AttachableType, attachableID uint) ([]*models.Attachment, error) {
+	if _, ok := attachmentRoles[attachableType]; !ok {
+		return nil, ErrInvalidAttachable
+	}
+
+	attachments, err := s.store.Attachments.GetByAttachable(attachableType, attachableID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los adjuntos: %w", err)
+	}
+	return attachments, nil
+}
+
+func (s *attachmentService) UpdateAttachmentPatch(id uint, data map[string]interface{}, userID uint) (*models.Attachment, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	var request dto.UpdateAttachmentRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	existing, err := s.store.Attachments.Get(id)
+	if err != nil {
+		return nil, notFound(ErrAttachmentNotFound, err)
+	}
+
+	if request.Role != nil {
+		if err := s.validateRole(existing.AttachableType, *request.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.store.Attachments.Patch(id, data); err != nil {
+		return nil, fmt.Errorf("error al actualizar el adjunto: %w", err)
+	}
+
+	updated, err := s.store.Attachments.Get(id)
+	if err != nil {
+		return nil, notFound(ErrAttachmentNotFound, err)
+	}
+
+	if updated.Role != existing.Role && updated.File != nil {
+		s.clearPrimaryURL(existing, updated.File.Url, userID)
+		s.syncPrimaryURL(updated, updated.File.Url, userID)
+	}
+
+	return updated, nil
+}
+
+// Detach removes the attachment; the file is deleted later if nothing else uses it
+func (s *attachmentService) Detach(id, userID uint) error {
+	if err := s.requireStaff(userID); err != nil {
+		return err
+	}
+
+	attachment, err := s.store.Attachments.Get(id)
+	if err != nil {
+		return notFound(ErrAttachmentNotFound, err)
+	}
+
+	if err := s.store.Attachments.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar el adjunto: %w", err)
+	}
+
+	if attachment.File != nil {
+		s.clearPrimaryURL(attachment, attachment.File.Url, userID)
+	}
+
+	return nil
+}
+
+func (s *attachmentService) validateRole(attachableType enums.AttachableType, role enums.AttachmentRole) error {
+	roles, ok := attachmentRoles[attachableType]
+	if !ok {
+		return ErrInvalidAttachable
+	}
+	if !slices.Contains(roles, role) {
+		return ErrInvalidAttachmentRole.WithDetails(map[string]interface{}{"role": role, "attachable_type": attachableType})
+	}
+	return nil
+}
+
+func (s *attachmentService) verifyAttachable(attachableType enums.AttachableType, attachableID uint) error {
+	var err error
+	switch attachableType {
+	case enums.AttachableCourse:
+		_, err = s.store.Courses.Get(attachableID)
+	case enums.AttachableContent:
+		_, err = s.store.Contents.Get(attachableID)
+	case enums.AttachableQuestion:
+		_, err = s.store.Questions.Get(attachableID)
+	case enums.AttachableAnswer:
+		_, err = s.store.Answers.Get(attachableID)
+	default:
+		return ErrInvalidAttachable
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// syncPrimaryURL keeps Course.ImageURL and Content.MediaURL pointing to their cover and media attachments
+func (s *attachmentService) syncPrimaryURL(attachment *models.Attachment, url string, userID uint) {
+	switch {
+	case attachment.AttachableType == enums.AttachableCourse && attachment.Role == enums.AttachmentRoleCover:
+		if err := s.store.Courses.Patch(attachment.AttachableID, map[string]interface{}{"image_url": url}); err != nil {
+			s.logger.Warnf("failed to update image url of course %d: %v", attachment.AttachableID, err)
+		}
+	case attachment.AttachableType == enums.AttachableContent && attachment.Role == enums.AttachmentRoleMedia:
+		s.setContentMediaURL(attachment.AttachableID, url, userID)
+	}
+}
+
+// clearPrimaryURL empties the URL set by syncPrimaryURL when it still points to the released file
+func (s *attachmentService) clearPrimaryURL(attachment *models.Attachment, url string, userID uint) {
+	switch {
+	case attachment.AttachableType == enums.AttachableCourse && attachment.Role == enums.AttachmentRoleCover:
+		course, err := s.store.Courses.Get(attachment.AttachableID)
+		if err != nil || course.ImageURL != url {
+			return
+		}
+		if err := s.store.Courses.Patch(course.ID, map[string]interface{}{"image_url": ""}); err != nil {
+			s.logger.Warnf("failed to clear image url of course %d: %v", course.ID, err)
+		}
+	case attachment.AttachableType == enums.AttachableContent && attachment.Role == enums.AttachmentRoleMedia:
+		content, err := s.store.Contents.Get(attachment.AttachableID)
+		if err != nil || content.MediaURL != url {
+			return
+		}
+		s.setContentMediaURL(content.ID, "", userID)
+	}
+}
+
+func (s *attachmentService) setContentMediaURL(contentID uint, url string, userID uint) {
+	content, err := s.store.Contents.Get(contentID)
+	if err != nil {
+		s.logger.Warnf("failed to load content %d: %v", contentID, err)
+		return
+	}
+	before := contentSnapshot(content)
+
+	if err := s.store.Contents.Patch(contentID, map[string]interface{}{"media_url": url}); err != nil {
+		s.logger.Warnf("failed to update media url of content %d: %v", contentID, err)
+		return
+	}
+
+	content.MediaURL = url
+	if err := s.revisionService.Record(enums.RevisionEntityContent, contentID, userID, before, contentSnapshot(content)); err != nil {
+		s.logger.Warnf("failed to record revision for content %d: %v", contentID, err)
+	}
+}
@@ -97,16 +97,16 @@ func (s *authService) RefreshToken(userID uint, refreshToken string) (*dto.AuthT
 
 func (s *authService) GetUser(userID uint) (*models.User, error) {
 	if userID == 0 {
-		return nil, errors.New("user ID cannot be zero")
+		return nil, ErrInvalidID
 	}
 
 	user, err := s.store.Users.GetByID(userID)
 	if err != nil {
-		return nil, err
+		return nil, notFound(ErrUserNotFound, err)
 	}
 
 	if user == nil {
-		return nil, errors.New("usuario no encontrado")
+		return nil, ErrUserNotFound
 	}
 
 	return user, nil
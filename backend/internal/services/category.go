@@ -0,0 +1,207 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+var (
+	ErrEmptyCategoryName   = apperrors.New("EMPTY_CATEGORY_NAME", apperrors.KindInvalid, "el nombre de la categoría no puede estar vacío", "the category name cannot be empty")
+	ErrInvalidCategoryName = apperrors.New("INVALID_CATEGORY_NAME", apperrors.KindInvalid, "el nombre de la categoría no es válido", "the category name is not valid")
+	ErrCategoryExists      = apperrors.New("CATEGORY_EXISTS", apperrors.KindConflict, "ya existe una categoría con ese identificador", "a category with that identifier already exists")
+	ErrCategoryCycle       = apperrors.New("CATEGORY_CYCLE", apperrors.KindInvalid, "una categoría no puede ser subcategoría de sí misma", "a category cannot be a subcategory of itself")
+)
+
+type CategoryService interface {
+	CreateCategory(request *dto.CreateCategoryRequest) (*models.Category, error)
+	GetCategory(id uint) (*models.Category, error)
+	UpdateCategoryPatch(id uint, data map[string]interface{}) (*models.Category, error)
+	DeleteCategory(id uint) error
+	GetCategoryTree() ([]*models.Category, error)
+}
+
+type categoryService struct {
+	*Service
+}
+
+func NewCategoryService(service *Service) CategoryService {
+	return &categoryService{
+		Service: service,
+	}
+}
+
+func (s *categoryService) CreateCategory(request *dto.CreateCategoryRequest) (*models.Category, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, ErrEmptyCategoryName
+	}
+
+	slug, err := s.buildSlug(name, request.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Categories.GetBySlug(slug); err == nil {
+		return nil, ErrCategoryExists.WithDetails(map[string]interface{}{"slug": slug})
+	}
+
+	category := &models.Category{
+		Name:        name,
+		Slug:        slug,
+		Description: request.Description,
+		Order:       request.Order,
+		ParentID:    request.ParentID,
+	}
+
+	if err := s.store.Categories.Create(category); err != nil {
+		return nil, fmt.Errorf("error al crear la categoría: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) GetCategory(id uint) (*models.Category, error) {
+	category, err := s.store.Categories.Get(id)
+	if err != nil {
+		return nil, notFound(ErrCategoryNotFound, err)
+	}
+	return category, nil
+}
+
+func (s *categoryService) UpdateCategoryPatch(id uint, data map[string]interface{}) (*models.Category, error) {
+	if id == 0 {
+		return nil, ErrInvalidID
+	}
+
+	var request dto.UpdateCategoryRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	category, err := s.store.Categories.Get(id)
+	if err != nil {
+		return nil, notFound(ErrCategoryNotFound, err)
+	}
+
+	parentID := category.ParentID
+	if _, ok := data["parent_id"]; ok {
+		parentID = request.ParentID
+		if err := s.validateParent(id, parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	// The slug follows the name and the position in the tree
+	name := category.Name
+	if request.Name != nil {
+		name = strings.TrimSpace(*request.Name)
+		if name == "" {
+			return nil, ErrEmptyCategoryName
+		}
+		data["name"] = name
+	}
+
+	slug, err := s.buildSlug(name, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if slug != category.Slug {
+		if existing, err := s.store.Categories.GetBySlug(slug); err == nil && existing.ID != id {
+			return nil, ErrCategoryExists.WithDetails(map[string]interface{}{"slug": slug})
+		}
+		data["slug"] = slug
+	}
+
+	if err := s.store.Categories.Patch(id, data); err != nil {
+		return nil, fmt.Errorf("error al actualizar la categoría: %w", err)
+	}
+
+	return s.store.Categories.Get(id)
+}
+
+func (s *categoryService) DeleteCategory(id uint) error {
+	if err := s.store.Categories.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar la categoría: %w", err)
+	}
+	return nil
+}
+
+// GetCategoryTree returns the root categories with their subcategories nested
+func (s *categoryService) GetCategoryTree() ([]*models.Category, error) {
+	categories, err := s.store.Categories.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las categorías: %w", err)
+	}
+
+	byID := make(map[uint]*models.Category, len(categories))
+	for _, category := range categories {
+		category.Children = []*models.Category{}
+		byID[category.ID] = category
+	}
+
+	roots := []*models.Category{}
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if parent, ok := byID[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+
+	return roots, nil
+}
+
+// buildSlug prefixes the category slug with the slug of its parent
+func (s *categoryService) buildSlug(name string, parentID *uint) (string, error) {
+	slug := utils.Slugify(name)
+	if slug == "" {
+		return "", ErrInvalidCategoryName
+	}
+
+	if parentID == nil {
+		return slug, nil
+	}
+
+	parent, err := s.store.Categories.Get(*parentID)
+	if err != nil {
+		return "", notFound(ErrCategoryNotFound, err)
+	}
+
+	return parent.Slug + "-" + slug, nil
+}
+
+// validateParent prevents a category from becoming its own ancestor
+func (s *categoryService) validateParent(id uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+
+	categories, err := s.store.Categories.GetAll()
+	if err != nil {
+		return fmt.Errorf("error al obtener las categorías: %w", err)
+	}
+
+	parents := make(map[uint]*uint, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+
+	if _, ok := parents[*parentID]; !ok {
+		return ErrCategoryNotFound.WithDetails(map[string]interface{}{"parent_id": *parentID})
+	}
+
+	for current := parentID; current != nil; current = parents[*current] {
+		if *current == id {
+			return ErrCategoryCycle
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+var (
+	ErrInvalidCohortDates      = apperrors.New("INVALID_COHORT_DATES", apperrors.KindInvalid, "la fecha de fin de la cohorte debe ser posterior a la de inicio", "the cohort end date must be after its start date")
+	ErrInvalidCohortInstructor = apperrors.New("INVALID_COHORT_INSTRUCTOR", apperrors.KindInvalid, "los instructores de la cohorte deben ser instructores o administradores", "cohort instructors must be instructors or administrators")
+	ErrInvalidDeadlineTarget   = apperrors.New("INVALID_DEADLINE_TARGET", apperrors.KindInvalid, "el módulo o la evaluación de la fecha límite no pertenece al curso de la cohorte", "the module or evaluation of the deadline does not belong to the cohort's course")
+)
+
+type CohortService interface {
+	CreateCohort(courseID, userID uint, request *dto.CreateCohortRequest) (*models.Cohort, error)
+	GetCohort(id, userID uint) (*models.Cohort, error)
+	ListCohorts(courseID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Cohort], error)
+	UpdateCohortPatch(id, userID uint, data map[string]interface{}) (*models.Cohort, error)
+	DeleteCohort(id, userID uint) error
+	CreateDeadline(cohortID, userID uint, request *dto.CreateCohortDeadlineRequest) (*models.CohortDeadline, error)
+	UpdateDeadlinePatch(id, userID uint, data map[string]interface{}) (*models.CohortDeadline, error)
+	DeleteDeadline(id, userID uint) error
+}
+
+type cohortService struct {
+	*Service
+	enrollmentService EnrollmentService
+}
+
+func NewCohortService(service *Service, enrollmentService EnrollmentService) CohortService {
+	return &cohortService{
+		Service:           service,
+		enrollmentService: enrollmentService,
+	}
+}
+
+func (s *cohortService) CreateCohort(courseID, userID uint, request *dto.CreateCohortRequest) (*models.Cohort, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Courses.Get(courseID); err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	if request.EndDate != nil && !request.EndDate.After(request.StartDate) {
+		return nil, ErrInvalidCohortDates
+	}
+
+	instructors, err := s.loadInstructors(request.InstructorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	cohort := &models.Cohort{
+		CourseID:    courseID,
+		Name:        strings.TrimSpace(request.Name),
+		Description: request.Description,
+		StartDate:   request.StartDate,
+		EndDate:     request.EndDate,
+		Schedule:    request.Schedule,
+		Instructors: instructors,
+	}
+
+	if err := s.store.Cohorts.Create(cohort); err != nil {
+		return nil, fmt.Errorf("error al crear la cohorte: %w", err)
+	}
+
+	return s.store.Cohorts.Get(cohort.ID)
+}
+
+// GetCohort returns the cohort with its instructors and deadlines. Learners can only
+// see the cohort they belong to.
+func (s *cohortService) GetCohort(id, userID uint) (*models.Cohort, error) {
+	cohort, err := s.store.Cohorts.Get(id)
+	if err != nil {
+		return nil, notFound(ErrCohortNotFound, err)
+	}
+
+	isStaff, err := s.userHasRole(userID, enums.UserRoleInstructor, enums.UserRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if isStaff {
+		return cohort, nil
+	}
+
+	enrollment, err := s.enrollmentService.GetUserCourseEnrollment(userID, cohort.CourseID)
+	if err != nil || enrollment.CohortID == nil || *enrollment.CohortID != id {
+		return nil, ErrForbidden
+	}
+
+	return cohort, nil
+}
+
+func (s *cohortService) ListCohorts(courseID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Cohort], error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+	return s.store.Cohorts.ListByCourse(courseID, request)
+}
+
+func (s *cohortService) UpdateCohortPatch(id, userID uint, data map[string]interface{}) (*models.Cohort, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	var request dto.UpdateCohortRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	cohort, err := s.store.Cohorts.Get(id)
+	if err != nil {
+		return nil, notFound(ErrCohortNotFound, err)
+	}
+
+	changes := map[string]interface{}{}
+	if request.Name != nil {
+		changes["name"] = strings.TrimSpace(*request.Name)
+	}
+	if request.Description != nil {
+		changes["description"] = *request.Description
+	}
+	if request.Schedule != nil {
+		changes["schedule"] = *request.Schedule
+	}
+	if request.StartDate != nil {
+		cohort.StartDate = *request.StartDate
+		changes["start_date"] = *request.StartDate
+	}
+	if _, ok := data["end_date"]; ok {
+		cohort.EndDate = request.EndDate
+		changes["end_date"] = request.EndDate
+	}
+
+	if cohort.EndDate != nil && !cohort.EndDate.After(cohort.StartDate) {
+		return nil, ErrInvalidCohortDates
+	}
+
+	if request.InstructorIDs != nil {
+		instructors, err := s.loadInstructors(*request.InstructorIDs)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.store.Cohorts.ReplaceInstructors(id, instructors); err != nil {
+			return nil, fmt.Errorf("error al actualizar los instructores: %w", err)
+		}
+	}
+
+	if len(changes) > 0 {
+		if err := s.store.Cohorts.Patch(id, changes); err != nil {
+			return nil, fmt.Errorf("error al actualizar la cohorte: %w", err)
+		}
+	}
+
+	return s.store.Cohorts.Get(id)
+}
+
+// DeleteCohort removes the cohort; its learners stay enrolled in the course without a cohort
+func (s *cohortService) DeleteCohort(id, userID uint) error {
+	if err := s.requireStaff(userID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.Cohorts.Get(id); err != nil {
+		return notFound(ErrCohortNotFound, err)
+	}
+
+	if err := s.store.Cohorts.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar la cohorte: %w", err)
+	}
+	return nil
+}
+
+// CreateDeadline adds a cohort-specific deadline, optionally tied to a module or an
+// evaluation of the cohort's course
+func (s *cohortService) CreateDeadline(cohortID, userID uint, request *dto.CreateCohortDeadlineRequest) (*models.CohortDeadline, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	cohort, err := s.store.Cohorts.Get(cohortID)
+	if err != nil {
+		return nil, notFound(ErrCohortNotFound, err)
+	}
+
+	if err := s.validateDeadlineTarget(cohort.CourseID, request.ModuleID, request.EvaluationID); err != nil {
+		return nil, err
+	}
+
+	if err := validateDeadlineWindow(request.OpensAt, &request.DueAt, request.CloseAt); err != nil {
+		return nil, err
+	}
+
+	deadline := &models.CohortDeadline{
+		CohortID:     cohortID,
+		Title:        strings.TrimSpace(request.Title),
+		OpensAt:      request.OpensAt,
+		DueAt:        request.DueAt,
+		CloseAt:      request.CloseAt,
+		ModuleID:     request.ModuleID,
+		EvaluationID: request.EvaluationID,
+	}
+
+	if err := s.store.Cohorts.CreateDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("error al crear la fecha límite: %w", err)
+	}
+
+	return deadline, nil
+}
+
+func (s *cohortService) UpdateDeadlinePatch(id, userID uint, data map[string]interface{}) (*models.CohortDeadline, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	var request dto.UpdateCohortDeadlineRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	deadline, err := s.store.Cohorts.GetDeadline(id)
+	if err != nil {
+		return nil, notFound(ErrDeadlineNotFound, err)
+	}
+
+	// Explicit nulls clear the opening and closing dates
+	changes := map[string]interface{}{}
+	if request.Title != nil {
+		changes["title"] = strings.TrimSpace(*request.Title)
+	}
+	if request.DueAt != nil {
+		deadline.DueAt = *request.DueAt
+		changes["due_at"] = *request.DueAt
+	}
+	if _, ok := data["opens_at"]; ok {
+		deadline.OpensAt = request.OpensAt
+		changes["opens_at"] = request.OpensAt
+	}
+	if _, ok := data["close_at"]; ok {
+		deadline.CloseAt = request.CloseAt
+		changes["close_at"] = request.CloseAt
+	}
+
+	if err := validateDeadlineWindow(deadline.OpensAt, &deadline.DueAt, deadline.CloseAt); err != nil {
+		return nil, err
+	}
+
+	if len(changes) > 0 {
+		if err := s.store.Cohorts.PatchDeadline(id, changes); err != nil {
+			return nil, fmt.Errorf("error al actualizar la fecha límite: %w", err)
+		}
+	}
+
+	return s.store.Cohorts.GetDeadline(id)
+}
+
+func (s *cohortService) DeleteDeadline(id, userID uint) error {
+	if err := s.requireStaff(userID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.Cohorts.GetDeadline(id); err != nil {
+		return notFound(ErrDeadlineNotFound, err)
+	}
+
+	if err := s.store.Cohorts.DeleteDeadline(id); err != nil {
+		return fmt.Errorf("error al eliminar la fecha límite: %w", err)
+	}
+	return nil
+}
+
+// loadInstructors resolves the instructor IDs, which must belong to staff users
+func (s *cohortService) loadInstructors(ids []uint) ([]*models.User, error) {
+	instructors := make([]*models.User, 0, len(ids))
+	seen := make(map[uint]bool)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		user, err := s.store.Users.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("instructor %d no encontrado: %w", id, err)
+		}
+		if user.Role != enums.UserRoleInstructor && user.Role != enums.UserRoleAdmin {
+			return nil, ErrInvalidCohortInstructor
+		}
+		instructors = append(instructors, user)
+	}
+	return instructors, nil
+}
+
+func (s *cohortService) validateDeadlineTarget(courseID uint, moduleID, evaluationID *uint) error {
+	if evaluationID != nil {
+		evaluation, err := s.store.Evaluations.Get(*evaluationID)
+		if err != nil {
+			return notFound(ErrEvaluationNotFound, err)
+		}
+		if moduleID != nil && *moduleID != evaluation.ModuleID {
+			return ErrInvalidDeadlineTarget
+		}
+		moduleID = &evaluation.ModuleID
+	}
+
+	if moduleID != nil {
+		module, err := s.store.Modules.Get(*moduleID)
+		if err != nil {
+			return notFound(ErrModuleNotFound, err)
+		}
+		if module.CourseID != courseID {
+			return ErrInvalidDeadlineTarget
+		}
+	}
+	return nil
+}
@@ -1,21 +1,22 @@
 package services
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"github.com/imlargo/go-api-template/pkg/utils"
 )
 
 type ContentService interface {
-	CreateContent(content *models.Content) (*models.Content, error)
-	GetContent(id uint) (*models.Content, error)
-	UpdateContent(id uint, content *models.Content) (*models.Content, error)
-	UpdateContentPatch(id uint, data map[string]interface{}) (*models.Content, error)
+	CreateContent(content *models.Content, authorID uint) (*models.Content, error)
+	GetContent(id, userID uint, locale dto.RequestLocale) (*models.Content, error)
+	UpdateContent(id uint, content *models.Content, authorID uint) (*models.Content, error)
+	UpdateContentPatch(id uint, data map[string]interface{}, authorID uint) (*models.Content, error)
 	DeleteContent(id uint) error
 	GetContentsByModule(moduleID uint) ([]*models.Content, error)
+	ListContentsByModule(moduleID, userID uint, locale dto.RequestLocale, request *dto.ListRequest) (*dto.Page[*models.Content], error)
 	ReorderContent(moduleID uint, contentOrders []struct {
 		ID    uint
 		Order int
@@ -24,65 +25,102 @@ type ContentService interface {
 
 type contentService struct {
 	*Service
+	revisionService    RevisionService
+	releaseService     ReleaseService
+	translationService TranslationService
 }
 
-func NewContentService(service *Service) ContentService {
+func NewContentService(service *Service, revisionService RevisionService, releaseService ReleaseService, translationService TranslationService) ContentService {
 	return &contentService{
-		Service: service,
+		Service:            service,
+		revisionService:    revisionService,
+		releaseService:     releaseService,
+		translationService: translationService,
 	}
 }
 
-func (s *contentService) CreateContent(content *models.Content) (*models.Content, error) {
+func (s *contentService) CreateContent(content *models.Content, authorID uint) (*models.Content, error) {
 	// Verify module exists
 	_, err := s.store.Modules.Get(content.ModuleID)
 	if err != nil {
-		return nil, fmt.Errorf("módulo no encontrado: %w", err)
+		return nil, notFound(ErrModuleNotFound, err)
 	}
 
 	if err := s.store.Contents.Create(content); err != nil {
 		return nil, fmt.Errorf("error al crear el contenido: %w", err)
 	}
+
+	s.recordRevision(content.ID, authorID, nil, contentSnapshot(content))
 	return content, nil
 }
 
-func (s *contentService) GetContent(id uint) (*models.Content, error) {
+// GetContent returns the content in the locale resolved for the request, with its caption
+// and transcript tracks. Contents of a module not released for the user are refused.
+func (s *contentService) GetContent(id, userID uint, locale dto.RequestLocale) (*models.Content, error) {
 	content, err := s.store.Contents.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("contenido no encontrado: %w", err)
+		return nil, notFound(ErrContentNotFound, err)
+	}
+
+	module, err := s.store.Modules.Get(content.ModuleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+	if err := s.releaseService.RequireModuleReleased(userID, module); err != nil {
+		return nil, err
+	}
+
+	if content.Tracks, err = s.store.ContentTracks.GetByContent(id); err != nil {
+		return nil, fmt.Errorf("error al obtener las pistas del contenido: %w", err)
+	}
+
+	if err := s.localize(module, userID, locale, content); err != nil {
+		return nil, err
 	}
 	return content, nil
 }
 
-func (s *contentService) UpdateContent(id uint, contentData *models.Content) (*models.Content, error) {
+func (s *contentService) UpdateContent(id uint, contentData *models.Content, authorID uint) (*models.Content, error) {
 	existingContent, err := s.store.Contents.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("contenido no encontrado: %w", err)
+		return nil, notFound(ErrContentNotFound, err)
 	}
 
+	before := contentSnapshot(existingContent)
+
 	// Update fields
 	existingContent.Title = contentData.Title
 	existingContent.Description = contentData.Description
 	existingContent.Order = contentData.Order
 	existingContent.Body = contentData.Body
 	existingContent.MediaURL = contentData.MediaURL
+	existingContent.MediaDuration = contentData.MediaDuration
 	existingContent.Type = contentData.Type
 
 	if err := s.store.Contents.Update(existingContent); err != nil {
 		return nil, fmt.Errorf("error al actualizar el contenido: %w", err)
 	}
 
+	s.recordRevision(id, authorID, before, contentSnapshot(existingContent))
+
 	return existingContent, nil
 }
 
-func (s *contentService) UpdateContentPatch(contentID uint, data map[string]interface{}) (*models.Content, error) {
+func (s *contentService) UpdateContentPatch(contentID uint, data map[string]interface{}, authorID uint) (*models.Content, error) {
 	if contentID == 0 {
-		return nil, errors.New("el ID del contenido no puede ser cero")
+		return nil, ErrInvalidID
 	}
 
 	var content dto.UpdateContentRequest
 	if err := utils.MapToStructStrict(data, &content); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
+	}
+
+	existing, err := s.store.Contents.Get(contentID)
+	if err != nil {
+		return nil, notFound(ErrContentNotFound, err)
 	}
+	before := contentSnapshot(existing)
 
 	if err := s.store.Contents.Patch(contentID, data); err != nil {
 		return nil, err
@@ -90,9 +128,11 @@ func (s *contentService) UpdateContentPatch(contentID uint, data map[string]inte
 
 	updated, err := s.store.Contents.Get(contentID)
 	if err != nil {
-		return nil, errors.New("contenido no encontrado")
+		return nil, notFound(ErrContentNotFound, err)
 	}
 
+	s.recordRevision(contentID, authorID, before, contentSnapshot(updated))
+
 	return updated, nil
 }
 
@@ -113,6 +153,49 @@ func (s *contentService) GetContentsByModule(moduleID uint) ([]*models.Content,
 	return contents, nil
 }
 
+// ListContentsByModule lists the module contents. While the module is not released for the
+// user the outline is kept but bodies and media are left out.
+func (s *contentService) ListContentsByModule(moduleID, userID uint, locale dto.RequestLocale, request *dto.ListRequest) (*dto.Page[*models.Content], error) {
+	module, err := s.store.Modules.Get(moduleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	page, err := s.store.Contents.ListByModuleID(moduleID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los contenidos: %w", err)
+	}
+
+	if err := s.releaseService.AnnotateModules(userID, module.CourseID, []*models.Module{module}); err != nil {
+		return nil, err
+	}
+	if module.Locked {
+		hideLockedContents(page.Items)
+	}
+
+	if err := s.localize(module, userID, locale, page.Items...); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// hideLockedContents leaves only the outline of contents whose module is not released yet
+func hideLockedContents(contents []*models.Content) {
+	for _, content := range contents {
+		content.Body = ""
+		content.MediaURL = ""
+	}
+}
+
+// localize translates contents of the module to the locale resolved for the request
+func (s *contentService) localize(module *models.Module, userID uint, locale dto.RequestLocale, contents ...*models.Content) error {
+	resolved, err := s.translationService.ResolveCourseLocale(userID, module.CourseID, locale)
+	if err != nil {
+		return err
+	}
+	return s.translationService.LocalizeContents(resolved, contents...)
+}
+
 func (s *contentService) ReorderContent(moduleID uint, contentOrders []struct {
 	ID    uint
 	Order int
@@ -120,7 +203,7 @@ func (s *contentService) ReorderContent(moduleID uint, contentOrders []struct {
 	// Verify module exists
 	_, err := s.store.Modules.Get(moduleID)
 	if err != nil {
-		return fmt.Errorf("módulo no encontrado: %w", err)
+		return notFound(ErrModuleNotFound, err)
 	}
 
 	// Update each content's order
@@ -142,3 +225,10 @@ func (s *contentService) ReorderContent(moduleID uint, contentOrders []struct {
 
 	return nil
 }
+
+// recordRevision saves the change in the revision history; a failure never undoes the edit
+func (s *contentService) recordRevision(id, authorID uint, before, after models.RevisionSnapshot) {
+	if err := s.revisionService.Record(enums.RevisionEntityContent, id, authorID, before, after); err != nil {
+		s.logger.Warnf("failed to record revision for content %d: %v", id, err)
+	}
+}
@@ -1,77 +1,159 @@
 package services
 
 import (
-	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/imlargo/go-api-template/internal/apperrors"
 	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/internal/repositories"
 	"github.com/imlargo/go-api-template/pkg/utils"
 )
 
+var (
+	ErrInvalidCourseLevel    = apperrors.New("INVALID_COURSE_LEVEL", apperrors.KindInvalid, "nivel de curso inválido", "invalid course level")
+	ErrInvalidCourseLanguage = apperrors.New("INVALID_COURSE_LANGUAGE", apperrors.KindInvalid, "el idioma del curso no puede estar vacío", "the course language cannot be empty")
+)
+
 type CourseService interface {
-	CreateCourse(course *models.Course) (*models.Course, error)
-	GetCourse(id uint) (*models.Course, error)
-	UpdateCourse(id uint, course *models.Course) (*models.Course, error)
+	CreateCourse(request *dto.CreateCourseRequest) (*models.Course, error)
+	GetCourse(id, userID uint, locale dto.RequestLocale) (*models.Course, error)
+	UpdateCourse(id uint, request *dto.ReplaceCourseRequest) (*models.Course, error)
 	UpdateCoursePatch(id uint, data map[string]interface{}) (*models.Course, error)
 	DeleteCourse(id uint) error
-	GetAllCourses() ([]*models.Course, error)
+	GetAllCourses(userID uint, locale dto.RequestLocale) ([]*models.Course, error)
+	ListCourses(userID uint, locale dto.RequestLocale, request *dto.ListRequest) (*dto.Page[*models.Course], error)
 	GetCourseWithModules(id uint) (*models.Course, error)
 	GetCoursesWithEnrollmentCount() ([]*models.Course, error)
+	GetCatalog(request *dto.CatalogRequest) (*dto.CatalogResponse, error)
+	SetCourseTags(courseID uint, names []string) ([]*models.Tag, error)
 }
 
 type courseService struct {
 	*Service
+	translationService TranslationService
 }
 
-func NewCourseService(service *Service) CourseService {
+func NewCourseService(service *Service, translationService TranslationService) CourseService {
 	return &courseService{
-		Service: service,
+		Service:            service,
+		translationService: translationService,
 	}
 }
 
-func (s *courseService) CreateCourse(course *models.Course) (*models.Course, error) {
+func (s *courseService) CreateCourse(request *dto.CreateCourseRequest) (*models.Course, error) {
+	if err := s.validateTaxonomy(request.CategoryID, request.Level); err != nil {
+		return nil, err
+	}
+
+	course := &models.Course{
+		Title:             request.Title,
+		Description:       request.Description,
+		ShortDescription:  request.ShortDescription,
+		ImageURL:          request.ImageURL,
+		IsPublished:       request.IsPublished == nil || *request.IsPublished,
+		CategoryID:        request.CategoryID,
+		Level:             request.Level,
+		Language:          request.Language,
+		EstimatedDuration: request.EstimatedDuration,
+	}
+
 	if err := s.store.Courses.Create(course); err != nil {
 		return nil, fmt.Errorf("error al crear el curso: %w", err)
 	}
 	return course, nil
 }
 
-func (s *courseService) GetCourse(id uint) (*models.Course, error) {
+// GetCourse returns the course in the locale resolved for the request
+func (s *courseService) GetCourse(id, userID uint, locale dto.RequestLocale) (*models.Course, error) {
 	course, err := s.store.Courses.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("curso no encontrado: %w", err)
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	resolved, err := s.translationService.ResolveCourseLocale(userID, course.ID, locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translationService.LocalizeCourses(resolved, course); err != nil {
+		return nil, err
 	}
 	return course, nil
 }
 
-func (s *courseService) UpdateCourse(id uint, courseData *models.Course) (*models.Course, error) {
-	existingCourse, err := s.store.Courses.Get(id)
-	if err != nil {
-		return nil, fmt.Errorf("curso no encontrado: %w", err)
+// UpdateCourse replaces the course texts; publication, taxonomy and language only change
+// when sent
+func (s *courseService) UpdateCourse(id uint, request *dto.ReplaceCourseRequest) (*models.Course, error) {
+	if _, err := s.store.Courses.Get(id); err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
 	}
 
-	// Update fields
-	existingCourse.Title = courseData.Title
-	existingCourse.Description = courseData.Description
-	existingCourse.ShortDescription = courseData.ShortDescription
-	existingCourse.ImageURL = courseData.ImageURL
+	var level enums.CourseLevel
+	if request.Level != nil {
+		level = *request.Level
+	}
+	if err := s.validateTaxonomy(request.CategoryID, level); err != nil {
+		return nil, err
+	}
+	if err := validateCourseLanguage(request.Language); err != nil {
+		return nil, err
+	}
 
-	if err := s.store.Courses.Update(existingCourse); err != nil {
+	// A map so false and empty values are written too
+	changes := map[string]interface{}{
+		"title":             request.Title,
+		"description":       request.Description,
+		"short_description": request.ShortDescription,
+		"image_url":         request.ImageURL,
+	}
+	if request.IsPublished != nil {
+		changes["is_published"] = *request.IsPublished
+	}
+	if request.CategoryID != nil {
+		changes["category_id"] = *request.CategoryID
+	}
+	if request.Level != nil {
+		changes["level"] = *request.Level
+	}
+	if request.Language != nil {
+		changes["language"] = strings.TrimSpace(*request.Language)
+	}
+	if request.EstimatedDuration != nil {
+		changes["estimated_duration"] = *request.EstimatedDuration
+	}
+
+	if err := s.store.Courses.Patch(id, changes); err != nil {
 		return nil, fmt.Errorf("error al actualizar el curso: %w", err)
 	}
 
-	return existingCourse, nil
+	updated, err := s.store.Courses.Get(id)
+	if err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+	return updated, nil
 }
 
 func (s *courseService) UpdateCoursePatch(courseID uint, data map[string]interface{}) (*models.Course, error) {
 	if courseID == 0 {
-		return nil, errors.New("course ID cannot be zero")
+		return nil, ErrInvalidID
 	}
 
 	var course dto.UpdateCourseRequest
 	if err := utils.MapToStructStrict(data, &course); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
+	}
+
+	var level enums.CourseLevel
+	if course.Level != nil {
+		level = *course.Level
+	}
+	if err := s.validateTaxonomy(course.CategoryID, level); err != nil {
+		return nil, err
+	}
+	if err := validateCourseLanguage(course.Language); err != nil {
+		return nil, err
 	}
 
 	if err := s.store.Courses.Patch(courseID, data); err != nil {
@@ -80,7 +162,7 @@ func (s *courseService) UpdateCoursePatch(courseID uint, data map[string]interfa
 
 	updated, err := s.store.Courses.Get(courseID)
 	if err != nil {
-		return nil, errors.New("curso no encontrado")
+		return nil, notFound(ErrCourseNotFound, err)
 	}
 
 	return updated, nil
@@ -93,19 +175,37 @@ func (s *courseService) DeleteCourse(id uint) error {
 	return nil
 }
 
-func (s *courseService) GetAllCourses() ([]*models.Course, error) {
+// GetAllCourses returns every course in the locale asked by the request or the user
+func (s *courseService) GetAllCourses(userID uint, locale dto.RequestLocale) ([]*models.Course, error) {
 	courses, err := s.store.Courses.GetAll()
 	if err != nil {
 		return nil, fmt.Errorf("error al obtener los cursos: %w", err)
 	}
+
+	if err := s.translationService.LocalizeCourseList(userID, locale, courses...); err != nil {
+		return nil, err
+	}
 	return courses, nil
 }
 
+// ListCourses lists the courses in the locale asked by the request or the user
+func (s *courseService) ListCourses(userID uint, locale dto.RequestLocale, request *dto.ListRequest) (*dto.Page[*models.Course], error) {
+	page, err := s.store.Courses.List(request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los cursos: %w", err)
+	}
+
+	if err := s.translationService.LocalizeCourseList(userID, locale, page.Items...); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
 func (s *courseService) GetCourseWithModules(id uint) (*models.Course, error) {
 	// This would require a repository method to preload modules
 	course, err := s.store.Courses.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("curso no encontrado: %w", err)
+		return nil, notFound(ErrCourseNotFound, err)
 	}
 
 	// For now, return the course - would need to implement preloading in repository
@@ -113,10 +213,126 @@ func (s *courseService) GetCourseWithModules(id uint) (*models.Course, error) {
 }
 
 func (s *courseService) GetCoursesWithEnrollmentCount() ([]*models.Course, error) {
-	// This would require a more complex repository method to calculate enrollment counts
-	courses, err := s.store.Courses.GetAll()
+	// Published courses ordered by their number of enrolled students
+	courses, _, err := s.store.Courses.GetCatalog(&dto.CatalogRequest{Sort: enums.CatalogSortPopular})
 	if err != nil {
 		return nil, fmt.Errorf("error al obtener los cursos: %w", err)
 	}
 	return courses, nil
 }
+
+func (s *courseService) GetCatalog(request *dto.CatalogRequest) (*dto.CatalogResponse, error) {
+	request.Query = strings.TrimSpace(request.Query)
+
+	if request.Limit <= 0 {
+		request.Limit = 20
+	}
+	if request.Limit > 100 {
+		request.Limit = 100
+	}
+	if request.Offset < 0 {
+		request.Offset = 0
+	}
+
+	switch request.Sort {
+	case "":
+		request.Sort = enums.CatalogSortPopular
+	case enums.CatalogSortPopular, enums.CatalogSortNewest, enums.CatalogSortRating, enums.CatalogSortTitle:
+	default:
+		return nil, repositories.ErrInvalidSort.WithDetails(map[string]interface{}{"field": request.Sort})
+	}
+
+	for _, level := range request.Levels {
+		if !isValidCourseLevel(level) {
+			return nil, ErrInvalidCourseLevel.WithDetails(map[string]interface{}{"level": level})
+		}
+	}
+
+	// Tags are filtered by slug so any spelling of the name matches
+	for i, tag := range request.Tags {
+		request.Tags[i] = utils.Slugify(tag)
+	}
+
+	courses, total, err := s.store.Courses.GetCatalog(request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener el catálogo: %w", err)
+	}
+
+	facets, err := s.store.Courses.GetCatalogFacets(request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los filtros del catálogo: %w", err)
+	}
+
+	paths, err := s.store.Courses.GetCatalogPaths(request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las rutas de aprendizaje: %w", err)
+	}
+
+	return &dto.CatalogResponse{
+		Courses:       courses,
+		Total:         total,
+		Facets:        *facets,
+		LearningPaths: paths,
+	}, nil
+}
+
+func (s *courseService) SetCourseTags(courseID uint, names []string) ([]*models.Tag, error) {
+	if _, err := s.store.Courses.Get(courseID); err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	seen := make(map[string]bool)
+	tags := make([]*models.Tag, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := utils.Slugify(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, &models.Tag{Name: name, Slug: slug})
+	}
+
+	tags, err := s.store.Tags.GetOrCreate(tags)
+	if err != nil {
+		return nil, fmt.Errorf("error al guardar las etiquetas: %w", err)
+	}
+
+	if err := s.store.Courses.ReplaceTags(courseID, tags); err != nil {
+		return nil, fmt.Errorf("error al asignar las etiquetas al curso: %w", err)
+	}
+
+	return tags, nil
+}
+
+// validateTaxonomy checks the category exists and the level is supported
+func (s *courseService) validateTaxonomy(categoryID *uint, level enums.CourseLevel) error {
+	if categoryID != nil {
+		if _, err := s.store.Categories.Get(*categoryID); err != nil {
+			return notFound(ErrCategoryNotFound, err)
+		}
+	}
+
+	if level != "" && !isValidCourseLevel(level) {
+		return ErrInvalidCourseLevel.WithDetails(map[string]interface{}{"level": level})
+	}
+
+	return nil
+}
+
+// validateCourseLanguage rejects a blank language; the language decides the default locale
+// of the course translations
+func validateCourseLanguage(language *string) error {
+	if language != nil && strings.TrimSpace(*language) == "" {
+		return ErrInvalidCourseLanguage
+	}
+	return nil
+}
+
+func isValidCourseLevel(level enums.CourseLevel) bool {
+	switch level {
+	case enums.CourseLevelBeginner, enums.CourseLevelIntermediate, enums.CourseLevelAdvanced:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,479 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+var (
+	ErrInvalidDeadlineWindow = apperrors.New("INVALID_DEADLINE_WINDOW", apperrors.KindInvalid, "las fechas deben cumplir apertura < entrega <= cierre", "dates must satisfy opening < due <= closing")
+	ErrInvalidOverrideTarget = apperrors.New("INVALID_OVERRIDE_TARGET", apperrors.KindInvalid, "la excepción debe indicar un módulo o una evaluación", "the override must target a module or an evaluation")
+	ErrInvalidLatePenalty    = apperrors.New("INVALID_LATE_PENALTY", apperrors.KindInvalid, "la penalización por entrega tardía es inválida", "the late penalty is invalid")
+)
+
+type DeadlineService interface {
+	GetEvaluationWindow(userID uint, evaluation *models.Evaluation) (*dto.DeadlineWindow, error)
+	GetUserEvaluationWindow(userID, evaluationID uint) (*dto.DeadlineWindow, error)
+	GetUpcomingDeadlines(userID, courseID uint) ([]*dto.UpcomingDeadline, error)
+	CreateOverride(actorID uint, request *dto.CreateDeadlineOverrideRequest) (*models.UserDeadlineOverride, error)
+	UpdateOverridePatch(id, actorID uint, data map[string]interface{}) (*models.UserDeadlineOverride, error)
+	DeleteOverride(id, actorID uint) error
+	ListUserOverrides(userID, actorID uint, request *dto.ListRequest) (*dto.Page[*models.UserDeadlineOverride], error)
+}
+
+type deadlineService struct {
+	*Service
+}
+
+func NewDeadlineService(service *Service) DeadlineService {
+	return &deadlineService{
+		Service: service,
+	}
+}
+
+// deadlineOverrides holds the cohort deadlines and the personal overrides of a learner,
+// and the date relative module releases count from
+type deadlineOverrides struct {
+	cohort      []*models.CohortDeadline
+	personal    []*models.UserDeadlineOverride
+	releaseBase *time.Time
+}
+
+// GetEvaluationWindow resolves the dates that apply to the learner for the evaluation.
+// The evaluation inherits the module dates it does not set; on top of each level the
+// cohort deadline and then the personal override take precedence. Nothing opens before
+// the module's drip release.
+func (s *deadlineService) GetEvaluationWindow(userID uint, evaluation *models.Evaluation) (*dto.DeadlineWindow, error) {
+	module, err := s.store.Modules.Get(evaluation.ModuleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	overrides, err := s.loadOverrides(userID, module.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	window := overrides.evaluationWindow(module, evaluation)
+	window.Status = deadlineStatus(window, time.Now())
+	return window, nil
+}
+
+func (s *deadlineService) GetUserEvaluationWindow(userID, evaluationID uint) (*dto.DeadlineWindow, error) {
+	evaluation, err := s.store.Evaluations.Get(evaluationID)
+	if err != nil {
+		return nil, notFound(ErrEvaluationNotFound, err)
+	}
+	return s.GetEvaluationWindow(userID, evaluation)
+}
+
+// GetUpcomingDeadlines lists the modules, evaluations and cohort deadlines of the learner
+// that are not closed yet, soonest first. Evaluations already passed are left out.
+// A zero courseID covers every course the learner is enrolled in.
+func (s *deadlineService) GetUpcomingDeadlines(userID, courseID uint) ([]*dto.UpcomingDeadline, error) {
+	enrollments, err := s.store.Enrollments.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las inscripciones: %w", err)
+	}
+
+	enrollmentByCourse := make(map[uint]*models.Enrollment)
+	courseTitles := make(map[uint]string)
+	var courseIDs []uint
+	for _, enrollment := range enrollments {
+		if courseID != 0 && enrollment.CourseID != courseID {
+			continue
+		}
+		courseIDs = append(courseIDs, enrollment.CourseID)
+		enrollmentByCourse[enrollment.CourseID] = enrollment
+		if enrollment.Course != nil {
+			courseTitles[enrollment.CourseID] = enrollment.Course.Title
+		}
+	}
+	if courseID != 0 && len(courseIDs) == 0 {
+		return nil, ErrNotEnrolled
+	}
+
+	modules, err := s.store.Deadlines.GetCourseSchedule(courseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener el calendario: %w", err)
+	}
+
+	personal, err := s.store.Deadlines.GetUserOverrides(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las excepciones de fechas: %w", err)
+	}
+
+	overridesByCourse := make(map[uint]*deadlineOverrides)
+	deadlines := []*dto.UpcomingDeadline{}
+	for _, id := range courseIDs {
+		enrollment := enrollmentByCourse[id]
+		overrides := &deadlineOverrides{personal: personal}
+
+		var cohort *models.Cohort
+		if enrollment.CohortID != nil {
+			if cohort, err = s.store.Cohorts.Get(*enrollment.CohortID); err == nil {
+				overrides.cohort = cohort.Deadlines
+			} else {
+				cohort = nil
+			}
+		}
+		base := releaseBase(enrollment, cohort)
+		overrides.releaseBase = &base
+		overridesByCourse[id] = overrides
+	}
+
+	var evaluationIDs []uint
+	for _, module := range modules {
+		for _, evaluation := range module.Evaluations {
+			evaluationIDs = append(evaluationIDs, evaluation.ID)
+		}
+	}
+	passed, err := s.store.EvaluationAttempts.GetPassedEvaluationIDs(userID, evaluationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los intentos: %w", err)
+	}
+
+	now := time.Now()
+	for _, module := range modules {
+		overrides := overridesByCourse[module.CourseID]
+
+		window := overrides.moduleWindow(module)
+		if upcoming := newUpcomingDeadline(window, now); upcoming != nil {
+			upcoming.TargetType = enums.DeadlineTargetModule
+			upcoming.TargetID = module.ID
+			upcoming.Title = module.Title
+			upcoming.CourseID = module.CourseID
+			upcoming.CourseTitle = courseTitles[module.CourseID]
+			upcoming.ModuleID = module.ID
+			deadlines = append(deadlines, upcoming)
+		}
+
+		for _, evaluation := range module.Evaluations {
+			if passed[evaluation.ID] {
+				continue
+			}
+			window := overrides.evaluationWindow(module, evaluation)
+			if upcoming := newUpcomingDeadline(window, now); upcoming != nil {
+				upcoming.TargetType = enums.DeadlineTargetEvaluation
+				upcoming.TargetID = evaluation.ID
+				upcoming.Title = evaluation.Title
+				upcoming.CourseID = module.CourseID
+				upcoming.CourseTitle = courseTitles[module.CourseID]
+				upcoming.ModuleID = module.ID
+				deadlines = append(deadlines, upcoming)
+			}
+		}
+	}
+
+	// Cohort milestones that are not tied to a module or an evaluation
+	for _, id := range courseIDs {
+		for _, deadline := range overridesByCourse[id].cohort {
+			if deadline.ModuleID != nil || deadline.EvaluationID != nil {
+				continue
+			}
+			dueAt := deadline.DueAt
+			window := &dto.DeadlineWindow{DueAt: &dueAt}
+			if upcoming := newUpcomingDeadline(window, now); upcoming != nil {
+				upcoming.TargetType = enums.DeadlineTargetCohort
+				upcoming.TargetID = deadline.ID
+				upcoming.Title = deadline.Title
+				upcoming.CourseID = id
+				upcoming.CourseTitle = courseTitles[id]
+				deadlines = append(deadlines, upcoming)
+			}
+		}
+	}
+
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		return deadlines[i].DueAt.Before(*deadlines[j].DueAt)
+	})
+
+	return deadlines, nil
+}
+
+// CreateOverride gives a learner their own dates for a module or an evaluation,
+// e.g. an extension
+func (s *deadlineService) CreateOverride(actorID uint, request *dto.CreateDeadlineOverrideRequest) (*models.UserDeadlineOverride, error) {
+	if err := s.requireStaff(actorID); err != nil {
+		return nil, err
+	}
+
+	if (request.ModuleID == nil) == (request.EvaluationID == nil) {
+		return nil, ErrInvalidOverrideTarget
+	}
+
+	if _, err := s.store.Users.GetByID(request.UserID); err != nil {
+		return nil, notFound(ErrUserNotFound, err)
+	}
+	if request.ModuleID != nil {
+		if _, err := s.store.Modules.Get(*request.ModuleID); err != nil {
+			return nil, notFound(ErrModuleNotFound, err)
+		}
+	}
+	if request.EvaluationID != nil {
+		if _, err := s.store.Evaluations.Get(*request.EvaluationID); err != nil {
+			return nil, notFound(ErrEvaluationNotFound, err)
+		}
+	}
+
+	if err := validateDeadlineWindow(request.OpensAt, request.DueAt, request.CloseAt); err != nil {
+		return nil, err
+	}
+
+	override := &models.UserDeadlineOverride{
+		UserID:       request.UserID,
+		ModuleID:     request.ModuleID,
+		EvaluationID: request.EvaluationID,
+		OpensAt:      request.OpensAt,
+		DueAt:        request.DueAt,
+		CloseAt:      request.CloseAt,
+		Reason:       request.Reason,
+		CreatedByID:  actorID,
+	}
+
+	if err := s.store.Deadlines.CreateOverride(override); err != nil {
+		return nil, fmt.Errorf("error al crear la excepción de fechas: %w", err)
+	}
+
+	return override, nil
+}
+
+func (s *deadlineService) UpdateOverridePatch(id, actorID uint, data map[string]interface{}) (*models.UserDeadlineOverride, error) {
+	if err := s.requireStaff(actorID); err != nil {
+		return nil, err
+	}
+
+	var request dto.UpdateDeadlineOverrideRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	override, err := s.store.Deadlines.GetOverride(id)
+	if err != nil {
+		return nil, notFound(ErrOverrideNotFound, err)
+	}
+
+	// Explicit nulls clear a date so it is inherited again
+	changes := map[string]interface{}{}
+	if _, ok := data["opens_at"]; ok {
+		override.OpensAt = request.OpensAt
+		changes["opens_at"] = request.OpensAt
+	}
+	if _, ok := data["due_at"]; ok {
+		override.DueAt = request.DueAt
+		changes["due_at"] = request.DueAt
+	}
+	if _, ok := data["close_at"]; ok {
+		override.CloseAt = request.CloseAt
+		changes["close_at"] = request.CloseAt
+	}
+	if request.Reason != nil {
+		changes["reason"] = *request.Reason
+	}
+
+	if err := validateDeadlineWindow(override.OpensAt, override.DueAt, override.CloseAt); err != nil {
+		return nil, err
+	}
+
+	if len(changes) > 0 {
+		if err := s.store.Deadlines.PatchOverride(id, changes); err != nil {
+			return nil, fmt.Errorf("error al actualizar la excepción de fechas: %w", err)
+		}
+	}
+
+	return s.store.Deadlines.GetOverride(id)
+}
+
+func (s *deadlineService) DeleteOverride(id, actorID uint) error {
+	if err := s.requireStaff(actorID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.Deadlines.GetOverride(id); err != nil {
+		return notFound(ErrOverrideNotFound, err)
+	}
+
+	if err := s.store.Deadlines.DeleteOverride(id); err != nil {
+		return fmt.Errorf("error al eliminar la excepción de fechas: %w", err)
+	}
+	return nil
+}
+
+func (s *deadlineService) ListUserOverrides(userID, actorID uint, request *dto.ListRequest) (*dto.Page[*models.UserDeadlineOverride], error) {
+	if err := s.requireStaff(actorID); err != nil {
+		return nil, err
+	}
+	return s.store.Deadlines.ListOverridesByUser(userID, request)
+}
+
+// loadOverrides loads the cohort deadlines of the learner's cohort in the course and their
+// personal overrides
+func (s *deadlineService) loadOverrides(userID, courseID uint) (*deadlineOverrides, error) {
+	overrides := &deadlineOverrides{}
+
+	enrollment, err := s.store.Enrollments.GetUserEnrollment(userID, courseID)
+	if err == nil {
+		var cohort *models.Cohort
+		if enrollment.CohortID != nil {
+			if cohort, err = s.store.Cohorts.Get(*enrollment.CohortID); err != nil {
+				return nil, fmt.Errorf("error al obtener la cohorte: %w", err)
+			}
+			overrides.cohort = cohort.Deadlines
+		}
+		base := releaseBase(enrollment, cohort)
+		overrides.releaseBase = &base
+	}
+
+	personal, err := s.store.Deadlines.GetUserOverrides(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las excepciones de fechas: %w", err)
+	}
+	overrides.personal = personal
+
+	return overrides, nil
+}
+
+func (o *deadlineOverrides) moduleWindow(module *models.Module) *dto.DeadlineWindow {
+	window := &dto.DeadlineWindow{}
+	applyDeadlineLayer(window, module.OpensAt, module.DueAt, module.CloseAt)
+
+	for _, deadline := range o.cohort {
+		if deadline.ModuleID != nil && *deadline.ModuleID == module.ID && deadline.EvaluationID == nil {
+			dueAt := deadline.DueAt
+			applyDeadlineLayer(window, deadline.OpensAt, &dueAt, deadline.CloseAt)
+		}
+	}
+	for _, override := range o.personal {
+		if override.ModuleID != nil && *override.ModuleID == module.ID {
+			applyDeadlineLayer(window, override.OpensAt, override.DueAt, override.CloseAt)
+		}
+	}
+	o.applyRelease(window, module)
+	return window
+}
+
+func (o *deadlineOverrides) evaluationWindow(module *models.Module, evaluation *models.Evaluation) *dto.DeadlineWindow {
+	window := o.moduleWindow(module)
+	applyDeadlineLayer(window, evaluation.OpensAt, evaluation.DueAt, evaluation.CloseAt)
+
+	for _, deadline := range o.cohort {
+		if deadline.EvaluationID != nil && *deadline.EvaluationID == evaluation.ID {
+			dueAt := deadline.DueAt
+			applyDeadlineLayer(window, deadline.OpensAt, &dueAt, deadline.CloseAt)
+		}
+	}
+	for _, override := range o.personal {
+		if override.EvaluationID != nil && *override.EvaluationID == evaluation.ID {
+			applyDeadlineLayer(window, override.OpensAt, override.DueAt, override.CloseAt)
+		}
+	}
+	o.applyRelease(window, module)
+	return window
+}
+
+// applyRelease keeps the module and its evaluations from opening before the drip release
+func (o *deadlineOverrides) applyRelease(window *dto.DeadlineWindow, module *models.Module) {
+	if unlocksAt := moduleUnlocksAt(module, o.releaseBase); unlocksAt != nil && (window.OpensAt == nil || unlocksAt.After(*window.OpensAt)) {
+		window.OpensAt = unlocksAt
+	}
+}
+
+// applyDeadlineLayer overrides the dates the layer sets. When a layer moves the due date
+// without its own close date, the inherited late window keeps its length.
+func applyDeadlineLayer(window *dto.DeadlineWindow, opensAt, dueAt, closeAt *time.Time) {
+	if opensAt != nil {
+		window.OpensAt = opensAt
+	}
+	if dueAt != nil {
+		if closeAt == nil && window.CloseAt != nil && window.DueAt != nil {
+			shifted := dueAt.Add(window.CloseAt.Sub(*window.DueAt))
+			window.CloseAt = &shifted
+		}
+		window.DueAt = dueAt
+	}
+	if closeAt != nil {
+		window.CloseAt = closeAt
+	}
+}
+
+// deadlineStatus places the moment in the window: after the due date submissions are
+// late until the close date, and closed after it (or right away without a close date)
+func deadlineStatus(window *dto.DeadlineWindow, now time.Time) enums.DeadlineStatus {
+	if window.OpensAt != nil && now.Before(*window.OpensAt) {
+		return enums.DeadlineStatusNotOpen
+	}
+	if window.DueAt != nil && now.After(*window.DueAt) {
+		if window.CloseAt != nil && !now.After(*window.CloseAt) {
+			return enums.DeadlineStatusLate
+		}
+		return enums.DeadlineStatusClosed
+	}
+	if window.CloseAt != nil && now.After(*window.CloseAt) {
+		return enums.DeadlineStatusClosed
+	}
+	return enums.DeadlineStatusOpen
+}
+
+func newUpcomingDeadline(window *dto.DeadlineWindow, now time.Time) *dto.UpcomingDeadline {
+	if window.DueAt == nil {
+		return nil
+	}
+	window.Status = deadlineStatus(window, now)
+	if window.Status == enums.DeadlineStatusClosed {
+		return nil
+	}
+	return &dto.UpcomingDeadline{DeadlineWindow: *window}
+}
+
+// latePenaltyPercent returns the percentage of the score deducted for a submission made
+// after the due date, capped at 100
+func latePenaltyPercent(evaluation *models.Evaluation, dueAt *time.Time, submittedAt time.Time) float64 {
+	if dueAt == nil || !submittedAt.After(*dueAt) {
+		return 0
+	}
+
+	var penalty float64
+	switch evaluation.LatePenaltyPolicy {
+	case enums.LatePenaltyFlat:
+		penalty = evaluation.LatePenaltyPercent
+	case enums.LatePenaltyPerDay:
+		days := math.Ceil(submittedAt.Sub(*dueAt).Hours() / 24)
+		penalty = evaluation.LatePenaltyPercent * days
+	}
+	return math.Min(math.Max(penalty, 0), 100)
+}
+
+// validateDeadlineWindow checks that the dates that are set keep their order
+func validateDeadlineWindow(opensAt, dueAt, closeAt *time.Time) error {
+	if opensAt != nil && dueAt != nil && !opensAt.Before(*dueAt) {
+		return ErrInvalidDeadlineWindow
+	}
+	if dueAt != nil && closeAt != nil && closeAt.Before(*dueAt) {
+		return ErrInvalidDeadlineWindow
+	}
+	if opensAt != nil && closeAt != nil && !opensAt.Before(*closeAt) {
+		return ErrInvalidDeadlineWindow
+	}
+	return nil
+}
+
+// validateLatePenalty checks the late penalty policy of an evaluation
+func validateLatePenalty(policy enums.LatePenaltyPolicy, percent float64) error {
+	switch policy {
+	case "", enums.LatePenaltyNone, enums.LatePenaltyFlat, enums.LatePenaltyPerDay:
+	default:
+		return ErrInvalidLatePenalty
+	}
+	if percent < 0 || percent > 100 {
+		return ErrInvalidLatePenalty
+	}
+	return nil
+}
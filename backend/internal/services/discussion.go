@@ -0,0 +1,665 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrThreadLocked             = apperrors.New("THREAD_LOCKED", apperrors.KindConflict, "el hilo está cerrado y no admite nuevas respuestas", "the thread is locked and does not accept new replies")
+	ErrInvalidMention           = apperrors.New("INVALID_MENTION", apperrors.KindInvalid, "los usuarios mencionados deben participar en el curso", "mentioned users must take part in the course")
+	ErrInvalidDiscussionParent  = apperrors.New("INVALID_DISCUSSION_PARENT", apperrors.KindInvalid, "la respuesta a la que se responde no pertenece al hilo", "the reply being answered does not belong to the thread")
+	ErrInvalidDiscussionContent = apperrors.New("INVALID_DISCUSSION_CONTENT", apperrors.KindInvalid, "el contenido no pertenece al curso", "the content does not belong to the course")
+	ErrInvalidDiscussionAction  = apperrors.New("INVALID_DISCUSSION_ACTION", apperrors.KindInvalid, "acción de moderación inválida", "invalid moderation action")
+)
+
+type DiscussionService interface {
+	CreateThread(courseID, userID uint, request *dto.CreateDiscussionThreadRequest) (*models.DiscussionThread, error)
+	ListCourseThreads(courseID, userID uint, request *dto.ListRequest) (*dto.Page[*models.DiscussionThread], error)
+	ListContentThreads(contentID, userID uint, request *dto.ListRequest) (*dto.Page[*models.DiscussionThread], error)
+	GetThread(id, userID uint) (*models.DiscussionThread, error)
+	UpdateThreadPatch(id, userID uint, data map[string]interface{}) (*models.DiscussionThread, error)
+	DeleteThread(id, userID uint) error
+	ModerateThread(id, userID uint, request *dto.ModerateDiscussionRequest) (*models.DiscussionThread, error)
+	CreatePost(threadID, userID uint, request *dto.CreateDiscussionPostRequest) (*models.DiscussionPost, error)
+	UpdatePostPatch(id, userID uint, data map[string]interface{}) (*models.DiscussionPost, error)
+	DeletePost(id, userID uint) error
+	ModeratePost(id, userID uint, request *dto.ModerateDiscussionRequest) (*models.DiscussionPost, error)
+	SetPostEndorsed(id, userID uint, endorsed bool) (*models.DiscussionPost, error)
+	SetVote(targetType enums.DiscussionTargetType, targetID, userID uint, upvote bool) error
+}
+
+type discussionService struct {
+	*Service
+	enrollmentService   EnrollmentService
+	notificationService NotificationService
+}
+
+func NewDiscussionService(service *Service, enrollmentService EnrollmentService, notificationService NotificationService) DiscussionService {
+	return &discussionService{
+		Service:             service,
+		enrollmentService:   enrollmentService,
+		notificationService: notificationService,
+	}
+}
+
+func (s *discussionService) CreateThread(courseID, userID uint, request *dto.CreateDiscussionThreadRequest) (*models.DiscussionThread, error) {
+	if _, err := s.store.Courses.Get(courseID); err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	if _, err := s.authorize(userID, courseID); err != nil {
+		return nil, err
+	}
+
+	if request.ContentID != nil {
+		contentCourseID, err := s.contentCourseID(*request.ContentID)
+		if err != nil {
+			return nil, err
+		}
+		if contentCourseID != courseID {
+			return nil, ErrInvalidDiscussionContent
+		}
+	}
+
+	mentions, err := s.resolveMentions(courseID, request.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &models.DiscussionThread{
+		CourseID:       courseID,
+		ContentID:      request.ContentID,
+		AuthorID:       userID,
+		Title:          strings.TrimSpace(request.Title),
+		Body:           strings.TrimSpace(request.Body),
+		LastActivityAt: time.Now(),
+		Mentions:       mentions,
+	}
+
+	if err := s.store.Discussions.CreateThread(thread); err != nil {
+		return nil, fmt.Errorf("error al crear el hilo: %w", err)
+	}
+
+	go s.notifyMentions(mentions, userID, thread, "")
+
+	return s.store.Discussions.GetThread(thread.ID)
+}
+
+func (s *discussionService) ListCourseThreads(courseID, userID uint, request *dto.ListRequest) (*dto.Page[*models.DiscussionThread], error) {
+	isStaff, err := s.authorize(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.store.Discussions.ListThreadsByCourse(courseID, isStaff, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, s.markVotedThreads(userID, page.Items)
+}
+
+func (s *discussionService) ListContentThreads(contentID, userID uint, request *dto.ListRequest) (*dto.Page[*models.DiscussionThread], error) {
+	courseID, err := s.contentCourseID(contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	isStaff, err := s.authorize(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.store.Discussions.ListThreadsByContent(contentID, isStaff, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, s.markVotedThreads(userID, page.Items)
+}
+
+// GetThread returns the thread with its replies arranged as a tree. Endorsed replies come first;
+// the rest keep chronological order. Hidden replies keep their place but lose their body for learners.
+func (s *discussionService) GetThread(id, userID uint) (*models.DiscussionThread, error) {
+	thread, isStaff, err := s.getAccessibleThread(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.markVotedThreads(userID, []*models.DiscussionThread{thread}); err != nil {
+		return nil, err
+	}
+
+	posts, err := s.store.Discussions.GetPostsByThread(id)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las respuestas: %w", err)
+	}
+
+	postIDs := make([]uint, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+	voted, err := s.store.Discussions.GetVotedTargets(userID, enums.DiscussionTargetPost, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los votos: %w", err)
+	}
+
+	byID := make(map[uint]*models.DiscussionPost, len(posts))
+	for _, post := range posts {
+		post.Upvoted = voted[post.ID]
+		post.Replies = []*models.DiscussionPost{}
+		if post.IsHidden && !isStaff && post.AuthorID != userID {
+			post.Body = ""
+			post.Mentions = nil
+		}
+		byID[post.ID] = post
+	}
+
+	thread.Posts = []*models.DiscussionPost{}
+	for _, post := range posts {
+		if post.ParentID != nil {
+			if parent, ok := byID[*post.ParentID]; ok {
+				parent.Replies = append(parent.Replies, post)
+				continue
+			}
+		}
+		thread.Posts = append(thread.Posts, post)
+	}
+
+	sort.SliceStable(thread.Posts, func(i, j int) bool {
+		return thread.Posts[i].IsEndorsed && !thread.Posts[j].IsEndorsed
+	})
+
+	return thread, nil
+}
+
+func (s *discussionService) UpdateThreadPatch(id, userID uint, data map[string]interface{}) (*models.DiscussionThread, error) {
+	var request dto.UpdateDiscussionThreadRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	thread, isStaff, err := s.getAccessibleThread(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if thread.AuthorID != userID && !isStaff {
+		return nil, ErrForbidden
+	}
+	if thread.IsLocked && !isStaff {
+		return nil, ErrThreadLocked
+	}
+
+	changes := map[string]interface{}{
+		"edited_at": time.Now(),
+	}
+	if request.Title != nil {
+		changes["title"] = strings.TrimSpace(*request.Title)
+	}
+	if request.Body != nil {
+		changes["body"] = strings.TrimSpace(*request.Body)
+	}
+
+	var added []*models.User
+	if request.Mentions != nil {
+		mentions, err := s.resolveMentions(thread.CourseID, *request.Mentions)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.store.Discussions.ReplaceThreadMentions(id, mentions); err != nil {
+			return nil, fmt.Errorf("error al actualizar las menciones: %w", err)
+		}
+		added = newMentions(thread.Mentions, mentions)
+	}
+
+	if err := s.store.Discussions.PatchThread(id, changes); err != nil {
+		return nil, fmt.Errorf("error al actualizar el hilo: %w", err)
+	}
+
+	go s.notifyMentions(added, userID, thread, "")
+
+	return s.store.Discussions.GetThread(id)
+}
+
+// DeleteThread removes a thread with all its replies; authors delete their own and staff can delete any
+func (s *discussionService) DeleteThread(id, userID uint) error {
+	thread, isStaff, err := s.getAccessibleThread(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if thread.AuthorID != userID && !isStaff {
+		return ErrForbidden
+	}
+
+	if err := s.store.Discussions.DeleteThread(id); err != nil {
+		return fmt.Errorf("error al eliminar el hilo: %w", err)
+	}
+	return nil
+}
+
+func (s *discussionService) ModerateThread(id, userID uint, request *dto.ModerateDiscussionRequest) (*models.DiscussionThread, error) {
+	thread, err := s.store.Discussions.GetThread(id)
+	if err != nil {
+		return nil, notFound(ErrThreadNotFound, err)
+	}
+
+	isStaff, err := s.authorize(userID, thread.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if !isStaff {
+		return nil, ErrForbidden
+	}
+
+	fields := map[enums.DiscussionModerationAction]string{
+		enums.DiscussionActionPin:    "is_pinned",
+		enums.DiscussionActionUnpin:  "is_pinned",
+		enums.DiscussionActionLock:   "is_locked",
+		enums.DiscussionActionUnlock: "is_locked",
+		enums.DiscussionActionHide:   "is_hidden",
+		enums.DiscussionActionUnhide: "is_hidden",
+	}
+
+	field, ok := fields[request.Action]
+	if !ok {
+		return nil, ErrInvalidDiscussionAction
+	}
+
+	if err := s.store.Discussions.PatchThread(id, map[string]interface{}{field: moderationValue(request.Action)}); err != nil {
+		return nil, fmt.Errorf("error al moderar el hilo: %w", err)
+	}
+
+	return s.store.Discussions.GetThread(id)
+}
+
+// CreatePost adds a reply to the thread and notifies its participants and the mentioned users
+func (s *discussionService) CreatePost(threadID, userID uint, request *dto.CreateDiscussionPostRequest) (*models.DiscussionPost, error) {
+	thread, isStaff, err := s.getAccessibleThread(threadID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if thread.IsLocked && !isStaff {
+		return nil, ErrThreadLocked
+	}
+
+	var parent *models.DiscussionPost
+	if request.ParentID != nil {
+		parent, err = s.store.Discussions.GetPost(*request.ParentID)
+		if err != nil {
+			return nil, notFound(ErrAnswerNotFound, err)
+		}
+		if parent.ThreadID != threadID {
+			return nil, ErrInvalidDiscussionParent
+		}
+	}
+
+	mentions, err := s.resolveMentions(thread.CourseID, request.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	post := &models.DiscussionPost{
+		ThreadID: threadID,
+		ParentID: request.ParentID,
+		AuthorID: userID,
+		Body:     strings.TrimSpace(request.Body),
+		Mentions: mentions,
+	}
+
+	if err := s.store.Discussions.CreatePost(post); err != nil {
+		return nil, fmt.Errorf("error al crear la respuesta: %w", err)
+	}
+
+	go s.notifyReply(thread, parent, post, mentions)
+
+	return s.store.Discussions.GetPost(post.ID)
+}
+
+func (s *discussionService) UpdatePostPatch(id, userID uint, data map[string]interface{}) (*models.DiscussionPost, error) {
+	var request dto.UpdateDiscussionPostRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	post, err := s.store.Discussions.GetPost(id)
+	if err != nil {
+		return nil, notFound(ErrAnswerNotFound, err)
+	}
+
+	thread, isStaff, err := s.getAccessibleThread(post.ThreadID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.AuthorID != userID && !isStaff {
+		return nil, ErrForbidden
+	}
+	if thread.IsLocked && !isStaff {
+		return nil, ErrThreadLocked
+	}
+
+	changes := map[string]interface{}{
+		"edited_at": time.Now(),
+	}
+	if request.Body != nil {
+		changes["body"] = strings.TrimSpace(*request.Body)
+	}
+
+	var added []*models.User
+	if request.Mentions != nil {
+		mentions, err := s.resolveMentions(thread.CourseID, *request.Mentions)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.store.Discussions.ReplacePostMentions(id, mentions); err != nil {
+			return nil, fmt.Errorf("error al actualizar las menciones: %w", err)
+		}
+		added = newMentions(post.Mentions, mentions)
+	}
+
+	if err := s.store.Discussions.PatchPost(id, changes); err != nil {
+		return nil, fmt.Errorf("error al actualizar la respuesta: %w", err)
+	}
+
+	go s.notifyMentions(added, userID, thread, " en una respuesta")
+
+	return s.store.Discussions.GetPost(id)
+}
+
+// DeletePost removes a reply together with its nested replies
+func (s *discussionService) DeletePost(id, userID uint) error {
+	post, err := s.store.Discussions.GetPost(id)
+	if err != nil {
+		return notFound(ErrAnswerNotFound, err)
+	}
+
+	_, isStaff, err := s.getAccessibleThread(post.ThreadID, userID)
+	if err != nil {
+		return err
+	}
+
+	if post.AuthorID != userID && !isStaff {
+		return ErrForbidden
+	}
+
+	if err := s.store.Discussions.DeletePost(id); err != nil {
+		return fmt.Errorf("error al eliminar la respuesta: %w", err)
+	}
+	return nil
+}
+
+func (s *discussionService) ModeratePost(id, userID uint, request *dto.ModerateDiscussionRequest) (*models.DiscussionPost, error) {
+	if request.Action != enums.DiscussionActionHide && request.Action != enums.DiscussionActionUnhide {
+		return nil, ErrInvalidDiscussionAction
+	}
+
+	post, err := s.getStaffPost(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Discussions.PatchPost(post.ID, map[string]interface{}{"is_hidden": moderationValue(request.Action)}); err != nil {
+		return nil, fmt.Errorf("error al moderar la respuesta: %w", err)
+	}
+
+	return s.store.Discussions.GetPost(id)
+}
+
+// SetPostEndorsed marks a reply as the answer endorsed by the course staff
+func (s *discussionService) SetPostEndorsed(id, userID uint, endorsed bool) (*models.DiscussionPost, error) {
+	post, err := s.getStaffPost(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Discussions.SetEndorsed(post.ID, endorsed, userID); err != nil {
+		return nil, fmt.Errorf("error al avalar la respuesta: %w", err)
+	}
+
+	if endorsed && !post.IsEndorsed && post.AuthorID != userID {
+		s.notificationService.DispatchNotification(
+			post.AuthorID,
+			"Respuesta avalada",
+			"Un instructor avaló tu respuesta en una discusión",
+			string(enums.NotificationTypeDiscussion),
+		)
+	}
+
+	return s.store.Discussions.GetPost(id)
+}
+
+// SetVote adds or withdraws the upvote of the user on a thread or a reply
+func (s *discussionService) SetVote(targetType enums.DiscussionTargetType, targetID, userID uint, upvote bool) error {
+	threadID := targetID
+	switch targetType {
+	case enums.DiscussionTargetThread:
+	case enums.DiscussionTargetPost:
+		post, err := s.store.Discussions.GetPost(targetID)
+		if err != nil {
+			return notFound(ErrAnswerNotFound, err)
+		}
+		threadID = post.ThreadID
+	default:
+		return ErrInvalidDiscussionAction
+	}
+
+	if _, _, err := s.getAccessibleThread(threadID, userID); err != nil {
+		return err
+	}
+
+	if !upvote {
+		return s.store.Discussions.RemoveVote(userID, targetType, targetID)
+	}
+
+	vote := &models.DiscussionVote{
+		UserID:     userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+	}
+	if err := s.store.Discussions.AddVote(vote); err != nil {
+		return fmt.Errorf("error al registrar el voto: %w", err)
+	}
+	return nil
+}
+
+// authorize checks that the user takes part in the course discussions: staff or enrolled learners
+func (s *discussionService) authorize(userID, courseID uint) (bool, error) {
+	isStaff, err := s.userHasRole(userID, enums.UserRoleInstructor, enums.UserRoleAdmin)
+	if err != nil {
+		return false, err
+	}
+	if isStaff {
+		return true, nil
+	}
+
+	if _, err := s.enrollmentService.GetUserCourseEnrollment(userID, courseID); err != nil {
+		return false, ErrNotEnrolled
+	}
+	return false, nil
+}
+
+// getAccessibleThread loads the thread when the user can take part in it. Hidden threads
+// are only visible to the staff and their author.
+func (s *discussionService) getAccessibleThread(id, userID uint) (*models.DiscussionThread, bool, error) {
+	thread, err := s.store.Discussions.GetThread(id)
+	if err != nil {
+		return nil, false, notFound(ErrThreadNotFound, err)
+	}
+
+	isStaff, err := s.authorize(userID, thread.CourseID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if thread.IsHidden && !isStaff && thread.AuthorID != userID {
+		return nil, false, notFound(ErrThreadNotFound, gorm.ErrRecordNotFound)
+	}
+
+	return thread, isStaff, nil
+}
+
+func (s *discussionService) getStaffPost(id, userID uint) (*models.DiscussionPost, error) {
+	post, err := s.store.Discussions.GetPost(id)
+	if err != nil {
+		return nil, notFound(ErrAnswerNotFound, err)
+	}
+
+	thread, err := s.store.Discussions.GetThread(post.ThreadID)
+	if err != nil {
+		return nil, notFound(ErrThreadNotFound, err)
+	}
+
+	isStaff, err := s.authorize(userID, thread.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if !isStaff {
+		return nil, ErrForbidden
+	}
+
+	return post, nil
+}
+
+func (s *discussionService) contentCourseID(contentID uint) (uint, error) {
+	content, err := s.store.Contents.Get(contentID)
+	if err != nil {
+		return 0, notFound(ErrContentNotFound, err)
+	}
+
+	module, err := s.store.Modules.Get(content.ModuleID)
+	if err != nil {
+		return 0, notFound(ErrModuleNotFound, err)
+	}
+
+	return module.CourseID, nil
+}
+
+// resolveMentions loads the mentioned users, all of which must take part in the course
+func (s *discussionService) resolveMentions(courseID uint, userIDs []uint) ([]*models.User, error) {
+	unique := make(map[uint]bool, len(userIDs))
+	ids := make([]uint, 0, len(userIDs))
+	for _, id := range userIDs {
+		if !unique[id] {
+			unique[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	users, err := s.store.Discussions.GetCourseParticipants(courseID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error al verificar las menciones: %w", err)
+	}
+	if len(users) != len(ids) {
+		return nil, ErrInvalidMention
+	}
+	return users, nil
+}
+
+func (s *discussionService) markVotedThreads(userID uint, threads []*models.DiscussionThread) error {
+	ids := make([]uint, len(threads))
+	for i, thread := range threads {
+		ids[i] = thread.ID
+	}
+
+	voted, err := s.store.Discussions.GetVotedTargets(userID, enums.DiscussionTargetThread, ids)
+	if err != nil {
+		return fmt.Errorf("error al obtener los votos: %w", err)
+	}
+
+	for _, thread := range threads {
+		thread.Upvoted = voted[thread.ID]
+	}
+	return nil
+}
+
+// notifyReply tells the thread participants about a new reply. Mentioned users get
+// the mention notification instead, and the author of the reply is never notified.
+func (s *discussionService) notifyReply(thread *models.DiscussionThread, parent *models.DiscussionPost, post *models.DiscussionPost, mentions []*models.User) {
+	s.notifyMentions(mentions, post.AuthorID, thread, " en una respuesta")
+
+	skip := map[uint]bool{post.AuthorID: true}
+	for _, user := range mentions {
+		skip[user.ID] = true
+	}
+
+	if parent != nil && !skip[parent.AuthorID] {
+		skip[parent.AuthorID] = true
+		s.notificationService.DispatchNotification(
+			parent.AuthorID,
+			"Nueva respuesta",
+			fmt.Sprintf("Respondieron tu mensaje en \"%s\"", thread.Title),
+			string(enums.NotificationTypeDiscussion),
+		)
+	}
+
+	participants, err := s.store.Discussions.GetThreadParticipantIDs(thread.ID)
+	if err != nil {
+		s.logger.Warnf("Failed to get participants of discussion thread %d: %v", thread.ID, err)
+		return
+	}
+
+	for _, participantID := range participants {
+		if skip[participantID] {
+			continue
+		}
+		s.notificationService.DispatchNotification(
+			participantID,
+			"Nueva respuesta",
+			fmt.Sprintf("Hay una nueva respuesta en \"%s\"", thread.Title),
+			string(enums.NotificationTypeDiscussion),
+		)
+	}
+}
+
+func (s *discussionService) notifyMentions(users []*models.User, authorID uint, thread *models.DiscussionThread, where string) {
+	for _, user := range users {
+		if user.ID == authorID {
+			continue
+		}
+		s.notificationService.DispatchNotification(
+			user.ID,
+			"Te mencionaron",
+			fmt.Sprintf("Te mencionaron%s en \"%s\"", where, thread.Title),
+			string(enums.NotificationTypeDiscussion),
+		)
+	}
+}
+
+// newMentions returns the users in next that were not mentioned before
+func newMentions(previous, next []*models.User) []*models.User {
+	seen := make(map[uint]bool, len(previous))
+	for _, user := range previous {
+		seen[user.ID] = true
+	}
+
+	var added []*models.User
+	for _, user := range next {
+		if !seen[user.ID] {
+			added = append(added, user)
+		}
+	}
+	return added
+}
+
+// moderationValue tells whether the action turns its flag on or off
+func moderationValue(action enums.DiscussionModerationAction) bool {
+	switch action {
+	case enums.DiscussionActionPin, enums.DiscussionActionLock, enums.DiscussionActionHide:
+		return true
+	default:
+		return false
+	}
+}
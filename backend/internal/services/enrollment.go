@@ -1,15 +1,18 @@
 package services
 
 import (
-	"errors"
 	"fmt"
 	"time"
 
+	"github.com/imlargo/go-api-template/internal/apperrors"
 	"github.com/imlargo/go-api-template/internal/dto"
 	"github.com/imlargo/go-api-template/internal/models"
 	"github.com/imlargo/go-api-template/pkg/utils"
+	"gorm.io/gorm"
 )
 
+var ErrAlreadyEnrolled = apperrors.New("ALREADY_ENROLLED", apperrors.KindConflict, "el usuario ya está inscrito en este curso", "the user is already enrolled in this course")
+
 type EnrollmentService interface {
 	CreateEnrollment(userID, courseID uint) (*models.Enrollment, error)
 	GetEnrollment(id uint) (*models.Enrollment, error)
@@ -19,10 +22,15 @@ type EnrollmentService interface {
 	DeleteEnrollment(id uint) error
 	GetUserEnrollments(userID uint) ([]*models.Enrollment, error)
 	GetCourseEnrollments(courseID uint) ([]*models.Enrollment, error)
+	ListUserEnrollments(userID uint, request *dto.ListRequest) (*dto.Page[*models.Enrollment], error)
+	ListCourseEnrollments(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Enrollment], error)
 	GetUserCourseEnrollment(userID, courseID uint) (*models.Enrollment, error)
 	CompleteEnrollment(userID, courseID uint) error
 	UpdateProgress(userID, courseID uint, progress float64) error
-	GetCourseKPIs(courseID uint) (*dto.CourseKPIResponse, error)
+	GetCourseKPIs(courseID uint, cohortID uint) (*dto.CourseKPIResponse, error)
+	GetCohortKPIs(courseID uint) ([]*dto.CourseKPIResponse, error)
+	EnrollCohort(cohortID, actorID uint, userIDs []uint) (*dto.CohortEnrollmentResult, error)
+	RemoveFromCohort(cohortID, actorID, userID uint) error
 }
 
 type enrollmentService struct {
@@ -39,19 +47,19 @@ func (s *enrollmentService) CreateEnrollment(userID, courseID uint) (*models.Enr
 	// Verify user exists
 	_, err := s.store.Users.GetByID(userID)
 	if err != nil {
-		return nil, fmt.Errorf("usuario no encontrado: %w", err)
+		return nil, notFound(ErrUserNotFound, err)
 	}
 
 	// Verify course exists
 	_, err = s.store.Courses.Get(courseID)
 	if err != nil {
-		return nil, fmt.Errorf("curso no encontrado: %w", err)
+		return nil, notFound(ErrCourseNotFound, err)
 	}
 
 	// Check if enrollment already exists
 	existing, _ := s.GetUserCourseEnrollment(userID, courseID)
 	if existing != nil {
-		return nil, fmt.Errorf("el usuario ya está inscrito en este curso")
+		return nil, ErrAlreadyEnrolled
 	}
 
 	enrollment := &models.Enrollment{
@@ -77,7 +85,7 @@ func (s *enrollmentService) CreateEnrollment(userID, courseID uint) (*models.Enr
 func (s *enrollmentService) GetEnrollment(id uint) (*models.Enrollment, error) {
 	enrollment, err := s.store.Enrollments.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("inscripción no encontrada: %w", err)
+		return nil, notFound(ErrEnrollmentNotFound, err)
 	}
 	return enrollment, nil
 }
@@ -85,7 +93,7 @@ func (s *enrollmentService) GetEnrollment(id uint) (*models.Enrollment, error) {
 func (s *enrollmentService) GetEnrollmentWithPreloads(id uint) (*models.Enrollment, error) {
 	enrollment, err := s.store.Enrollments.GetWithPreloads(id)
 	if err != nil {
-		return nil, fmt.Errorf("inscripción no encontrada: %w", err)
+		return nil, notFound(ErrEnrollmentNotFound, err)
 	}
 	return enrollment, nil
 }
@@ -93,7 +101,7 @@ func (s *enrollmentService) GetEnrollmentWithPreloads(id uint) (*models.Enrollme
 func (s *enrollmentService) UpdateEnrollment(id uint, enrollmentData *models.Enrollment) (*models.Enrollment, error) {
 	existingEnrollment, err := s.store.Enrollments.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("inscripción no encontrada: %w", err)
+		return nil, notFound(ErrEnrollmentNotFound, err)
 	}
 
 	// Update fields
@@ -109,12 +117,12 @@ func (s *enrollmentService) UpdateEnrollment(id uint, enrollmentData *models.Enr
 
 func (s *enrollmentService) UpdateEnrollmentPatch(enrollmentID uint, data map[string]interface{}) (*models.Enrollment, error) {
 	if enrollmentID == 0 {
-		return nil, errors.New("el ID de inscripción no puede ser cero")
+		return nil, ErrInvalidID
 	}
 
 	var enrollment dto.UpdateEnrollmentRequest
 	if err := utils.MapToStructStrict(data, &enrollment); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
 	}
 
 	if err := s.store.Enrollments.Patch(enrollmentID, data); err != nil {
@@ -123,7 +131,7 @@ func (s *enrollmentService) UpdateEnrollmentPatch(enrollmentID uint, data map[st
 
 	updated, err := s.store.Enrollments.Get(enrollmentID)
 	if err != nil {
-		return nil, errors.New("inscripción no encontrada")
+		return nil, notFound(ErrEnrollmentNotFound, err)
 	}
 
 	return updated, nil
@@ -171,6 +179,22 @@ func (s *enrollmentService) GetCourseEnrollments(courseID uint) ([]*models.Enrol
 	return enrollments, nil
 }
 
+func (s *enrollmentService) ListUserEnrollments(userID uint, request *dto.ListRequest) (*dto.Page[*models.Enrollment], error) {
+	page, err := s.store.Enrollments.ListByUserID(userID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las inscripciones: %w", err)
+	}
+	return page, nil
+}
+
+func (s *enrollmentService) ListCourseEnrollments(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Enrollment], error) {
+	page, err := s.store.Enrollments.ListByCourseID(courseID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las inscripciones: %w", err)
+	}
+	return page, nil
+}
+
 func (s *enrollmentService) GetUserCourseEnrollment(userID, courseID uint) (*models.Enrollment, error) {
 	// This would require a repository method to filter by both user ID and course ID
 	enrollment, err := s.store.Enrollments.GetUserEnrollment(userID, courseID)
@@ -184,7 +208,7 @@ func (s *enrollmentService) GetUserCourseEnrollment(userID, courseID uint) (*mod
 func (s *enrollmentService) CompleteEnrollment(userID, courseID uint) error {
 	enrollment, err := s.GetUserCourseEnrollment(userID, courseID)
 	if err != nil {
-		return fmt.Errorf("inscripción no encontrada: %w", err)
+		return notFound(ErrEnrollmentNotFound, err)
 	}
 
 	now := time.Now()
@@ -201,7 +225,7 @@ func (s *enrollmentService) CompleteEnrollment(userID, courseID uint) error {
 func (s *enrollmentService) UpdateProgress(userID, courseID uint, progress float64) error {
 	enrollment, err := s.GetUserCourseEnrollment(userID, courseID)
 	if err != nil {
-		return fmt.Errorf("inscripción no encontrada: %w", err)
+		return notFound(ErrEnrollmentNotFound, err)
 	}
 
 	// Validate progress value
@@ -226,17 +250,128 @@ func (s *enrollmentService) UpdateProgress(userID, courseID uint, progress float
 	return nil
 }
 
-func (s *enrollmentService) GetCourseKPIs(courseID uint) (*dto.CourseKPIResponse, error) {
-	studentCount, completionRate, avgProgress, courseTitle, err := s.store.Enrollments.GetCourseKPIs(courseID)
+// GetCourseKPIs returns the course metrics; a non-zero cohortID restricts them to the cohort
+func (s *enrollmentService) GetCourseKPIs(courseID uint, cohortID uint) (*dto.CourseKPIResponse, error) {
+	var cohort *models.Cohort
+	if cohortID != 0 {
+		var err error
+		cohort, err = s.store.Cohorts.Get(cohortID)
+		if err != nil || cohort.CourseID != courseID {
+			return nil, notFound(ErrCohortNotFound, gorm.ErrRecordNotFound)
+		}
+	}
+
+	studentCount, completionRate, avgProgress, courseTitle, err := s.store.Enrollments.GetCourseKPIs(courseID, cohortID)
 	if err != nil {
 		return nil, fmt.Errorf("error al obtener KPIs del curso: %w", err)
 	}
 
-	return &dto.CourseKPIResponse{
+	kpis := &dto.CourseKPIResponse{
 		CourseID:        courseID,
 		CourseTitle:     courseTitle,
 		StudentCount:    studentCount,
 		CompletionRate:  completionRate,
 		AverageProgress: avgProgress,
-	}, nil
+	}
+	if cohort != nil {
+		kpis.CohortID = &cohort.ID
+		kpis.CohortName = cohort.Name
+	}
+
+	return kpis, nil
+}
+
+// GetCohortKPIs returns the course metrics of every cohort of the course
+func (s *enrollmentService) GetCohortKPIs(courseID uint) ([]*dto.CourseKPIResponse, error) {
+	cohorts, err := s.store.Cohorts.GetAllByCourse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las cohortes: %w", err)
+	}
+
+	report := make([]*dto.CourseKPIResponse, 0, len(cohorts))
+	for _, cohort := range cohorts {
+		studentCount, completionRate, avgProgress, courseTitle, err := s.store.Enrollments.GetCourseKPIs(courseID, cohort.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error al obtener KPIs de la cohorte: %w", err)
+		}
+
+		report = append(report, &dto.CourseKPIResponse{
+			CourseID:        courseID,
+			CourseTitle:     courseTitle,
+			CohortID:        &cohort.ID,
+			CohortName:      cohort.Name,
+			StudentCount:    studentCount,
+			CompletionRate:  completionRate,
+			AverageProgress: avgProgress,
+		})
+	}
+
+	return report, nil
+}
+
+// EnrollCohort enrolls every user in the cohort's course and assigns them to the cohort.
+// Users already enrolled in the course are moved to the cohort; failures are reported
+// per user without aborting the rest.
+func (s *enrollmentService) EnrollCohort(cohortID, actorID uint, userIDs []uint) (*dto.CohortEnrollmentResult, error) {
+	if err := s.requireStaff(actorID); err != nil {
+		return nil, err
+	}
+
+	cohort, err := s.store.Cohorts.Get(cohortID)
+	if err != nil {
+		return nil, notFound(ErrCohortNotFound, err)
+	}
+
+	result := &dto.CohortEnrollmentResult{
+		CohortID: cohortID,
+		Failed:   []*dto.CohortEnrollmentFailure{},
+	}
+
+	seen := make(map[uint]bool)
+	members := make([]uint, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if existing, _ := s.GetUserCourseEnrollment(userID, cohort.CourseID); existing != nil {
+			result.Assigned++
+		} else {
+			if _, err := s.CreateEnrollment(userID, cohort.CourseID); err != nil {
+				result.Failed = append(result.Failed, &dto.CohortEnrollmentFailure{UserID: userID, Error: err.Error()})
+				continue
+			}
+			result.Enrolled++
+		}
+		members = append(members, userID)
+	}
+
+	if err := s.store.Enrollments.SetCohort(cohort.CourseID, members, &cohort.ID); err != nil {
+		return nil, fmt.Errorf("error al asignar la cohorte: %w", err)
+	}
+
+	return result, nil
+}
+
+// RemoveFromCohort takes the learner out of the cohort; the course enrollment is kept
+func (s *enrollmentService) RemoveFromCohort(cohortID, actorID, userID uint) error {
+	if err := s.requireStaff(actorID); err != nil {
+		return err
+	}
+
+	cohort, err := s.store.Cohorts.Get(cohortID)
+	if err != nil {
+		return notFound(ErrCohortNotFound, err)
+	}
+
+	enrollment, err := s.GetUserCourseEnrollment(userID, cohort.CourseID)
+	if err != nil || enrollment.CohortID == nil || *enrollment.CohortID != cohortID {
+		return fmt.Errorf("el usuario no pertenece a la cohorte: %w", gorm.ErrRecordNotFound)
+	}
+
+	if err := s.store.Enrollments.SetCohort(cohort.CourseID, []uint{userID}, nil); err != nil {
+		return fmt.Errorf("error al retirar de la cohorte: %w", err)
+	}
+	return nil
 }
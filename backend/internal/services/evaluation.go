@@ -1,61 +1,79 @@
 package services
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"github.com/imlargo/go-api-template/pkg/utils"
 )
 
 type EvaluationService interface {
-	CreateEvaluation(evaluation *models.Evaluation) (*models.Evaluation, error)
-	GetEvaluation(id uint) (*models.Evaluation, error)
-	UpdateEvaluation(id uint, evaluation *models.Evaluation) (*models.Evaluation, error)
-	UpdateEvaluationPatch(id uint, data map[string]interface{}) (*models.Evaluation, error)
+	CreateEvaluation(evaluation *models.Evaluation, authorID uint) (*models.Evaluation, error)
+	GetEvaluation(id, userID uint, locale dto.RequestLocale) (*models.Evaluation, error)
+	UpdateEvaluation(id uint, evaluation *models.Evaluation, authorID uint) (*models.Evaluation, error)
+	UpdateEvaluationPatch(id uint, data map[string]interface{}, authorID uint) (*models.Evaluation, error)
 	DeleteEvaluation(id uint) error
 	GetEvaluationsByModule(moduleID uint) ([]*models.Evaluation, error)
+	ListEvaluationsByModule(moduleID, userID uint, locale dto.RequestLocale, request *dto.ListRequest) (*dto.Page[*models.Evaluation], error)
 	GetEvaluationWithQuestions(id uint) (*models.Evaluation, error)
 }
 
 type evaluationService struct {
 	*Service
+	revisionService    RevisionService
+	translationService TranslationService
 }
 
-func NewEvaluationService(service *Service) EvaluationService {
+func NewEvaluationService(service *Service, revisionService RevisionService, translationService TranslationService) EvaluationService {
 	return &evaluationService{
-		Service: service,
+		Service:            service,
+		revisionService:    revisionService,
+		translationService: translationService,
 	}
 }
 
-func (s *evaluationService) CreateEvaluation(evaluation *models.Evaluation) (*models.Evaluation, error) {
+func (s *evaluationService) CreateEvaluation(evaluation *models.Evaluation, authorID uint) (*models.Evaluation, error) {
 	// Verify module exists
 	_, err := s.store.Modules.Get(evaluation.ModuleID)
 	if err != nil {
-		return nil, fmt.Errorf("módulo no encontrado: %w", err)
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	if err := validateEvaluationSchedule(evaluation); err != nil {
+		return nil, err
 	}
 
 	if err := s.store.Evaluations.Create(evaluation); err != nil {
 		return nil, fmt.Errorf("error al crear la evaluación: %w", err)
 	}
+
+	s.recordRevision(evaluation.ID, authorID, nil, evaluationSnapshot(evaluation))
 	return evaluation, nil
 }
 
-func (s *evaluationService) GetEvaluation(id uint) (*models.Evaluation, error) {
+// GetEvaluation returns the evaluation in the locale resolved for the request
+func (s *evaluationService) GetEvaluation(id, userID uint, locale dto.RequestLocale) (*models.Evaluation, error) {
 	evaluation, err := s.store.Evaluations.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("evaluación no encontrada: %w", err)
+		return nil, notFound(ErrEvaluationNotFound, err)
+	}
+
+	if err := s.localize(evaluation.ModuleID, userID, locale, evaluation); err != nil {
+		return nil, err
 	}
 	return evaluation, nil
 }
 
-func (s *evaluationService) UpdateEvaluation(id uint, evaluationData *models.Evaluation) (*models.Evaluation, error) {
+func (s *evaluationService) UpdateEvaluation(id uint, evaluationData *models.Evaluation, authorID uint) (*models.Evaluation, error) {
 	existingEvaluation, err := s.store.Evaluations.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("evaluación no encontrada: %w", err)
+		return nil, notFound(ErrEvaluationNotFound, err)
 	}
 
+	before := evaluationSnapshot(existingEvaluation)
+
 	// Update fields
 	existingEvaluation.Title = evaluationData.Title
 	existingEvaluation.Description = evaluationData.Description
@@ -65,22 +83,63 @@ func (s *evaluationService) UpdateEvaluation(id uint, evaluationData *models.Eva
 	existingEvaluation.MaxAttempts = evaluationData.MaxAttempts
 	existingEvaluation.TimeLimit = evaluationData.TimeLimit
 	existingEvaluation.Type = evaluationData.Type
+	existingEvaluation.OpensAt = evaluationData.OpensAt
+	existingEvaluation.DueAt = evaluationData.DueAt
+	existingEvaluation.CloseAt = evaluationData.CloseAt
+	existingEvaluation.LatePenaltyPolicy = evaluationData.LatePenaltyPolicy
+	existingEvaluation.LatePenaltyPercent = evaluationData.LatePenaltyPercent
+	existingEvaluation.ScoringPolicy = evaluationData.ScoringPolicy
+
+	if err := validateEvaluationSchedule(existingEvaluation); err != nil {
+		return nil, err
+	}
 
 	if err := s.store.Evaluations.Update(existingEvaluation); err != nil {
 		return nil, fmt.Errorf("error al actualizar la evaluación: %w", err)
 	}
 
+	s.recordRevision(id, authorID, before, evaluationSnapshot(existingEvaluation))
+
 	return existingEvaluation, nil
 }
 
-func (s *evaluationService) UpdateEvaluationPatch(evaluationID uint, data map[string]interface{}) (*models.Evaluation, error) {
+func (s *evaluationService) UpdateEvaluationPatch(evaluationID uint, data map[string]interface{}, authorID uint) (*models.Evaluation, error) {
 	if evaluationID == 0 {
-		return nil, errors.New("el ID de la evaluación no puede ser cero")
+		return nil, ErrInvalidID
 	}
 
 	var evaluation dto.UpdateEvaluationRequest
 	if err := utils.MapToStructStrict(data, &evaluation); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
+	}
+
+	existing, err := s.store.Evaluations.Get(evaluationID)
+	if err != nil {
+		return nil, notFound(ErrEvaluationNotFound, err)
+	}
+	before := evaluationSnapshot(existing)
+
+	// Validate the dates and late policy as they will be stored; explicit nulls clear a date
+	if _, ok := data["opens_at"]; ok {
+		existing.OpensAt = evaluation.OpensAt
+	}
+	if _, ok := data["due_at"]; ok {
+		existing.DueAt = evaluation.DueAt
+	}
+	if _, ok := data["close_at"]; ok {
+		existing.CloseAt = evaluation.CloseAt
+	}
+	if evaluation.LatePenaltyPolicy != nil {
+		existing.LatePenaltyPolicy = *evaluation.LatePenaltyPolicy
+	}
+	if evaluation.LatePenaltyPercent != nil {
+		existing.LatePenaltyPercent = *evaluation.LatePenaltyPercent
+	}
+	if evaluation.ScoringPolicy != nil {
+		existing.ScoringPolicy = *evaluation.ScoringPolicy
+	}
+	if err := validateEvaluationSchedule(existing); err != nil {
+		return nil, err
 	}
 
 	if err := s.store.Evaluations.Patch(evaluationID, data); err != nil {
@@ -89,9 +148,11 @@ func (s *evaluationService) UpdateEvaluationPatch(evaluationID uint, data map[st
 
 	updated, err := s.store.Evaluations.Get(evaluationID)
 	if err != nil {
-		return nil, errors.New("evaluación no encontrada")
+		return nil, notFound(ErrEvaluationNotFound, err)
 	}
 
+	s.recordRevision(evaluationID, authorID, before, evaluationSnapshot(updated))
+
 	return updated, nil
 }
 
@@ -112,12 +173,63 @@ func (s *evaluationService) GetEvaluationsByModule(moduleID uint) ([]*models.Eva
 	return evaluations, nil
 }
 
+func (s *evaluationService) ListEvaluationsByModule(moduleID, userID uint, locale dto.RequestLocale, request *dto.ListRequest) (*dto.Page[*models.Evaluation], error) {
+	page, err := s.store.Evaluations.ListByModuleID(moduleID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las evaluaciones: %w", err)
+	}
+
+	if err := s.localize(moduleID, userID, locale, page.Items...); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// localize translates evaluations of the module to the locale resolved for the request
+func (s *evaluationService) localize(moduleID, userID uint, locale dto.RequestLocale, evaluations ...*models.Evaluation) error {
+	module, err := s.store.Modules.Get(moduleID)
+	if err != nil {
+		return notFound(ErrModuleNotFound, err)
+	}
+
+	resolved, err := s.translationService.ResolveCourseLocale(userID, module.CourseID, locale)
+	if err != nil {
+		return err
+	}
+	return s.translationService.LocalizeEvaluations(resolved, evaluations...)
+}
+
 func (s *evaluationService) GetEvaluationWithQuestions(id uint) (*models.Evaluation, error) {
 	// Use the new repository method to preload questions
 	evaluation, err := s.store.Evaluations.GetWithQuestions(id)
 	if err != nil {
-		return nil, fmt.Errorf("evaluación no encontrada: %w", err)
+		return nil, notFound(ErrEvaluationNotFound, err)
 	}
 
 	return evaluation, nil
 }
+
+// recordRevision saves the change in the revision history; a failure never undoes the edit
+func (s *evaluationService) recordRevision(id, authorID uint, before, after models.RevisionSnapshot) {
+	if err := s.revisionService.Record(enums.RevisionEntityEvaluation, id, authorID, before, after); err != nil {
+		s.logger.Warnf("failed to record revision for evaluation %d: %v", id, err)
+	}
+}
+
+// validateEvaluationSchedule checks the availability dates and the late penalty and
+// scoring policies
+func validateEvaluationSchedule(evaluation *models.Evaluation) error {
+	if evaluation.LatePenaltyPolicy == "" {
+		evaluation.LatePenaltyPolicy = enums.LatePenaltyNone
+	}
+	if evaluation.ScoringPolicy == "" {
+		evaluation.ScoringPolicy = enums.ScoringAllOrNothing
+	}
+	if !isValidScoringPolicy(evaluation.ScoringPolicy) {
+		return invalidScoringPolicy(evaluation.ScoringPolicy)
+	}
+	if err := validateLatePenalty(evaluation.LatePenaltyPolicy, evaluation.LatePenaltyPercent); err != nil {
+		return err
+	}
+	return validateDeadlineWindow(evaluation.OpensAt, evaluation.DueAt, evaluation.CloseAt)
+}
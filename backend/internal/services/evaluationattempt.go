@@ -4,62 +4,101 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"time"
 
+	"github.com/imlargo/go-api-template/internal/apperrors"
 	"github.com/imlargo/go-api-template/internal/dto"
 	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/i18n"
 	"github.com/imlargo/go-api-template/internal/models"
 	"github.com/imlargo/go-api-template/pkg/utils"
 )
 
+var (
+	ErrEvaluationNotOpen       = apperrors.New("EVALUATION_NOT_OPEN", apperrors.KindForbidden, "la evaluación aún no está disponible", "the evaluation is not available yet")
+	ErrEvaluationClosed        = apperrors.New("EVALUATION_CLOSED", apperrors.KindForbidden, "el plazo de la evaluación ha cerrado", "the evaluation deadline has passed")
+	ErrAttemptInProgress       = apperrors.New("ATTEMPT_IN_PROGRESS", apperrors.KindConflict, "ya hay un intento en curso", "an attempt is already in progress")
+	ErrMaxAttemptsReached      = apperrors.New("MAX_ATTEMPTS_REACHED", apperrors.KindConflict, "número máximo de intentos alcanzado", "maximum number of attempts reached")
+	ErrAttemptSubmitted        = apperrors.New("ATTEMPT_ALREADY_SUBMITTED", apperrors.KindConflict, "el intento ya fue enviado", "the attempt was already submitted")
+	ErrTimeLimitExceeded       = apperrors.New("TIME_LIMIT_EXCEEDED", apperrors.KindConflict, "tiempo límite excedido", "time limit exceeded")
+	ErrNoValidQuestions        = apperrors.New("NO_VALID_QUESTIONS", apperrors.KindConflict, "ninguna pregunta tiene respuestas válidas", "no question has valid answers")
+	ErrNotEnoughQuestions      = apperrors.New("NOT_ENOUGH_QUESTIONS", apperrors.KindConflict, "preguntas insuficientes disponibles", "not enough questions available")
+	ErrAttemptQuestionNotFound = apperrors.New("ATTEMPT_QUESTION_NOT_FOUND", apperrors.KindNotFound, "la pregunta no pertenece al intento", "the question does not belong to the attempt")
+	ErrAnswerVersionConflict   = apperrors.New("ANSWER_VERSION_CONFLICT", apperrors.KindConflict, "la respuesta fue guardada desde otra sesión", "the answer was saved from another session")
+)
+
+// expiredAttemptsBatch bounds the attempts submitted by each sweep
+const expiredAttemptsBatch = 200
+
 type EvaluationAttemptService interface {
-	StartAttempt(userID, evaluationID uint) (*models.EvaluationAttempt, error)
+	StartAttempt(userID, evaluationID uint, locale dto.RequestLocale) (*models.EvaluationAttempt, error)
 	SubmitAttempt(attemptID uint, answers []models.AttemptAnswer) (*models.EvaluationAttempt, error)
+	SaveAnswer(attemptID uint, answer models.AttemptAnswer) (*models.AttemptAnswer, error)
 	GetAttempt(id uint) (*models.EvaluationAttempt, error)
 	UpdateEvaluationAttemptPatch(id uint, data map[string]interface{}) (*models.EvaluationAttempt, error)
 	GetUserAttempts(userID, evaluationID uint) ([]*models.EvaluationAttempt, error)
+	ListUserAttempts(userID, evaluationID uint, request *dto.ListRequest) (*dto.Page[*models.EvaluationAttempt], error)
 	CanUserAttempt(userID, evaluationID uint) (bool, string, error)
 	ScoreAttempt(attemptID uint) (*models.EvaluationAttempt, error)
+	ExpireAttempts() error
 }
 
 type evaluationAttemptService struct {
 	*Service
 	answerService       AnswerService
 	userProgressService UserProgressService
+	revisionService     RevisionService
+	deadlineService     DeadlineService
+	translationService  TranslationService
 }
 
-func NewEvaluationAttemptService(service *Service, answerService AnswerService, userProgressService UserProgressService) EvaluationAttemptService {
+func NewEvaluationAttemptService(service *Service, answerService AnswerService, userProgressService UserProgressService, revisionService RevisionService, deadlineService DeadlineService, translationService TranslationService) EvaluationAttemptService {
 	return &evaluationAttemptService{
 		Service:             service,
 		answerService:       answerService,
 		userProgressService: userProgressService,
+		revisionService:     revisionService,
+		deadlineService:     deadlineService,
+		translationService:  translationService,
 	}
 }
 
-func (s *evaluationAttemptService) StartAttempt(userID, evaluationID uint) (*models.EvaluationAttempt, error) {
+// StartAttempt generates the questions of a new attempt in the learner's language; the
+// attempt keeps that wording for its whole life
+func (s *evaluationAttemptService) StartAttempt(userID, evaluationID uint, locale dto.RequestLocale) (*models.EvaluationAttempt, error) {
 	// Verify user exists
 	_, err := s.store.Users.GetByID(userID)
 	if err != nil {
-		return nil, fmt.Errorf("usuario no encontrado: %w", err)
+		return nil, notFound(ErrUserNotFound, err)
 	}
 
 	// Verify evaluation exists and get configuration
 	evaluation, err := s.store.Evaluations.Get(evaluationID)
 	if err != nil {
-		return nil, fmt.Errorf("evaluación no encontrada: %w", err)
+		return nil, notFound(ErrEvaluationNotFound, err)
 	}
 
 	// Check if user can attempt this evaluation
-	canAttempt, reason, err := s.CanUserAttempt(userID, evaluationID)
+	_, reason, err := s.attemptEligibility(userID, evaluation)
 	if err != nil {
 		return nil, err
 	}
-	if !canAttempt {
-		return nil, fmt.Errorf("cannot start attempt: %s", reason)
+	if reason != nil {
+		return nil, reason
+	}
+
+	module, err := s.store.Modules.Get(evaluation.ModuleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+	resolved, err := s.translationService.ResolveCourseLocale(userID, module.CourseID, locale)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate dynamic questions and answers for this attempt
-	attemptQuestions, totalPoints, err := s.generateAttemptQuestions(evaluation)
+	attemptQuestions, totalPoints, err := s.generateAttemptQuestions(evaluation, resolved)
 	if err != nil {
 		return nil, fmt.Errorf("error al generar las preguntas del intento: %w", err)
 	}
@@ -68,72 +107,158 @@ func (s *evaluationAttemptService) StartAttempt(userID, evaluationID uint) (*mod
 	attempt := &models.EvaluationAttempt{
 		UserID:       userID,
 		EvaluationID: evaluationID,
+		Locale:       resolved,
 		Questions:    attemptQuestions,
 		StartedAt:    time.Now(),
 		Score:        0,
-		TotalPoints:  totalPoints,
+		TotalPoints:  float64(totalPoints),
 		Passed:       false,
 		Answers:      models.AttemptAnswers{},
 	}
+	attempt.ExpiresAt = attemptDeadline(attempt, evaluation)
 
 	if err := s.store.EvaluationAttempts.Create(attempt); err != nil {
 		return nil, fmt.Errorf("error al crear el intento de evaluación: %w", err)
 	}
 
+	setRemainingTime(attempt, time.Now())
+	hideAnswerKey(attempt)
 	return attempt, nil
 }
 
 // generateAttemptQuestions generates random questions and answer options for an attempt
-func (s *evaluationAttemptService) generateAttemptQuestions(evaluation *models.Evaluation) (models.AttemptQuestions, int, error) {
-	// Get all questions for this evaluation
-	allQuestions, err := s.store.Questions.GetByEvaluationID(evaluation.ID)
+func (s *evaluationAttemptService) generateAttemptQuestions(evaluation *models.Evaluation, locale string) (models.AttemptQuestions, int, error) {
+	selectedQuestions, err := s.drawQuestions(evaluation)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error al obtener las preguntas: %w", err)
+		return nil, 0, err
 	}
 
-	if len(allQuestions) < evaluation.QuestionCount {
-		return nil, 0, fmt.Errorf("preguntas insuficientes disponibles: se necesitan %d, hay %d",
-			evaluation.QuestionCount, len(allQuestions))
+	// Keep the revision of each question so the exact wording shown can be looked up later
+	snapshots := make(map[uint]models.RevisionSnapshot, len(selectedQuestions))
+	for _, question := range selectedQuestions {
+		snapshots[question.ID] = questionSnapshot(question)
+	}
+	revisionVersions, err := s.revisionService.EnsureVersions(enums.RevisionEntityQuestion, snapshots)
+	if err != nil {
+		s.logger.Warnf("Failed to resolve question revisions for evaluation %d: %v", evaluation.ID, err)
 	}
 
-	// Randomly select questions
-	selectedQuestions := s.selectRandomQuestions(allQuestions, evaluation.QuestionCount)
+	// Revisions track the original wording; the attempt shows it in the learner's language
+	if err := s.translationService.LocalizeQuestions(locale, selectedQuestions...); err != nil {
+		return nil, 0, err
+	}
 
 	var attemptQuestions models.AttemptQuestions
 	totalPoints := 0
 
 	for i, question := range selectedQuestions {
-		// Get all answers for this question
-		allAnswers := question.Answers
+		attemptQuestion := models.AttemptQuestion{
+			ID:              uint(i + 1), // Sequential ID for this attempt
+			Text:            question.Text,
+			Type:            question.Type,
+			Explanation:     question.Explanation,
+			Points:          question.Points,
+			OriginalID:      question.ID,
+			RevisionVersion: revisionVersions[question.ID],
+			ScoringPolicy:   question.ScoringPolicy,
+		}
 
-		// Generate answer options for this question
-		answerOptions, err := s.generateAnswerOptions(allAnswers, evaluation.AnswerOptionsCount, question.Type)
+		// Choice questions draw a random subset of options; the other types show their
+		// whole answer key in the shape of the type
+		var err error
+		switch question.Type {
+		case enums.QuestionTypeSingle, enums.QuestionTypeMultiple:
+			attemptQuestion.AnswerOptions, err = s.generateAnswerOptions(question.Answers, evaluation.AnswerOptionsCount, question.Type)
+		default:
+			err = buildAttemptAnswerKey(&attemptQuestion, question.Answers)
+		}
 		if err != nil {
 			s.logger.Warnf("Failed to generate answer options for question %d: %v", question.ID, err)
 			continue
 		}
 
-		attemptQuestion := models.AttemptQuestion{
-			ID:            uint(i + 1), // Sequential ID for this attempt
-			Text:          question.Text,
-			Type:          question.Type,
-			Explanation:   question.Explanation,
-			Points:        question.Points,
-			OriginalID:    question.ID,
-			AnswerOptions: answerOptions,
-		}
-
 		attemptQuestions = append(attemptQuestions, attemptQuestion)
 		totalPoints += question.Points
 	}
 
 	if len(attemptQuestions) == 0 {
-		return nil, 0, fmt.Errorf("error al generar preguntas válidas")
+		return nil, 0, ErrNoValidQuestions
 	}
 
 	return attemptQuestions, totalPoints, nil
 }
 
+// drawQuestions picks the questions of a new attempt. The evaluation's own questions are
+// drawn first as set by QuestionCount; each draw rule then adds its share from a course bank
+// without repeating a question, and the result is shuffled so pools do not show in blocks.
+func (s *evaluationAttemptService) drawQuestions(evaluation *models.Evaluation) ([]*models.Question, error) {
+	var selected []*models.Question
+	if evaluation.QuestionCount > 0 {
+		allQuestions, err := s.store.Questions.GetByEvaluationID(evaluation.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error al obtener las preguntas: %w", err)
+		}
+
+		if len(allQuestions) < evaluation.QuestionCount {
+			return nil, ErrNotEnoughQuestions.WithDetails(map[string]interface{}{
+				"required":  evaluation.QuestionCount,
+				"available": len(allQuestions),
+			})
+		}
+
+		selected = s.selectRandomQuestions(allQuestions, evaluation.QuestionCount)
+	}
+
+	rules, err := s.store.QuestionBanks.GetDrawRules(evaluation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las reglas de sorteo: %w", err)
+	}
+	if len(rules) == 0 {
+		return selected, nil
+	}
+
+	drawn := make(map[uint]bool, len(selected))
+	for _, question := range selected {
+		drawn[question.ID] = true
+	}
+
+	for _, rule := range rules {
+		candidates, err := s.store.QuestionBanks.GetQuestions(rule.BankID, rule.Tag, rule.Difficulty)
+		if err != nil {
+			return nil, fmt.Errorf("error al obtener las preguntas del banco: %w", err)
+		}
+
+		// Rules may overlap; a question already drawn by an earlier rule is not a candidate
+		available := make([]*models.Question, 0, len(candidates))
+		for _, question := range candidates {
+			if !drawn[question.ID] {
+				available = append(available, question)
+			}
+		}
+
+		if len(available) < rule.Count {
+			return nil, ErrNotEnoughQuestions.WithDetails(map[string]interface{}{
+				"rule_id":   rule.ID,
+				"bank_id":   rule.BankID,
+				"required":  rule.Count,
+				"available": len(available),
+			})
+		}
+
+		for _, question := range s.selectRandomQuestions(available, rule.Count) {
+			drawn[question.ID] = true
+			selected = append(selected, question)
+		}
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r.Shuffle(len(selected), func(i, j int) {
+		selected[i], selected[j] = selected[j], selected[i]
+	})
+
+	return selected, nil
+}
+
 // selectRandomQuestions randomly selects the specified number of questions
 func (s *evaluationAttemptService) selectRandomQuestions(questions []*models.Question, count int) []*models.Question {
 	if len(questions) <= count {
@@ -237,18 +362,20 @@ func (s *evaluationAttemptService) generateAnswerOptions(allAnswers []*models.An
 	// Add correct options
 	for i, answer := range selectedCorrect {
 		options = append(options, models.AttemptAnswerOption{
-			ID:        uint(i + 1),
-			Text:      answer.Text,
-			IsCorrect: true,
+			ID:         uint(i + 1),
+			OriginalID: answer.ID,
+			Text:       answer.Text,
+			IsCorrect:  true,
 		})
 	}
 
 	// Add incorrect options
 	for i, answer := range selectedIncorrect {
 		options = append(options, models.AttemptAnswerOption{
-			ID:        uint(len(selectedCorrect) + i + 1),
-			Text:      answer.Text,
-			IsCorrect: false,
+			ID:         uint(len(selectedCorrect) + i + 1),
+			OriginalID: answer.ID,
+			Text:       answer.Text,
+			IsCorrect:  false,
 		})
 	}
 
@@ -287,49 +414,209 @@ func (s *evaluationAttemptService) selectRandomAnswers(answers []*models.Answer,
 	return selected[:count]
 }
 
+// SubmitAttempt scores the attempt with its saved answers, replaced by those in the payload
+// for the questions it includes; an empty payload submits the saved answers as they are
 func (s *evaluationAttemptService) SubmitAttempt(attemptID uint, answers []models.AttemptAnswer) (*models.EvaluationAttempt, error) {
 	// Get existing attempt
 	attempt, err := s.store.EvaluationAttempts.Get(attemptID)
 	if err != nil {
-		return nil, fmt.Errorf("attempt not found: %w", err)
+		return nil, notFound(ErrAttemptNotFound, err)
 	}
 
 	// Check if already submitted
 	if attempt.SubmittedAt != nil && !attempt.SubmittedAt.IsZero() {
-		return nil, fmt.Errorf("el intento ya fue enviado")
+		return nil, ErrAttemptSubmitted
 	}
 
 	// Get evaluation to check time limit
 	evaluation, err := s.store.Evaluations.Get(attempt.EvaluationID)
 	if err != nil {
-		return nil, fmt.Errorf("evaluación no encontrada: %w", err)
+		return nil, notFound(ErrEvaluationNotFound, err)
 	}
 
-	// Check time limit if set
-	if evaluation.TimeLimit > 0 {
-		elapsed := time.Since(attempt.StartedAt)
-		if int(elapsed.Minutes()) > evaluation.TimeLimit {
-			return nil, fmt.Errorf("tiempo límite excedido")
+	// Past the grace period the attempt is closed with the answers saved so far, the same
+	// way the sweeper would, instead of being lost
+	now := time.Now()
+	if s.isExpired(attempt, evaluation, now) {
+		if err := s.expireAttempt(attempt, evaluation); err != nil {
+			return nil, err
 		}
+		return nil, ErrTimeLimitExceeded.WithDetails(map[string]interface{}{"attempt_id": attempt.ID, "auto_submitted": true})
 	}
 
-	// Calculate time spent
-	timeSpent := int(time.Since(attempt.StartedAt).Minutes())
+	if err := s.finishAttempt(attempt, evaluation, now, answers); err != nil {
+		return nil, err
+	}
 
-	// Set answers and submission time
-	now := time.Now()
-	attempt.Answers = models.AttemptAnswers(answers)
-	attempt.SubmittedAt = &now
-	attempt.TimeSpent = timeSpent
+	return attempt, nil
+}
 
-	// Perform scoring and saving in a single optimized operation
-	s.scoreAttemptInline(attempt, evaluation)
+// SaveAnswer stores the answer to one question of an attempt in progress. The answer carries
+// the version the client last saw, 0 for a question never saved; when another session saved
+// it since, the save is refused with the current answer so the client can reconcile.
+func (s *evaluationAttemptService) SaveAnswer(attemptID uint, answer models.AttemptAnswer) (*models.AttemptAnswer, error) {
+	attempt, err := s.store.EvaluationAttempts.Get(attemptID)
+	if err != nil {
+		return nil, notFound(ErrAttemptNotFound, err)
+	}
+	if attempt.SubmittedAt != nil {
+		return nil, ErrAttemptSubmitted
+	}
 
-	// Save attempt with all updates (answers + scores) in single transaction
-	if err := s.store.EvaluationAttempts.Update(attempt); err != nil {
-		return nil, fmt.Errorf("error al actualizar el intento: %w", err)
+	evaluation, err := s.store.Evaluations.Get(attempt.EvaluationID)
+	if err != nil {
+		return nil, notFound(ErrEvaluationNotFound, err)
+	}
+
+	// Saves keep working through the grace period; after it the attempt is submitted as is
+	if s.isExpired(attempt, evaluation, time.Now()) {
+		if err := s.expireAttempt(attempt, evaluation); err != nil {
+			return nil, err
+		}
+		return nil, ErrTimeLimitExceeded.WithDetails(map[string]interface{}{"attempt_id": attempt.ID, "auto_submitted": true})
+	}
+
+	if !slices.ContainsFunc(attempt.Questions, func(question models.AttemptQuestion) bool {
+		return question.ID == answer.AttemptQuestionID
+	}) {
+		return nil, ErrAttemptQuestionNotFound.WithDetails(map[string]interface{}{"attempt_question_id": answer.AttemptQuestionID})
 	}
 
+	// Scoring fills these on submission
+	answer.IsCorrect = false
+	answer.Points = 0
+
+	_, err = s.store.EvaluationAttempts.UpdateAnswers(attemptID, func(locked *models.EvaluationAttempt) error {
+		if locked.SubmittedAt != nil {
+			return ErrAttemptSubmitted
+		}
+
+		index := slices.IndexFunc(locked.Answers, func(saved models.AttemptAnswer) bool {
+			return saved.AttemptQuestionID == answer.AttemptQuestionID
+		})
+
+		current := 0
+		if index >= 0 {
+			current = locked.Answers[index].Version
+		}
+		if answer.Version != current {
+			details := map[string]interface{}{"attempt_question_id": answer.AttemptQuestionID, "current_version": current}
+			if index >= 0 {
+				details["answer"] = locked.Answers[index]
+			}
+			return ErrAnswerVersionConflict.WithDetails(details)
+		}
+
+		savedAt := time.Now()
+		answer.Version = current + 1
+		answer.SavedAt = &savedAt
+		if index >= 0 {
+			locked.Answers[index] = answer
+		} else {
+			locked.Answers = append(locked.Answers, answer)
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := apperrors.From(err); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error al guardar la respuesta: %w", err)
+	}
+
+	return &answer, nil
+}
+
+// mergeAnswers returns the saved answers with those submitted replacing the ones of the same
+// question, keeping the saved order
+func mergeAnswers(saved models.AttemptAnswers, submitted []models.AttemptAnswer) models.AttemptAnswers {
+	merged := make(models.AttemptAnswers, 0, len(saved)+len(submitted))
+	positions := make(map[uint]int, len(saved)+len(submitted))
+	for _, answer := range append(slices.Clone(saved), submitted...) {
+		if index, ok := positions[answer.AttemptQuestionID]; ok {
+			merged[index] = answer
+			continue
+		}
+		positions[answer.AttemptQuestionID] = len(merged)
+		merged = append(merged, answer)
+	}
+	return merged
+}
+
+// ExpireAttempts submits the attempts whose time limit and grace period are over with the
+// answers they have saved. It runs as a background job.
+func (s *evaluationAttemptService) ExpireAttempts() error {
+	cutoff := time.Now().Add(-s.config.Evaluations.AttemptGrace)
+	attempts, err := s.store.EvaluationAttempts.GetExpiredInProgress(cutoff, expiredAttemptsBatch)
+	if err != nil {
+		return fmt.Errorf("error al buscar intentos vencidos: %w", err)
+	}
+
+	evaluations := make(map[uint]*models.Evaluation)
+	expired := 0
+	for _, attempt := range attempts {
+		evaluation, ok := evaluations[attempt.EvaluationID]
+		if !ok {
+			evaluation, err = s.store.Evaluations.Get(attempt.EvaluationID)
+			if err != nil {
+				s.logger.Errorf("Failed to load evaluation %d of expired attempt %d: %v", attempt.EvaluationID, attempt.ID, err)
+				continue
+			}
+			evaluations[attempt.EvaluationID] = evaluation
+		}
+
+		if err := s.expireAttempt(attempt, evaluation); err != nil {
+			s.logger.Errorf("Failed to submit expired attempt %d: %v", attempt.ID, err)
+			continue
+		}
+		expired++
+	}
+
+	if expired > 0 {
+		s.logger.Infof("Submitted %d expired evaluation attempts", expired)
+	}
+	return nil
+}
+
+// expireAttempt submits the attempt with its saved answers as of the end of its time limit
+func (s *evaluationAttemptService) expireAttempt(attempt *models.EvaluationAttempt, evaluation *models.Evaluation) error {
+	deadline := attemptDeadline(attempt, evaluation)
+	if deadline == nil {
+		return nil
+	}
+
+	attempt.AutoSubmitted = true
+	if err := s.finishAttempt(attempt, evaluation, *deadline, nil); err != nil {
+		// Submitted by the learner or another sweep in the meantime
+		if errors.Is(err, ErrAttemptSubmitted) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// finishAttempt scores and stores the attempt as submitted at the given time, with its saved
+// answers replaced by the given ones, and updates the course progress when it passes. The
+// answers are merged under the row lock, so an autosave that lands meanwhile is kept.
+func (s *evaluationAttemptService) finishAttempt(attempt *models.EvaluationAttempt, evaluation *models.Evaluation, submittedAt time.Time, answers []models.AttemptAnswer) error {
+	finished, submitted, err := s.store.EvaluationAttempts.Submit(attempt.ID, func(locked *models.EvaluationAttempt) error {
+		locked.Answers = mergeAnswers(locked.Answers, answers)
+		locked.AutoSubmitted = attempt.AutoSubmitted
+		locked.SubmittedAt = &submittedAt
+		locked.TimeSpent = int(submittedAt.Sub(locked.StartedAt).Minutes())
+
+		s.scoreAttempt(locked, evaluation)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error al actualizar el intento: %w", err)
+	}
+	if !submitted {
+		return ErrAttemptSubmitted
+	}
+	*attempt = *finished
+
 	// If the attempt was passed, update course progress
 	if attempt.Passed {
 		// Get the module to find the course ID
@@ -344,73 +631,122 @@ func (s *evaluationAttemptService) SubmitAttempt(attemptID uint, answers []model
 		}
 	}
 
-	return attempt, nil
+	return nil
 }
 
-// scoreAttemptInline performs scoring directly on the attempt object without database round trips.
-//
-// Parameters:
-//   - attempt: Pointer to the EvaluationAttempt to be scored. Must be non-nil and have its Questions and Answers fields populated.
-//     This object will be mutated: its Score and Passed fields will be updated based on the evaluation.
-//   - evaluation: Pointer to the Evaluation containing scoring rules (e.g., PassingScore). Must be non-nil.
-//
-// Side effects:
-//   - Mutates the provided attempt object by setting its Score and Passed fields.
-func (s *evaluationAttemptService) scoreAttemptInline(attempt *models.EvaluationAttempt, evaluation *models.Evaluation) {
-	totalScore := 0
-	totalPoints := attempt.TotalPoints
-
-	// Create a map for faster question lookups
-	questionMap := make(map[uint]*models.AttemptQuestion)
-	for i := range attempt.Questions {
-		questionMap[attempt.Questions[i].ID] = &attempt.Questions[i]
-	}
-
-	// Score each answer using the optimized map lookup
-	for i, answer := range attempt.Answers {
-		// Find the corresponding attempt question using map (O(1) vs O(n))
-		attemptQuestion, exists := questionMap[answer.AttemptQuestionID]
-		if !exists {
-			s.logger.Warnf("Attempt question %d not found for answer", answer.AttemptQuestionID)
-			continue
-		}
+// isExpired reports whether the attempt is still in progress after its time limit and the
+// grace period
+func (s *evaluationAttemptService) isExpired(attempt *models.EvaluationAttempt, evaluation *models.Evaluation, now time.Time) bool {
+	deadline := attemptDeadline(attempt, evaluation)
+	return attempt.SubmittedAt == nil && deadline != nil && now.After(deadline.Add(s.config.Evaluations.AttemptGrace))
+}
 
-		// Validate the selected options
-		isCorrect, points := s.validateAttemptAnswer(attemptQuestion, answer.SelectedOptionIDs)
+// attemptDeadline returns when the time limit of the attempt ends, or nil without a limit.
+// Attempts started before expires_at existed use the time limit of their evaluation.
+func attemptDeadline(attempt *models.EvaluationAttempt, evaluation *models.Evaluation) *time.Time {
+	if attempt.ExpiresAt != nil {
+		return attempt.ExpiresAt
+	}
+	if evaluation.TimeLimit <= 0 {
+		return nil
+	}
+	deadline := attempt.StartedAt.Add(time.Duration(evaluation.TimeLimit) * time.Minute)
+	return &deadline
+}
 
-		// Update the answer in the attempt
-		attempt.Answers[i].IsCorrect = isCorrect
-		attempt.Answers[i].Points = points
+// setRemainingTime fills the seconds left of an attempt in progress with a time limit
+func setRemainingTime(attempt *models.EvaluationAttempt, now time.Time) {
+	attempt.RemainingSeconds = nil
+	if attempt.SubmittedAt != nil || attempt.ExpiresAt == nil {
+		return
+	}
+	remaining := max(int(attempt.ExpiresAt.Sub(now).Seconds()), 0)
+	attempt.RemainingSeconds = &remaining
+}
 
-		totalScore += points
+// hideAnswerKey removes the answer key of every question from an attempt in progress
+// before it reaches the learner. The stored attempt keeps it for grading.
+func hideAnswerKey(attempt *models.EvaluationAttempt) {
+	if attempt.SubmittedAt != nil {
+		return
+	}
+	questions := make(models.AttemptQuestions, len(attempt.Questions))
+	for i, question := range attempt.Questions {
+		question.AcceptedAnswers = nil
+		question.AnswerOptions = slices.Clone(question.AnswerOptions)
+		for j := range question.AnswerOptions {
+			question.AnswerOptions[j].IsCorrect = false
+			question.AnswerOptions[j].Position = 0
+			question.AnswerOptions[j].MatchID = 0
+		}
+		questions[i] = question
+	}
+	attempt.Questions = questions
+}
+
+// applyLatePenalty sets the attempt score from its raw score, deducting the evaluation's
+// late penalty when the attempt was submitted after the learner's due date
+func (s *evaluationAttemptService) applyLatePenalty(attempt *models.EvaluationAttempt, evaluation *models.Evaluation) {
+	attempt.Score = attempt.RawScore
+	attempt.IsLate = false
+	attempt.LatePenalty = 0
+
+	if attempt.SubmittedAt == nil {
+		return
 	}
 
-	// Update attempt with scores
-	attempt.Score = totalScore
+	window, err := s.deadlineService.GetEvaluationWindow(attempt.UserID, evaluation)
+	if err != nil {
+		s.logger.Warnf("Failed to resolve the deadline of evaluation %d for user %d: %v", evaluation.ID, attempt.UserID, err)
+		return
+	}
 
-	// Check if passed based on passing score
-	if totalPoints > 0 {
-		percentage := float64(totalScore) / float64(totalPoints) * 100
-		attempt.Passed = percentage >= float64(evaluation.PassingScore)
+	if window.DueAt == nil || !attempt.SubmittedAt.After(*window.DueAt) {
+		return
 	}
+
+	attempt.IsLate = true
+	attempt.LatePenalty = latePenaltyPercent(evaluation, window.DueAt, *attempt.SubmittedAt)
+	attempt.Score = roundScore(attempt.RawScore * (1 - attempt.LatePenalty/100))
 }
 
+// GetAttempt returns the attempt with the seconds left of its time limit. An attempt whose
+// grace period is over is submitted first, so it never shows as in progress.
 func (s *evaluationAttemptService) GetAttempt(id uint) (*models.EvaluationAttempt, error) {
 	attempt, err := s.store.EvaluationAttempts.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("attempt not found: %w", err)
+		return nil, notFound(ErrAttemptNotFound, err)
 	}
+
+	if attempt.SubmittedAt == nil {
+		evaluation, err := s.store.Evaluations.Get(attempt.EvaluationID)
+		if err != nil {
+			return nil, notFound(ErrEvaluationNotFound, err)
+		}
+		// Older attempts get their deadline from the evaluation
+		attempt.ExpiresAt = attemptDeadline(attempt, evaluation)
+
+		if s.isExpired(attempt, evaluation, time.Now()) {
+			if err := s.expireAttempt(attempt, evaluation); err != nil {
+				return nil, err
+			}
+			return s.store.EvaluationAttempts.Get(id)
+		}
+	}
+
+	setRemainingTime(attempt, time.Now())
+	hideAnswerKey(attempt)
 	return attempt, nil
 }
 
 func (s *evaluationAttemptService) UpdateEvaluationAttemptPatch(attemptID uint, data map[string]interface{}) (*models.EvaluationAttempt, error) {
 	if attemptID == 0 {
-		return nil, errors.New("attempt ID cannot be zero")
+		return nil, ErrInvalidID
 	}
 
 	var attempt dto.UpdateEvaluationAttemptRequest
 	if err := utils.MapToStructStrict(data, &attempt); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
 	}
 
 	if err := s.store.EvaluationAttempts.Patch(attemptID, data); err != nil {
@@ -419,9 +755,10 @@ func (s *evaluationAttemptService) UpdateEvaluationAttemptPatch(attemptID uint,
 
 	updated, err := s.store.EvaluationAttempts.Get(attemptID)
 	if err != nil {
-		return nil, errors.New("intento no encontrado")
+		return nil, notFound(ErrAttemptNotFound, err)
 	}
 
+	hideAnswerKey(updated)
 	return updated, nil
 }
 
@@ -432,89 +769,106 @@ func (s *evaluationAttemptService) GetUserAttempts(userID, evaluationID uint) ([
 		return nil, fmt.Errorf("error al obtener los intentos: %w", err)
 	}
 
+	for _, attempt := range attempts {
+		hideAnswerKey(attempt)
+	}
 	return attempts, nil
 }
 
+func (s *evaluationAttemptService) ListUserAttempts(userID, evaluationID uint, request *dto.ListRequest) (*dto.Page[*models.EvaluationAttempt], error) {
+	page, err := s.store.EvaluationAttempts.ListByUserAndEvaluation(userID, evaluationID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los intentos: %w", err)
+	}
+
+	for _, attempt := range page.Items {
+		hideAnswerKey(attempt)
+	}
+	return page, nil
+}
+
 func (s *evaluationAttemptService) CanUserAttempt(userID, evaluationID uint) (bool, string, error) {
 	// Get evaluation to check max attempts
 	evaluation, err := s.store.Evaluations.Get(evaluationID)
 	if err != nil {
-		return false, "", fmt.Errorf("evaluation not found: %w", err)
+		return false, "", notFound(ErrEvaluationNotFound, err)
 	}
 
-	// Check if there's an ongoing attempt with optimized query
-	_, err = s.store.EvaluationAttempts.GetInProgressAttempt(userID, evaluationID)
-	if err == nil {
-		return false, "attempt already in progress", nil
+	notice, reason, err := s.attemptEligibility(userID, evaluation)
+	if err != nil {
+		return false, "", err
+	}
+	if reason != nil {
+		return false, reason.Message(string(i18n.DefaultLocale)), nil
+	}
+	return true, notice, nil
+}
+
+// attemptEligibility returns the domain error that keeps the user from starting an
+// attempt, nil when allowed, along with a notice for late submissions
+func (s *evaluationAttemptService) attemptEligibility(userID uint, evaluation *models.Evaluation) (string, *apperrors.Error, error) {
+	// Check the availability window of the evaluation for this user
+	window, err := s.deadlineService.GetEvaluationWindow(userID, evaluation)
+	if err != nil {
+		return "", nil, err
+	}
+
+	notice := ""
+	switch window.Status {
+	case enums.DeadlineStatusNotOpen:
+		return "", ErrEvaluationNotOpen, nil
+	case enums.DeadlineStatusClosed:
+		return "", ErrEvaluationClosed, nil
+	case enums.DeadlineStatusLate:
+		notice = "entrega tardía: la evaluación está vencida"
+		if evaluation.LatePenaltyPolicy == enums.LatePenaltyFlat || evaluation.LatePenaltyPolicy == enums.LatePenaltyPerDay {
+			notice = "entrega tardía: se aplicará una penalización al puntaje"
+		}
+	}
+
+	// Check if there's an ongoing attempt with optimized query. One left past its time
+	// limit is submitted now rather than waiting for the sweeper.
+	if attempt, err := s.store.EvaluationAttempts.GetInProgressAttempt(userID, evaluation.ID); err == nil {
+		if !s.isExpired(attempt, evaluation, time.Now()) {
+			return "", ErrAttemptInProgress, nil
+		}
+		if err := s.expireAttempt(attempt, evaluation); err != nil {
+			return "", nil, err
+		}
 	}
 
 	// If no max attempts set, user can always attempt
 	if evaluation.MaxAttempts <= 0 {
-		return true, "", nil
+		return notice, nil, nil
 	}
 
 	// Use optimized database query to count completed attempts
-	completedAttempts, err := s.store.EvaluationAttempts.CountCompletedAttempts(userID, evaluationID)
+	completedAttempts, err := s.store.EvaluationAttempts.CountCompletedAttempts(userID, evaluation.ID)
 	if err != nil {
-		return false, "", fmt.Errorf("error al contar los intentos del usuario: %w", err)
+		return "", nil, fmt.Errorf("error al contar los intentos del usuario: %w", err)
 	}
 
 	if int(completedAttempts) >= evaluation.MaxAttempts {
-		return false, "número máximo de intentos alcanzado", nil
+		return "", ErrMaxAttemptsReached, nil
 	}
 
-	return true, "", nil
+	return notice, nil, nil
 }
 
 func (s *evaluationAttemptService) ScoreAttempt(attemptID uint) (*models.EvaluationAttempt, error) {
 	// Get attempt
 	attempt, err := s.store.EvaluationAttempts.Get(attemptID)
 	if err != nil {
-		return nil, fmt.Errorf("attempt not found: %w", err)
+		return nil, notFound(ErrAttemptNotFound, err)
 	}
 
 	// Get evaluation
 	evaluation, err := s.store.Evaluations.Get(attempt.EvaluationID)
 	if err != nil {
-		return nil, fmt.Errorf("evaluación no encontrada: %w", err)
+		return nil, notFound(ErrEvaluationNotFound, err)
 	}
 
-	totalScore := 0
-	totalPoints := attempt.TotalPoints // Already calculated during attempt creation
-
-	// Create a map for faster question lookups instead of nested loops
-	questionMap := make(map[uint]*models.AttemptQuestion)
-	for i := range attempt.Questions {
-		questionMap[attempt.Questions[i].ID] = &attempt.Questions[i]
-	}
-
-	// Score each answer using the optimized map lookup
-	for i, answer := range attempt.Answers {
-		// Find the corresponding attempt question using map (O(1) vs O(n))
-		attemptQuestion, exists := questionMap[answer.AttemptQuestionID]
-		if !exists {
-			s.logger.Warnf("Attempt question %d not found for answer", answer.AttemptQuestionID)
-			continue
-		}
-
-		// Validate the selected options
-		isCorrect, points := s.validateAttemptAnswer(attemptQuestion, answer.SelectedOptionIDs)
-
-		// Update the answer in the attempt
-		attempt.Answers[i].IsCorrect = isCorrect
-		attempt.Answers[i].Points = points
-
-		totalScore += points
-	}
-
-	// Update attempt with scores
-	attempt.Score = totalScore
-
-	// Check if passed based on passing score
-	if totalPoints > 0 {
-		percentage := float64(totalScore) / float64(totalPoints) * 100
-		attempt.Passed = percentage >= float64(evaluation.PassingScore)
-	}
+	s.scoreAttempt(attempt, evaluation)
 
 	// Save updated attempt
 	if err := s.store.EvaluationAttempts.Update(attempt); err != nil {
@@ -523,61 +877,3 @@ func (s *evaluationAttemptService) ScoreAttempt(attemptID uint) (*models.Evaluat
 
 	return attempt, nil
 }
-
-// validateAttemptAnswer validates user's selected options against the attempt question
-func (s *evaluationAttemptService) validateAttemptAnswer(question *models.AttemptQuestion, selectedOptionIDs []uint) (bool, int) {
-	if len(selectedOptionIDs) == 0 {
-		return false, 0
-	}
-
-	// Get correct option IDs
-	var correctOptionIDs []uint
-	for _, option := range question.AnswerOptions {
-		if option.IsCorrect {
-			correctOptionIDs = append(correctOptionIDs, option.ID)
-		}
-	}
-
-	// Check if all selected options are correct and all correct options are selected
-	selectedMap := make(map[uint]bool)
-	for _, id := range selectedOptionIDs {
-		selectedMap[id] = true
-	}
-
-	correctMap := make(map[uint]bool)
-	for _, id := range correctOptionIDs {
-		correctMap[id] = true
-	}
-
-	// For single choice: only one option should be selected and it should be correct
-	if question.Type == "single_choice" {
-		if len(selectedOptionIDs) != 1 {
-			return false, 0
-		}
-		if correctMap[selectedOptionIDs[0]] {
-			return true, question.Points
-		}
-		return false, 0
-	}
-
-	// For multiple choice: all selected must be correct, and all correct must be selected
-	if question.Type == "multiple_choice" {
-		// Check if all selected are correct
-		for _, id := range selectedOptionIDs {
-			if !correctMap[id] {
-				return false, 0 // Selected an incorrect option
-			}
-		}
-
-		// Check if all correct options are selected
-		for _, id := range correctOptionIDs {
-			if !selectedMap[id] {
-				return false, 0 // Missed a correct option
-			}
-		}
-
-		return true, question.Points
-	}
-
-	return false, 0
-}
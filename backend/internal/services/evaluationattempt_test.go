@@ -0,0 +1,79 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+func TestHideAnswerKey(t *testing.T) {
+	tests := []struct {
+		questionType enums.QuestionType
+		answers      []*models.Answer
+	}{
+		{enums.QuestionTypeTrueFalse, []*models.Answer{{ID: 1, Text: "Verdadero", IsCorrect: true}, {ID: 2, Text: "Falso"}}},
+		{enums.QuestionTypeShortAnswer, []*models.Answer{{Text: "agua"}}},
+		{enums.QuestionTypeNumeric, []*models.Answer{{Text: "3,14", Tolerance: 0.01}}},
+		{enums.QuestionTypeOrdering, []*models.Answer{{Text: "uno", Order: 1}, {Text: "dos", Order: 2}, {Text: "tres", Order: 3}}},
+		{enums.QuestionTypeMatching, []*models.Answer{{Text: "perro", MatchText: "dog"}, {Text: "gato", MatchText: "cat"}}},
+		{enums.QuestionTypeFillBlank, []*models.Answer{{Text: "azul", Blank: 1}, {Text: "rojo", Blank: 2}}},
+	}
+
+	var questions models.AttemptQuestions
+	for i, tt := range tests {
+		question := models.AttemptQuestion{ID: uint(i + 1), Type: tt.questionType}
+		if err := buildAttemptAnswerKey(&question, tt.answers); err != nil {
+			t.Fatalf("buildAttemptAnswerKey(%s) error = %v", tt.questionType, err)
+		}
+		questions = append(questions, question)
+	}
+	// Choice options are drawn by the attempt service, which copies the key as is
+	for i, questionType := range []enums.QuestionType{enums.QuestionTypeSingle, enums.QuestionTypeMultiple} {
+		questions = append(questions, models.AttemptQuestion{
+			ID:   uint(len(tests) + i + 1),
+			Type: questionType,
+			AnswerOptions: []models.AttemptAnswerOption{
+				{ID: 1, OriginalID: 10, Text: "correcta", IsCorrect: true},
+				{ID: 2, OriginalID: 11, Text: "incorrecta"},
+			},
+		})
+	}
+
+	stored, err := json.Marshal(questions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attempt := &models.EvaluationAttempt{Questions: questions}
+	hideAnswerKey(attempt)
+
+	for _, question := range attempt.Questions {
+		t.Run(string(question.Type), func(t *testing.T) {
+			data, err := json.Marshal(question)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, field := range []string{`"is_correct":true`, `"accepted_answers"`, `"position"`, `"match_id"`} {
+				if strings.Contains(string(data), field) {
+					t.Errorf("question %s keeps %s: %s", question.Type, field, data)
+				}
+			}
+		})
+	}
+
+	// The key stays in the attempt used for grading
+	if kept, _ := json.Marshal(questions); string(kept) != string(stored) {
+		t.Errorf("hideAnswerKey() changed the stored questions")
+	}
+
+	submittedAt := time.Now()
+	submitted := &models.EvaluationAttempt{Questions: questions, SubmittedAt: &submittedAt}
+	hideAnswerKey(submitted)
+	if kept, _ := json.Marshal(submitted.Questions); string(kept) != string(stored) {
+		t.Errorf("hideAnswerKey() hid the key of a submitted attempt")
+	}
+}
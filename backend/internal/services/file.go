@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -10,14 +11,16 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/imlargo/go-api-template/internal/apperrors"
 	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"github.com/imlargo/go-api-template/pkg/storage"
 	"github.com/imlargo/go-api-template/pkg/utils"
 )
 
 type FileService interface {
-	UploadFromMultipart(file *multipart.FileHeader) (*models.File, error)
+	UploadFromMultipart(file *multipart.FileHeader, uploadedByID uint) (*models.File, error)
 	UploadFromReader(file *storage.File) (*models.File, error)
 	UploadFromUrl(url string) (*models.File, error)
 	GetFile(id uint) (*models.File, error)
@@ -25,8 +28,16 @@ type FileService interface {
 	GetPresignedURL(fileID uint, expiryMins int) (*dto.PresignedURL, error)
 	BulkDeleteFiles(fileIDs []uint) error
 	DownloadFile(fileID uint) (*models.File, *storage.FileDownload, error)
+	CanManageFile(fileID, userID uint) error
+	CanAccessFile(fileID, userID uint) error
+	PurgeReleasedFiles() error
 }
 
+var (
+	ErrFileInUse    = apperrors.New("FILE_IN_USE", apperrors.KindConflict, "el archivo está adjunto a uno o más elementos", "the file is attached to one or more items")
+	ErrFileTooLarge = apperrors.New("FILE_TOO_LARGE", apperrors.KindInvalid, "el tamaño del archivo excede el límite", "the file size exceeds the limit")
+)
+
 type fileService struct {
 	*Service
 	storageService     storage.FileStorage
@@ -55,10 +66,15 @@ func (s *fileService) UploadFromUrl(url string) (*models.File, error) {
 	return s.UploadFromReader(file)
 }
 
+// fileTooLarge reports a file over the upload limit, carrying the limit in bytes
+func (s *fileService) fileTooLarge() error {
+	return ErrFileTooLarge.WithDetails(map[string]interface{}{"max_bytes": s.maxFileSize})
+}
+
 func (s *fileService) UploadFromReader(file *storage.File) (*models.File, error) {
 
 	if file.Size > s.maxFileSize {
-		return nil, fmt.Errorf("el tamaño del archivo excede el límite de 1GB")
+		return nil, s.fileTooLarge()
 	}
 
 	fileID := uuid.New()
@@ -88,6 +104,7 @@ func (s *fileService) UploadFromReader(file *storage.File) (*models.File, error)
 		Etag:        uploadResult.Etag,
 		Path:        uploadResult.Key,
 		Url:         uploadResult.Url,
+		Name:        file.Filename,
 	}
 	if err := s.store.Files.Create(createdFile); err != nil {
 		s.storageService.Delete(key)
@@ -97,9 +114,9 @@ func (s *fileService) UploadFromReader(file *storage.File) (*models.File, error)
 	return createdFile, nil
 }
 
-func (s *fileService) UploadFromMultipart(file *multipart.FileHeader) (*models.File, error) {
+func (s *fileService) UploadFromMultipart(file *multipart.FileHeader, uploadedByID uint) (*models.File, error) {
 	if file.Size > s.maxFileSize {
-		return nil, fmt.Errorf("el tamaño del archivo excede el límite de 1GB")
+		return nil, s.fileTooLarge()
 	}
 
 	src, err := file.Open()
@@ -137,6 +154,10 @@ func (s *fileService) UploadFromMultipart(file *multipart.FileHeader) (*models.F
 		Etag:        uploadResult.Etag,
 		Path:        uploadResult.Key,
 		Url:         uploadResult.Url,
+		Name:        file.Filename,
+	}
+	if uploadedByID != 0 {
+		createdFile.UploadedByID = &uploadedByID
 	}
 	if err := s.store.Files.Create(createdFile); err != nil {
 		s.storageService.Delete(key)
@@ -149,7 +170,7 @@ func (s *fileService) UploadFromMultipart(file *multipart.FileHeader) (*models.F
 func (s *fileService) GetFile(id uint) (*models.File, error) {
 	file, err := s.store.Files.GetByID(id)
 	if err != nil {
-		return nil, fmt.Errorf("archivo no encontrado: %w", err)
+		return nil, notFound(ErrFileNotFound, err)
 	}
 
 	return file, nil
@@ -158,7 +179,23 @@ func (s *fileService) GetFile(id uint) (*models.File, error) {
 func (s *fileService) DeleteFile(id uint) error {
 	file, err := s.store.Files.GetByID(id)
 	if err != nil {
-		return fmt.Errorf("archivo no encontrado: %w", err)
+		return notFound(ErrFileNotFound, err)
+	}
+
+	attachments, err := s.store.Attachments.CountByFile(id)
+	if err != nil {
+		return fmt.Errorf("error al verificar los adjuntos del archivo: %w", err)
+	}
+	if attachments > 0 {
+		return ErrFileInUse
+	}
+
+	tracks, err := s.store.ContentTracks.CountByFile(id)
+	if err != nil {
+		return fmt.Errorf("error al verificar las pistas del archivo: %w", err)
+	}
+	if tracks > 0 {
+		return ErrFileInUse
 	}
 
 	if err := s.storageService.Delete(file.Path); err != nil {
@@ -176,7 +213,7 @@ func (s *fileService) DeleteFile(id uint) error {
 func (s *fileService) GetPresignedURL(fileID uint, expiryMins int) (*dto.PresignedURL, error) {
 	file, err := s.store.Files.GetByID(fileID)
 	if err != nil {
-		return nil, fmt.Errorf("archivo no encontrado: %w", err)
+		return nil, notFound(ErrFileNotFound, err)
 	}
 
 	expiry := time.Duration(expiryMins) * time.Minute
@@ -228,7 +265,7 @@ func (s *fileService) createFileFromUrl(urlStr string) (*storage.File, error) {
 
 	// Check Content-Length before reading to prevent downloading huge files
 	if resp.ContentLength > 0 && resp.ContentLength > s.maxFileSize {
-		return nil, fmt.Errorf("el tamaño del archivo %d excede el límite de %d bytes", resp.ContentLength, s.maxFileSize)
+		return nil, s.fileTooLarge()
 	}
 
 	// Use LimitReader to prevent memory exhaustion even if Content-Length is not set
@@ -241,7 +278,7 @@ func (s *fileService) createFileFromUrl(urlStr string) (*storage.File, error) {
 
 	// Check if we hit the limit (meaning file is too large)
 	if int64(len(data)) > s.maxFileSize {
-		return nil, fmt.Errorf("el tamaño del archivo excede el límite de %d bytes", s.maxFileSize)
+		return nil, s.fileTooLarge()
 	}
 
 	contentType := resp.Header.Get("Content-Type")
@@ -285,7 +322,7 @@ func (s *fileService) DownloadFile(fileID uint) (*models.File, *storage.FileDown
 
 	file, err := s.store.Files.GetByID(fileID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("archivo no encontrado: %w", err)
+		return nil, nil, notFound(ErrFileNotFound, err)
 	}
 
 	if file.Path == "" {
@@ -331,3 +368,70 @@ func (s *fileService) extractUrlFilename(urlStr, disposition, contentType string
 
 	return fmt.Sprintf("file_%d.%s", time.Now().Unix(), ext)
 }
+
+// CanManageFile allows the uploader and administrators to delete a file
+func (s *fileService) CanManageFile(fileID, userID uint) error {
+	file, err := s.store.Files.GetByID(fileID)
+	if err != nil {
+		return notFound(ErrFileNotFound, err)
+	}
+
+	if file.UploadedByID != nil && *file.UploadedByID == userID {
+		return nil
+	}
+
+	isAdmin, err := s.userHasRole(userID, enums.UserRoleAdmin)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// CanAccessFile allows downloading or signing a file to those who can manage it, and to any
+// user once the file is published as an attachment or a caption track
+func (s *fileService) CanAccessFile(fileID, userID uint) error {
+	err := s.CanManageFile(fileID, userID)
+	if !errors.Is(err, ErrForbidden) {
+		return err
+	}
+
+	attachments, err := s.store.Attachments.CountByFile(fileID)
+	if err != nil {
+		return fmt.Errorf("error al verificar los adjuntos del archivo: %w", err)
+	}
+	if attachments > 0 {
+		return nil
+	}
+
+	tracks, err := s.store.ContentTracks.CountByFile(fileID)
+	if err != nil {
+		return fmt.Errorf("error al verificar las pistas del archivo: %w", err)
+	}
+	if tracks > 0 {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// PurgeReleasedFiles deletes from storage the files that stayed without attachments longer than the grace period
+func (s *fileService) PurgeReleasedFiles() error {
+	cutoff := time.Now().Add(-s.config.Attachments.ReleaseGrace)
+
+	fileIDs, err := s.store.Files.GetReleasedBefore(cutoff)
+	if err != nil {
+		return fmt.Errorf("error al obtener los archivos liberados: %w", err)
+	}
+
+	if err := s.BulkDeleteFiles(fileIDs); err != nil {
+		return fmt.Errorf("error al eliminar los archivos liberados: %w", err)
+	}
+
+	if len(fileIDs) > 0 {
+		s.logger.Infof("Purged %d released files", len(fileIDs))
+	}
+
+	return nil
+}
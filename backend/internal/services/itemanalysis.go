@@ -0,0 +1,367 @@
+package services
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"gorm.io/gorm"
+)
+
+type ItemAnalysisService interface {
+	GetItemAnalysis(evaluationID, userID uint) (*models.EvaluationItemAnalysis, error)
+	RefreshStaleAnalyses() error
+}
+
+type itemAnalysisService struct {
+	*Service
+}
+
+func NewItemAnalysisService(service *Service) ItemAnalysisService {
+	return &itemAnalysisService{
+		Service: service,
+	}
+}
+
+// GetItemAnalysis returns the last computed analysis of the evaluation. The background job
+// keeps it current; an evaluation never analysed is computed on the spot.
+func (s *itemAnalysisService) GetItemAnalysis(evaluationID, userID uint) (*models.EvaluationItemAnalysis, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Evaluations.Get(evaluationID); err != nil {
+		return nil, notFound(ErrEvaluationNotFound, err)
+	}
+
+	analysis, err := s.store.ItemAnalyses.GetByEvaluation(evaluationID)
+	if err == nil {
+		return analysis, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error al obtener el análisis de la evaluación: %w", err)
+	}
+
+	return s.analyzeEvaluation(evaluationID)
+}
+
+// RefreshStaleAnalyses recomputes the evaluations with attempts submitted or rescored since
+// their last analysis. A failing evaluation does not stop the others.
+func (s *itemAnalysisService) RefreshStaleAnalyses() error {
+	ids, err := s.store.ItemAnalyses.GetStaleEvaluationIDs()
+	if err != nil {
+		return fmt.Errorf("error al buscar evaluaciones por analizar: %w", err)
+	}
+
+	for _, id := range ids {
+		if _, err := s.analyzeEvaluation(id); err != nil {
+			s.logger.Errorf("Failed to analyse items of evaluation %d: %v", id, err)
+		}
+	}
+
+	if len(ids) > 0 {
+		s.logger.Infof("Item analysis refreshed for %d evaluations", len(ids))
+	}
+	return nil
+}
+
+func (s *itemAnalysisService) analyzeEvaluation(evaluationID uint) (*models.EvaluationItemAnalysis, error) {
+	// Taken before reading so attempts submitted meanwhile mark the analysis stale again
+	computedAt := time.Now()
+
+	attempts, err := s.store.EvaluationAttempts.GetFirstSubmittedByEvaluation(evaluationID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los intentos: %w", err)
+	}
+
+	items, reliability, method := analyzeItems(attempts)
+	analysis := &models.EvaluationItemAnalysis{
+		EvaluationID:      evaluationID,
+		AttemptCount:      len(attempts),
+		Reliability:       reliability,
+		ReliabilityMethod: method,
+		Items:             items,
+		ComputedAt:        computedAt,
+	}
+
+	if err := s.store.ItemAnalyses.Save(analysis); err != nil {
+		return nil, fmt.Errorf("error al guardar el análisis de la evaluación: %w", err)
+	}
+	return analysis, nil
+}
+
+// itemTally accumulates the responses to one question across attempts
+type itemTally struct {
+	stat       models.ItemStatistic
+	shownAt    time.Time
+	scores     map[int]float64 // Share of the points earned, by attempt index
+	pairs      [][2]float64    // Item score and share earned in the rest of the attempt
+	timeTotal  int
+	timeCount  int
+	options    map[string]*models.OptionStatistic
+	optionKeys []string
+}
+
+// analyzeItems computes the classical test statistics of the attempts. Each question scores
+// the share of its points earned, so partial credit is kept; questions that only score 0 or
+// 1 make the reliability a KR-20, otherwise it is Cronbach's alpha.
+func analyzeItems(attempts []*models.EvaluationAttempt) (models.ItemStatistics, *float64, enums.ReliabilityMethod) {
+	tallies := make(map[uint]*itemTally)
+	dichotomous := true
+	itemsPerAttempt := 0
+
+	for index, attempt := range attempts {
+		answers := make(map[uint]*models.AttemptAnswer, len(attempt.Answers))
+		for i := range attempt.Answers {
+			answers[attempt.Answers[i].AttemptQuestionID] = &attempt.Answers[i]
+		}
+
+		type itemScore struct {
+			tally  *itemTally
+			score  float64
+			points float64
+		}
+		var scored []itemScore
+		earned, total := 0.0, 0.0
+
+		for i := range attempt.Questions {
+			question := &attempt.Questions[i]
+			if question.Points <= 0 {
+				continue
+			}
+
+			tally, ok := tallies[question.OriginalID]
+			if !ok {
+				tally = &itemTally{
+					stat:    models.ItemStatistic{QuestionID: question.OriginalID, Type: question.Type},
+					scores:  make(map[int]float64),
+					options: make(map[string]*models.OptionStatistic),
+				}
+				tallies[question.OriginalID] = tally
+			}
+			// The statistics show the wording of the most recent attempt
+			if attempt.StartedAt.After(tally.shownAt) || tally.stat.Text == "" {
+				tally.stat.Text = question.Text
+				tally.shownAt = attempt.StartedAt
+			}
+
+			answer := answers[question.ID]
+			score := 0.0
+			if answer == nil {
+				tally.stat.Omitted++
+			} else if answer.IsCorrect {
+				score = 1
+			} else {
+				score = math.Max(math.Min(answer.Points/float64(question.Points), 1), 0)
+			}
+			if score != 0 && score != 1 {
+				dichotomous = false
+			}
+
+			tally.stat.Responses++
+			tally.scores[index] = score
+			if answer != nil && answer.TimeSpent > 0 {
+				tally.timeTotal += answer.TimeSpent
+				tally.timeCount++
+			}
+			tallyOptions(tally, question, answer)
+
+			scored = append(scored, itemScore{tally, score, float64(question.Points)})
+			earned += score * float64(question.Points)
+			total += float64(question.Points)
+		}
+
+		// Discrimination compares each item with the rest of the attempt, so the item does
+		// not correlate with itself
+		for _, item := range scored {
+			if rest := total - item.points; rest > 0 {
+				item.tally.pairs = append(item.tally.pairs, [2]float64{item.score, (earned - item.score*item.points) / rest})
+			}
+		}
+		itemsPerAttempt += len(scored)
+	}
+
+	tallyList := make([]*itemTally, 0, len(tallies))
+	for _, tally := range tallies {
+		tallyList = append(tallyList, tally)
+	}
+	slices.SortFunc(tallyList, func(a, b *itemTally) int { return cmp.Compare(a.stat.QuestionID, b.stat.QuestionID) })
+
+	items := make(models.ItemStatistics, 0, len(tallyList))
+	for _, tally := range tallyList {
+		stat := tally.stat
+
+		sum := 0.0
+		for _, score := range tally.scores {
+			sum += score
+		}
+		stat.PValue = roundStatistic(sum / float64(stat.Responses))
+
+		xs := make([]float64, len(tally.pairs))
+		ys := make([]float64, len(tally.pairs))
+		for i, pair := range tally.pairs {
+			xs[i], ys[i] = pair[0], pair[1]
+		}
+		stat.PointBiserial = correlation(xs, ys)
+
+		if tally.timeCount > 0 {
+			average := roundStatistic(float64(tally.timeTotal) / float64(tally.timeCount))
+			stat.AverageTime = &average
+		}
+
+		for _, key := range tally.optionKeys {
+			option := tally.options[key]
+			option.ChosenRate = roundStatistic(float64(option.Chosen) / float64(option.Shown))
+			stat.Options = append(stat.Options, *option)
+		}
+
+		items = append(items, stat)
+	}
+
+	if len(attempts) == 0 {
+		return items, nil, ""
+	}
+
+	method := enums.ReliabilityCronbachAlpha
+	if dichotomous {
+		method = enums.ReliabilityKR20
+	}
+	return items, estimateReliability(tallyList, float64(itemsPerAttempt)/float64(len(attempts))), method
+}
+
+// tallyOptions counts how often each option of a choice question is shown and chosen.
+// Options are told apart by their original answer, or by text in older attempts.
+func tallyOptions(tally *itemTally, question *models.AttemptQuestion, answer *models.AttemptAnswer) {
+	switch question.Type {
+	case enums.QuestionTypeSingle, enums.QuestionTypeMultiple, enums.QuestionTypeTrueFalse:
+	default:
+		return
+	}
+
+	var selected []uint
+	if answer != nil {
+		selected = answer.SelectedOptionIDs
+	}
+
+	for _, option := range question.AnswerOptions {
+		key := "text:" + option.Text
+		if option.OriginalID != 0 {
+			key = fmt.Sprintf("id:%d", option.OriginalID)
+		}
+
+		stat, ok := tally.options[key]
+		if !ok {
+			stat = &models.OptionStatistic{AnswerID: option.OriginalID, Text: option.Text, IsCorrect: option.IsCorrect}
+			tally.options[key] = stat
+			tally.optionKeys = append(tally.optionKeys, key)
+		}
+
+		stat.Shown++
+		if slices.Contains(selected, option.ID) {
+			stat.Chosen++
+		}
+	}
+}
+
+// estimateReliability estimates Cronbach's alpha as k·c/(v + (k-1)·c), with v the mean item
+// variance and c the mean covariance between items. Covariances use the attempts that saw
+// both items, so evaluations that draw different questions per attempt still get an
+// estimate; when every attempt sees every item it equals the usual KR-20 or alpha.
+func estimateReliability(tallies []*itemTally, itemsPerAttempt float64) *float64 {
+	var variances, covariances []float64
+	for i, a := range tallies {
+		if len(a.scores) < 2 {
+			continue
+		}
+		variances = append(variances, covariance(a.scores, a.scores))
+
+		for _, b := range tallies[i+1:] {
+			shared := make(map[int]float64)
+			for index, score := range b.scores {
+				if _, ok := a.scores[index]; ok {
+					shared[index] = score
+				}
+			}
+			if len(shared) < 2 {
+				continue
+			}
+			own := make(map[int]float64, len(shared))
+			for index := range shared {
+				own[index] = a.scores[index]
+			}
+			covariances = append(covariances, covariance(own, shared))
+		}
+	}
+
+	if len(variances) < 2 || len(covariances) == 0 || itemsPerAttempt < 2 {
+		return nil
+	}
+
+	v := mean(variances)
+	c := mean(covariances)
+	denominator := v + (itemsPerAttempt-1)*c
+	if denominator <= 0 {
+		return nil
+	}
+
+	alpha := roundStatistic(itemsPerAttempt * c / denominator)
+	return &alpha
+}
+
+// covariance returns the population covariance of two series keyed by attempt; both hold
+// the same keys
+func covariance(xs, ys map[int]float64) float64 {
+	meanX, meanY := 0.0, 0.0
+	for index, x := range xs {
+		meanX += x
+		meanY += ys[index]
+	}
+	meanX /= float64(len(xs))
+	meanY /= float64(len(xs))
+
+	sum := 0.0
+	for index, x := range xs {
+		sum += (x - meanX) * (ys[index] - meanY)
+	}
+	return sum / float64(len(xs))
+}
+
+// correlation returns the Pearson correlation of the series, or nil when either of them
+// does not vary
+func correlation(xs, ys []float64) *float64 {
+	if len(xs) < 2 {
+		return nil
+	}
+
+	meanX, meanY := mean(xs), mean(ys)
+	var sxy, sxx, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return nil
+	}
+
+	r := roundStatistic(sxy / math.Sqrt(sxx*syy))
+	return &r
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func roundStatistic(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
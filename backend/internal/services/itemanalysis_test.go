@@ -0,0 +1,115 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+// scoredAttempts builds one attempt per row with a question per column. Each cell is the
+// share of the question points earned, out of pointsPerItem.
+func scoredAttempts(rows [][]float64, pointsPerItem int) []*models.EvaluationAttempt {
+	attempts := make([]*models.EvaluationAttempt, len(rows))
+	for i, row := range rows {
+		attempt := &models.EvaluationAttempt{}
+		for j, share := range row {
+			id := uint(j + 1)
+			attempt.Questions = append(attempt.Questions, models.AttemptQuestion{
+				ID: id, OriginalID: id, Type: enums.QuestionTypeShortAnswer, Points: pointsPerItem,
+			})
+			attempt.Answers = append(attempt.Answers, models.AttemptAnswer{
+				AttemptQuestionID: id,
+				IsCorrect:         share == 1,
+				Points:            share * float64(pointsPerItem),
+			})
+		}
+		attempts[i] = attempt
+	}
+	return attempts
+}
+
+func TestAnalyzeItemsReliability(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempts   []*models.EvaluationAttempt
+		want       *float64
+		wantMethod enums.ReliabilityMethod
+	}{
+		{
+			// p = .75, .5, .25 and total variance 1.25: 3/2 · (1 - .625/1.25)
+			name:       "right or wrong items use KR-20",
+			attempts:   scoredAttempts([][]float64{{1, 1, 1}, {1, 1, 0}, {1, 0, 0}, {0, 0, 0}}, 1),
+			want:       ptr(0.75),
+			wantMethod: enums.ReliabilityKR20,
+		},
+		{
+			// Item variances .171875, .171875, .1875 and total variance 1.3125
+			name:       "partial credit uses Cronbach's alpha",
+			attempts:   scoredAttempts([][]float64{{1, 1, 1}, {1, 0.5, 0}, {0.5, 0, 0}, {0, 0, 0}}, 2),
+			want:       ptr(0.8929),
+			wantMethod: enums.ReliabilityCronbachAlpha,
+		},
+		{
+			name:       "a single attempt has no estimate",
+			attempts:   scoredAttempts([][]float64{{1, 0, 1}}, 1),
+			wantMethod: enums.ReliabilityKR20,
+		},
+		{
+			name:       "unrelated items have no estimate",
+			attempts:   scoredAttempts([][]float64{{1, 0}, {0, 1}}, 1),
+			wantMethod: enums.ReliabilityKR20,
+		},
+		{
+			name: "no attempts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got, method := analyzeItems(tt.attempts)
+			if method != tt.wantMethod {
+				t.Errorf("method = %q, want %q", method, tt.wantMethod)
+			}
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil:
+				t.Errorf("reliability = %v, want %v", got, tt.want)
+			case *got != *tt.want:
+				t.Errorf("reliability = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeItemsStatistics(t *testing.T) {
+	attempts := scoredAttempts([][]float64{{1, 1}, {1, 0.5}, {0, 0}, {1, 0}}, 2)
+	// The last attempt leaves the second question unanswered
+	attempts[3].Answers = attempts[3].Answers[:1]
+
+	items, _, _ := analyzeItems(attempts)
+	if len(items) != 2 {
+		t.Fatalf("len(items) = %d, want 2", len(items))
+	}
+
+	tests := []struct {
+		questionID uint
+		pValue     float64
+		responses  int
+		omitted    int
+	}{
+		{1, 0.75, 4, 0},
+		{2, 0.375, 4, 1},
+	}
+	for i, tt := range tests {
+		item := items[i]
+		if item.QuestionID != tt.questionID || item.PValue != tt.pValue || item.Responses != tt.responses || item.Omitted != tt.omitted {
+			t.Errorf("item %d = {id %d, p %v, responses %d, omitted %d}, want {id %d, p %v, responses %d, omitted %d}",
+				i, item.QuestionID, item.PValue, item.Responses, item.Omitted, tt.questionID, tt.pValue, tt.responses, tt.omitted)
+		}
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
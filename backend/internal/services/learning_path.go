@@ -0,0 +1,367 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidPathGroupKind  = apperrors.New("INVALID_PATH_GROUP_KIND", apperrors.KindInvalid, "tipo de grupo inválido", "invalid group type")
+	ErrInvalidPathMinCourses = apperrors.New("INVALID_PATH_MIN_COURSES", apperrors.KindInvalid, "la cantidad mínima de cursos del grupo optativo es inválida", "the minimum number of courses of the elective group is invalid")
+	ErrDuplicatePathCourse   = apperrors.New("DUPLICATE_PATH_COURSE", apperrors.KindInvalid, "un curso no puede aparecer más de una vez en la ruta", "a course cannot appear more than once in the path")
+	ErrAlreadyEnrolledInPath = apperrors.New("ALREADY_ENROLLED_IN_PATH", apperrors.KindConflict, "el usuario ya está inscrito en esta ruta de aprendizaje", "the user is already enrolled in this learning path")
+)
+
+type LearningPathService interface {
+	CreatePath(userID uint, request *dto.CreateLearningPathRequest) (*models.LearningPath, error)
+	GetPath(id, userID uint) (*models.LearningPath, error)
+	ListPaths(userID uint, request *dto.ListRequest) (*dto.Page[*models.LearningPath], error)
+	UpdatePathPatch(id, userID uint, data map[string]interface{}) (*models.LearningPath, error)
+	DeletePath(id, userID uint) error
+	SetStructure(id, userID uint, request *dto.LearningPathStructureRequest) (*models.LearningPath, error)
+	Enroll(id, userID uint) (*dto.LearningPathProgress, error)
+	GetProgress(id, userID uint) (*dto.LearningPathProgress, error)
+	ListUserPaths(userID uint, request *dto.ListRequest) (*dto.Page[*models.LearningPathEnrollment], error)
+}
+
+type learningPathService struct {
+	*Service
+	enrollmentService EnrollmentService
+}
+
+func NewLearningPathService(service *Service, enrollmentService EnrollmentService) LearningPathService {
+	return &learningPathService{
+		Service:           service,
+		enrollmentService: enrollmentService,
+	}
+}
+
+func (s *learningPathService) CreatePath(userID uint, request *dto.CreateLearningPathRequest) (*models.LearningPath, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	path := &models.LearningPath{
+		Title:       strings.TrimSpace(request.Title),
+		Description: request.Description,
+		ImageURL:    request.ImageURL,
+		IsPublished: request.IsPublished,
+	}
+
+	if err := s.store.LearningPaths.Create(path); err != nil {
+		return nil, fmt.Errorf("error al crear la ruta de aprendizaje: %w", err)
+	}
+
+	return s.store.LearningPaths.Get(path.ID)
+}
+
+// GetPath returns the path with its groups and courses. Unpublished paths are only
+// visible to the staff.
+func (s *learningPathService) GetPath(id, userID uint) (*models.LearningPath, error) {
+	path, err := s.store.LearningPaths.Get(id)
+	if err != nil {
+		return nil, notFound(ErrLearningPathNotFound, err)
+	}
+
+	if !path.IsPublished && !s.isStaff(userID) {
+		return nil, notFound(ErrLearningPathNotFound, gorm.ErrRecordNotFound)
+	}
+
+	return path, nil
+}
+
+func (s *learningPathService) ListPaths(userID uint, request *dto.ListRequest) (*dto.Page[*models.LearningPath], error) {
+	return s.store.LearningPaths.List(!s.isStaff(userID), request)
+}
+
+func (s *learningPathService) UpdatePathPatch(id, userID uint, data map[string]interface{}) (*models.LearningPath, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	var request dto.UpdateLearningPathRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	if _, err := s.store.LearningPaths.Get(id); err != nil {
+		return nil, notFound(ErrLearningPathNotFound, err)
+	}
+
+	if request.Title != nil {
+		data["title"] = strings.TrimSpace(*request.Title)
+	}
+
+	if err := s.store.LearningPaths.Patch(id, data); err != nil {
+		return nil, fmt.Errorf("error al actualizar la ruta de aprendizaje: %w", err)
+	}
+
+	return s.store.LearningPaths.Get(id)
+}
+
+func (s *learningPathService) DeletePath(id, userID uint) error {
+	if err := s.requireStaff(userID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.LearningPaths.Get(id); err != nil {
+		return notFound(ErrLearningPathNotFound, err)
+	}
+
+	if err := s.store.LearningPaths.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar la ruta de aprendizaje: %w", err)
+	}
+	return nil
+}
+
+// SetStructure replaces the ordered groups of the path. Required groups need every course;
+// elective groups need min_courses of them.
+func (s *learningPathService) SetStructure(id, userID uint, request *dto.LearningPathStructureRequest) (*models.LearningPath, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.LearningPaths.Get(id); err != nil {
+		return nil, notFound(ErrLearningPathNotFound, err)
+	}
+
+	seen := make(map[uint]bool)
+	groups := make([]*models.LearningPathGroup, 0, len(request.Groups))
+	for i, groupRequest := range request.Groups {
+		group := &models.LearningPathGroup{
+			Title:   strings.TrimSpace(groupRequest.Title),
+			Kind:    groupRequest.Kind,
+			Order:   i + 1,
+			Courses: make([]*models.LearningPathCourse, 0, len(groupRequest.CourseIDs)),
+		}
+
+		switch group.Kind {
+		case enums.LearningPathGroupRequired:
+			group.MinCourses = len(groupRequest.CourseIDs)
+		case enums.LearningPathGroupElective:
+			if groupRequest.MinCourses < 1 || groupRequest.MinCourses > len(groupRequest.CourseIDs) {
+				return nil, ErrInvalidPathMinCourses
+			}
+			group.MinCourses = groupRequest.MinCourses
+		default:
+			return nil, ErrInvalidPathGroupKind
+		}
+
+		for j, courseID := range groupRequest.CourseIDs {
+			if seen[courseID] {
+				return nil, ErrDuplicatePathCourse
+			}
+			seen[courseID] = true
+
+			if _, err := s.store.Courses.Get(courseID); err != nil {
+				return nil, fmt.Errorf("curso %d no encontrado: %w", courseID, err)
+			}
+
+			group.Courses = append(group.Courses, &models.LearningPathCourse{
+				CourseID: courseID,
+				Order:    j + 1,
+			})
+		}
+
+		groups = append(groups, group)
+	}
+
+	if err := s.store.LearningPaths.ReplaceStructure(id, groups); err != nil {
+		return nil, fmt.Errorf("error al guardar la estructura de la ruta: %w", err)
+	}
+
+	return s.store.LearningPaths.Get(id)
+}
+
+// Enroll registers the user in the courses of the required groups they are not enrolled in
+// yet and then in the path, so a failed course enrollment can simply be retried. Enrolling
+// again in a path completes the course enrollments an earlier attempt left missing. Learners
+// pick the courses of elective groups themselves by enrolling in them.
+func (s *learningPathService) Enroll(id, userID uint) (*dto.LearningPathProgress, error) {
+	path, err := s.GetPath(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _ := s.store.LearningPaths.GetEnrollment(id, userID)
+
+	added, err := s.enrollInPathCourses(path, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		if added == 0 {
+			return nil, ErrAlreadyEnrolledInPath
+		}
+		return s.buildProgress(path, existing)
+	}
+
+	enrollment := &models.LearningPathEnrollment{
+		PathID:     id,
+		UserID:     userID,
+		EnrolledAt: time.Now(),
+	}
+	if err := s.store.LearningPaths.CreateEnrollment(enrollment); err != nil {
+		return nil, fmt.Errorf("error al crear la inscripción a la ruta: %w", err)
+	}
+
+	return s.buildProgress(path, enrollment)
+}
+
+// enrollInPathCourses enrolls the user in the courses of the required groups they are
+// missing and returns how many enrollments it created
+func (s *learningPathService) enrollInPathCourses(path *models.LearningPath, userID uint) (int, error) {
+	added := 0
+	for _, group := range path.Groups {
+		if group.Kind != enums.LearningPathGroupRequired {
+			continue
+		}
+		for _, pathCourse := range group.Courses {
+			if existing, _ := s.enrollmentService.GetUserCourseEnrollment(userID, pathCourse.CourseID); existing != nil {
+				continue
+			}
+			if _, err := s.enrollmentService.CreateEnrollment(userID, pathCourse.CourseID); err != nil {
+				return added, fmt.Errorf("error al inscribir en el curso %d: %w", pathCourse.CourseID, err)
+			}
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (s *learningPathService) GetProgress(id, userID uint) (*dto.LearningPathProgress, error) {
+	path, err := s.store.LearningPaths.Get(id)
+	if err != nil {
+		return nil, notFound(ErrLearningPathNotFound, err)
+	}
+
+	enrollment, err := s.store.LearningPaths.GetEnrollment(id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotEnrolled
+		}
+		return nil, err
+	}
+
+	return s.buildProgress(path, enrollment)
+}
+
+func (s *learningPathService) ListUserPaths(userID uint, request *dto.ListRequest) (*dto.Page[*models.LearningPathEnrollment], error) {
+	return s.store.LearningPaths.ListEnrollmentsByUser(userID, request)
+}
+
+// buildProgress derives the path progress from the course enrollments. Each group
+// counts as many courses as it requires; elective groups credit their most advanced
+// courses. The path enrollment is marked completed the first time every group is.
+func (s *learningPathService) buildProgress(path *models.LearningPath, enrollment *models.LearningPathEnrollment) (*dto.LearningPathProgress, error) {
+	enrollments, err := s.store.Enrollments.GetByUserAndCourses(enrollment.UserID, learningPathCourseIDs(path))
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las inscripciones: %w", err)
+	}
+
+	byCourse := make(map[uint]*models.Enrollment, len(enrollments))
+	for _, courseEnrollment := range enrollments {
+		byCourse[courseEnrollment.CourseID] = courseEnrollment
+	}
+
+	progress := &dto.LearningPathProgress{
+		PathID:      path.ID,
+		EnrolledAt:  enrollment.EnrolledAt,
+		CompletedAt: enrollment.CompletedAt,
+		Completed:   true,
+		Groups:      make([]*dto.LearningPathGroupProgress, 0, len(path.Groups)),
+	}
+
+	var credit, required float64
+	for _, group := range path.Groups {
+		groupProgress := &dto.LearningPathGroupProgress{
+			GroupID:         group.ID,
+			Title:           group.Title,
+			Kind:            group.Kind,
+			RequiredCourses: group.MinCourses,
+			Courses:         make([]*dto.LearningPathCourseProgress, 0, len(group.Courses)),
+		}
+
+		fractions := make([]float64, 0, len(group.Courses))
+		for _, pathCourse := range group.Courses {
+			courseProgress := &dto.LearningPathCourseProgress{CourseID: pathCourse.CourseID}
+			if pathCourse.Course != nil {
+				courseProgress.Title = pathCourse.Course.Title
+			}
+
+			if courseEnrollment, ok := byCourse[pathCourse.CourseID]; ok {
+				courseProgress.Enrolled = true
+				courseProgress.Progress = courseEnrollment.Progress
+				courseProgress.Completed = !courseEnrollment.CompletedAt.IsZero() || courseEnrollment.Progress >= 100
+			}
+
+			if courseProgress.Completed {
+				groupProgress.CompletedCourses++
+				fractions = append(fractions, 1)
+			} else {
+				fractions = append(fractions, courseProgress.Progress/100)
+			}
+			groupProgress.Courses = append(groupProgress.Courses, courseProgress)
+		}
+
+		groupProgress.Completed = groupProgress.CompletedCourses >= group.MinCourses
+		if !groupProgress.Completed {
+			progress.Completed = false
+		}
+
+		sort.Sort(sort.Reverse(sort.Float64Slice(fractions)))
+		for i := 0; i < group.MinCourses && i < len(fractions); i++ {
+			credit += fractions[i]
+		}
+		required += float64(group.MinCourses)
+
+		progress.Groups = append(progress.Groups, groupProgress)
+	}
+
+	if required > 0 {
+		progress.Progress = credit / required * 100
+	} else {
+		progress.Completed = false
+	}
+
+	if progress.Completed && enrollment.CompletedAt == nil {
+		now := time.Now()
+		if err := s.store.LearningPaths.MarkEnrollmentCompleted(enrollment.ID, now); err != nil {
+			s.logger.Errorf("Failed to complete learning path enrollment %d: %v", enrollment.ID, err)
+		} else {
+			progress.CompletedAt = &now
+		}
+	}
+
+	return progress, nil
+}
+
+// isStaff reports whether the user is an instructor or an admin; anonymous users are not
+func (s *learningPathService) isStaff(userID uint) bool {
+	if userID == 0 {
+		return false
+	}
+	isStaff, err := s.userHasRole(userID, enums.UserRoleInstructor, enums.UserRoleAdmin)
+	return err == nil && isStaff
+}
+
+func learningPathCourseIDs(path *models.LearningPath) []uint {
+	var ids []uint
+	for _, group := range path.Groups {
+		for _, pathCourse := range group.Courses {
+			ids = append(ids, pathCourse.CourseID)
+		}
+	}
+	return ids
+}
@@ -1,7 +1,6 @@
 package services
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/imlargo/go-api-template/internal/dto"
@@ -11,12 +10,13 @@ import (
 
 type ModuleService interface {
 	CreateModule(module *models.Module) (*models.Module, error)
-	GetModule(id uint) (*models.Module, error)
+	GetModule(id, userID uint, locale dto.RequestLocale) (*models.Module, error)
 	UpdateModule(id uint, module *models.Module) (*models.Module, error)
 	UpdateModulePatch(id uint, data map[string]interface{}) (*models.Module, error)
 	DeleteModule(id uint) error
 	GetModulesByCourse(courseID uint) ([]*models.Module, error)
-	GetModuleWithContent(id uint) (*models.Module, error)
+	ListModulesByCourse(courseID, userID uint, locale dto.RequestLocale, request *dto.ListRequest) (*dto.Page[*models.Module], error)
+	GetModuleWithContent(id, userID uint, locale dto.RequestLocale) (*models.Module, error)
 	ReorderModules(courseID uint, moduleOrders []struct {
 		ID    uint
 		Order int
@@ -25,11 +25,15 @@ type ModuleService interface {
 
 type moduleService struct {
 	*Service
+	releaseService     ReleaseService
+	translationService TranslationService
 }
 
-func NewModuleService(service *Service) ModuleService {
+func NewModuleService(service *Service, releaseService ReleaseService, translationService TranslationService) ModuleService {
 	return &moduleService{
-		Service: service,
+		Service:            service,
+		releaseService:     releaseService,
+		translationService: translationService,
 	}
 }
 
@@ -37,7 +41,14 @@ func (s *moduleService) CreateModule(module *models.Module) (*models.Module, err
 	// Verify course exists
 	_, err := s.store.Courses.Get(module.CourseID)
 	if err != nil {
-		return nil, fmt.Errorf("curso no encontrado: %w", err)
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	if err := validateDeadlineWindow(module.OpensAt, module.DueAt, module.CloseAt); err != nil {
+		return nil, err
+	}
+	if err := validateReleaseRule(module); err != nil {
+		return nil, err
 	}
 
 	if err := s.store.Modules.Create(module); err != nil {
@@ -53,10 +64,24 @@ func (s *moduleService) CreateModule(module *models.Module) (*models.Module, err
 	return module, nil
 }
 
-func (s *moduleService) GetModule(id uint) (*models.Module, error) {
+// GetModule returns the module with its release state for the user, in the locale resolved
+// for the request
+func (s *moduleService) GetModule(id, userID uint, locale dto.RequestLocale) (*models.Module, error) {
 	module, err := s.store.Modules.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("módulo no encontrado: %w", err)
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	if err := s.releaseService.AnnotateModules(userID, module.CourseID, []*models.Module{module}); err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.translationService.ResolveCourseLocale(userID, module.CourseID, locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translationService.LocalizeModules(resolved, module); err != nil {
+		return nil, err
 	}
 	return module, nil
 }
@@ -64,13 +89,25 @@ func (s *moduleService) GetModule(id uint) (*models.Module, error) {
 func (s *moduleService) UpdateModule(id uint, moduleData *models.Module) (*models.Module, error) {
 	existingModule, err := s.store.Modules.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("módulo no encontrado: %w", err)
+		return nil, notFound(ErrModuleNotFound, err)
 	}
 
 	// Update fields
 	existingModule.Title = moduleData.Title
 	existingModule.Description = moduleData.Description
 	existingModule.Order = moduleData.Order
+	existingModule.OpensAt = moduleData.OpensAt
+	existingModule.DueAt = moduleData.DueAt
+	existingModule.CloseAt = moduleData.CloseAt
+	existingModule.ReleaseAfterDays = moduleData.ReleaseAfterDays
+	existingModule.ReleaseAt = moduleData.ReleaseAt
+
+	if err := validateDeadlineWindow(existingModule.OpensAt, existingModule.DueAt, existingModule.CloseAt); err != nil {
+		return nil, err
+	}
+	if err := validateReleaseRule(existingModule); err != nil {
+		return nil, err
+	}
 
 	if err := s.store.Modules.Update(existingModule); err != nil {
 		return nil, fmt.Errorf("error al actualizar el módulo: %w", err)
@@ -81,12 +118,40 @@ func (s *moduleService) UpdateModule(id uint, moduleData *models.Module) (*model
 
 func (s *moduleService) UpdateModulePatch(moduleID uint, data map[string]interface{}) (*models.Module, error) {
 	if moduleID == 0 {
-		return nil, errors.New("el ID del módulo no puede ser cero")
+		return nil, ErrInvalidID
 	}
 
 	var module dto.UpdateModuleRequest
 	if err := utils.MapToStructStrict(data, &module); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
+	}
+
+	existing, err := s.store.Modules.Get(moduleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	// Validate the dates as they will be stored; explicit nulls clear a date
+	if _, ok := data["opens_at"]; ok {
+		existing.OpensAt = module.OpensAt
+	}
+	if _, ok := data["due_at"]; ok {
+		existing.DueAt = module.DueAt
+	}
+	if _, ok := data["close_at"]; ok {
+		existing.CloseAt = module.CloseAt
+	}
+	if err := validateDeadlineWindow(existing.OpensAt, existing.DueAt, existing.CloseAt); err != nil {
+		return nil, err
+	}
+	if _, ok := data["release_after_days"]; ok {
+		existing.ReleaseAfterDays = module.ReleaseAfterDays
+	}
+	if _, ok := data["release_at"]; ok {
+		existing.ReleaseAt = module.ReleaseAt
+	}
+	if err := validateReleaseRule(existing); err != nil {
+		return nil, err
 	}
 
 	if err := s.store.Modules.Patch(moduleID, data); err != nil {
@@ -95,7 +160,7 @@ func (s *moduleService) UpdateModulePatch(moduleID uint, data map[string]interfa
 
 	updated, err := s.store.Modules.Get(moduleID)
 	if err != nil {
-		return nil, errors.New("módulo no encontrado")
+		return nil, notFound(ErrModuleNotFound, err)
 	}
 
 	return updated, nil
@@ -133,13 +198,56 @@ func (s *moduleService) GetModulesByCourse(courseID uint) ([]*models.Module, err
 	return modules, nil
 }
 
-func (s *moduleService) GetModuleWithContent(id uint) (*models.Module, error) {
+// ListModulesByCourse lists the course modules with their release state for the user, in the
+// locale resolved for the request
+func (s *moduleService) ListModulesByCourse(courseID, userID uint, locale dto.RequestLocale, request *dto.ListRequest) (*dto.Page[*models.Module], error) {
+	page, err := s.store.Modules.ListByCourseID(courseID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los módulos: %w", err)
+	}
+
+	if err := s.releaseService.AnnotateModules(userID, courseID, page.Items); err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.translationService.ResolveCourseLocale(userID, courseID, locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translationService.LocalizeModules(resolved, page.Items...); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetModuleWithContent returns the module with its contents in the locale resolved for the
+// request; a module not released for the user keeps the outline without bodies or media
+func (s *moduleService) GetModuleWithContent(id, userID uint, locale dto.RequestLocale) (*models.Module, error) {
 	// Use the new repository method to preload content
 	module, err := s.store.Modules.GetWithContent(id)
 	if err != nil {
-		return nil, fmt.Errorf("módulo no encontrado: %w", err)
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	if err := s.releaseService.AnnotateModules(userID, module.CourseID, []*models.Module{module}); err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.translationService.ResolveCourseLocale(userID, module.CourseID, locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translationService.LocalizeModules(resolved, module); err != nil {
+		return nil, err
+	}
+	if err := s.translationService.LocalizeContents(resolved, module.Contents...); err != nil {
+		return nil, err
 	}
 
+	// Translations may carry a body too, so the locked contents are stripped last
+	if module.Locked {
+		hideLockedContents(module.Contents)
+	}
 	return module, nil
 }
 
@@ -150,7 +258,7 @@ func (s *moduleService) ReorderModules(courseID uint, moduleOrders []struct {
 	// Verify course exists
 	_, err := s.store.Courses.Get(courseID)
 	if err != nil {
-		return fmt.Errorf("curso no encontrado: %w", err)
+		return notFound(ErrCourseNotFound, err)
 	}
 
 	// Update each module's order
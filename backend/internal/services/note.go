@@ -0,0 +1,264 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+var (
+	ErrInvalidNoteKind   = apperrors.New("INVALID_NOTE_KIND", apperrors.KindInvalid, "tipo de nota inválido", "invalid note type")
+	ErrInvalidNoteAnchor = apperrors.New("INVALID_NOTE_ANCHOR", apperrors.KindInvalid, "el anclaje de la nota es inválido", "the note anchor is invalid")
+	ErrEmptyNote         = apperrors.New("EMPTY_NOTE", apperrors.KindInvalid, "la nota no puede estar vacía", "the note cannot be empty")
+	ErrEmptyNoteQuery    = apperrors.New("EMPTY_NOTE_QUERY", apperrors.KindInvalid, "el texto de búsqueda es requerido", "the search text is required")
+)
+
+type NoteService interface {
+	CreateNote(contentID, userID uint, request *dto.CreateNoteRequest) (*models.Note, error)
+	GetCourseNotes(courseID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Note], error)
+	GetContentNotes(contentID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Note], error)
+	SearchNotes(userID uint, query string, request *dto.ListRequest) (*dto.Page[*models.Note], error)
+	UpdateNotePatch(id, userID uint, data map[string]interface{}) (*models.Note, error)
+	DeleteNote(id, userID uint) error
+	ExportCourseNotes(courseID, userID uint) (string, []byte, error)
+}
+
+type noteService struct {
+	*Service
+	enrollmentService EnrollmentService
+}
+
+func NewNoteService(service *Service, enrollmentService EnrollmentService) NoteService {
+	return &noteService{
+		Service:           service,
+		enrollmentService: enrollmentService,
+	}
+}
+
+func (s *noteService) CreateNote(contentID, userID uint, request *dto.CreateNoteRequest) (*models.Note, error) {
+	content, err := s.store.Contents.Get(contentID)
+	if err != nil {
+		return nil, notFound(ErrContentNotFound, err)
+	}
+
+	module, err := s.store.Modules.Get(content.ModuleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	if _, err := s.enrollmentService.GetUserCourseEnrollment(userID, module.CourseID); err != nil {
+		return nil, ErrNotEnrolled
+	}
+
+	note := &models.Note{
+		UserID:      userID,
+		CourseID:    module.CourseID,
+		ContentID:   contentID,
+		Kind:        request.Kind,
+		Body:        strings.TrimSpace(request.Body),
+		Quote:       request.Quote,
+		AnchorStart: request.AnchorStart,
+		AnchorEnd:   request.AnchorEnd,
+		Timestamp:   request.Timestamp,
+		Color:       request.Color,
+	}
+
+	if err := validateNote(note); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Notes.Create(note); err != nil {
+		return nil, fmt.Errorf("error al crear la nota: %w", err)
+	}
+
+	return note, nil
+}
+
+func (s *noteService) GetCourseNotes(courseID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Note], error) {
+	return s.store.Notes.ListByUserAndCourse(userID, courseID, request)
+}
+
+func (s *noteService) GetContentNotes(contentID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Note], error) {
+	return s.store.Notes.ListByUserAndContent(userID, contentID, request)
+}
+
+func (s *noteService) SearchNotes(userID uint, query string, request *dto.ListRequest) (*dto.Page[*models.Note], error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptyNoteQuery
+	}
+	return s.store.Notes.Search(userID, query, request)
+}
+
+func (s *noteService) UpdateNotePatch(id, userID uint, data map[string]interface{}) (*models.Note, error) {
+	var request dto.UpdateNoteRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	note, err := s.getOwnNote(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the note as it will be stored; explicit nulls clear the anchors
+	if request.Body != nil {
+		note.Body = strings.TrimSpace(*request.Body)
+		data["body"] = note.Body
+	}
+	if request.Quote != nil {
+		note.Quote = *request.Quote
+	}
+	if _, ok := data["anchor_start"]; ok {
+		note.AnchorStart = request.AnchorStart
+	}
+	if _, ok := data["anchor_end"]; ok {
+		note.AnchorEnd = request.AnchorEnd
+	}
+	if _, ok := data["timestamp"]; ok {
+		note.Timestamp = request.Timestamp
+	}
+
+	if err := validateNote(note); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Notes.Patch(id, data); err != nil {
+		return nil, fmt.Errorf("error al actualizar la nota: %w", err)
+	}
+
+	return s.store.Notes.Get(id)
+}
+
+func (s *noteService) DeleteNote(id, userID uint) error {
+	if _, err := s.getOwnNote(id, userID); err != nil {
+		return err
+	}
+
+	if err := s.store.Notes.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar la nota: %w", err)
+	}
+	return nil
+}
+
+// ExportCourseNotes renders the user's notes of a course as a Markdown document that follows
+// the course outline. It returns the suggested file name and the document.
+func (s *noteService) ExportCourseNotes(courseID, userID uint) (string, []byte, error) {
+	course, err := s.store.Courses.Get(courseID)
+	if err != nil {
+		return "", nil, notFound(ErrCourseNotFound, err)
+	}
+
+	notes, err := s.store.Notes.GetCourseNotesInOrder(userID, courseID)
+	if err != nil {
+		return "", nil, fmt.Errorf("error al obtener las notas: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Notas: %s\n\n", course.Title)
+	fmt.Fprintf(&b, "_Exportado el %s_\n", time.Now().Format("2006-01-02"))
+
+	if len(notes) == 0 {
+		b.WriteString("\nTodavía no tienes notas en este curso.\n")
+	}
+
+	var moduleID, contentID uint
+	for _, note := range notes {
+		if note.Content == nil {
+			continue
+		}
+		if note.Content.Module != nil && note.Content.ModuleID != moduleID {
+			moduleID = note.Content.ModuleID
+			fmt.Fprintf(&b, "\n## %s\n", note.Content.Module.Title)
+		}
+		if note.ContentID != contentID {
+			contentID = note.ContentID
+			fmt.Fprintf(&b, "\n### %s\n", note.Content.Title)
+		}
+
+		fmt.Fprintf(&b, "\n**%s**", noteKindLabels[note.Kind])
+		if anchor := noteAnchorLabel(note); anchor != "" {
+			fmt.Fprintf(&b, " · %s", anchor)
+		}
+		b.WriteString("\n")
+
+		if note.Quote != "" {
+			b.WriteString("\n> " + strings.ReplaceAll(strings.TrimSpace(note.Quote), "\n", "\n> ") + "\n")
+		}
+		if note.Body != "" {
+			b.WriteString("\n" + note.Body + "\n")
+		}
+	}
+
+	filename := fmt.Sprintf("notas-%s.md", utils.Slugify(course.Title))
+	return filename, []byte(b.String()), nil
+}
+
+func (s *noteService) getOwnNote(id, userID uint) (*models.Note, error) {
+	note, err := s.store.Notes.Get(id)
+	if err != nil {
+		return nil, notFound(ErrNoteNotFound, err)
+	}
+	if note.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return note, nil
+}
+
+var noteKindLabels = map[enums.NoteKind]string{
+	enums.NoteKindNote:      "Nota",
+	enums.NoteKindHighlight: "Resaltado",
+	enums.NoteKindBookmark:  "Marcador",
+}
+
+// validateNote checks the kind and the anchor: a text range needs both ends and a
+// highlight needs something to highlight; notes need a body
+func validateNote(note *models.Note) error {
+	if _, ok := noteKindLabels[note.Kind]; !ok {
+		return ErrInvalidNoteKind
+	}
+
+	if (note.AnchorStart == nil) != (note.AnchorEnd == nil) {
+		return ErrInvalidNoteAnchor
+	}
+	if note.AnchorStart != nil && (*note.AnchorStart < 0 || *note.AnchorEnd <= *note.AnchorStart) {
+		return ErrInvalidNoteAnchor
+	}
+	if note.Timestamp != nil && *note.Timestamp < 0 {
+		return ErrInvalidNoteAnchor
+	}
+
+	switch note.Kind {
+	case enums.NoteKindNote:
+		if note.Body == "" {
+			return ErrEmptyNote
+		}
+	case enums.NoteKindHighlight:
+		if note.AnchorStart == nil && note.Timestamp == nil {
+			return ErrInvalidNoteAnchor
+		}
+	}
+	return nil
+}
+
+// noteAnchorLabel describes where the note is anchored: a video time or a text range
+func noteAnchorLabel(note *models.Note) string {
+	if note.Timestamp != nil {
+		seconds := int(math.Floor(*note.Timestamp))
+		if seconds >= 3600 {
+			return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+		}
+		return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+	}
+	if note.AnchorStart != nil {
+		return fmt.Sprintf("caracteres %d-%d", *note.AnchorStart, *note.AnchorEnd)
+	}
+	return ""
+}
@@ -2,9 +2,9 @@ package services
 
 import (
 	"context"
-	"errors"
 	"time"
 
+	"github.com/imlargo/go-api-template/internal/dto"
 	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"github.com/imlargo/go-api-template/pkg/push"
@@ -13,6 +13,7 @@ import (
 
 type NotificationService interface {
 	DispatchNotification(userID uint, title, message, notifType string) error
+	DispatchNotificationBatch(userIDs []uint, title, message, notifType string) error
 
 	DispatchSSE(notification *models.Notification) error
 	SubscribeSSE(ctx context.Context, userID uint, deviceID string) (sse.Connection, error)
@@ -26,6 +27,7 @@ type NotificationService interface {
 	GetPushSubscription(subscriptionID uint) (*models.PushNotificationSubscription, error)
 
 	GetUserNotifications(userID uint) ([]*models.Notification, error)
+	ListUserNotifications(userID uint, request *dto.ListRequest) (*dto.Page[*models.Notification], error)
 	MarkNotificationsAsRead(userID uint) error
 }
 
@@ -65,6 +67,58 @@ func (s *notificationService) DispatchNotification(userID uint, title, message s
 	return nil
 }
 
+// DispatchNotificationBatch sends the same notification to many users: it is saved with a single
+// insert and then delivered through SSE and push to each recipient
+func (s *notificationService) DispatchNotificationBatch(userIDs []uint, title, message string, notifType string) error {
+	notifications := make([]*models.Notification, len(userIDs))
+	for i, userID := range userIDs {
+		notifications[i] = &models.Notification{
+			UserID:      userID,
+			Title:       title,
+			Description: message,
+			Category:    enums.NotificationType(notifType),
+			Read:        false,
+		}
+	}
+
+	if err := s.store.Notifications.CreateBatch(notifications); err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		if err := s.SSE.Send(notification.UserID, &sse.Message{
+			Event: "notification",
+			Data:  notification,
+		}); err != nil {
+			s.logger.Errorln("Error dispatching SSE notification:", err.Error())
+		}
+	}
+
+	subs, err := s.store.PushSubscriptions.GetSubscriptionsByUsers(userIDs)
+	if err != nil {
+		s.logger.Errorln("Error getting push subscriptions:", err.Error())
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"title":    title,
+		"message":  message,
+		"category": notifType,
+	}
+	for _, subscription := range subs {
+		err := s.Push.Send(&push.Subscription{
+			Endpoint: subscription.Endpoint,
+			P256dh:   subscription.P256dh,
+			Auth:     subscription.Auth,
+		}, payload)
+		if err != nil {
+			s.store.PushSubscriptions.Delete(subscription.ID)
+		}
+	}
+
+	return nil
+}
+
 func (s *notificationService) DispatchSSE(notification *models.Notification) error {
 	// 1. Guardar en base de datos
 	err := s.store.Notifications.Create(notification)
@@ -141,7 +195,7 @@ func (s *notificationService) GetSSESubscriptions() map[string]interface{} {
 
 func (s *notificationService) GetUserNotifications(userID uint) ([]*models.Notification, error) {
 	if userID == 0 {
-		return nil, errors.New("se requiere el ID del usuario")
+		return nil, ErrInvalidID
 	}
 
 	notifications, err := s.store.Notifications.GetByUser(userID)
@@ -152,9 +206,17 @@ func (s *notificationService) GetUserNotifications(userID uint) ([]*models.Notif
 	return notifications, nil
 }
 
+func (s *notificationService) ListUserNotifications(userID uint, request *dto.ListRequest) (*dto.Page[*models.Notification], error) {
+	if userID == 0 {
+		return nil, ErrInvalidID
+	}
+
+	return s.store.Notifications.ListByUser(userID, request)
+}
+
 func (s *notificationService) MarkNotificationsAsRead(userID uint) error {
 	if userID == 0 {
-		return errors.New("se requiere el ID del usuario")
+		return ErrInvalidID
 	}
 
 	now := time.Now()
@@ -168,7 +230,7 @@ func (s *notificationService) MarkNotificationsAsRead(userID uint) error {
 
 func (s *notificationService) GetPushSubscription(subscriptionID uint) (*models.PushNotificationSubscription, error) {
 	if subscriptionID == 0 {
-		return nil, errors.New("subscription ID is required")
+		return nil, ErrInvalidID
 	}
 
 	subscription, err := s.store.PushSubscriptions.GetByID(subscriptionID)
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxHeartbeatSeconds caps the watch time a single heartbeat can add
+	maxHeartbeatSeconds = 300
+	retentionBuckets    = 10
+
+	// maxPlaybackRate is the fastest speed the player offers; a heartbeat cannot cover more
+	// video than plays at that speed since the previous one
+	maxPlaybackRate = 2
+)
+
+var ErrContentHasNoVideo = apperrors.New("CONTENT_HAS_NO_VIDEO", apperrors.KindInvalid, "el contenido no tiene un video asociado", "the content has no video")
+
+type PlaybackService interface {
+	RecordHeartbeat(userID, contentID uint, request *dto.PlaybackHeartbeatRequest) (*models.PlaybackProgress, error)
+	GetPlayback(userID, contentID uint) (*models.PlaybackProgress, error)
+	GetPlaybackStats(contentID, userID uint) (*dto.PlaybackStats, error)
+}
+
+type playbackService struct {
+	*Service
+	enrollmentService   EnrollmentService
+	userProgressService UserProgressService
+}
+
+func NewPlaybackService(service *Service, enrollmentService EnrollmentService, userProgressService UserProgressService) PlaybackService {
+	return &playbackService{
+		Service:             service,
+		enrollmentService:   enrollmentService,
+		userProgressService: userProgressService,
+	}
+}
+
+// RecordHeartbeat stores the playback position and merges the newly watched intervals.
+// Crossing the completion threshold marks the content as completed.
+func (s *playbackService) RecordHeartbeat(userID, contentID uint, request *dto.PlaybackHeartbeatRequest) (*models.PlaybackProgress, error) {
+	content, err := s.store.Contents.Get(contentID)
+	if err != nil {
+		return nil, notFound(ErrContentNotFound, err)
+	}
+
+	if content.MediaURL == "" {
+		return nil, ErrContentHasNoVideo
+	}
+
+	module, err := s.store.Modules.Get(content.ModuleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	if _, err := s.enrollmentService.GetUserCourseEnrollment(userID, module.CourseID); err != nil {
+		return nil, ErrNotEnrolled
+	}
+
+	progress, err := s.store.Playback.GetByUserAndContent(userID, contentID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("error al obtener el progreso de reproducción: %w", err)
+		}
+		progress = &models.PlaybackProgress{
+			UserID:    userID,
+			ContentID: contentID,
+		}
+	}
+
+	// The player only reports the length of videos whose content does not record it
+	if content.MediaDuration > 0 {
+		progress.Duration = content.MediaDuration
+	} else {
+		progress.Duration = math.Max(progress.Duration, request.Duration)
+	}
+	progress.Position = math.Min(request.Position, progress.Duration)
+
+	// The seconds a heartbeat can add are bounded by the time passed since the previous one,
+	// so a single request cannot report the whole video as watched
+	now := time.Now()
+	budget := float64(maxHeartbeatSeconds)
+	if !progress.LastWatchedAt.IsZero() {
+		budget = math.Min(budget, now.Sub(progress.LastWatchedAt).Seconds()*maxPlaybackRate)
+	}
+
+	watched := clipIntervals(request.Intervals, progress.Duration)
+	added := coveredSeconds(watched)
+	newlyWatched := limitIntervals(uncoveredIntervals(mergeIntervals(watched), progress.Intervals), budget)
+
+	progress.Intervals = mergeIntervals(append(progress.Intervals, newlyWatched...))
+	progress.WatchedSeconds = coveredSeconds(progress.Intervals)
+	progress.Percentage = math.Min(100, utils.CalculatePercentage(progress.WatchedSeconds, progress.Duration))
+	progress.TimeSpent += int(math.Round(math.Min(added, budget)))
+	progress.LastWatchedAt = now
+
+	// Completing also needs enough wall-clock time since the first heartbeat to have played
+	// the watched seconds, so a short reported duration cannot complete in one request
+	elapsed := 0.0
+	if !progress.CreatedAt.IsZero() {
+		elapsed = now.Sub(progress.CreatedAt).Seconds()
+	}
+	completedNow := progress.CompletedAt == nil &&
+		progress.Percentage >= s.config.Playback.CompletionThreshold &&
+		elapsed*maxPlaybackRate >= progress.WatchedSeconds
+	if completedNow {
+		progress.CompletedAt = &now
+	}
+
+	if err := s.store.Playback.Save(progress); err != nil {
+		return nil, fmt.Errorf("error al guardar el progreso de reproducción: %w", err)
+	}
+
+	if completedNow {
+		if _, err := s.userProgressService.MarkContentComplete(userID, module.CourseID, module.ID, contentID); err != nil {
+			s.logger.Warnf("Failed to mark video %d as completed for user %d: %v", contentID, userID, err)
+		}
+	}
+
+	s.syncUserProgress(progress)
+
+	return progress, nil
+}
+
+// GetPlayback returns where the user left the video; an empty state is returned before the first heartbeat
+func (s *playbackService) GetPlayback(userID, contentID uint) (*models.PlaybackProgress, error) {
+	progress, err := s.store.Playback.GetByUserAndContent(userID, contentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.PlaybackProgress{
+				UserID:    userID,
+				ContentID: contentID,
+				Intervals: models.PlaybackIntervals{},
+			}, nil
+		}
+		return nil, fmt.Errorf("error al obtener el progreso de reproducción: %w", err)
+	}
+	return progress, nil
+}
+
+func (s *playbackService) GetPlaybackStats(contentID, userID uint) (*dto.PlaybackStats, error) {
+	allowed, err := s.userHasRole(userID, enums.UserRoleInstructor, enums.UserRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrForbidden
+	}
+
+	if _, err := s.store.Contents.Get(contentID); err != nil {
+		return nil, notFound(ErrContentNotFound, err)
+	}
+
+	records, err := s.store.Playback.GetByContent(contentID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las reproducciones: %w", err)
+	}
+
+	stats := &dto.PlaybackStats{
+		ContentID: contentID,
+		Viewers:   len(records),
+		Retention: make([]dto.PlaybackRetentionBucket, retentionBuckets),
+	}
+
+	for i := range stats.Retention {
+		stats.Retention[i] = dto.PlaybackRetentionBucket{
+			FromPercentage: float64(i) * 100 / retentionBuckets,
+			ToPercentage:   float64(i+1) * 100 / retentionBuckets,
+		}
+	}
+
+	percentageSum := 0.0
+	for _, record := range records {
+		if record.CompletedAt != nil {
+			stats.Completions++
+		}
+		percentageSum += record.Percentage
+		stats.TotalTimeSpent += record.TimeSpent
+
+		if record.Duration <= 0 {
+			continue
+		}
+		bucketSize := record.Duration / retentionBuckets
+		for i := range stats.Retention {
+			from := float64(i) * bucketSize
+			if overlapsIntervals(record.Intervals, from, from+bucketSize) {
+				stats.Retention[i].Viewers++
+			}
+		}
+	}
+
+	stats.CompletionRate = utils.CalculatePercentage(float64(stats.Completions), float64(stats.Viewers))
+	stats.AverageWatchedPercentage = utils.DivideOrZero(percentageSum, float64(stats.Viewers))
+	stats.AverageTimeSpent = utils.DivideOrZero(float64(stats.TotalTimeSpent), float64(stats.Viewers))
+
+	return stats, nil
+}
+
+// syncUserProgress copies the watch data to the completion record, when there is one
+func (s *playbackService) syncUserProgress(progress *models.PlaybackProgress) {
+	userProgress, err := s.store.UserProgresss.GetByUserAndContent(progress.UserID, progress.ContentID)
+	if err != nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"progress":   progress.Percentage,
+		"time_spent": progress.TimeSpent,
+	}
+	if err := s.store.UserProgresss.Patch(userProgress.ID, data); err != nil {
+		s.logger.Warnf("Failed to update progress %d with playback data: %v", userProgress.ID, err)
+	}
+}
+
+// clipIntervals drops empty or inverted intervals and trims the rest to the video length
+func clipIntervals(intervals [][2]float64, duration float64) models.PlaybackIntervals {
+	clipped := models.PlaybackIntervals{}
+	for _, interval := range intervals {
+		start := math.Max(0, interval[0])
+		end := math.Min(duration, interval[1])
+		if end > start {
+			clipped = append(clipped, [2]float64{start, end})
+		}
+	}
+	return clipped
+}
+
+// mergeIntervals sorts the intervals and joins the ones that overlap or touch
+func mergeIntervals(intervals models.PlaybackIntervals) models.PlaybackIntervals {
+	if len(intervals) == 0 {
+		return models.PlaybackIntervals{}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i][0] < intervals[j][0]
+	})
+
+	merged := models.PlaybackIntervals{intervals[0]}
+	for _, interval := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if interval[0] <= last[1] {
+			last[1] = math.Max(last[1], interval[1])
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// uncoveredIntervals returns the parts of the merged intervals not already covered
+func uncoveredIntervals(intervals, covered models.PlaybackIntervals) models.PlaybackIntervals {
+	uncovered := models.PlaybackIntervals{}
+	for _, interval := range intervals {
+		start := interval[0]
+		for _, seen := range covered {
+			if seen[1] <= start || seen[0] >= interval[1] {
+				continue
+			}
+			if seen[0] > start {
+				uncovered = append(uncovered, [2]float64{start, seen[0]})
+			}
+			start = math.Max(start, seen[1])
+		}
+		if interval[1] > start {
+			uncovered = append(uncovered, [2]float64{start, interval[1]})
+		}
+	}
+	return uncovered
+}
+
+// limitIntervals keeps the intervals in order until they add up to the given seconds
+func limitIntervals(intervals models.PlaybackIntervals, seconds float64) models.PlaybackIntervals {
+	limited := models.PlaybackIntervals{}
+	for _, interval := range intervals {
+		if seconds <= 0 {
+			break
+		}
+		end := math.Min(interval[1], interval[0]+seconds)
+		limited = append(limited, [2]float64{interval[0], end})
+		seconds -= end - interval[0]
+	}
+	return limited
+}
+
+// coveredSeconds adds up merged intervals, i.e. the distinct seconds watched
+func coveredSeconds(intervals models.PlaybackIntervals) float64 {
+	total := 0.0
+	for _, interval := range intervals {
+		total += interval[1] - interval[0]
+	}
+	return total
+}
+
+func overlapsIntervals(intervals models.PlaybackIntervals, from, to float64) bool {
+	for _, interval := range intervals {
+		if interval[0] < to && interval[1] > from {
+			return true
+		}
+	}
+	return false
+}
@@ -1,93 +1,155 @@
 package services
 
 import (
-	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/models"
 	"github.com/imlargo/go-api-template/pkg/utils"
 )
 
 type QuestionService interface {
-	CreateQuestion(question *models.Question) (*models.Question, error)
+	CreateQuestion(question *models.Question, authorID uint) (*models.Question, error)
 	GetQuestion(id uint) (*models.Question, error)
-	UpdateQuestion(id uint, question *models.Question) (*models.Question, error)
-	UpdateQuestionPatch(id uint, data map[string]interface{}) (*models.Question, error)
+	UpdateQuestion(id uint, question *models.Question, authorID uint) (*models.Question, error)
+	UpdateQuestionPatch(id uint, data map[string]interface{}, authorID uint) (*models.Question, error)
 	DeleteQuestion(id uint) error
 	GetQuestionsByEvaluation(evaluationID uint) ([]*models.Question, error)
+	ListQuestionsByEvaluation(evaluationID uint, request *dto.ListRequest) (*dto.Page[*models.Question], error)
 	GetQuestionWithAnswers(id uint) (*models.Question, error)
+	SetQuestionTags(id, userID uint, names []string) ([]*models.Tag, error)
 }
 
 type questionService struct {
 	*Service
+	revisionService RevisionService
 }
 
-func NewQuestionService(service *Service) QuestionService {
+func NewQuestionService(service *Service, revisionService RevisionService) QuestionService {
 	return &questionService{
-		Service: service,
+		Service:         service,
+		revisionService: revisionService,
 	}
 }
 
-func (s *questionService) CreateQuestion(question *models.Question) (*models.Question, error) {
-	// Verify evaluation exists
-	_, err := s.store.Evaluations.Get(question.EvaluationID)
-	if err != nil {
-		return nil, fmt.Errorf("evaluación no encontrada: %w", err)
+func (s *questionService) CreateQuestion(question *models.Question, authorID uint) (*models.Question, error) {
+	// A question lives in exactly one evaluation or bank
+	if (question.EvaluationID == nil) == (question.BankID == nil) {
+		return nil, ErrInvalidQuestionOwner
+	}
+	if question.EvaluationID != nil {
+		if _, err := s.store.Evaluations.Get(*question.EvaluationID); err != nil {
+			return nil, notFound(ErrEvaluationNotFound, err)
+		}
+	} else if _, err := s.store.QuestionBanks.Get(*question.BankID); err != nil {
+		return nil, notFound(ErrQuestionBankNotFound, err)
+	}
+
+	if !isValidQuestionType(question.Type) {
+		return nil, ErrInvalidQuestionType.WithDetails(map[string]interface{}{"type": question.Type})
+	}
+	if question.ScoringPolicy != "" && !isValidScoringPolicy(question.ScoringPolicy) {
+		return nil, invalidScoringPolicy(question.ScoringPolicy)
+	}
+	if question.Difficulty != "" && !isValidDifficulty(question.Difficulty) {
+		return nil, invalidDifficulty(question.Difficulty)
+	}
+	// Answers sent with the question are created with it and must already grade
+	if len(question.Answers) > 0 {
+		if err := validateQuestionKey(question.Type, question.Answers); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.store.Questions.Create(question); err != nil {
 		return nil, fmt.Errorf("error al crear la pregunta: %w", err)
 	}
+
+	s.recordRevision(question.ID, authorID, nil, questionSnapshot(question))
 	return question, nil
 }
 
 func (s *questionService) GetQuestion(id uint) (*models.Question, error) {
 	question, err := s.store.Questions.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("pregunta no encontrada: %w", err)
+		return nil, notFound(ErrQuestionNotFound, err)
 	}
 	return question, nil
 }
 
-func (s *questionService) UpdateQuestion(id uint, questionData *models.Question) (*models.Question, error) {
+func (s *questionService) UpdateQuestion(id uint, questionData *models.Question, authorID uint) (*models.Question, error) {
 	existingQuestion, err := s.store.Questions.Get(id)
 	if err != nil {
-		return nil, fmt.Errorf("pregunta no encontrada: %w", err)
+		return nil, notFound(ErrQuestionNotFound, err)
+	}
+
+	if !isValidQuestionType(questionData.Type) {
+		return nil, ErrInvalidQuestionType.WithDetails(map[string]interface{}{"type": questionData.Type})
+	}
+	if questionData.ScoringPolicy != "" && !isValidScoringPolicy(questionData.ScoringPolicy) {
+		return nil, invalidScoringPolicy(questionData.ScoringPolicy)
 	}
+	if questionData.Difficulty != "" && !isValidDifficulty(questionData.Difficulty) {
+		return nil, invalidDifficulty(questionData.Difficulty)
+	}
+
+	before := questionSnapshot(existingQuestion)
 
 	// Update fields
 	existingQuestion.Text = questionData.Text
 	existingQuestion.Type = questionData.Type
 	existingQuestion.Explanation = questionData.Explanation
 	existingQuestion.Points = questionData.Points
+	existingQuestion.ScoringPolicy = questionData.ScoringPolicy
+	existingQuestion.Difficulty = questionData.Difficulty
 
 	if err := s.store.Questions.Update(existingQuestion); err != nil {
 		return nil, fmt.Errorf("error al actualizar la pregunta: %w", err)
 	}
 
+	s.recordRevision(id, authorID, before, questionSnapshot(existingQuestion))
+
 	return existingQuestion, nil
 }
 
-func (s *questionService) UpdateQuestionPatch(questionID uint, data map[string]interface{}) (*models.Question, error) {
+func (s *questionService) UpdateQuestionPatch(questionID uint, data map[string]interface{}, authorID uint) (*models.Question, error) {
 	if questionID == 0 {
-		return nil, errors.New("question ID cannot be zero")
+		return nil, ErrInvalidID
 	}
 
 	var question dto.UpdateQuestionRequest
 	if err := utils.MapToStructStrict(data, &question); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
+	}
+	if _, ok := data["type"]; ok && !isValidQuestionType(question.Type) {
+		return nil, ErrInvalidQuestionType.WithDetails(map[string]interface{}{"type": question.Type})
+	}
+	if question.ScoringPolicy != "" && !isValidScoringPolicy(question.ScoringPolicy) {
+		return nil, invalidScoringPolicy(question.ScoringPolicy)
+	}
+	if question.Difficulty != "" && !isValidDifficulty(question.Difficulty) {
+		return nil, invalidDifficulty(question.Difficulty)
 	}
 
+	existing, err := s.store.Questions.Get(questionID)
+	if err != nil {
+		return nil, notFound(ErrQuestionNotFound, err)
+	}
+	before := questionSnapshot(existing)
+
 	if err := s.store.Questions.Patch(questionID, data); err != nil {
 		return nil, err
 	}
 
 	updated, err := s.store.Questions.Get(questionID)
 	if err != nil {
-		return nil, errors.New("pregunta no encontrada")
+		return nil, notFound(ErrQuestionNotFound, err)
 	}
 
+	s.recordRevision(questionID, authorID, before, questionSnapshot(updated))
+
 	return updated, nil
 }
 
@@ -108,12 +170,61 @@ func (s *questionService) GetQuestionsByEvaluation(evaluationID uint) ([]*models
 	return questions, nil
 }
 
+func (s *questionService) ListQuestionsByEvaluation(evaluationID uint, request *dto.ListRequest) (*dto.Page[*models.Question], error) {
+	page, err := s.store.Questions.ListByEvaluationID(evaluationID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las preguntas: %w", err)
+	}
+	return page, nil
+}
+
 func (s *questionService) GetQuestionWithAnswers(id uint) (*models.Question, error) {
 	// Use the new repository method to preload answers
 	question, err := s.store.Questions.GetWithAnswers(id)
 	if err != nil {
-		return nil, fmt.Errorf("pregunta no encontrada: %w", err)
+		return nil, notFound(ErrQuestionNotFound, err)
 	}
 
 	return question, nil
 }
+
+// SetQuestionTags replaces the tags used by the draw rules to pick the question
+func (s *questionService) SetQuestionTags(id, userID uint, names []string) ([]*models.Tag, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Questions.Get(id); err != nil {
+		return nil, notFound(ErrQuestionNotFound, err)
+	}
+
+	seen := make(map[string]bool)
+	tags := make([]*models.Tag, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := utils.Slugify(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, &models.Tag{Name: name, Slug: slug})
+	}
+
+	tags, err := s.store.Tags.GetOrCreate(tags)
+	if err != nil {
+		return nil, fmt.Errorf("error al guardar las etiquetas: %w", err)
+	}
+
+	if err := s.store.Questions.ReplaceTags(id, tags); err != nil {
+		return nil, fmt.Errorf("error al asignar las etiquetas a la pregunta: %w", err)
+	}
+
+	return tags, nil
+}
+
+// recordRevision saves the change in the revision history; a failure never undoes the edit
+func (s *questionService) recordRevision(id, authorID uint, before, after models.RevisionSnapshot) {
+	if err := s.revisionService.Record(enums.RevisionEntityQuestion, id, authorID, before, after); err != nil {
+		s.logger.Warnf("failed to record revision for question %d: %v", id, err)
+	}
+}
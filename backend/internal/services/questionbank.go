@@ -0,0 +1,266 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+var (
+	ErrInvalidQuestionOwner = apperrors.New("INVALID_QUESTION_OWNER", apperrors.KindInvalid, "la pregunta debe pertenecer a una evaluación o a un banco, no a ambos", "the question must belong to either an evaluation or a bank")
+	ErrInvalidDifficulty    = apperrors.New("INVALID_DIFFICULTY", apperrors.KindInvalid, "la dificultad debe ser easy, medium o hard", "the difficulty must be easy, medium or hard")
+	ErrInvalidDrawRule      = apperrors.New("INVALID_DRAW_RULE", apperrors.KindInvalid, "cada regla de sorteo debe pedir al menos una pregunta", "each draw rule must ask for at least one question")
+	ErrBankOutsideCourse    = apperrors.New("BANK_OUTSIDE_COURSE", apperrors.KindInvalid, "el banco de preguntas no pertenece al curso de la evaluación", "the question bank does not belong to the evaluation's course")
+)
+
+func isValidDifficulty(difficulty enums.QuestionDifficulty) bool {
+	switch difficulty {
+	case enums.QuestionDifficultyEasy, enums.QuestionDifficultyMedium, enums.QuestionDifficultyHard:
+		return true
+	}
+	return false
+}
+
+func invalidDifficulty(difficulty enums.QuestionDifficulty) error {
+	return ErrInvalidDifficulty.WithDetails(map[string]interface{}{"difficulty": difficulty})
+}
+
+type QuestionBankService interface {
+	CreateBank(courseID, userID uint, request *dto.CreateQuestionBankRequest) (*models.QuestionBank, error)
+	GetBank(id, userID uint) (*models.QuestionBank, error)
+	ListBanks(courseID, userID uint) ([]*models.QuestionBank, error)
+	UpdateBankPatch(id, userID uint, data map[string]interface{}) (*models.QuestionBank, error)
+	DeleteBank(id, userID uint) error
+	GetBankQuestions(id, userID uint, filter *dto.BankQuestionFilter) ([]*models.Question, error)
+	GetDrawRules(evaluationID, userID uint) ([]*models.EvaluationDrawRule, error)
+	SetDrawRules(evaluationID, userID uint, request *dto.SetDrawRulesRequest) ([]*models.EvaluationDrawRule, error)
+}
+
+type questionBankService struct {
+	*Service
+}
+
+func NewQuestionBankService(service *Service) QuestionBankService {
+	return &questionBankService{
+		Service: service,
+	}
+}
+
+func (s *questionBankService) CreateBank(courseID, userID uint, request *dto.CreateQuestionBankRequest) (*models.QuestionBank, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Courses.Get(courseID); err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	bank := &models.QuestionBank{
+		CourseID:    courseID,
+		Name:        strings.TrimSpace(request.Name),
+		Description: request.Description,
+		CreatedByID: &userID,
+	}
+	if err := s.store.QuestionBanks.Create(bank); err != nil {
+		return nil, fmt.Errorf("error al crear el banco de preguntas: %w", err)
+	}
+
+	return bank, nil
+}
+
+func (s *questionBankService) GetBank(id, userID uint) (*models.QuestionBank, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	bank, err := s.store.QuestionBanks.Get(id)
+	if err != nil {
+		return nil, notFound(ErrQuestionBankNotFound, err)
+	}
+
+	if err := s.fillQuestionCounts(bank); err != nil {
+		return nil, err
+	}
+	return bank, nil
+}
+
+func (s *questionBankService) ListBanks(courseID, userID uint) ([]*models.QuestionBank, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Courses.Get(courseID); err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	banks, err := s.store.QuestionBanks.GetByCourse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los bancos de preguntas: %w", err)
+	}
+
+	if err := s.fillQuestionCounts(banks...); err != nil {
+		return nil, err
+	}
+	return banks, nil
+}
+
+func (s *questionBankService) UpdateBankPatch(id, userID uint, data map[string]interface{}) (*models.QuestionBank, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	var request dto.UpdateQuestionBankRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	if _, err := s.store.QuestionBanks.Get(id); err != nil {
+		return nil, notFound(ErrQuestionBankNotFound, err)
+	}
+
+	changes := map[string]interface{}{}
+	if request.Name != nil {
+		changes["name"] = strings.TrimSpace(*request.Name)
+	}
+	if request.Description != nil {
+		changes["description"] = *request.Description
+	}
+
+	if len(changes) > 0 {
+		if err := s.store.QuestionBanks.Patch(id, changes); err != nil {
+			return nil, fmt.Errorf("error al actualizar el banco de preguntas: %w", err)
+		}
+	}
+
+	return s.GetBank(id, userID)
+}
+
+// DeleteBank removes the bank with its questions and the draw rules that use it
+func (s *questionBankService) DeleteBank(id, userID uint) error {
+	if err := s.requireStaff(userID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.QuestionBanks.Get(id); err != nil {
+		return notFound(ErrQuestionBankNotFound, err)
+	}
+
+	if err := s.store.QuestionBanks.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar el banco de preguntas: %w", err)
+	}
+	return nil
+}
+
+// GetBankQuestions lists the questions of the bank, optionally only those with a tag or
+// difficulty, the same way a draw rule would pick them
+func (s *questionBankService) GetBankQuestions(id, userID uint, filter *dto.BankQuestionFilter) ([]*models.Question, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if filter.Difficulty != "" && !isValidDifficulty(filter.Difficulty) {
+		return nil, invalidDifficulty(filter.Difficulty)
+	}
+
+	if _, err := s.store.QuestionBanks.Get(id); err != nil {
+		return nil, notFound(ErrQuestionBankNotFound, err)
+	}
+
+	questions, err := s.store.QuestionBanks.GetQuestions(id, utils.Slugify(filter.Tag), filter.Difficulty)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las preguntas del banco: %w", err)
+	}
+	return questions, nil
+}
+
+func (s *questionBankService) GetDrawRules(evaluationID, userID uint) ([]*models.EvaluationDrawRule, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Evaluations.Get(evaluationID); err != nil {
+		return nil, notFound(ErrEvaluationNotFound, err)
+	}
+
+	rules, err := s.store.QuestionBanks.GetDrawRules(evaluationID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las reglas de sorteo: %w", err)
+	}
+	return rules, nil
+}
+
+// SetDrawRules replaces the draw rules of the evaluation. Every bank must belong to the
+// evaluation's course; an empty list leaves the evaluation with its own questions only.
+func (s *questionBankService) SetDrawRules(evaluationID, userID uint, request *dto.SetDrawRulesRequest) ([]*models.EvaluationDrawRule, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	evaluation, err := s.store.Evaluations.Get(evaluationID)
+	if err != nil {
+		return nil, notFound(ErrEvaluationNotFound, err)
+	}
+	module, err := s.store.Modules.Get(evaluation.ModuleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+
+	banks := make(map[uint]*models.QuestionBank)
+	rules := make([]*models.EvaluationDrawRule, 0, len(request.Rules))
+	for i, rule := range request.Rules {
+		if rule.Count <= 0 {
+			return nil, ErrInvalidDrawRule.WithDetails(map[string]interface{}{"rule": i, "count": rule.Count})
+		}
+		if rule.Difficulty != "" && !isValidDifficulty(rule.Difficulty) {
+			return nil, invalidDifficulty(rule.Difficulty)
+		}
+
+		bank, ok := banks[rule.BankID]
+		if !ok {
+			bank, err = s.store.QuestionBanks.Get(rule.BankID)
+			if err != nil {
+				return nil, notFound(ErrQuestionBankNotFound, err)
+			}
+			banks[rule.BankID] = bank
+		}
+		if bank.CourseID != module.CourseID {
+			return nil, ErrBankOutsideCourse.WithDetails(map[string]interface{}{"bank_id": rule.BankID})
+		}
+
+		rules = append(rules, &models.EvaluationDrawRule{
+			EvaluationID: evaluationID,
+			BankID:       rule.BankID,
+			Count:        rule.Count,
+			Tag:          utils.Slugify(rule.Tag),
+			Difficulty:   rule.Difficulty,
+			Order:        i + 1,
+		})
+	}
+
+	if err := s.store.QuestionBanks.ReplaceDrawRules(evaluationID, rules); err != nil {
+		return nil, fmt.Errorf("error al guardar las reglas de sorteo: %w", err)
+	}
+
+	return s.store.QuestionBanks.GetDrawRules(evaluationID)
+}
+
+func (s *questionBankService) fillQuestionCounts(banks ...*models.QuestionBank) error {
+	ids := make([]uint, len(banks))
+	for i, bank := range banks {
+		ids[i] = bank.ID
+	}
+
+	counts, err := s.store.QuestionBanks.CountQuestions(ids)
+	if err != nil {
+		return fmt.Errorf("error al contar las preguntas del banco: %w", err)
+	}
+	for _, bank := range banks {
+		bank.QuestionCount = counts[bank.ID]
+	}
+	return nil
+}
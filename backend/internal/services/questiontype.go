@@ -0,0 +1,420 @@
+package services
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+// numericEpsilon absorbs floating point error when comparing numeric answers
+const numericEpsilon = 1e-9
+
+var (
+	ErrInvalidQuestionType = apperrors.New("INVALID_QUESTION_TYPE", apperrors.KindInvalid, "tipo de pregunta inválido", "invalid question type")
+	ErrInvalidAnswerKey    = apperrors.New("INVALID_ANSWER_KEY", apperrors.KindInvalid, "la respuesta no es válida para el tipo de pregunta", "the answer is not valid for the question type")
+)
+
+func isValidQuestionType(questionType enums.QuestionType) bool {
+	switch questionType {
+	case enums.QuestionTypeSingle, enums.QuestionTypeMultiple, enums.QuestionTypeTrueFalse,
+		enums.QuestionTypeShortAnswer, enums.QuestionTypeNumeric, enums.QuestionTypeOrdering,
+		enums.QuestionTypeMatching, enums.QuestionTypeFillBlank:
+		return true
+	}
+	return false
+}
+
+// invalidAnswerKey reports a key the question type cannot grade
+func invalidAnswerKey(questionType enums.QuestionType, details map[string]interface{}) error {
+	details["type"] = questionType
+	return ErrInvalidAnswerKey.WithDetails(details)
+}
+
+// validateAnswerKey checks the fields the question type reads from the answer
+func validateAnswerKey(questionType enums.QuestionType, answer *models.Answer) error {
+	invalid := func(field string) error {
+		return invalidAnswerKey(questionType, map[string]interface{}{"field": field})
+	}
+
+	if questionType != enums.QuestionTypeNumeric && normalizeAnswerText(answer.Text) == "" {
+		return invalid("text")
+	}
+
+	switch questionType {
+	case enums.QuestionTypeNumeric:
+		if _, ok := parseNumber(answer.Text); !ok {
+			return invalid("text")
+		}
+		if answer.Tolerance < 0 {
+			return invalid("tolerance")
+		}
+	case enums.QuestionTypeMatching:
+		if strings.TrimSpace(answer.MatchText) == "" {
+			return invalid("match_text")
+		}
+	case enums.QuestionTypeFillBlank:
+		if answer.Blank < 1 {
+			return invalid("blank")
+		}
+	}
+	return nil
+}
+
+// validateQuestionKey checks that the answers sent with a new question make a key its
+// type can grade. Choice questions are checked when the attempt draws their options.
+func validateQuestionKey(questionType enums.QuestionType, answers []*models.Answer) error {
+	for _, answer := range answers {
+		if err := validateAnswerKey(questionType, answer); err != nil {
+			return err
+		}
+	}
+
+	switch questionType {
+	case enums.QuestionTypeSingle, enums.QuestionTypeMultiple:
+		return nil
+	}
+	return buildAttemptAnswerKey(&models.AttemptQuestion{Type: questionType}, answers)
+}
+
+// buildAttemptAnswerKey fills the options and the answer key of the attempt question from
+// the answers of the original question, following the rules of its type
+func buildAttemptAnswerKey(attemptQuestion *models.AttemptQuestion, answers []*models.Answer) error {
+	// Keys that depend on a sequence follow the order set by the instructor
+	answers = slices.Clone(answers)
+	slices.SortStableFunc(answers, func(a, b *models.Answer) int { return a.Order - b.Order })
+
+	switch attemptQuestion.Type {
+	case enums.QuestionTypeTrueFalse:
+		return trueFalseKey(attemptQuestion, answers)
+	case enums.QuestionTypeShortAnswer:
+		return shortAnswerKey(attemptQuestion, answers)
+	case enums.QuestionTypeNumeric:
+		return numericKey(attemptQuestion, answers)
+	case enums.QuestionTypeOrdering:
+		return orderingKey(attemptQuestion, answers)
+	case enums.QuestionTypeMatching:
+		return matchingKey(attemptQuestion, answers)
+	case enums.QuestionTypeFillBlank:
+		return fillBlankKey(attemptQuestion, answers)
+	default:
+		return ErrInvalidQuestionType.WithDetails(map[string]interface{}{"type": attemptQuestion.Type})
+	}
+}
+
+// trueFalseKey shows both statements in the instructor's order, without shuffling
+func trueFalseKey(attemptQuestion *models.AttemptQuestion, answers []*models.Answer) error {
+	if len(answers) != 2 || answers[0].IsCorrect == answers[1].IsCorrect {
+		return invalidAnswerKey(attemptQuestion.Type, map[string]interface{}{"answers": len(answers), "required": 2, "correct": 1})
+	}
+
+	attemptQuestion.AnswerOptions = make([]models.AttemptAnswerOption, len(answers))
+	for i, answer := range answers {
+		attemptQuestion.AnswerOptions[i] = models.AttemptAnswerOption{
+			ID:         uint(i + 1),
+			OriginalID: answer.ID,
+			Text:       answer.Text,
+			IsCorrect:  answer.IsCorrect,
+		}
+	}
+	return nil
+}
+
+// shortAnswerKey accepts the text of every answer
+func shortAnswerKey(attemptQuestion *models.AttemptQuestion, answers []*models.Answer) error {
+	for _, answer := range answers {
+		if normalizeAnswerText(answer.Text) == "" {
+			continue
+		}
+		attemptQuestion.AcceptedAnswers = append(attemptQuestion.AcceptedAnswers, models.AttemptAcceptedAnswer{Text: answer.Text})
+	}
+	if len(attemptQuestion.AcceptedAnswers) == 0 {
+		return invalidAnswerKey(attemptQuestion.Type, map[string]interface{}{"accepted_answers": 0})
+	}
+	return nil
+}
+
+// numericKey accepts every answer holding a number, within its tolerance
+func numericKey(attemptQuestion *models.AttemptQuestion, answers []*models.Answer) error {
+	for _, answer := range answers {
+		if _, ok := parseNumber(answer.Text); !ok {
+			continue
+		}
+		attemptQuestion.AcceptedAnswers = append(attemptQuestion.AcceptedAnswers, models.AttemptAcceptedAnswer{
+			Text:      answer.Text,
+			Tolerance: math.Abs(answer.Tolerance),
+		})
+	}
+	if len(attemptQuestion.AcceptedAnswers) == 0 {
+		return invalidAnswerKey(attemptQuestion.Type, map[string]interface{}{"accepted_answers": 0})
+	}
+	return nil
+}
+
+// orderingKey shows every item shuffled, never already in the correct order
+func orderingKey(attemptQuestion *models.AttemptQuestion, answers []*models.Answer) error {
+	if len(answers) < 2 {
+		return invalidAnswerKey(attemptQuestion.Type, map[string]interface{}{"answers": len(answers), "required": 2})
+	}
+
+	options := make([]models.AttemptAnswerOption, len(answers))
+	for i, answer := range answers {
+		options[i] = models.AttemptAnswerOption{Text: answer.Text, Position: i + 1}
+	}
+
+	rand.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+	if slices.IsSortedFunc(options, func(a, b models.AttemptAnswerOption) int { return a.Position - b.Position }) {
+		options[0], options[1] = options[1], options[0]
+	}
+
+	for i := range options {
+		options[i].ID = uint(i + 1)
+	}
+	attemptQuestion.AnswerOptions = options
+	return nil
+}
+
+// matchingKey shows the prompts and their matches as two columns shuffled independently
+func matchingKey(attemptQuestion *models.AttemptQuestion, answers []*models.Answer) error {
+	var pairs []*models.Answer
+	for _, answer := range answers {
+		if strings.TrimSpace(answer.MatchText) != "" {
+			pairs = append(pairs, answer)
+		}
+	}
+	if len(pairs) < 2 {
+		return invalidAnswerKey(attemptQuestion.Type, map[string]interface{}{"answers": len(pairs), "required": 2})
+	}
+
+	prompts := make([]models.AttemptAnswerOption, len(pairs))
+	matches := make([]models.AttemptAnswerOption, len(pairs))
+	promptOrder := rand.Perm(len(pairs))
+	matchOrder := rand.Perm(len(pairs))
+	for i, pair := range pairs {
+		matchID := uint(matchOrder[i] + 1)
+		matches[matchOrder[i]] = models.AttemptAnswerOption{ID: matchID, Text: pair.MatchText}
+		prompts[promptOrder[i]] = models.AttemptAnswerOption{ID: uint(promptOrder[i] + 1), Text: pair.Text, MatchID: matchID}
+	}
+
+	attemptQuestion.AnswerOptions = prompts
+	attemptQuestion.MatchOptions = matches
+	return nil
+}
+
+// fillBlankKey accepts, for each blank, the texts of the answers pointing to it. Every
+// blank up to the highest one must have an accepted text.
+func fillBlankKey(attemptQuestion *models.AttemptQuestion, answers []*models.Answer) error {
+	blanks := 0
+	covered := make(map[int]bool)
+	for _, answer := range answers {
+		if answer.Blank < 1 || normalizeAnswerText(answer.Text) == "" {
+			continue
+		}
+		attemptQuestion.AcceptedAnswers = append(attemptQuestion.AcceptedAnswers, models.AttemptAcceptedAnswer{
+			Text:  answer.Text,
+			Blank: answer.Blank,
+		})
+		covered[answer.Blank] = true
+		blanks = max(blanks, answer.Blank)
+	}
+
+	if blanks == 0 {
+		return invalidAnswerKey(attemptQuestion.Type, map[string]interface{}{"blanks": 0})
+	}
+	for blank := 1; blank <= blanks; blank++ {
+		if !covered[blank] {
+			return invalidAnswerKey(attemptQuestion.Type, map[string]interface{}{"blank": blank})
+		}
+	}
+
+	attemptQuestion.BlankCount = blanks
+	return nil
+}
+
+// isAnswerCorrect checks the answer against the key of the attempt question
+func isAnswerCorrect(question *models.AttemptQuestion, answer *models.AttemptAnswer) bool {
+	switch question.Type {
+	case enums.QuestionTypeSingle, enums.QuestionTypeTrueFalse:
+		return isSingleChoiceCorrect(question, answer.SelectedOptionIDs)
+	case enums.QuestionTypeMultiple:
+		return isMultipleChoiceCorrect(question, answer.SelectedOptionIDs)
+	case enums.QuestionTypeShortAnswer:
+		return isTextAccepted(question.AcceptedAnswers, 0, answer.Text)
+	case enums.QuestionTypeNumeric:
+		return isNumberAccepted(question.AcceptedAnswers, answer.Text)
+	case enums.QuestionTypeOrdering:
+		return isOrderingCorrect(question, answer.OrderedOptionIDs)
+	case enums.QuestionTypeMatching:
+		return isMatchingCorrect(question, answer.Matches)
+	case enums.QuestionTypeFillBlank:
+		return isFillBlankCorrect(question, answer.Blanks)
+	default:
+		return false
+	}
+}
+
+// isSingleChoiceCorrect requires exactly one selected option and that it is correct
+func isSingleChoiceCorrect(question *models.AttemptQuestion, selectedOptionIDs []uint) bool {
+	if len(selectedOptionIDs) != 1 {
+		return false
+	}
+	for _, option := range question.AnswerOptions {
+		if option.ID == selectedOptionIDs[0] {
+			return option.IsCorrect
+		}
+	}
+	return false
+}
+
+// isMultipleChoiceCorrect requires all selected options to be correct and all correct
+// options to be selected
+func isMultipleChoiceCorrect(question *models.AttemptQuestion, selectedOptionIDs []uint) bool {
+	if len(selectedOptionIDs) == 0 {
+		return false
+	}
+
+	selected := make(map[uint]bool, len(selectedOptionIDs))
+	for _, id := range selectedOptionIDs {
+		selected[id] = true
+	}
+
+	correct := 0
+	for _, option := range question.AnswerOptions {
+		if option.IsCorrect != selected[option.ID] {
+			return false
+		}
+		if option.IsCorrect {
+			correct++
+		}
+	}
+	return len(selected) == correct
+}
+
+// isTextAccepted compares ignoring case, accents and extra spaces; blank 0 matches the
+// accepted answers of any blank
+func isTextAccepted(accepted []models.AttemptAcceptedAnswer, blank int, text string) bool {
+	text = normalizeAnswerText(text)
+	if text == "" {
+		return false
+	}
+	for _, answer := range accepted {
+		if (blank == 0 || answer.Blank == blank) && normalizeAnswerText(answer.Text) == text {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumberAccepted(accepted []models.AttemptAcceptedAnswer, text string) bool {
+	value, ok := parseNumber(text)
+	if !ok {
+		return false
+	}
+	for _, answer := range accepted {
+		expected, ok := parseNumber(answer.Text)
+		if ok && math.Abs(value-expected) <= answer.Tolerance+numericEpsilon {
+			return true
+		}
+	}
+	return false
+}
+
+// isOrderingCorrect requires every item, each one in its position
+func isOrderingCorrect(question *models.AttemptQuestion, orderedOptionIDs []uint) bool {
+	if len(orderedOptionIDs) != len(question.AnswerOptions) {
+		return false
+	}
+
+	positions := make(map[uint]int, len(question.AnswerOptions))
+	for _, option := range question.AnswerOptions {
+		positions[option.ID] = option.Position
+	}
+	for i, id := range orderedOptionIDs {
+		if positions[id] != i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// isMatchingCorrect requires every prompt matched once, and right
+func isMatchingCorrect(question *models.AttemptQuestion, matches []models.AttemptMatch) bool {
+	if len(matches) != len(question.AnswerOptions) {
+		return false
+	}
+
+	seen := make(map[uint]bool, len(matches))
+	for _, match := range matches {
+		if seen[match.OptionID] || !isMatchRight(question, match) {
+			return false
+		}
+		seen[match.OptionID] = true
+	}
+	return true
+}
+
+// isMatchRight compares the match by text so prompts sharing the same match accept
+// either copy
+func isMatchRight(question *models.AttemptQuestion, match models.AttemptMatch) bool {
+	var expectedID uint
+	for _, option := range question.AnswerOptions {
+		if option.ID == match.OptionID {
+			expectedID = option.MatchID
+		}
+	}
+
+	var want, got string
+	found := false
+	for _, option := range question.MatchOptions {
+		if option.ID == expectedID {
+			want = normalizeAnswerText(option.Text)
+		}
+		if option.ID == match.MatchID {
+			got = normalizeAnswerText(option.Text)
+			found = true
+		}
+	}
+	return expectedID != 0 && found && got == want
+}
+
+func isFillBlankCorrect(question *models.AttemptQuestion, blanks []string) bool {
+	if question.BlankCount == 0 || len(blanks) != question.BlankCount {
+		return false
+	}
+	for i, text := range blanks {
+		if !isTextAccepted(question.AcceptedAnswers, i+1, text) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeAnswerText lowercases, removes accents and collapses spaces
+func normalizeAnswerText(text string) string {
+	return strings.Join(strings.Fields(utils.RemoveAccents(utils.NormalizeString(text))), " ")
+}
+
+// parseNumber reads a number written with a decimal point or a decimal comma. When both
+// appear the last one is the decimal separator and the other separates thousands, so
+// "1,250.5" and "1.250,5" read the same.
+func parseNumber(text string) (float64, bool) {
+	text = strings.ReplaceAll(strings.TrimSpace(text), " ", "")
+	if strings.LastIndex(text, ",") > strings.LastIndex(text, ".") {
+		text = strings.ReplaceAll(text, ".", "")
+		text = strings.Replace(text, ",", ".", 1)
+	} else {
+		text = strings.ReplaceAll(text, ",", "")
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   float64
+		wantOK bool
+	}{
+		{"42", 42, true},
+		{"3.5", 3.5, true},
+		{"3,5", 3.5, true},
+		{"-0,25", -0.25, true},
+		{" 2 ", 2, true},
+		{"1 000,5", 1000.5, true},
+		{"1,250.5", 1250.5, true},
+		{"1.250,5", 1250.5, true},
+		{"1.250.000,75", 1250000.75, true},
+		{"1,250,000.75", 1250000.75, true},
+		{"1,2,3", 0, false},
+		{"", 0, false},
+		{"abc", 0, false},
+		{"NaN", 0, false},
+		{"Inf", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := parseNumber(tt.text)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseNumber(%q) = %v, %v, want %v, %v", tt.text, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateQuestionKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		questionType enums.QuestionType
+		answers      []*models.Answer
+		wantErr      bool
+	}{
+		{"numeric with decimal comma", enums.QuestionTypeNumeric, []*models.Answer{{Text: "2,5", Tolerance: 0.1}}, false},
+		{"numeric without a number", enums.QuestionTypeNumeric, []*models.Answer{{Text: "dos"}}, true},
+		{"numeric with negative tolerance", enums.QuestionTypeNumeric, []*models.Answer{{Text: "2", Tolerance: -1}}, true},
+		{"short answer without text", enums.QuestionTypeShortAnswer, []*models.Answer{{Text: "  "}}, true},
+		{"true false with one correct statement", enums.QuestionTypeTrueFalse, []*models.Answer{{Text: "Verdadero", IsCorrect: true}, {Text: "Falso"}}, false},
+		{"true false with both correct", enums.QuestionTypeTrueFalse, []*models.Answer{{Text: "Verdadero", IsCorrect: true}, {Text: "Falso", IsCorrect: true}}, true},
+		{"ordering with one item", enums.QuestionTypeOrdering, []*models.Answer{{Text: "Primero"}}, true},
+		{"matching without a match", enums.QuestionTypeMatching, []*models.Answer{{Text: "Perro", MatchText: "Dog"}, {Text: "Gato"}}, true},
+		{"fill blank missing the first blank", enums.QuestionTypeFillBlank, []*models.Answer{{Text: "azul", Blank: 2}}, true},
+		{"fill blank", enums.QuestionTypeFillBlank, []*models.Answer{{Text: "azul", Blank: 1}, {Text: "rojo", Blank: 2}}, false},
+		{"choice options are checked when drawn", enums.QuestionTypeSingle, []*models.Answer{{Text: "Única"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateQuestionKey(tt.questionType, tt.answers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateQuestionKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidAnswerKey) {
+				t.Errorf("validateQuestionKey() error = %v, want %v", err, ErrInvalidAnswerKey)
+			}
+		})
+	}
+}
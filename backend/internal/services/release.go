@@ -0,0 +1,196 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/internal/repositories"
+)
+
+// releaseNoticeBatchSize is how many pending unlocks are notified per query
+const releaseNoticeBatchSize = 500
+
+var (
+	ErrModuleLocked       = apperrors.New("MODULE_LOCKED", apperrors.KindForbidden, "el módulo aún no está disponible", "the module is not available yet")
+	ErrInvalidReleaseRule = apperrors.New("INVALID_RELEASE_RULE", apperrors.KindInvalid, "los días de liberación no pueden ser negativos", "release days cannot be negative")
+)
+
+type ReleaseService interface {
+	AnnotateModules(userID, courseID uint, modules []*models.Module) error
+	RequireModuleReleased(userID uint, module *models.Module) error
+	NotifyReleasedModules() error
+}
+
+type releaseService struct {
+	*Service
+	notificationService NotificationService
+}
+
+func NewReleaseService(service *Service, notificationService NotificationService) ReleaseService {
+	return &releaseService{
+		Service:             service,
+		notificationService: notificationService,
+	}
+}
+
+// AnnotateModules sets the unlock date and the locked flag of the course modules for the
+// user. Staff never see locked modules; without an enrollment only fixed dates apply.
+func (s *releaseService) AnnotateModules(userID, courseID uint, modules []*models.Module) error {
+	base, isStaff, err := s.releaseContext(userID, courseID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, module := range modules {
+		module.UnlocksAt = moduleUnlocksAt(module, base)
+		module.Locked = !isStaff && module.UnlocksAt != nil && now.Before(*module.UnlocksAt)
+	}
+	return nil
+}
+
+// RequireModuleReleased returns ErrModuleLocked while the module is not released for the user
+func (s *releaseService) RequireModuleReleased(userID uint, module *models.Module) error {
+	if module.ReleaseAt == nil && module.ReleaseAfterDays == nil {
+		return nil
+	}
+
+	base, isStaff, err := s.releaseContext(userID, module.CourseID)
+	if err != nil {
+		return err
+	}
+	if isStaff {
+		return nil
+	}
+
+	if unlocksAt := moduleUnlocksAt(module, base); unlocksAt != nil && time.Now().Before(*unlocksAt) {
+		return ErrModuleLocked.WithDetails(map[string]interface{}{"unlocks_at": unlocksAt})
+	}
+	return nil
+}
+
+// NotifyReleasedModules notifies the enrollees of the modules that unlocked for them since
+// the last run. A notice is stored per learner and module so each unlock is sent once.
+func (s *releaseService) NotifyReleasedModules() error {
+	now := time.Now()
+	since := now.Add(-s.config.Releases.NotifyWindow)
+
+	for {
+		releases, err := s.store.Releases.GetPendingReleases(now, since, releaseNoticeBatchSize)
+		if err != nil {
+			return fmt.Errorf("error al obtener los módulos liberados: %w", err)
+		}
+		if len(releases) == 0 {
+			return nil
+		}
+
+		// Rows come ordered by module, so each module is notified in one batch
+		var group []*repositories.PendingModuleRelease
+		for i, release := range releases {
+			group = append(group, release)
+			if i+1 < len(releases) && releases[i+1].ModuleID == release.ModuleID {
+				continue
+			}
+			if err := s.notifyRelease(group); err != nil {
+				return err
+			}
+			group = nil
+		}
+
+		if len(releases) < releaseNoticeBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *releaseService) notifyRelease(releases []*repositories.PendingModuleRelease) error {
+	userIDs := make([]uint, len(releases))
+	notices := make([]*models.ModuleReleaseNotice, len(releases))
+	for i, release := range releases {
+		userIDs[i] = release.UserID
+		notices[i] = &models.ModuleReleaseNotice{
+			ModuleID:   release.ModuleID,
+			UserID:     release.UserID,
+			UnlockedAt: release.UnlockedAt,
+		}
+	}
+
+	first := releases[0]
+	title := fmt.Sprintf("Nuevo módulo disponible en %s", first.CourseTitle)
+	if err := s.notificationService.DispatchNotificationBatch(userIDs, title, first.ModuleTitle, string(enums.NotificationTypeRelease)); err != nil {
+		return fmt.Errorf("error al notificar la liberación del módulo %d: %w", first.ModuleID, err)
+	}
+
+	if err := s.store.Releases.CreateNotices(notices); err != nil {
+		return fmt.Errorf("error al registrar las notificaciones de liberación: %w", err)
+	}
+	return nil
+}
+
+// releaseContext returns the date relative releases count from for the user in the course,
+// nil when the user is not enrolled, and whether the user is staff
+func (s *releaseService) releaseContext(userID, courseID uint) (*time.Time, bool, error) {
+	if userID == 0 {
+		return nil, false, nil
+	}
+
+	isStaff, err := s.userHasRole(userID, enums.UserRoleInstructor, enums.UserRoleAdmin)
+	if err != nil {
+		return nil, false, err
+	}
+	if isStaff {
+		return nil, true, nil
+	}
+
+	enrollment, err := s.store.Enrollments.GetUserEnrollment(userID, courseID)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	var cohort *models.Cohort
+	if enrollment.CohortID != nil {
+		if cohort, err = s.store.Cohorts.Get(*enrollment.CohortID); err != nil {
+			return nil, false, fmt.Errorf("error al obtener la cohorte: %w", err)
+		}
+	}
+
+	base := releaseBase(enrollment, cohort)
+	return &base, false, nil
+}
+
+// releaseBase is the date relative releases count from: the cohort start, so the cohort
+// moves through the material together, or the enrollment date without a cohort
+func releaseBase(enrollment *models.Enrollment, cohort *models.Cohort) time.Time {
+	if cohort != nil {
+		return cohort.StartDate
+	}
+	return enrollment.EnrolledAt
+}
+
+// moduleUnlocksAt returns when the module unlocks from the given base date, the later of
+// the fixed date and the relative one. Nil means the module has no release rule, or only
+// a relative one and no base date.
+func moduleUnlocksAt(module *models.Module, base *time.Time) *time.Time {
+	var unlocksAt *time.Time
+	if module.ReleaseAt != nil {
+		releaseAt := *module.ReleaseAt
+		unlocksAt = &releaseAt
+	}
+	if module.ReleaseAfterDays != nil && base != nil {
+		relative := base.AddDate(0, 0, *module.ReleaseAfterDays)
+		if unlocksAt == nil || relative.After(*unlocksAt) {
+			unlocksAt = &relative
+		}
+	}
+	return unlocksAt
+}
+
+func validateReleaseRule(module *models.Module) error {
+	if module.ReleaseAfterDays != nil && *module.ReleaseAfterDays < 0 {
+		return ErrInvalidReleaseRule
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReviewExists            = apperrors.New("REVIEW_EXISTS", apperrors.KindConflict, "ya has publicado una reseña para este curso", "you have already reviewed this course")
+	ErrInvalidRating           = apperrors.New("INVALID_RATING", apperrors.KindInvalid, "la calificación debe estar entre 1 y 5", "the rating must be between 1 and 5")
+	ErrReviewAlreadyReported   = apperrors.New("REVIEW_ALREADY_REPORTED", apperrors.KindConflict, "ya has reportado esta reseña", "you have already reported this review")
+	ErrCannotReportOwnReview   = apperrors.New("CANNOT_REPORT_OWN_REVIEW", apperrors.KindInvalid, "no puedes reportar tu propia reseña", "you cannot report your own review")
+	ErrInvalidModerationAction = apperrors.New("INVALID_MODERATION_ACTION", apperrors.KindInvalid, "acción de moderación inválida", "invalid moderation action")
+)
+
+type ReviewService interface {
+	CreateReview(courseID, userID uint, request *dto.CreateReviewRequest) (*models.Review, error)
+	GetCourseReviews(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Review], error)
+	GetUserCourseReview(userID, courseID uint) (*models.Review, error)
+	UpdateReviewPatch(id, userID uint, data map[string]interface{}) (*models.Review, error)
+	DeleteReview(id, userID uint) error
+	ReplyReview(id, userID uint, request *dto.ReplyReviewRequest) (*models.Review, error)
+	ReportReview(id, userID uint, request *dto.ReportReviewRequest) error
+	GetModerationQueue(userID uint, request *dto.ListRequest) (*dto.Page[*models.Review], error)
+	ModerateReview(id, userID uint, request *dto.ModerateReviewRequest) (*models.Review, error)
+}
+
+type reviewService struct {
+	*Service
+	enrollmentService   EnrollmentService
+	notificationService NotificationService
+}
+
+func NewReviewService(service *Service, enrollmentService EnrollmentService, notificationService NotificationService) ReviewService {
+	return &reviewService{
+		Service:             service,
+		enrollmentService:   enrollmentService,
+		notificationService: notificationService,
+	}
+}
+
+// CreateReview publishes the rating of an enrolled learner; each learner reviews a course once
+func (s *reviewService) CreateReview(courseID, userID uint, request *dto.CreateReviewRequest) (*models.Review, error) {
+	if request.Rating < 1 || request.Rating > 5 {
+		return nil, ErrInvalidRating
+	}
+
+	if _, err := s.store.Courses.Get(courseID); err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	if _, err := s.enrollmentService.GetUserCourseEnrollment(userID, courseID); err != nil {
+		return nil, ErrNotEnrolled
+	}
+
+	if _, err := s.store.Reviews.GetByUserAndCourse(userID, courseID); err == nil {
+		return nil, ErrReviewExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error al verificar la reseña existente: %w", err)
+	}
+
+	review := &models.Review{
+		CourseID: courseID,
+		UserID:   userID,
+		Rating:   request.Rating,
+		Comment:  strings.TrimSpace(request.Comment),
+		Status:   enums.ReviewStatusVisible,
+	}
+
+	if err := s.store.Reviews.Create(review); err != nil {
+		return nil, fmt.Errorf("error al crear la reseña: %w", err)
+	}
+
+	if err := s.store.Reviews.RefreshCourseRating(courseID); err != nil {
+		return nil, fmt.Errorf("error al actualizar la calificación del curso: %w", err)
+	}
+
+	return s.store.Reviews.Get(review.ID)
+}
+
+func (s *reviewService) GetCourseReviews(courseID uint, request *dto.ListRequest) (*dto.Page[*models.Review], error) {
+	return s.store.Reviews.ListVisibleByCourse(courseID, request)
+}
+
+func (s *reviewService) GetUserCourseReview(userID, courseID uint) (*models.Review, error) {
+	review, err := s.store.Reviews.GetByUserAndCourse(userID, courseID)
+	if err != nil {
+		return nil, notFound(ErrReviewNotFound, err)
+	}
+	return review, nil
+}
+
+// UpdateReviewPatch lets the author change the rating or the comment of a review
+func (s *reviewService) UpdateReviewPatch(id, userID uint, data map[string]interface{}) (*models.Review, error) {
+	var request dto.UpdateReviewRequest
+	if err := utils.MapToStructStrict(data, &request); err != nil {
+		return nil, invalidData(err)
+	}
+
+	review, err := s.store.Reviews.Get(id)
+	if err != nil {
+		return nil, notFound(ErrReviewNotFound, err)
+	}
+
+	if review.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	if request.Rating != nil && (*request.Rating < 1 || *request.Rating > 5) {
+		return nil, ErrInvalidRating
+	}
+
+	changes := map[string]interface{}{
+		"edited_at": time.Now(),
+	}
+	if request.Rating != nil {
+		changes["rating"] = *request.Rating
+	}
+	if request.Comment != nil {
+		changes["comment"] = strings.TrimSpace(*request.Comment)
+	}
+
+	if err := s.store.Reviews.Patch(id, changes); err != nil {
+		return nil, fmt.Errorf("error al actualizar la reseña: %w", err)
+	}
+
+	if request.Rating != nil {
+		if err := s.store.Reviews.RefreshCourseRating(review.CourseID); err != nil {
+			return nil, fmt.Errorf("error al actualizar la calificación del curso: %w", err)
+		}
+	}
+
+	return s.store.Reviews.Get(id)
+}
+
+// DeleteReview removes a review; authors delete their own and staff can delete any
+func (s *reviewService) DeleteReview(id, userID uint) error {
+	review, err := s.store.Reviews.Get(id)
+	if err != nil {
+		return notFound(ErrReviewNotFound, err)
+	}
+
+	if review.UserID != userID {
+		if err := s.requireStaff(userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.Reviews.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar la reseña: %w", err)
+	}
+
+	if err := s.store.Reviews.RefreshCourseRating(review.CourseID); err != nil {
+		return fmt.Errorf("error al actualizar la calificación del curso: %w", err)
+	}
+
+	return nil
+}
+
+// ReplyReview stores the instructor answer to a review and notifies its author
+func (s *reviewService) ReplyReview(id, userID uint, request *dto.ReplyReviewRequest) (*models.Review, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	review, err := s.store.Reviews.Get(id)
+	if err != nil {
+		return nil, notFound(ErrReviewNotFound, err)
+	}
+
+	data := map[string]interface{}{
+		"reply":         strings.TrimSpace(request.Reply),
+		"replied_at":    time.Now(),
+		"replied_by_id": userID,
+	}
+	if err := s.store.Reviews.Patch(id, data); err != nil {
+		return nil, fmt.Errorf("error al responder la reseña: %w", err)
+	}
+
+	if course, err := s.store.Courses.Get(review.CourseID); err == nil {
+		s.notificationService.DispatchNotification(
+			review.UserID,
+			"Respuesta a tu reseña",
+			fmt.Sprintf("El instructor respondió tu reseña del curso \"%s\"", course.Title),
+			string(enums.NotificationTypeReview),
+		)
+	}
+
+	return s.store.Reviews.Get(id)
+}
+
+// ReportReview flags a review for moderation; a user reports a review only once
+func (s *reviewService) ReportReview(id, userID uint, request *dto.ReportReviewRequest) error {
+	review, err := s.store.Reviews.Get(id)
+	if err != nil {
+		return notFound(ErrReviewNotFound, err)
+	}
+
+	if review.UserID == userID {
+		return ErrCannotReportOwnReview
+	}
+
+	reported, err := s.store.Reviews.HasReport(id, userID)
+	if err != nil {
+		return fmt.Errorf("error al verificar el reporte: %w", err)
+	}
+	if reported {
+		return ErrReviewAlreadyReported
+	}
+
+	report := &models.ReviewReport{
+		ReviewID: id,
+		UserID:   userID,
+		Reason:   strings.TrimSpace(request.Reason),
+	}
+	if err := s.store.Reviews.CreateReport(report); err != nil {
+		return fmt.Errorf("error al reportar la reseña: %w", err)
+	}
+
+	return nil
+}
+
+func (s *reviewService) GetModerationQueue(userID uint, request *dto.ListRequest) (*dto.Page[*models.Review], error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+	return s.store.Reviews.ListReported(request)
+}
+
+// ModerateReview hides, restores or clears the reports of a review. Every action resolves
+// the pending reports and the course rating only counts visible reviews.
+func (s *reviewService) ModerateReview(id, userID uint, request *dto.ModerateReviewRequest) (*models.Review, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	review, err := s.store.Reviews.Get(id)
+	if err != nil {
+		return nil, notFound(ErrReviewNotFound, err)
+	}
+
+	var data map[string]interface{}
+	switch request.Action {
+	case enums.ReviewActionHide:
+		data = map[string]interface{}{
+			"status":        enums.ReviewStatusHidden,
+			"hidden_reason": strings.TrimSpace(request.Reason),
+		}
+	case enums.ReviewActionUnhide:
+		data = map[string]interface{}{
+			"status":        enums.ReviewStatusVisible,
+			"hidden_reason": "",
+		}
+	case enums.ReviewActionDismiss:
+	default:
+		return nil, ErrInvalidModerationAction
+	}
+
+	if data != nil {
+		if err := s.store.Reviews.Patch(id, data); err != nil {
+			return nil, fmt.Errorf("error al moderar la reseña: %w", err)
+		}
+	}
+
+	if err := s.store.Reviews.ResolveReports(id); err != nil {
+		return nil, fmt.Errorf("error al resolver los reportes: %w", err)
+	}
+
+	if data != nil {
+		if err := s.store.Reviews.RefreshCourseRating(review.CourseID); err != nil {
+			return nil, fmt.Errorf("error al actualizar la calificación del curso: %w", err)
+		}
+	}
+
+	return s.store.Reviews.Get(id)
+}
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/utils"
+)
+
+var ErrInvalidRevisionEntity = apperrors.New("INVALID_REVISION_ENTITY", apperrors.KindInvalid, "tipo de entidad inválido", "invalid entity type")
+
+// revisionFields lists, per entity, the columns stored in each revision in display order.
+// Ordering fields are left out: reordering is not an editorial change.
+var revisionFields = map[enums.RevisionEntityType][]string{
+	enums.RevisionEntityContent:    {"title", "description", "type", "body", "media_url", "media_duration"},
+	enums.RevisionEntityEvaluation: {"title", "description", "question_count", "answer_options_count", "passing_score", "max_attempts", "time_limit", "scoring_policy"},
+	enums.RevisionEntityQuestion:   {"text", "type", "explanation", "points", "scoring_policy", "difficulty"},
+	enums.RevisionEntityAnswer:     {"text", "is_correct", "match_text", "tolerance", "blank"},
+}
+
+type RevisionService interface {
+	Record(entityType enums.RevisionEntityType, entityID, authorID uint, before, after models.RevisionSnapshot) error
+	EnsureVersions(entityType enums.RevisionEntityType, snapshots map[uint]models.RevisionSnapshot) (map[uint]int, error)
+	ListRevisions(entityType enums.RevisionEntityType, entityID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Revision], error)
+	GetRevision(entityType enums.RevisionEntityType, entityID uint, version int, userID uint) (*models.Revision, error)
+	Diff(entityType enums.RevisionEntityType, entityID uint, fromVersion, toVersion int, userID uint) (*dto.RevisionDiff, error)
+	Rollback(entityType enums.RevisionEntityType, entityID uint, version int, authorID uint) (*models.Revision, error)
+}
+
+type revisionService struct {
+	*Service
+}
+
+func NewRevisionService(service *Service) RevisionService {
+	return &revisionService{
+		Service: service,
+	}
+}
+
+// Record stores a new revision when the tracked fields changed.
+// Entities edited before revisions existed get their previous state saved first as the initial version.
+func (s *revisionService) Record(entityType enums.RevisionEntityType, entityID, authorID uint, before, after models.RevisionSnapshot) error {
+	return s.record(entityType, entityID, authorID, before, after, "")
+}
+
+func (s *revisionService) record(entityType enums.RevisionEntityType, entityID, authorID uint, before, after models.RevisionSnapshot, summary string) error {
+	if _, ok := revisionFields[entityType]; !ok {
+		return ErrInvalidRevisionEntity
+	}
+
+	latest, err := s.store.Revisions.GetLatest(entityType, entityID)
+	if err != nil {
+		return fmt.Errorf("error al obtener la última revisión: %w", err)
+	}
+
+	if latest == nil && before != nil {
+		latest = &models.Revision{
+			EntityType: entityType,
+			EntityID:   entityID,
+			Summary:    "Versión inicial",
+			Snapshot:   normalizeSnapshot(before),
+		}
+		if err := s.store.Revisions.Create(latest); err != nil {
+			return fmt.Errorf("error al guardar la versión inicial: %w", err)
+		}
+	}
+
+	after = normalizeSnapshot(after)
+
+	var changed []string
+	if latest != nil {
+		changed = changedFields(entityType, latest.Snapshot, after)
+		if len(changed) == 0 {
+			return nil
+		}
+	}
+
+	if summary == "" {
+		if latest == nil {
+			summary = "Versión inicial"
+		} else {
+			summary = "Campos modificados: " + strings.Join(changed, ", ")
+		}
+	}
+
+	revision := &models.Revision{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Summary:    summary,
+		Snapshot:   after,
+	}
+	if authorID != 0 {
+		revision.AuthorID = &authorID
+	}
+
+	if err := s.store.Revisions.Create(revision); err != nil {
+		return fmt.Errorf("error al guardar la revisión: %w", err)
+	}
+	return nil
+}
+
+// EnsureVersions returns the current revision version of each entity, saving the
+// given snapshot as the initial version for entities that have no history yet
+func (s *revisionService) EnsureVersions(entityType enums.RevisionEntityType, snapshots map[uint]models.RevisionSnapshot) (map[uint]int, error) {
+	ids := make([]uint, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+
+	versions, err := s.store.Revisions.GetLatestVersions(entityType, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las revisiones: %w", err)
+	}
+
+	for id, snapshot := range snapshots {
+		if _, ok := versions[id]; ok {
+			continue
+		}
+		if err := s.record(entityType, id, 0, nil, snapshot, ""); err != nil {
+			return nil, err
+		}
+		versions[id] = 1
+	}
+
+	return versions, nil
+}
+
+func (s *revisionService) ListRevisions(entityType enums.RevisionEntityType, entityID, userID uint, request *dto.ListRequest) (*dto.Page[*models.Revision], error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+	if _, ok := revisionFields[entityType]; !ok {
+		return nil, ErrInvalidRevisionEntity
+	}
+
+	page, err := s.store.Revisions.ListByEntity(entityType, entityID, request)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las revisiones: %w", err)
+	}
+	return page, nil
+}
+
+func (s *revisionService) GetRevision(entityType enums.RevisionEntityType, entityID uint, version int, userID uint) (*models.Revision, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+	return s.getRevision(entityType, entityID, version)
+}
+
+func (s *revisionService) getRevision(entityType enums.RevisionEntityType, entityID uint, version int) (*models.Revision, error) {
+	if _, ok := revisionFields[entityType]; !ok {
+		return nil, ErrInvalidRevisionEntity
+	}
+
+	revision, err := s.store.Revisions.GetVersion(entityType, entityID, version)
+	if err != nil {
+		return nil, notFound(ErrRevisionNotFound, err)
+	}
+	return revision, nil
+}
+
+func (s *revisionService) Diff(entityType enums.RevisionEntityType, entityID uint, fromVersion, toVersion int, userID uint) (*dto.RevisionDiff, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	from, err := s.getRevision(entityType, entityID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.getRevision(entityType, entityID, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &dto.RevisionDiff{
+		EntityType:  entityType,
+		EntityID:    entityID,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Changes:     []dto.RevisionFieldChange{},
+	}
+
+	for _, field := range changedFields(entityType, from.Snapshot, to.Snapshot) {
+		change := dto.RevisionFieldChange{
+			Field: field,
+			From:  from.Snapshot[field],
+			To:    to.Snapshot[field],
+		}
+
+		// Multiline text gets a line diff so long bodies can be reviewed
+		fromText, fromIsText := change.From.(string)
+		toText, toIsText := change.To.(string)
+		if fromIsText && toIsText && (strings.Contains(fromText, "\n") || strings.Contains(toText, "\n")) {
+			change.Lines = utils.DiffLines(fromText, toText)
+		}
+
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	return diff, nil
+}
+
+// Rollback restores the fields of a previous revision and records the result as a new revision
+func (s *revisionService) Rollback(entityType enums.RevisionEntityType, entityID uint, version int, authorID uint) (*models.Revision, error) {
+	if err := s.requireStaff(authorID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.getRevision(entityType, entityID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.loadSnapshot(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	for _, field := range revisionFields[entityType] {
+		value, ok := target.Snapshot[field]
+		if !ok {
+			continue
+		}
+		// JSON numbers come back as float64; integer columns expect integers
+		if number, isNumber := value.(float64); isNumber && number == math.Trunc(number) {
+			value = int64(number)
+		}
+		data[field] = value
+	}
+
+	if err := s.patchEntity(entityType, entityID, data); err != nil {
+		return nil, fmt.Errorf("error al restaurar la revisión: %w", err)
+	}
+
+	after, err := s.loadSnapshot(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("Restaurada la versión %d", version)
+	if err := s.record(entityType, entityID, authorID, before, after, summary); err != nil {
+		return nil, err
+	}
+
+	latest, err := s.store.Revisions.GetLatest(entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la última revisión: %w", err)
+	}
+	return latest, nil
+}
+
+func (s *revisionService) loadSnapshot(entityType enums.RevisionEntityType, entityID uint) (models.RevisionSnapshot, error) {
+	switch entityType {
+	case enums.RevisionEntityContent:
+		content, err := s.store.Contents.Get(entityID)
+		if err != nil {
+			return nil, notFound(ErrContentNotFound, err)
+		}
+		return contentSnapshot(content), nil
+	case enums.RevisionEntityEvaluation:
+		evaluation, err := s.store.Evaluations.Get(entityID)
+		if err != nil {
+			return nil, notFound(ErrEvaluationNotFound, err)
+		}
+		return evaluationSnapshot(evaluation), nil
+	case enums.RevisionEntityQuestion:
+		question, err := s.store.Questions.Get(entityID)
+		if err != nil {
+			return nil, notFound(ErrQuestionNotFound, err)
+		}
+		return questionSnapshot(question), nil
+	case enums.RevisionEntityAnswer:
+		answer, err := s.store.Answers.Get(entityID)
+		if err != nil {
+			return nil, notFound(ErrAnswerNotFound, err)
+		}
+		return answerSnapshot(answer), nil
+	}
+	return nil, ErrInvalidRevisionEntity
+}
+
+func (s *revisionService) patchEntity(entityType enums.RevisionEntityType, entityID uint, data map[string]interface{}) error {
+	switch entityType {
+	case enums.RevisionEntityContent:
+		return s.store.Contents.Patch(entityID, data)
+	case enums.RevisionEntityEvaluation:
+		return s.store.Evaluations.Patch(entityID, data)
+	case enums.RevisionEntityQuestion:
+		return s.store.Questions.Patch(entityID, data)
+	case enums.RevisionEntityAnswer:
+		return s.store.Answers.Patch(entityID, data)
+	}
+	return ErrInvalidRevisionEntity
+}
+
+func contentSnapshot(content *models.Content) models.RevisionSnapshot {
+	return models.RevisionSnapshot{
+		"title":          content.Title,
+		"description":    content.Description,
+		"type":           content.Type,
+		"body":           content.Body,
+		"media_url":      content.MediaURL,
+		"media_duration": content.MediaDuration,
+	}
+}
+
+func evaluationSnapshot(evaluation *models.Evaluation) models.RevisionSnapshot {
+	return models.RevisionSnapshot{
+		"title":                evaluation.Title,
+		"description":          evaluation.Description,
+		"question_count":       evaluation.QuestionCount,
+		"answer_options_count": evaluation.AnswerOptionsCount,
+		"passing_score":        evaluation.PassingScore,
+		"max_attempts":         evaluation.MaxAttempts,
+		"time_limit":           evaluation.TimeLimit,
+		"scoring_policy":       evaluation.ScoringPolicy,
+	}
+}
+
+func questionSnapshot(question *models.Question) models.RevisionSnapshot {
+	return models.RevisionSnapshot{
+		"text":           question.Text,
+		"type":           question.Type,
+		"explanation":    question.Explanation,
+		"points":         question.Points,
+		"scoring_policy": question.ScoringPolicy,
+		"difficulty":     question.Difficulty,
+	}
+}
+
+func answerSnapshot(answer *models.Answer) models.RevisionSnapshot {
+	return models.RevisionSnapshot{
+		"text":       answer.Text,
+		"is_correct": answer.IsCorrect,
+		"match_text": answer.MatchText,
+		"tolerance":  answer.Tolerance,
+		"blank":      answer.Blank,
+	}
+}
+
+// normalizeSnapshot round-trips the snapshot through JSON so freshly built
+// snapshots compare equal to the ones read back from the database
+func normalizeSnapshot(snapshot models.RevisionSnapshot) models.RevisionSnapshot {
+	bytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return snapshot
+	}
+
+	var normalized models.RevisionSnapshot
+	if err := json.Unmarshal(bytes, &normalized); err != nil {
+		return snapshot
+	}
+	return normalized
+}
+
+func changedFields(entityType enums.RevisionEntityType, from, to models.RevisionSnapshot) []string {
+	var changed []string
+	for _, field := range revisionFields[entityType] {
+		if !reflect.DeepEqual(from[field], to[field]) {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"math"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+var ErrInvalidScoringPolicy = apperrors.New("INVALID_SCORING_POLICY", apperrors.KindInvalid, "política de puntuación inválida", "invalid scoring policy")
+
+func isValidScoringPolicy(policy enums.ScoringPolicy) bool {
+	switch policy {
+	case enums.ScoringAllOrNothing, enums.ScoringPartialCredit, enums.ScoringRightMinusWrong:
+		return true
+	}
+	return false
+}
+
+func invalidScoringPolicy(policy enums.ScoringPolicy) error {
+	return ErrInvalidScoringPolicy.WithDetails(map[string]interface{}{"scoring_policy": policy})
+}
+
+// answerTally counts the parts of an answer: the items of the key, the ones answered
+// right and the ones answered wrong. Unanswered items count as neither.
+type answerTally struct {
+	items float64
+	right float64
+	wrong float64
+	// extra are the wrong options selected in multiple choice questions. They also cost
+	// partial credit, otherwise selecting every option would earn it.
+	extra float64
+	// wrongWeight is what each wrong item takes away under right_minus_wrong. Single choice
+	// questions use 1/(options-1) so guessing is worth zero on average.
+	wrongWeight float64
+}
+
+// scoreAttempt grades every answer of the attempt with the scoring policy of its question
+// and sets the raw score, the late penalty and the passed flag. It is the only scoring
+// path, shared by submissions and rescoring.
+func (s *evaluationAttemptService) scoreAttempt(attempt *models.EvaluationAttempt, evaluation *models.Evaluation) {
+	questionMap := make(map[uint]*models.AttemptQuestion, len(attempt.Questions))
+	for i := range attempt.Questions {
+		questionMap[attempt.Questions[i].ID] = &attempt.Questions[i]
+	}
+
+	totalScore := 0.0
+	for i := range attempt.Answers {
+		answer := &attempt.Answers[i]
+		question, exists := questionMap[answer.AttemptQuestionID]
+		if !exists {
+			s.logger.Warnf("Attempt question %d not found for answer", answer.AttemptQuestionID)
+			answer.IsCorrect, answer.Points = false, 0
+			continue
+		}
+
+		policy := question.ScoringPolicy
+		if policy == "" {
+			policy = evaluation.ScoringPolicy
+		}
+
+		answer.IsCorrect = isAnswerCorrect(question, answer)
+		answer.Points = roundPoints(answerFraction(question, answer, policy) * float64(question.Points))
+		totalScore += answer.Points
+	}
+
+	// Negative marking never takes the attempt below zero
+	attempt.RawScore = roundScore(math.Max(totalScore, 0))
+	s.applyLatePenalty(attempt, evaluation)
+
+	attempt.Passed = false
+	if attempt.TotalPoints > 0 {
+		percentage := attempt.Score / attempt.TotalPoints * 100
+		attempt.Passed = percentage >= float64(evaluation.PassingScore)
+	}
+}
+
+// answerFraction returns the share of the question points the answer earns under the
+// policy, between -1 and 1
+func answerFraction(question *models.AttemptQuestion, answer *models.AttemptAnswer, policy enums.ScoringPolicy) float64 {
+	if policy == enums.ScoringAllOrNothing || policy == "" {
+		if isAnswerCorrect(question, answer) {
+			return 1
+		}
+		return 0
+	}
+
+	tally := tallyAnswer(question, answer)
+	if tally.items == 0 {
+		return 0
+	}
+
+	switch policy {
+	case enums.ScoringPartialCredit:
+		return math.Max(tally.right-tally.extra, 0) / tally.items
+	case enums.ScoringRightMinusWrong:
+		fraction := (tally.right - tally.wrong*tally.wrongWeight) / tally.items
+		return math.Max(math.Min(fraction, 1), -1)
+	default:
+		return 0
+	}
+}
+
+// tallyAnswer splits the answer into the items of its question type
+func tallyAnswer(question *models.AttemptQuestion, answer *models.AttemptAnswer) answerTally {
+	tally := answerTally{wrongWeight: 1}
+
+	switch question.Type {
+	case enums.QuestionTypeSingle, enums.QuestionTypeTrueFalse:
+		tally.items = 1
+		if len(question.AnswerOptions) > 1 {
+			tally.wrongWeight = 1 / float64(len(question.AnswerOptions)-1)
+		}
+		if len(answer.SelectedOptionIDs) == 0 {
+			break
+		}
+		if isSingleChoiceCorrect(question, answer.SelectedOptionIDs) {
+			tally.right = 1
+		} else {
+			tally.wrong = 1
+		}
+
+	case enums.QuestionTypeMultiple:
+		// Items are the correct options; selecting a wrong one counts against them
+		selected := make(map[uint]bool, len(answer.SelectedOptionIDs))
+		for _, id := range answer.SelectedOptionIDs {
+			selected[id] = true
+		}
+		for _, option := range question.AnswerOptions {
+			if option.IsCorrect {
+				tally.items++
+			}
+			if !selected[option.ID] {
+				continue
+			}
+			if option.IsCorrect {
+				tally.right++
+			} else {
+				tally.wrong++
+				tally.extra++
+			}
+		}
+
+	case enums.QuestionTypeShortAnswer, enums.QuestionTypeNumeric:
+		tally.items = 1
+		if normalizeAnswerText(answer.Text) == "" {
+			break
+		}
+		if isAnswerCorrect(question, answer) {
+			tally.right = 1
+		} else {
+			tally.wrong = 1
+		}
+
+	case enums.QuestionTypeOrdering:
+		// Each item in its position is right; items placed elsewhere are wrong
+		positions := make(map[uint]int, len(question.AnswerOptions))
+		for _, option := range question.AnswerOptions {
+			positions[option.ID] = option.Position
+		}
+		tally.items = float64(len(question.AnswerOptions))
+		seen := make(map[uint]bool, len(answer.OrderedOptionIDs))
+		for i, id := range answer.OrderedOptionIDs {
+			if seen[id] || i >= len(question.AnswerOptions) {
+				continue
+			}
+			seen[id] = true
+			if positions[id] == i+1 {
+				tally.right++
+			} else {
+				tally.wrong++
+			}
+		}
+
+	case enums.QuestionTypeMatching:
+		tally.items = float64(len(question.AnswerOptions))
+		seen := make(map[uint]bool, len(answer.Matches))
+		for _, match := range answer.Matches {
+			if seen[match.OptionID] {
+				continue
+			}
+			seen[match.OptionID] = true
+			if isMatchRight(question, match) {
+				tally.right++
+			} else {
+				tally.wrong++
+			}
+		}
+
+	case enums.QuestionTypeFillBlank:
+		tally.items = float64(question.BlankCount)
+		for i, text := range answer.Blanks {
+			if i >= question.BlankCount || normalizeAnswerText(text) == "" {
+				continue
+			}
+			if isTextAccepted(question.AcceptedAnswers, i+1, text) {
+				tally.right++
+			} else {
+				tally.wrong++
+			}
+		}
+	}
+
+	return tally
+}
+
+// roundPoints keeps answer points readable while the sum stays accurate to the cent
+func roundPoints(points float64) float64 {
+	return math.Round(points*10000) / 10000
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
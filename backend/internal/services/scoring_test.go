@@ -0,0 +1,159 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"go.uber.org/zap"
+)
+
+// choiceQuestion builds a choice question with options numbered from 1; the listed ones are correct
+func choiceQuestion(questionType enums.QuestionType, options int, correct ...uint) *models.AttemptQuestion {
+	question := &models.AttemptQuestion{ID: 1, Type: questionType, Points: 1}
+	for id := uint(1); id <= uint(options); id++ {
+		option := models.AttemptAnswerOption{ID: id}
+		for _, correctID := range correct {
+			option.IsCorrect = option.IsCorrect || correctID == id
+		}
+		question.AnswerOptions = append(question.AnswerOptions, option)
+	}
+	return question
+}
+
+func TestAnswerFraction(t *testing.T) {
+	single := choiceQuestion(enums.QuestionTypeSingle, 4, 1)
+	multiple := choiceQuestion(enums.QuestionTypeMultiple, 4, 1, 2)
+	ordering := &models.AttemptQuestion{ID: 1, Type: enums.QuestionTypeOrdering, AnswerOptions: []models.AttemptAnswerOption{
+		{ID: 1, Position: 2}, {ID: 2, Position: 3}, {ID: 3, Position: 1},
+	}}
+	fillBlank := &models.AttemptQuestion{ID: 1, Type: enums.QuestionTypeFillBlank, BlankCount: 2, AcceptedAnswers: []models.AttemptAcceptedAnswer{
+		{Text: "Bogotá", Blank: 1}, {Text: "Medellín", Blank: 2},
+	}}
+	numeric := &models.AttemptQuestion{ID: 1, Type: enums.QuestionTypeNumeric, AcceptedAnswers: []models.AttemptAcceptedAnswer{
+		{Text: "3,14", Tolerance: 0.01},
+	}}
+
+	tests := []struct {
+		name     string
+		question *models.AttemptQuestion
+		answer   models.AttemptAnswer
+		policy   enums.ScoringPolicy
+		want     float64
+	}{
+		{"single right", single, models.AttemptAnswer{SelectedOptionIDs: []uint{1}}, enums.ScoringRightMinusWrong, 1},
+		{"single wrong costs a share per distractor", single, models.AttemptAnswer{SelectedOptionIDs: []uint{2}}, enums.ScoringRightMinusWrong, -1.0 / 3},
+		{"single unanswered", single, models.AttemptAnswer{}, enums.ScoringRightMinusWrong, 0},
+		{"single wrong without negative marking", single, models.AttemptAnswer{SelectedOptionIDs: []uint{2}}, enums.ScoringPartialCredit, 0},
+		{"empty policy is all or nothing", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{1}}, "", 0},
+		{"all or nothing right", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{2, 1}}, enums.ScoringAllOrNothing, 1},
+		{"all or nothing partial", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{1}}, enums.ScoringAllOrNothing, 0},
+		{"partial credit half", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{1}}, enums.ScoringPartialCredit, 0.5},
+		{"partial credit extra selection cancels a right one", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{1, 3}}, enums.ScoringPartialCredit, 0},
+		{"partial credit both right and one extra", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{1, 2, 3}}, enums.ScoringPartialCredit, 0.5},
+		{"partial credit selecting every option", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{1, 2, 3, 4}}, enums.ScoringPartialCredit, 0},
+		{"partial credit never negative", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{3, 4}}, enums.ScoringPartialCredit, 0},
+		{"right minus wrong multiple", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{3}}, enums.ScoringRightMinusWrong, -0.5},
+		{"right minus wrong floors at minus one", multiple, models.AttemptAnswer{SelectedOptionIDs: []uint{3, 4}}, enums.ScoringRightMinusWrong, -1},
+		{"ordering one item in place", ordering, models.AttemptAnswer{OrderedOptionIDs: []uint{3, 2, 1}}, enums.ScoringPartialCredit, 1.0 / 3},
+		{"ordering right", ordering, models.AttemptAnswer{OrderedOptionIDs: []uint{3, 1, 2}}, enums.ScoringPartialCredit, 1},
+		{"ordering repeated items count once", ordering, models.AttemptAnswer{OrderedOptionIDs: []uint{3, 3, 3}}, enums.ScoringPartialCredit, 1.0 / 3},
+		{"fill blank one of two", fillBlank, models.AttemptAnswer{Blanks: []string{"bogota", "Cali"}}, enums.ScoringPartialCredit, 0.5},
+		{"fill blank right minus wrong", fillBlank, models.AttemptAnswer{Blanks: []string{"Cali", ""}}, enums.ScoringRightMinusWrong, -0.5},
+		{"numeric with decimal comma", numeric, models.AttemptAnswer{Text: "3.145"}, enums.ScoringAllOrNothing, 1},
+		{"numeric out of tolerance", numeric, models.AttemptAnswer{Text: "3,2"}, enums.ScoringRightMinusWrong, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := answerFraction(tt.question, &tt.answer, tt.policy)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("answerFraction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTallyAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		question *models.AttemptQuestion
+		answer   models.AttemptAnswer
+		want     answerTally
+	}{
+		{
+			name:     "true false weighs a wrong answer fully",
+			question: choiceQuestion(enums.QuestionTypeTrueFalse, 2, 1),
+			answer:   models.AttemptAnswer{SelectedOptionIDs: []uint{2}},
+			want:     answerTally{items: 1, wrong: 1, wrongWeight: 1},
+		},
+		{
+			name:     "single choice weighs a wrong answer by its distractors",
+			question: choiceQuestion(enums.QuestionTypeSingle, 5, 3),
+			answer:   models.AttemptAnswer{SelectedOptionIDs: []uint{3}},
+			want:     answerTally{items: 1, right: 1, wrongWeight: 0.25},
+		},
+		{
+			name:     "multiple choice counts extra selections",
+			question: choiceQuestion(enums.QuestionTypeMultiple, 5, 1, 2, 3),
+			answer:   models.AttemptAnswer{SelectedOptionIDs: []uint{1, 4, 5}},
+			want:     answerTally{items: 3, right: 1, wrong: 2, extra: 2, wrongWeight: 1},
+		},
+		{
+			name:     "blank short answer is unanswered",
+			question: &models.AttemptQuestion{Type: enums.QuestionTypeShortAnswer, AcceptedAnswers: []models.AttemptAcceptedAnswer{{Text: "agua"}}},
+			answer:   models.AttemptAnswer{Text: "  "},
+			want:     answerTally{items: 1, wrongWeight: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tallyAnswer(tt.question, &tt.answer); got != tt.want {
+				t.Errorf("tallyAnswer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreAttemptFloorsNegativeMarkingAtZero(t *testing.T) {
+	service := &evaluationAttemptService{Service: &Service{logger: zap.NewNop().Sugar()}}
+	evaluation := &models.Evaluation{ScoringPolicy: enums.ScoringRightMinusWrong, PassingScore: 60}
+
+	tests := []struct {
+		name      string
+		selected  [2]uint
+		wantScore float64
+		wantPass  bool
+	}{
+		{"both wrong", [2]uint{2, 2}, 0, false},
+		{"right and wrong cancel out", [2]uint{1, 2}, 0, false},
+		{"both right", [2]uint{1, 1}, 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := choiceQuestion(enums.QuestionTypeTrueFalse, 2, 1)
+			second := choiceQuestion(enums.QuestionTypeTrueFalse, 2, 1)
+			first.Points, second.ID, second.Points = 2, 2, 2
+
+			attempt := &models.EvaluationAttempt{
+				Questions:   models.AttemptQuestions{*first, *second},
+				TotalPoints: 4,
+				Answers: models.AttemptAnswers{
+					{AttemptQuestionID: 1, SelectedOptionIDs: []uint{tt.selected[0]}},
+					{AttemptQuestionID: 2, SelectedOptionIDs: []uint{tt.selected[1]}},
+				},
+			}
+			service.scoreAttempt(attempt, evaluation)
+
+			if attempt.RawScore != tt.wantScore || attempt.Score != tt.wantScore {
+				t.Errorf("score = %v (raw %v), want %v", attempt.Score, attempt.RawScore, tt.wantScore)
+			}
+			if attempt.Passed != tt.wantPass {
+				t.Errorf("passed = %v, want %v", attempt.Passed, tt.wantPass)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/repositories"
+)
+
+var ErrEmptySearchQuery = apperrors.New("EMPTY_SEARCH_QUERY", apperrors.KindInvalid, "el término de búsqueda no puede estar vacío", "the search term cannot be empty")
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+type SearchService interface {
+	Search(userID uint, request *dto.SearchRequest, locale dto.RequestLocale) ([]*dto.SearchResult, error)
+}
+
+type searchService struct {
+	*Service
+	translationService TranslationService
+}
+
+func NewSearchService(service *Service, translationService TranslationService) SearchService {
+	return &searchService{
+		Service:            service,
+		translationService: translationService,
+	}
+}
+
+// Search ranks the course material matching the query; titles are shown in the locale
+// asked by the request or the user
+func (s *searchService) Search(userID uint, request *dto.SearchRequest, locale dto.RequestLocale) ([]*dto.SearchResult, error) {
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	user, err := s.store.Users.GetByID(userID)
+	if err != nil {
+		return nil, notFound(ErrUserNotFound, err)
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	offset := request.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	language := request.Language
+	if language != enums.SearchLanguageEnglish {
+		language = enums.SearchLanguageSpanish
+	}
+
+	results, err := s.store.Search.Search(repositories.SearchFilter{
+		Query:    query,
+		Language: language,
+		CourseID: request.CourseID,
+		UserID:   user.ID,
+		// Students only see published courses or courses they are enrolled in, and only the
+		// material of the modules already released to them
+		Restricted: user.Role == enums.UserRoleStudent,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar la búsqueda: %w", err)
+	}
+
+	if err := s.translationService.LocalizeSearchResults(user.ID, locale, results...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -1,11 +1,52 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
 	"github.com/imlargo/go-api-template/internal/cache"
 	"github.com/imlargo/go-api-template/internal/config"
+	"github.com/imlargo/go-api-template/internal/enums"
 	"github.com/imlargo/go-api-template/internal/store"
 	"github.com/imlargo/go-api-template/pkg/kv"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrForbidden   = apperrors.New("FORBIDDEN", apperrors.KindForbidden, "no tienes permiso para realizar esta acción", "you are not allowed to perform this action")
+	ErrNotEnrolled = apperrors.New("NOT_ENROLLED", apperrors.KindForbidden, "el usuario no está inscrito en el curso", "the user is not enrolled in the course")
+	ErrInvalidData = apperrors.New("INVALID_DATA", apperrors.KindInvalid, "datos inválidos", "invalid data")
+	ErrInvalidID   = apperrors.New("INVALID_ID", apperrors.KindInvalid, "el ID no puede ser cero", "the ID cannot be zero")
+)
+
+// Not found errors of the main entities, so clients can tell which record is missing
+var (
+	ErrUserNotFound         = apperrors.New("USER_NOT_FOUND", apperrors.KindNotFound, "usuario no encontrado", "user not found")
+	ErrCourseNotFound       = apperrors.New("COURSE_NOT_FOUND", apperrors.KindNotFound, "curso no encontrado", "course not found")
+	ErrCategoryNotFound     = apperrors.New("CATEGORY_NOT_FOUND", apperrors.KindNotFound, "categoría no encontrada", "category not found")
+	ErrModuleNotFound       = apperrors.New("MODULE_NOT_FOUND", apperrors.KindNotFound, "módulo no encontrado", "module not found")
+	ErrContentNotFound      = apperrors.New("CONTENT_NOT_FOUND", apperrors.KindNotFound, "contenido no encontrado", "content not found")
+	ErrEvaluationNotFound   = apperrors.New("EVALUATION_NOT_FOUND", apperrors.KindNotFound, "evaluación no encontrada", "evaluation not found")
+	ErrQuestionNotFound     = apperrors.New("QUESTION_NOT_FOUND", apperrors.KindNotFound, "pregunta no encontrada", "question not found")
+	ErrAnswerNotFound       = apperrors.New("ANSWER_NOT_FOUND", apperrors.KindNotFound, "respuesta no encontrada", "answer not found")
+	ErrAttemptNotFound      = apperrors.New("ATTEMPT_NOT_FOUND", apperrors.KindNotFound, "intento no encontrado", "attempt not found")
+	ErrEnrollmentNotFound   = apperrors.New("ENROLLMENT_NOT_FOUND", apperrors.KindNotFound, "inscripción no encontrada", "enrollment not found")
+	ErrProgressNotFound     = apperrors.New("PROGRESS_NOT_FOUND", apperrors.KindNotFound, "progreso no encontrado", "progress not found")
+	ErrCohortNotFound       = apperrors.New("COHORT_NOT_FOUND", apperrors.KindNotFound, "cohorte no encontrada", "cohort not found")
+	ErrLearningPathNotFound = apperrors.New("LEARNING_PATH_NOT_FOUND", apperrors.KindNotFound, "ruta de aprendizaje no encontrada", "learning path not found")
+	ErrReviewNotFound       = apperrors.New("REVIEW_NOT_FOUND", apperrors.KindNotFound, "reseña no encontrada", "review not found")
+	ErrFileNotFound         = apperrors.New("FILE_NOT_FOUND", apperrors.KindNotFound, "archivo no encontrado", "file not found")
+	ErrThreadNotFound       = apperrors.New("THREAD_NOT_FOUND", apperrors.KindNotFound, "hilo no encontrado", "thread not found")
+	ErrAnnouncementNotFound = apperrors.New("ANNOUNCEMENT_NOT_FOUND", apperrors.KindNotFound, "anuncio no encontrado", "announcement not found")
+	ErrAttachmentNotFound   = apperrors.New("ATTACHMENT_NOT_FOUND", apperrors.KindNotFound, "adjunto no encontrado", "attachment not found")
+	ErrDeadlineNotFound     = apperrors.New("DEADLINE_NOT_FOUND", apperrors.KindNotFound, "fecha límite no encontrada", "deadline not found")
+	ErrOverrideNotFound     = apperrors.New("DEADLINE_OVERRIDE_NOT_FOUND", apperrors.KindNotFound, "excepción de fechas no encontrada", "deadline override not found")
+	ErrRevisionNotFound     = apperrors.New("REVISION_NOT_FOUND", apperrors.KindNotFound, "revisión no encontrada", "revision not found")
+	ErrNoteNotFound         = apperrors.New("NOTE_NOT_FOUND", apperrors.KindNotFound, "nota no encontrada", "note not found")
+	ErrTrackNotFound        = apperrors.New("TRACK_NOT_FOUND", apperrors.KindNotFound, "pista de subtítulos no encontrada", "caption track not found")
+	ErrQuestionBankNotFound = apperrors.New("QUESTION_BANK_NOT_FOUND", apperrors.KindNotFound, "banco de preguntas no encontrado", "question bank not found")
 )
 
 type Service struct {
@@ -31,3 +72,65 @@ func NewService(
 		cache,
 	}
 }
+
+// userHasRole reports whether the user has one of the given roles
+func (s *Service) userHasRole(userID uint, roles ...enums.UserRole) (bool, error) {
+	user, err := s.store.Users.GetByID(userID)
+	if err != nil {
+		return false, notFound(ErrUserNotFound, err)
+	}
+
+	for _, role := range roles {
+		if user.Role == role {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// requireStaff returns ErrForbidden unless the user is an instructor or an admin
+func (s *Service) requireStaff(userID uint) error {
+	allowed, err := s.userHasRole(userID, enums.UserRoleInstructor, enums.UserRoleAdmin)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// requireCourseStaff returns ErrForbidden unless the user is an admin or teaches a cohort of the course
+func (s *Service) requireCourseStaff(userID, courseID uint) error {
+	user, err := s.store.Users.GetByID(userID)
+	if err != nil {
+		return notFound(ErrUserNotFound, err)
+	}
+
+	switch user.Role {
+	case enums.UserRoleAdmin:
+		return nil
+	case enums.UserRoleInstructor:
+		teaches, err := s.store.Cohorts.IsCourseInstructor(courseID, userID)
+		if err != nil {
+			return fmt.Errorf("error al verificar los instructores del curso: %w", err)
+		}
+		if teaches {
+			return nil
+		}
+	}
+	return ErrForbidden
+}
+
+// notFound reports a missing record as the given domain error; other failures pass through
+func notFound(notFoundErr *apperrors.Error, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return notFoundErr.Wrap(err)
+	}
+	return err
+}
+
+// invalidData reports a payload that does not match the request DTO
+func invalidData(err error) error {
+	return ErrInvalidData.Wrap(err).WithDetails(map[string]interface{}{"reason": err.Error()})
+}
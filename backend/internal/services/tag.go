@@ -0,0 +1,37 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+type TagService interface {
+	GetAllTags() ([]*models.Tag, error)
+	DeleteTag(id uint) error
+}
+
+type tagService struct {
+	*Service
+}
+
+func NewTagService(service *Service) TagService {
+	return &tagService{
+		Service: service,
+	}
+}
+
+func (s *tagService) GetAllTags() ([]*models.Tag, error) {
+	tags, err := s.store.Tags.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las etiquetas: %w", err)
+	}
+	return tags, nil
+}
+
+func (s *tagService) DeleteTag(id uint) error {
+	if err := s.store.Tags.Delete(id); err != nil {
+		return fmt.Errorf("error al eliminar la etiqueta: %w", err)
+	}
+	return nil
+}
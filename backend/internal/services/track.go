@@ -0,0 +1,212 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/models"
+	"github.com/imlargo/go-api-template/pkg/captions"
+	"github.com/imlargo/go-api-template/pkg/storage"
+)
+
+// maxTrackSize bounds caption and transcript uploads, which are read into memory to be validated
+const maxTrackSize = 5 * 1024 * 1024 // 5MB
+
+var (
+	ErrContentHasNoMedia  = apperrors.New("CONTENT_HAS_NO_MEDIA", apperrors.KindConflict, "el contenido no tiene un video o audio asociado", "the content has no video or audio")
+	ErrInvalidTrackKind   = apperrors.New("INVALID_TRACK_KIND", apperrors.KindInvalid, "el tipo de pista debe ser captions o transcript", "the track kind must be captions or transcript")
+	ErrInvalidTrackLocale = apperrors.New("INVALID_TRACK_LOCALE", apperrors.KindInvalid, "el idioma de la pista no es válido", "the track language is not valid")
+	ErrInvalidCaptions    = apperrors.New("INVALID_CAPTIONS", apperrors.KindInvalid, "el archivo de subtítulos no es válido", "the caption file is not valid")
+	ErrEmptyTranscript    = apperrors.New("EMPTY_TRANSCRIPT", apperrors.KindInvalid, "la transcripción está vacía", "the transcript is empty")
+)
+
+// trackLocale matches BCP 47 style tags like "es", "en-us" or "pt-br"
+var trackLocale = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+type TrackService interface {
+	UploadTrack(contentID uint, request *dto.UploadTrackRequest, file *multipart.FileHeader, userID uint) (*models.ContentTrack, error)
+	GetTracks(contentID uint) ([]*models.ContentTrack, error)
+	DeleteTrack(id, userID uint) error
+}
+
+type trackService struct {
+	*Service
+	fileService FileService
+}
+
+func NewTrackService(service *Service, fileService FileService) TrackService {
+	return &trackService{
+		Service:     service,
+		fileService: fileService,
+	}
+}
+
+// UploadTrack validates a caption or transcript file and stores it for the content, replacing
+// the track of the same kind and language. Captions in SRT are converted to WebVTT so players
+// get a single format.
+func (s *trackService) UploadTrack(contentID uint, request *dto.UploadTrackRequest, file *multipart.FileHeader, userID uint) (*models.ContentTrack, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	content, err := s.store.Contents.Get(contentID)
+	if err != nil {
+		return nil, notFound(ErrContentNotFound, err)
+	}
+	if content.MediaURL == "" {
+		return nil, ErrContentHasNoMedia
+	}
+
+	kind := enums.TrackKind(request.Kind)
+	if kind != enums.TrackKindCaptions && kind != enums.TrackKindTranscript {
+		return nil, ErrInvalidTrackKind
+	}
+
+	locale := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(request.Locale)), "_", "-")
+	if !trackLocale.MatchString(locale) {
+		return nil, ErrInvalidTrackLocale.WithDetails(map[string]interface{}{"locale": request.Locale})
+	}
+
+	data, err := readTrackFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	track := &models.ContentTrack{
+		ContentID: contentID,
+		Kind:      kind,
+		Locale:    locale,
+		Label:     strings.TrimSpace(request.Label),
+	}
+	if track.Label == "" {
+		track.Label = locale
+	}
+	if userID != 0 {
+		track.CreatedByID = &userID
+	}
+
+	var body []byte
+	var ext, contentType string
+	switch kind {
+	case enums.TrackKindCaptions:
+		cues, err := captions.Parse(data)
+		if err != nil {
+			return nil, invalidCaptions(err)
+		}
+		body, ext, contentType = captions.WriteWebVTT(cues), "vtt", "text/vtt"
+	case enums.TrackKindTranscript:
+		text, err := transcriptText(data)
+		if err != nil {
+			return nil, err
+		}
+		track.Text = text
+		body, ext, contentType = []byte(text), "txt", "text/plain; charset=utf-8"
+	}
+
+	stored, err := s.fileService.UploadFromReader(&storage.File{
+		Reader:      bytes.NewReader(body),
+		Filename:    fmt.Sprintf("content-%d-%s.%s", contentID, locale, ext),
+		Size:        int64(len(body)),
+		ContentType: contentType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	track.FileID = stored.ID
+	track.File = stored
+
+	if err := s.store.ContentTracks.Save(track); err != nil {
+		if deleteErr := s.fileService.DeleteFile(stored.ID); deleteErr != nil {
+			s.logger.Warnw("No se pudo eliminar el archivo de la pista", "file_id", stored.ID, "error", deleteErr)
+		}
+		return nil, fmt.Errorf("error al guardar la pista: %w", err)
+	}
+
+	return track, nil
+}
+
+func (s *trackService) GetTracks(contentID uint) ([]*models.ContentTrack, error) {
+	if _, err := s.store.Contents.Get(contentID); err != nil {
+		return nil, notFound(ErrContentNotFound, err)
+	}
+
+	tracks, err := s.store.ContentTracks.GetByContent(contentID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las pistas: %w", err)
+	}
+	return tracks, nil
+}
+
+// DeleteTrack removes the track; its file is released for the cleanup job
+func (s *trackService) DeleteTrack(id, userID uint) error {
+	if err := s.requireStaff(userID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.ContentTracks.Get(id); err != nil {
+		return notFound(ErrTrackNotFound, err)
+	}
+
+	if err := s.store.ContentTracks.Delete(id); err != nil {
+		return notFound(ErrTrackNotFound, err)
+	}
+	return nil
+}
+
+func readTrackFile(file *multipart.FileHeader) ([]byte, error) {
+	tooLarge := ErrFileTooLarge.WithDetails(map[string]interface{}{"max_bytes": maxTrackSize})
+	if file.Size > maxTrackSize {
+		return nil, tooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error al abrir el archivo: %w", err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxTrackSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el archivo: %w", err)
+	}
+	if len(data) > maxTrackSize {
+		return nil, tooLarge
+	}
+	return data, nil
+}
+
+// transcriptText returns the plain text of a transcript. Caption files are accepted too and
+// reduced to their text, so the same file can be uploaded as captions and transcript.
+func transcriptText(data []byte) (string, error) {
+	if captions.Detect(data) != captions.FormatUnknown {
+		cues, err := captions.Parse(data)
+		if err != nil {
+			return "", invalidCaptions(err)
+		}
+		return captions.Transcript(cues), nil
+	}
+
+	if !utf8.Valid(data) {
+		return "", invalidCaptions(captions.ErrInvalidEncoding)
+	}
+
+	text := strings.TrimPrefix(string(data), "\uFEFF")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r", "\n"))
+	if text == "" {
+		return "", ErrEmptyTranscript
+	}
+	return text, nil
+}
+
+func invalidCaptions(err error) error {
+	return ErrInvalidCaptions.Wrap(err).WithDetails(map[string]interface{}{"reason": err.Error()})
+}
@@ -0,0 +1,577 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+	"github.com/imlargo/go-api-template/internal/i18n"
+	"github.com/imlargo/go-api-template/internal/models"
+)
+
+var (
+	ErrUnsupportedLocale          = apperrors.New("UNSUPPORTED_LOCALE", apperrors.KindInvalid, "idioma no soportado", "unsupported language")
+	ErrInvalidTranslationEntity   = apperrors.New("INVALID_TRANSLATION_ENTITY", apperrors.KindInvalid, "tipo de entidad no traducible", "the entity type cannot be translated")
+	ErrInvalidTranslationField    = apperrors.New("INVALID_TRANSLATION_FIELD", apperrors.KindInvalid, "campo no traducible", "the field cannot be translated")
+	ErrTranslationInDefaultLocale = apperrors.New("TRANSLATION_IN_DEFAULT_LOCALE", apperrors.KindInvalid, "el texto original ya está en el idioma del curso", "the original text is already in the course language")
+	ErrEmptyTranslation           = apperrors.New("EMPTY_TRANSLATION", apperrors.KindInvalid, "no se indicaron campos a traducir", "no fields to translate were given")
+)
+
+// translatableFields lists the text fields of each entity that can be translated
+var translatableFields = map[enums.TranslationEntityType][]string{
+	enums.TranslationEntityCourse:     {"title", "short_description", "description"},
+	enums.TranslationEntityModule:     {"title", "description"},
+	enums.TranslationEntityContent:    {"title", "description", "body"},
+	enums.TranslationEntityEvaluation: {"title", "description"},
+	enums.TranslationEntityQuestion:   {"text", "explanation"},
+	enums.TranslationEntityAnswer:     {"text", "match_text"},
+}
+
+type TranslationService interface {
+	SetTranslations(actorID uint, entityType enums.TranslationEntityType, entityID uint, locale string, request *dto.SetTranslationsRequest) ([]*models.Translation, error)
+	GetTranslations(entityType enums.TranslationEntityType, entityID uint) ([]*models.Translation, error)
+	DeleteTranslations(actorID uint, entityType enums.TranslationEntityType, entityID uint, locale string) error
+	GetCourseCompleteness(actorID, courseID uint) (*dto.CourseTranslationCompleteness, error)
+	SetUserLocale(userID uint, locale string) (*models.User, error)
+	ResolveCourseLocale(userID, courseID uint, request dto.RequestLocale) (string, error)
+	LocalizeCourseList(userID uint, request dto.RequestLocale, courses ...*models.Course) error
+	LocalizeSearchResults(userID uint, request dto.RequestLocale, results ...*dto.SearchResult) error
+	LocalizeCourses(locale string, courses ...*models.Course) error
+	LocalizeModules(locale string, modules ...*models.Module) error
+	LocalizeContents(locale string, contents ...*models.Content) error
+	LocalizeEvaluations(locale string, evaluations ...*models.Evaluation) error
+	LocalizeQuestions(locale string, questions ...*models.Question) error
+}
+
+type translationService struct {
+	*Service
+}
+
+func NewTranslationService(service *Service) TranslationService {
+	return &translationService{
+		Service: service,
+	}
+}
+
+// SetTranslations stores the text of the given fields in the locale. Fields sent empty
+// lose their translation and fall back to the course language again.
+func (s *translationService) SetTranslations(actorID uint, entityType enums.TranslationEntityType, entityID uint, locale string, request *dto.SetTranslationsRequest) ([]*models.Translation, error) {
+	if err := s.requireStaff(actorID); err != nil {
+		return nil, err
+	}
+
+	locale, ok := i18n.NormalizeLocale(locale)
+	if !ok {
+		return nil, ErrUnsupportedLocale
+	}
+	if len(request.Fields) == 0 {
+		return nil, ErrEmptyTranslation
+	}
+
+	allowed, ok := translatableFields[entityType]
+	if !ok {
+		return nil, ErrInvalidTranslationEntity
+	}
+	for field := range request.Fields {
+		if !slices.Contains(allowed, field) {
+			return nil, ErrInvalidTranslationField.WithDetails(map[string]interface{}{"field": field})
+		}
+	}
+
+	course, err := s.translationCourse(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if defaultLocale, _ := i18n.NormalizeLocale(course.Language); defaultLocale == locale {
+		return nil, ErrTranslationInDefaultLocale
+	}
+
+	var upserts []*models.Translation
+	var cleared []string
+	for field, value := range request.Fields {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			cleared = append(cleared, field)
+			continue
+		}
+		upserts = append(upserts, &models.Translation{
+			EntityType: entityType,
+			EntityID:   entityID,
+			Locale:     locale,
+			Field:      field,
+			Value:      value,
+			CourseID:   course.ID,
+		})
+	}
+
+	if err := s.store.Translations.Upsert(upserts); err != nil {
+		return nil, fmt.Errorf("error al guardar las traducciones: %w", err)
+	}
+	if err := s.store.Translations.DeleteFields(entityType, entityID, locale, cleared); err != nil {
+		return nil, fmt.Errorf("error al eliminar las traducciones: %w", err)
+	}
+
+	return s.store.Translations.GetByEntity(entityType, entityID)
+}
+
+func (s *translationService) GetTranslations(entityType enums.TranslationEntityType, entityID uint) ([]*models.Translation, error) {
+	if _, ok := translatableFields[entityType]; !ok {
+		return nil, ErrInvalidTranslationEntity
+	}
+	return s.store.Translations.GetByEntity(entityType, entityID)
+}
+
+func (s *translationService) DeleteTranslations(actorID uint, entityType enums.TranslationEntityType, entityID uint, locale string) error {
+	if err := s.requireStaff(actorID); err != nil {
+		return err
+	}
+	if _, ok := translatableFields[entityType]; !ok {
+		return ErrInvalidTranslationEntity
+	}
+
+	locale, ok := i18n.NormalizeLocale(locale)
+	if !ok {
+		return ErrUnsupportedLocale
+	}
+
+	if err := s.store.Translations.DeleteLocale(entityType, entityID, locale); err != nil {
+		return fmt.Errorf("error al eliminar las traducciones: %w", err)
+	}
+	return nil
+}
+
+// GetCourseCompleteness reports, for every locale other than the course language, how
+// many of the non-empty text fields of the course are translated and which are missing
+func (s *translationService) GetCourseCompleteness(actorID, courseID uint) (*dto.CourseTranslationCompleteness, error) {
+	if err := s.requireStaff(actorID); err != nil {
+		return nil, err
+	}
+
+	course, err := s.store.Translations.GetCourseTree(courseID)
+	if err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+
+	translations, err := s.store.Translations.GetByCourse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las traducciones: %w", err)
+	}
+
+	translated := make(map[string]bool, len(translations))
+	for _, translation := range translations {
+		translated[translationKey(translation.EntityType, translation.EntityID, translation.Locale, translation.Field)] = true
+	}
+
+	type sourceField struct {
+		entityType enums.TranslationEntityType
+		entityID   uint
+		field      string
+	}
+	var sources []sourceField
+	add := func(entityType enums.TranslationEntityType, entityID uint, values map[string]string) {
+		for _, field := range translatableFields[entityType] {
+			if strings.TrimSpace(values[field]) != "" {
+				sources = append(sources, sourceField{entityType, entityID, field})
+			}
+		}
+	}
+
+	add(enums.TranslationEntityCourse, course.ID, courseFields(course))
+	for _, module := range course.Modules {
+		add(enums.TranslationEntityModule, module.ID, moduleFields(module))
+		for _, content := range module.Contents {
+			add(enums.TranslationEntityContent, content.ID, contentFields(content))
+		}
+		for _, evaluation := range module.Evaluations {
+			add(enums.TranslationEntityEvaluation, evaluation.ID, evaluationFields(evaluation))
+			for _, question := range evaluation.Questions {
+				add(enums.TranslationEntityQuestion, question.ID, questionFields(question))
+				for _, answer := range question.Answers {
+					add(enums.TranslationEntityAnswer, answer.ID, map[string]string{"text": answer.Text, "match_text": answer.MatchText})
+				}
+			}
+		}
+	}
+	for _, bank := range course.QuestionBanks {
+		for _, question := range bank.Questions {
+			add(enums.TranslationEntityQuestion, question.ID, questionFields(question))
+			for _, answer := range question.Answers {
+				add(enums.TranslationEntityAnswer, answer.ID, map[string]string{"text": answer.Text, "match_text": answer.MatchText})
+			}
+		}
+	}
+
+	defaultLocale, _ := i18n.NormalizeLocale(course.Language)
+	report := &dto.CourseTranslationCompleteness{
+		CourseID:      course.ID,
+		DefaultLocale: defaultLocale,
+		Locales:       []*dto.LocaleCompleteness{},
+	}
+
+	for _, supported := range i18n.SupportedLocales {
+		locale := string(supported)
+		if locale == defaultLocale {
+			continue
+		}
+
+		completeness := &dto.LocaleCompleteness{
+			Locale:      locale,
+			TotalFields: len(sources),
+			Missing:     []*dto.MissingTranslation{},
+			Percentage:  100.0,
+		}
+		for _, source := range sources {
+			if translated[translationKey(source.entityType, source.entityID, locale, source.field)] {
+				completeness.TranslatedFields++
+				continue
+			}
+			completeness.Missing = append(completeness.Missing, &dto.MissingTranslation{
+				EntityType: source.entityType,
+				EntityID:   source.entityID,
+				Field:      source.field,
+			})
+		}
+		if completeness.TotalFields > 0 {
+			completeness.Percentage = float64(completeness.TranslatedFields) / float64(completeness.TotalFields) * 100.0
+		}
+
+		report.Locales = append(report.Locales, completeness)
+	}
+
+	return report, nil
+}
+
+// SetUserLocale saves the preferred locale of the user; an empty locale clears it
+func (s *translationService) SetUserLocale(userID uint, locale string) (*models.User, error) {
+	if strings.TrimSpace(locale) != "" {
+		normalized, ok := i18n.NormalizeLocale(locale)
+		if !ok {
+			return nil, ErrUnsupportedLocale
+		}
+		locale = normalized
+	} else {
+		locale = ""
+	}
+
+	if err := s.store.Users.SetLocale(userID, locale); err != nil {
+		return nil, fmt.Errorf("error al guardar el idioma: %w", err)
+	}
+	return s.store.Users.GetByID(userID)
+}
+
+// ResolveCourseLocale picks the locale to show a course in: an explicit ?locale=, then the
+// user's preference, then Accept-Language, and finally the course language. Only supported
+// locales are taken into account.
+func (s *translationService) ResolveCourseLocale(userID, courseID uint, request dto.RequestLocale) (string, error) {
+	if locale, ok := s.preferredLocale(userID, request); ok {
+		return locale, nil
+	}
+
+	course, err := s.store.Courses.Get(courseID)
+	if err != nil {
+		return "", notFound(ErrCourseNotFound, err)
+	}
+	locale, _ := i18n.NormalizeLocale(course.Language)
+	return locale, nil
+}
+
+// LocalizeCourseList shows courses of different languages in the locale asked by the request
+// or the user. Without one every course keeps its own language, which has no translations.
+func (s *translationService) LocalizeCourseList(userID uint, request dto.RequestLocale, courses ...*models.Course) error {
+	locale, ok := s.preferredLocale(userID, request)
+	if !ok {
+		return nil
+	}
+	return s.LocalizeCourses(locale, courses...)
+}
+
+// LocalizeSearchResults translates the course and item titles of the search hits in the same
+// way as LocalizeCourseList. Question hits are titled by their evaluation, which the hit does
+// not identify, so only their course title is translated. Snippets quote the indexed text.
+func (s *translationService) LocalizeSearchResults(userID uint, request dto.RequestLocale, results ...*dto.SearchResult) error {
+	locale, ok := s.preferredLocale(userID, request)
+	if !ok {
+		return nil
+	}
+
+	var courseIDs, moduleIDs, contentIDs []uint
+	for _, result := range results {
+		courseIDs = append(courseIDs, result.CourseID)
+		switch result.EntityType {
+		case enums.SearchEntityModule:
+			moduleIDs = append(moduleIDs, result.EntityID)
+		case enums.SearchEntityContent, enums.SearchEntityTranscript:
+			contentIDs = append(contentIDs, result.EntityID)
+		}
+	}
+
+	courseTexts, err := s.lookup(enums.TranslationEntityCourse, courseIDs, locale)
+	if err != nil {
+		return err
+	}
+	moduleTexts, err := s.lookup(enums.TranslationEntityModule, moduleIDs, locale)
+	if err != nil {
+		return err
+	}
+	contentTexts, err := s.lookup(enums.TranslationEntityContent, contentIDs, locale)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		applyTranslation(courseTexts[result.CourseID], "title", &result.CourseTitle)
+		switch result.EntityType {
+		case enums.SearchEntityCourse:
+			applyTranslation(courseTexts[result.EntityID], "title", &result.Title)
+		case enums.SearchEntityModule:
+			applyTranslation(moduleTexts[result.EntityID], "title", &result.Title)
+		case enums.SearchEntityContent, enums.SearchEntityTranscript:
+			applyTranslation(contentTexts[result.EntityID], "title", &result.Title)
+		}
+	}
+	return nil
+}
+
+func (s *translationService) LocalizeCourses(locale string, courses ...*models.Course) error {
+	ids := make([]uint, len(courses))
+	for i, course := range courses {
+		ids[i] = course.ID
+	}
+	texts, err := s.lookup(enums.TranslationEntityCourse, ids, locale)
+	if err != nil {
+		return err
+	}
+	for _, course := range courses {
+		fields := texts[course.ID]
+		applyTranslation(fields, "title", &course.Title)
+		applyTranslation(fields, "short_description", &course.ShortDescription)
+		applyTranslation(fields, "description", &course.Description)
+	}
+	return nil
+}
+
+func (s *translationService) LocalizeModules(locale string, modules ...*models.Module) error {
+	ids := make([]uint, len(modules))
+	for i, module := range modules {
+		ids[i] = module.ID
+	}
+	texts, err := s.lookup(enums.TranslationEntityModule, ids, locale)
+	if err != nil {
+		return err
+	}
+	for _, module := range modules {
+		fields := texts[module.ID]
+		applyTranslation(fields, "title", &module.Title)
+		applyTranslation(fields, "description", &module.Description)
+	}
+	return nil
+}
+
+func (s *translationService) LocalizeContents(locale string, contents ...*models.Content) error {
+	ids := make([]uint, len(contents))
+	for i, content := range contents {
+		ids[i] = content.ID
+	}
+	texts, err := s.lookup(enums.TranslationEntityContent, ids, locale)
+	if err != nil {
+		return err
+	}
+	for _, content := range contents {
+		fields := texts[content.ID]
+		applyTranslation(fields, "title", &content.Title)
+		applyTranslation(fields, "description", &content.Description)
+		applyTranslation(fields, "body", &content.Body)
+	}
+	return nil
+}
+
+func (s *translationService) LocalizeEvaluations(locale string, evaluations ...*models.Evaluation) error {
+	ids := make([]uint, len(evaluations))
+	for i, evaluation := range evaluations {
+		ids[i] = evaluation.ID
+	}
+	texts, err := s.lookup(enums.TranslationEntityEvaluation, ids, locale)
+	if err != nil {
+		return err
+	}
+	for _, evaluation := range evaluations {
+		fields := texts[evaluation.ID]
+		applyTranslation(fields, "title", &evaluation.Title)
+		applyTranslation(fields, "description", &evaluation.Description)
+	}
+	return nil
+}
+
+// LocalizeQuestions translates the questions and their loaded answers
+func (s *translationService) LocalizeQuestions(locale string, questions ...*models.Question) error {
+	ids := make([]uint, len(questions))
+	var answers []*models.Answer
+	var answerIDs []uint
+	for i, question := range questions {
+		ids[i] = question.ID
+		for _, answer := range question.Answers {
+			answers = append(answers, answer)
+			answerIDs = append(answerIDs, answer.ID)
+		}
+	}
+
+	texts, err := s.lookup(enums.TranslationEntityQuestion, ids, locale)
+	if err != nil {
+		return err
+	}
+	for _, question := range questions {
+		fields := texts[question.ID]
+		applyTranslation(fields, "text", &question.Text)
+		applyTranslation(fields, "explanation", &question.Explanation)
+	}
+
+	answerTexts, err := s.lookup(enums.TranslationEntityAnswer, answerIDs, locale)
+	if err != nil {
+		return err
+	}
+	for _, answer := range answers {
+		applyTranslation(answerTexts[answer.ID], "text", &answer.Text)
+		applyTranslation(answerTexts[answer.ID], "match_text", &answer.MatchText)
+	}
+	return nil
+}
+
+// preferredLocale returns the locale asked by ?locale=, the user's preference or
+// Accept-Language, in that order
+func (s *translationService) preferredLocale(userID uint, request dto.RequestLocale) (string, bool) {
+	if locale, ok := i18n.NormalizeLocale(request.Requested); ok {
+		return locale, true
+	}
+
+	if userID != 0 {
+		if user, err := s.store.Users.GetByID(userID); err == nil {
+			if locale, ok := i18n.NormalizeLocale(user.Locale); ok {
+				return locale, true
+			}
+		}
+	}
+
+	return i18n.ParseAcceptLanguage(request.AcceptLanguage)
+}
+
+// lookup returns the translated fields of the entities in the locale, keyed by entity
+func (s *translationService) lookup(entityType enums.TranslationEntityType, ids []uint, locale string) (map[uint]map[string]string, error) {
+	texts := make(map[uint]map[string]string)
+	if locale == "" || len(ids) == 0 {
+		return texts, nil
+	}
+
+	translations, err := s.store.Translations.GetForEntities(entityType, ids, locale)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las traducciones: %w", err)
+	}
+	for _, translation := range translations {
+		if texts[translation.EntityID] == nil {
+			texts[translation.EntityID] = make(map[string]string)
+		}
+		texts[translation.EntityID][translation.Field] = translation.Value
+	}
+	return texts, nil
+}
+
+// translationCourse finds the course an entity belongs to
+func (s *translationService) translationCourse(entityType enums.TranslationEntityType, entityID uint) (*models.Course, error) {
+	var moduleID uint
+	switch entityType {
+	case enums.TranslationEntityCourse:
+		course, err := s.store.Courses.Get(entityID)
+		if err != nil {
+			return nil, notFound(ErrCourseNotFound, err)
+		}
+		return course, nil
+	case enums.TranslationEntityModule:
+		moduleID = entityID
+	case enums.TranslationEntityContent:
+		content, err := s.store.Contents.Get(entityID)
+		if err != nil {
+			return nil, notFound(ErrContentNotFound, err)
+		}
+		moduleID = content.ModuleID
+	case enums.TranslationEntityEvaluation, enums.TranslationEntityQuestion, enums.TranslationEntityAnswer:
+		evaluationID := entityID
+		if entityType == enums.TranslationEntityAnswer {
+			answer, err := s.store.Answers.Get(entityID)
+			if err != nil {
+				return nil, notFound(ErrAnswerNotFound, err)
+			}
+			entityID = answer.QuestionID
+		}
+		if entityType != enums.TranslationEntityEvaluation {
+			question, err := s.store.Questions.Get(entityID)
+			if err != nil {
+				return nil, notFound(ErrQuestionNotFound, err)
+			}
+			// Bank questions belong to the course directly
+			if question.BankID != nil {
+				bank, err := s.store.QuestionBanks.Get(*question.BankID)
+				if err != nil {
+					return nil, notFound(ErrQuestionBankNotFound, err)
+				}
+				course, err := s.store.Courses.Get(bank.CourseID)
+				if err != nil {
+					return nil, notFound(ErrCourseNotFound, err)
+				}
+				return course, nil
+			}
+			if question.EvaluationID == nil {
+				return nil, ErrQuestionNotFound
+			}
+			evaluationID = *question.EvaluationID
+		}
+		evaluation, err := s.store.Evaluations.Get(evaluationID)
+		if err != nil {
+			return nil, notFound(ErrEvaluationNotFound, err)
+		}
+		moduleID = evaluation.ModuleID
+	default:
+		return nil, ErrInvalidTranslationEntity
+	}
+
+	module, err := s.store.Modules.Get(moduleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+	course, err := s.store.Courses.Get(module.CourseID)
+	if err != nil {
+		return nil, notFound(ErrCourseNotFound, err)
+	}
+	return course, nil
+}
+
+func courseFields(course *models.Course) map[string]string {
+	return map[string]string{"title": course.Title, "short_description": course.ShortDescription, "description": course.Description}
+}
+
+func moduleFields(module *models.Module) map[string]string {
+	return map[string]string{"title": module.Title, "description": module.Description}
+}
+
+func contentFields(content *models.Content) map[string]string {
+	return map[string]string{"title": content.Title, "description": content.Description, "body": content.Body}
+}
+
+func evaluationFields(evaluation *models.Evaluation) map[string]string {
+	return map[string]string{"title": evaluation.Title, "description": evaluation.Description}
+}
+
+func questionFields(question *models.Question) map[string]string {
+	return map[string]string{"text": question.Text, "explanation": question.Explanation}
+}
+
+func applyTranslation(fields map[string]string, field string, target *string) {
+	if value, ok := fields[field]; ok && value != "" {
+		*target = value
+	}
+}
+
+func translationKey(entityType enums.TranslationEntityType, entityID uint, locale, field string) string {
+	return fmt.Sprintf("%s:%d:%s:%s", entityType, entityID, locale, field)
+}
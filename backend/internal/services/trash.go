@@ -0,0 +1,106 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/imlargo/go-api-template/internal/apperrors"
+	"github.com/imlargo/go-api-template/internal/dto"
+	"github.com/imlargo/go-api-template/internal/enums"
+)
+
+var ErrInvalidTrashEntity = apperrors.New("INVALID_TRASH_ENTITY", apperrors.KindInvalid, "tipo de elemento inválido", "invalid item type")
+
+type TrashService interface {
+	GetDeletedCourses(userID uint) ([]*dto.TrashItem, error)
+	GetCourseTrash(courseID, userID uint) ([]*dto.TrashItem, error)
+	Restore(entity enums.TrashEntityType, id, userID uint) error
+	PurgeExpired() error
+}
+
+type trashService struct {
+	*Service
+}
+
+func NewTrashService(service *Service) TrashService {
+	return &trashService{
+		Service: service,
+	}
+}
+
+func (s *trashService) GetDeletedCourses(userID uint) ([]*dto.TrashItem, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.store.Trash.GetDeletedCourses()
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la papelera: %w", err)
+	}
+	return s.withPurgeDates(items), nil
+}
+
+func (s *trashService) GetCourseTrash(courseID, userID uint) ([]*dto.TrashItem, error) {
+	if err := s.requireStaff(userID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.store.Trash.GetCourseTrash(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la papelera del curso: %w", err)
+	}
+	return s.withPurgeDates(items), nil
+}
+
+func (s *trashService) Restore(entity enums.TrashEntityType, id, userID uint) error {
+	if err := s.requireStaff(userID); err != nil {
+		return err
+	}
+
+	switch entity {
+	case enums.TrashEntityCourse, enums.TrashEntityModule, enums.TrashEntityContent,
+		enums.TrashEntityEvaluation, enums.TrashEntityQuestion, enums.TrashEntityAnswer:
+	default:
+		return ErrInvalidTrashEntity
+	}
+
+	if err := s.store.Trash.Restore(entity, id); err != nil {
+		return err
+	}
+
+	// Restored modules count again in their course
+	if entity == enums.TrashEntityModule {
+		module, err := s.store.Modules.Get(id)
+		if err != nil {
+			return notFound(ErrModuleNotFound, err)
+		}
+		if err := s.store.Courses.IncrementModuleCount(module.CourseID); err != nil {
+			s.logger.Warnf("failed to increment module count for course %d: %v", module.CourseID, err)
+		}
+	}
+
+	return nil
+}
+
+// PurgeExpired permanently removes the material that stayed in the trash longer than the retention period
+func (s *trashService) PurgeExpired() error {
+	cutoff := time.Now().Add(-s.config.Trash.Retention)
+
+	purged, err := s.store.Trash.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return fmt.Errorf("error al vaciar la papelera: %w", err)
+	}
+
+	if purged > 0 {
+		s.logger.Infof("Purged %d items deleted before %s", purged, cutoff.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (s *trashService) withPurgeDates(items []*dto.TrashItem) []*dto.TrashItem {
+	for _, item := range items {
+		item.PurgeAt = item.DeletedAt.Add(s.config.Trash.Retention)
+	}
+	return items
+}
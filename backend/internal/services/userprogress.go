@@ -1,7 +1,6 @@
 package services
 
 import (
-	"errors"
 	"fmt"
 	"time"
 
@@ -29,12 +28,14 @@ type UserProgressService interface {
 type userProgressService struct {
 	*Service
 	enrollmentService EnrollmentService
+	releaseService    ReleaseService
 }
 
-func NewUserProgressService(service *Service, enrollmentService EnrollmentService) UserProgressService {
+func NewUserProgressService(service *Service, enrollmentService EnrollmentService, releaseService ReleaseService) UserProgressService {
 	return &userProgressService{
 		Service:           service,
 		enrollmentService: enrollmentService,
+		releaseService:    releaseService,
 	}
 }
 
@@ -45,6 +46,15 @@ func (s *userProgressService) MarkContentComplete(userID, courseID, moduleID, co
 		return nil, fmt.Errorf("el usuario no está inscrito en el curso: %w", err)
 	}
 
+	// Content of a module that is not released yet cannot be completed
+	module, err := s.store.Modules.Get(moduleID)
+	if err != nil {
+		return nil, notFound(ErrModuleNotFound, err)
+	}
+	if err := s.releaseService.RequireModuleReleased(userID, module); err != nil {
+		return nil, err
+	}
+
 	// Check if progress already exists
 	existing, _ := s.GetUserProgressForContent(userID, contentID)
 	if existing != nil {
@@ -82,7 +92,7 @@ func (s *userProgressService) MarkContentIncomplete(userID, courseID, moduleID,
 	// Find existing progress
 	existing, err := s.GetUserProgressForContent(userID, contentID)
 	if err != nil {
-		return fmt.Errorf("progress not found: %w", err)
+		return notFound(ErrProgressNotFound, err)
 	}
 
 	// Reset completion
@@ -102,12 +112,12 @@ func (s *userProgressService) MarkContentIncomplete(userID, courseID, moduleID,
 
 func (s *userProgressService) UpdateUserProgressPatch(progressID uint, data map[string]interface{}) (*models.UserProgress, error) {
 	if progressID == 0 {
-		return nil, errors.New("progress ID cannot be zero")
+		return nil, ErrInvalidID
 	}
 
 	var progress dto.UpdateUserProgressRequest
 	if err := utils.MapToStructStrict(data, &progress); err != nil {
-		return nil, errors.New("datos inválidos: " + err.Error())
+		return nil, invalidData(err)
 	}
 
 	if err := s.store.UserProgresss.Patch(progressID, data); err != nil {
@@ -116,7 +126,7 @@ func (s *userProgressService) UpdateUserProgressPatch(progressID uint, data map[
 
 	updated, err := s.store.UserProgresss.Get(progressID)
 	if err != nil {
-		return nil, errors.New("progreso no encontrado")
+		return nil, notFound(ErrProgressNotFound, err)
 	}
 
 	return updated, nil
@@ -247,7 +257,7 @@ func (s *userProgressService) GetUserProgressForContent(userID, contentID uint)
 	// Use the new repository method to filter by user ID and content ID at database level
 	progress, err := s.store.UserProgresss.GetByUserAndContent(userID, contentID)
 	if err != nil {
-		return nil, fmt.Errorf("progress not found: %w", err)
+		return nil, notFound(ErrProgressNotFound, err)
 	}
 
 	return progress, nil
@@ -303,7 +313,55 @@ func (s *userProgressService) HasUserPassedEvaluation(userID, evaluationID uint)
 
 func (s *userProgressService) GetComprehensiveCourseProgress(userID, courseID uint) (*dto.CourseProgressSummary, error) {
 	// Delegate to repository layer which handles the complex SQL query and data processing
-	return s.store.UserProgresss.GetCourseProgressSummary(userID, courseID)
+	summary, err := s.store.UserProgresss.GetCourseProgressSummary(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyReleaseState(userID, courseID, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// applyReleaseState flags the modules that are not released yet. The total percentage
+// still counts every module, so the course is only complete once all of them are done;
+// the released percentage tells whether the learner is up to date with what is available.
+func (s *userProgressService) applyReleaseState(userID, courseID uint, summary *dto.CourseProgressSummary) error {
+	modules, err := s.store.Modules.GetByCourseID(courseID)
+	if err != nil {
+		return fmt.Errorf("error al obtener los módulos: %w", err)
+	}
+	if err := s.releaseService.AnnotateModules(userID, courseID, modules); err != nil {
+		return err
+	}
+
+	byID := make(map[uint]*models.Module, len(modules))
+	for _, module := range modules {
+		byID[module.ID] = module
+	}
+
+	released, completed := 0, 0
+	for i := range summary.ModulesProgress {
+		detail := &summary.ModulesProgress[i]
+		if module, ok := byID[detail.ModuleID]; ok {
+			detail.Locked = module.Locked
+			detail.UnlocksAt = module.UnlocksAt
+		}
+		if detail.Locked {
+			continue
+		}
+		released++
+		if detail.IsCompleted {
+			completed++
+		}
+	}
+
+	summary.ReleasedPercentage = 100.0
+	if released > 0 {
+		summary.ReleasedPercentage = float64(completed) / float64(released) * 100.0
+	}
+	return nil
 }
 
 func (s *userProgressService) GetModuleContentProgress(userID, moduleID uint) ([]*dto.ContentProgressResponse, error) {
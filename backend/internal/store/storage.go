@@ -19,6 +19,26 @@ type Store struct {
 	Modules            repositories.ModuleRepository
 	UserProgresss      repositories.UserProgressRepository
 	Questions          repositories.QuestionRepository
+	Search             repositories.SearchRepository
+	Categories         repositories.CategoryRepository
+	Tags               repositories.TagRepository
+	Trash              repositories.TrashRepository
+	Revisions          repositories.RevisionRepository
+	Attachments        repositories.AttachmentRepository
+	Playback           repositories.PlaybackRepository
+	Activity           repositories.ActivityRepository
+	Reviews            repositories.ReviewRepository
+	Discussions        repositories.DiscussionRepository
+	Notes              repositories.NoteRepository
+	Announcements      repositories.AnnouncementRepository
+	LearningPaths      repositories.LearningPathRepository
+	Cohorts            repositories.CohortRepository
+	Deadlines          repositories.DeadlineRepository
+	Releases           repositories.ReleaseRepository
+	Translations       repositories.TranslationRepository
+	ContentTracks      repositories.ContentTrackRepository
+	QuestionBanks      repositories.QuestionBankRepository
+	ItemAnalyses       repositories.ItemAnalysisRepository
 	repository         *repositories.Repository
 }
 
@@ -37,6 +57,26 @@ func NewStorage(container *repositories.Repository) *Store {
 		Modules:            repositories.NewModuleRepository(container),
 		UserProgresss:      repositories.NewUserProgressRepository(container),
 		Questions:          repositories.NewQuestionRepository(container),
+		Search:             repositories.NewSearchRepository(container),
+		Categories:         repositories.NewCategoryRepository(container),
+		Tags:               repositories.NewTagRepository(container),
+		Trash:              repositories.NewTrashRepository(container),
+		Revisions:          repositories.NewRevisionRepository(container),
+		Attachments:        repositories.NewAttachmentRepository(container),
+		Playback:           repositories.NewPlaybackRepository(container),
+		Activity:           repositories.NewActivityRepository(container),
+		Reviews:            repositories.NewReviewRepository(container),
+		Discussions:        repositories.NewDiscussionRepository(container),
+		Notes:              repositories.NewNoteRepository(container),
+		Announcements:      repositories.NewAnnouncementRepository(container),
+		LearningPaths:      repositories.NewLearningPathRepository(container),
+		Cohorts:            repositories.NewCohortRepository(container),
+		Deadlines:          repositories.NewDeadlineRepository(container),
+		Releases:           repositories.NewReleaseRepository(container),
+		Translations:       repositories.NewTranslationRepository(container),
+		ContentTracks:      repositories.NewContentTrackRepository(container),
+		QuestionBanks:      repositories.NewQuestionBankRepository(container),
+		ItemAnalyses:       repositories.NewItemAnalysisRepository(container),
 		repository:         container,
 	}
 }
@@ -0,0 +1,266 @@
+package captions
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type Format string
+
+const (
+	FormatWebVTT  Format = "vtt"
+	FormatSRT     Format = "srt"
+	FormatUnknown Format = ""
+)
+
+var (
+	ErrInvalidEncoding = errors.New("el archivo debe estar codificado en UTF-8")
+	ErrUnknownFormat   = errors.New("el archivo no es WebVTT ni SRT")
+	ErrNoCues          = errors.New("el archivo no contiene subtítulos")
+)
+
+// ParseError reports the line of a caption file that could not be read
+type ParseError struct {
+	Line   int
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("línea %d: %s", e.Line, e.Reason)
+}
+
+// Cue is a caption shown on screen between Start and End
+type Cue struct {
+	ID       string
+	Start    time.Duration
+	End      time.Duration
+	Settings string // WebVTT position settings, empty for SRT
+	Text     string
+}
+
+var (
+	// srtMarkup matches the SRT markup WebVTT does not support: font tags and ASS overrides like {\an8}
+	srtMarkup = regexp.MustCompile(`(?i)</?font[^>]*>|\{\\[^}]*\}`)
+	tags      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Detect tells a WebVTT file by its header and an SRT file by its timing lines
+func Detect(data []byte) Format {
+	text := normalizeText(string(data))
+	switch {
+	case isWebVTTHeader(firstLine(text)):
+		return FormatWebVTT
+	case strings.Contains(text, "-->"):
+		return FormatSRT
+	default:
+		return FormatUnknown
+	}
+}
+
+// Parse reads a WebVTT or SRT file and returns its cues ordered by start time.
+// WebVTT comments, styles and regions are dropped.
+func Parse(data []byte) ([]Cue, error) {
+	if !utf8.Valid(data) {
+		return nil, ErrInvalidEncoding
+	}
+
+	format := Detect(data)
+	if format == FormatUnknown {
+		return nil, ErrUnknownFormat
+	}
+
+	lines := strings.Split(normalizeText(string(data)), "\n")
+	first := 0
+	if format == FormatWebVTT {
+		// The header block ends at the first blank line
+		for first < len(lines) && strings.TrimSpace(lines[first]) != "" {
+			first++
+		}
+	}
+
+	var cues []Cue
+	for start := first; start < len(lines); {
+		if strings.TrimSpace(lines[start]) == "" {
+			start++
+			continue
+		}
+		end := start
+		for end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+			end++
+		}
+
+		cue, ok, err := parseBlock(lines[start:end], start+1, format)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cues = append(cues, cue)
+		}
+		start = end
+	}
+
+	if len(cues) == 0 {
+		return nil, ErrNoCues
+	}
+
+	sort.SliceStable(cues, func(i, j int) bool { return cues[i].Start < cues[j].Start })
+	return cues, nil
+}
+
+// WriteWebVTT renders the cues as a WebVTT file
+func WriteWebVTT(cues []Cue) []byte {
+	var builder strings.Builder
+	builder.WriteString("WEBVTT\n")
+	for _, cue := range cues {
+		builder.WriteString("\n")
+		if cue.ID != "" {
+			builder.WriteString(cue.ID + "\n")
+		}
+		builder.WriteString(formatTimestamp(cue.Start) + " --> " + formatTimestamp(cue.End))
+		if cue.Settings != "" {
+			builder.WriteString(" " + cue.Settings)
+		}
+		builder.WriteString("\n" + cue.Text + "\n")
+	}
+	return []byte(builder.String())
+}
+
+// Transcript returns the plain text of the cues, one line per cue, without markup
+// and without the repeated lines roll-up captions produce
+func Transcript(cues []Cue) string {
+	var lines []string
+	for _, cue := range cues {
+		text := html.UnescapeString(tags.ReplaceAllString(cue.Text, ""))
+		text = strings.Join(strings.Fields(text), " ")
+		if text == "" || (len(lines) > 0 && lines[len(lines)-1] == text) {
+			continue
+		}
+		lines = append(lines, text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseBlock reads a block of non blank lines starting at line number lineNumber.
+// It reports false for WebVTT blocks that are not cues.
+func parseBlock(block []string, lineNumber int, format Format) (Cue, bool, error) {
+	if format == FormatWebVTT {
+		keyword := strings.Fields(block[0])[0]
+		if keyword == "NOTE" || keyword == "STYLE" || keyword == "REGION" {
+			return Cue{}, false, nil
+		}
+	}
+
+	var cue Cue
+	timing := 0
+	if !strings.Contains(block[0], "-->") {
+		// The first line identifies the cue: an index in SRT, an optional name in WebVTT
+		if format == FormatWebVTT {
+			cue.ID = strings.TrimSpace(block[0])
+		}
+		timing = 1
+	}
+	if timing >= len(block) || !strings.Contains(block[timing], "-->") {
+		return Cue{}, false, &ParseError{Line: lineNumber, Reason: "se esperaba una línea de tiempos"}
+	}
+
+	startValue, rest, _ := strings.Cut(block[timing], "-->")
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return Cue{}, false, &ParseError{Line: lineNumber + timing, Reason: "falta el tiempo de fin"}
+	}
+
+	var ok bool
+	if cue.Start, ok = parseTimestamp(strings.TrimSpace(startValue)); !ok {
+		return Cue{}, false, &ParseError{Line: lineNumber + timing, Reason: "tiempo de inicio inválido"}
+	}
+	if cue.End, ok = parseTimestamp(fields[0]); !ok {
+		return Cue{}, false, &ParseError{Line: lineNumber + timing, Reason: "tiempo de fin inválido"}
+	}
+	if cue.End <= cue.Start {
+		return Cue{}, false, &ParseError{Line: lineNumber + timing, Reason: "el fin debe ser posterior al inicio"}
+	}
+	if format == FormatWebVTT {
+		cue.Settings = strings.Join(fields[1:], " ")
+	}
+
+	text := strings.Join(block[timing+1:], "\n")
+	if format == FormatSRT {
+		text = srtMarkup.ReplaceAllString(text, "")
+	}
+	cue.Text = strings.TrimSpace(text)
+	if cue.Text == "" {
+		return Cue{}, false, nil
+	}
+	return cue, true, nil
+}
+
+// parseTimestamp reads "hh:mm:ss.ttt" or "mm:ss.ttt"; SRT uses a comma before the milliseconds
+func parseTimestamp(value string) (time.Duration, bool) {
+	clock, millis, found := strings.Cut(strings.Replace(value, ",", ".", 1), ".")
+	if !found || len(millis) != 3 {
+		return 0, false
+	}
+
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+
+	hours := 0
+	if len(parts) == 3 {
+		h, err := strconv.Atoi(parts[0])
+		if err != nil || h < 0 {
+			return 0, false
+		}
+		hours = h
+		parts = parts[1:]
+	}
+
+	minutes, err := strconv.Atoi(parts[0])
+	if err != nil || len(parts[0]) != 2 || minutes > 59 {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(parts[1])
+	if err != nil || len(parts[1]) != 2 || seconds > 59 {
+		return 0, false
+	}
+	ms, err := strconv.Atoi(millis)
+	if err != nil || ms < 0 {
+		return 0, false
+	}
+
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(ms)*time.Millisecond, true
+}
+
+func formatTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// normalizeText drops the byte order mark and unifies line endings
+func normalizeText(text string) string {
+	text = strings.TrimPrefix(text, "\uFEFF")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\r", "\n")
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// isWebVTTHeader reports whether the line is "WEBVTT", optionally followed by a space or tab and a title
+func isWebVTTHeader(line string) bool {
+	rest, found := strings.CutPrefix(line, "WEBVTT")
+	return found && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
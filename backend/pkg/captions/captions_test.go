@@ -0,0 +1,117 @@
+package captions
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSRTToWebVTT(t *testing.T) {
+	tests := []struct {
+		name string
+		srt  string
+		want string
+	}{
+		{
+			name: "timings use a dot and cue numbers are dropped",
+			srt:  "1\n00:00:01,000 --> 00:00:02,500\nHola\n\n2\n00:00:03,000 --> 00:00:04,000\nAdiós\n",
+			want: "WEBVTT\n\n00:00:01.000 --> 00:00:02.500\nHola\n\n00:00:03.000 --> 00:00:04.000\nAdiós\n",
+		},
+		{
+			name: "byte order mark and CRLF line endings",
+			srt:  "\uFEFF1\r\n00:00:01,000 --> 00:00:02,000\r\nprimera línea\r\nsegunda línea\r\n",
+			want: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nprimera línea\nsegunda línea\n",
+		},
+		{
+			name: "font tags and ASS overrides are removed, other markup is kept",
+			srt:  "1\n00:00:01,000 --> 00:00:02,000\n{\\an8}<font color=\"#ff0000\"><i>Atención</i></font>\n",
+			want: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\n<i>Atención</i>\n",
+		},
+		{
+			name: "cues are sorted by start time",
+			srt:  "1\n00:00:05,000 --> 00:00:06,000\nsegunda\n\n2\n00:00:01,000 --> 00:00:02,000\nprimera\n",
+			want: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nprimera\n\n00:00:05.000 --> 00:00:06.000\nsegunda\n",
+		},
+		{
+			name: "empty cues are skipped",
+			srt:  "1\n00:00:01,000 --> 00:00:02,000\n{\\an8}\n\n2\n00:00:03,000 --> 00:00:04,000\ntexto\n",
+			want: "WEBVTT\n\n00:00:03.000 --> 00:00:04.000\ntexto\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if format := Detect([]byte(tt.srt)); format != FormatSRT {
+				t.Fatalf("Detect() = %q, want %q", format, FormatSRT)
+			}
+			cues, err := Parse([]byte(tt.srt))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := string(WriteWebVTT(cues)); got != tt.want {
+				t.Errorf("WriteWebVTT() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWebVTT(t *testing.T) {
+	vtt := "WEBVTT - Clase 1\n\nNOTE revisar\n\nSTYLE\n::cue { color: white }\n\nintro\n01:02.500 --> 01:04.000 align:start\nBienvenidos\n"
+
+	cues, err := Parse([]byte(vtt))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := Cue{ID: "intro", Start: time.Minute + 2500*time.Millisecond, End: time.Minute + 4*time.Second, Settings: "align:start", Text: "Bienvenidos"}
+	if len(cues) != 1 || cues[0] != want {
+		t.Fatalf("Parse() = %+v, want [%+v]", cues, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantErr  error
+		wantLine int
+	}{
+		{name: "not UTF-8", data: "1\n00:00:01,000 --> 00:00:02,000\n\xff\n", wantErr: ErrInvalidEncoding},
+		{name: "unknown format", data: "solo texto\n", wantErr: ErrUnknownFormat},
+		{name: "header without cues", data: "WEBVTT\n", wantErr: ErrNoCues},
+		{name: "only comments", data: "WEBVTT\n\nNOTE nada\n", wantErr: ErrNoCues},
+		{name: "invalid start time", data: "1\n00:00:1,000 --> 00:00:02,000\nHola\n", wantLine: 2},
+		{name: "end before start", data: "1\n00:00:03,000 --> 00:00:02,000\nHola\n", wantLine: 2},
+		{name: "missing timing line", data: "1\n00:00:01,000 --> 00:00:02,000\nHola\n\n2\nAdiós\n", wantLine: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || parseErr.Line != tt.wantLine {
+				t.Errorf("Parse() error = %v, want a parse error on line %d", err, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestTranscript(t *testing.T) {
+	cues := []Cue{
+		{Text: "<v Ana>Hola &amp; bienvenidos</v>"},
+		{Text: "Hola &amp;   bienvenidos"},
+		{Text: "<b></b>"},
+		{Text: "a la clase"},
+	}
+
+	want := "Hola & bienvenidos\na la clase"
+	if got := Transcript(cues); got != want {
+		t.Errorf("Transcript() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job is a unit of background work executed periodically
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Scheduler runs registered jobs on their own interval until stopped
+type Scheduler struct {
+	jobs   []Job
+	logger *zap.SugaredLogger
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(logger *zap.SugaredLogger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Every registers a job that runs once per interval. A non-positive interval disables the job.
+func (s *Scheduler) Every(name string, interval time.Duration, run func() error) {
+	if interval <= 0 {
+		return
+	}
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Start launches every registered job in its own goroutine
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+// Stop signals the jobs to finish and waits for the running ones
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.run(job)
+		}
+	}
+}
+
+func (s *Scheduler) run(job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Errorf("Background job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(); err != nil {
+		s.logger.Errorf("Background job %s failed: %v", job.Name, err)
+	}
+}
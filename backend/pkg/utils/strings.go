@@ -1,7 +1,43 @@
 package utils
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"Á", "A", "É", "E", "Í", "I", "Ó", "O", "Ú", "U", "Ü", "U", "Ñ", "N",
+	"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u",
+	"À", "A", "È", "E", "Ì", "I", "Ò", "O", "Ù", "U",
+)
 
 func NormalizeString(s string) string {
 	return strings.ToLower(strings.TrimSpace(s))
 }
+
+// RemoveAccents replaces accented latin characters with their unaccented version
+func RemoveAccents(s string) string {
+	return accentReplacer.Replace(s)
+}
+
+// Slugify converts a text into a lowercase, accent free, dash separated identifier
+func Slugify(s string) string {
+	s = RemoveAccents(NormalizeString(s))
+
+	var builder strings.Builder
+	lastDash := true
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			lastDash = false
+			continue
+		}
+		if !lastDash {
+			builder.WriteByte('-')
+			lastDash = true
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "-")
+}
@@ -1,5 +1,6 @@
 import api from '$lib/services/api';
 import { authStore } from '$lib/stores/auth.svelte';
+import type { Page } from '$lib/types';
 
 /**
  * Base controller class that handles authentication and common API functionality
@@ -26,6 +27,24 @@ export class BaseController {
 		return this.api.get<T>(endpoint, {}, this.getToken());
 	}
 
+	/**
+	 * Fetch every item of a paginated list endpoint, following next_cursor
+	 */
+	protected async getAll<T>(endpoint: string): Promise<T[]> {
+		const separator = endpoint.includes('?') ? '&' : '?';
+		const items: T[] = [];
+		let cursor: string | undefined;
+
+		do {
+			const query = cursor ? `limit=100&cursor=${encodeURIComponent(cursor)}` : 'limit=100';
+			const page = await this.get<Page<T>>(`${endpoint}${separator}${query}`);
+			items.push(...page.items);
+			cursor = page.has_more ? page.next_cursor : undefined;
+		} while (cursor);
+
+		return items;
+	}
+
 	/**
 	 * Make an authenticated POST request
 	 */
@@ -19,7 +19,7 @@ export class ContentController extends BaseController {
 	 * Get all content for a specific module
 	 */
 	async getContentsByModule(moduleId: number): Promise<Content[]> {
-		return this.get<Content[]>(`/api/v1/modules/${moduleId}/content`);
+		return this.getAll<Content>(`/api/v1/modules/${moduleId}/content`);
 	}
 
 	/**
@@ -6,7 +6,7 @@ export class CourseController extends BaseController {
 	 * Get all courses
 	 */
 	async getCourses(): Promise<Course[]> {
-		return this.get<Course[]>('/api/v1/courses');
+		return this.getAll<Course>('/api/v1/courses');
 	}
 
 	/**
@@ -55,13 +55,13 @@ export class CourseController extends BaseController {
 	 * Get all modules for a specific course
 	 */
 	async getCourseModules(courseId: number): Promise<Module[]> {
-		return this.get<Module[]>(`/api/v1/courses/${courseId}/modules`);
+		return this.getAll<Module>(`/api/v1/courses/${courseId}/modules`);
 	}
 
 	/**
 	 * Get all enrollments for a specific course
 	 */
 	async getCourseEnrollments(courseId: number): Promise<Enrollment[]> {
-		return this.get<Enrollment[]>(`/api/v1/courses/${courseId}/enrollments`);
+		return this.getAll<Enrollment>(`/api/v1/courses/${courseId}/enrollments`);
 	}
 }
@@ -32,14 +32,14 @@ export class EnrollmentController extends BaseController {
 	 * Get all enrollments for a specific user
 	 */
 	async getUserEnrollments(userId: number): Promise<Enrollment[]> {
-		return this.get<Enrollment[]>(`/api/v1/users/${userId}/enrollments`);
+		return this.getAll<Enrollment>(`/api/v1/users/${userId}/enrollments`);
 	}
 
 	/**
 	 * Get all enrollments for a specific course
 	 */
 	async getCourseEnrollments(courseId: number): Promise<Enrollment[]> {
-		return this.get<Enrollment[]>(`/api/v1/courses/${courseId}/enrollments`);
+		return this.getAll<Enrollment>(`/api/v1/courses/${courseId}/enrollments`);
 	}
 
 	/**
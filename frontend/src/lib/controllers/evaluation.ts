@@ -13,7 +13,7 @@ export class EvaluationController extends BaseController {
 	 * Get all evaluations for a specific module
 	 */
 	async getEvaluationsByModule(moduleId: number): Promise<Evaluation[]> {
-		return this.get<Evaluation[]>(`/api/v1/modules/${moduleId}/evaluations`);
+		return this.getAll<Evaluation>(`/api/v1/modules/${moduleId}/evaluations`);
 	}
 
 	/**
@@ -37,7 +37,7 @@ export class EvaluationAttemptController extends BaseController {
 	 * Get all attempts for a user and evaluation
 	 */
 	async getUserAttempts(userId: number, evaluationId: number): Promise<EvaluationAttempt[]> {
-		return this.get<EvaluationAttempt[]>(
+		return this.getAll<EvaluationAttempt>(
 			`/api/v1/users/${userId}/evaluations/${evaluationId}/attempts`
 		);
 	}
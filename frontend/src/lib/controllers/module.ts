@@ -27,7 +27,7 @@ export class ModuleController extends BaseController {
 	 * Get all modules for a specific course
 	 */
 	async getModulesByCourse(courseId: number): Promise<Module[]> {
-		return this.get<Module[]>(`/api/v1/courses/${courseId}/modules`);
+		return this.getAll<Module>(`/api/v1/courses/${courseId}/modules`);
 	}
 
 	/**
@@ -73,14 +73,14 @@ export class ModuleController extends BaseController {
 	 * Get all content for a specific module
 	 */
 	async getModuleContents(moduleId: number): Promise<Content[]> {
-		return this.get<Content[]>(`/api/v1/modules/${moduleId}/content`);
+		return this.getAll<Content>(`/api/v1/modules/${moduleId}/content`);
 	}
 
 	/**
 	 * Get all evaluations for a specific module
 	 */
 	async getModuleEvaluations(moduleId: number): Promise<Evaluation[]> {
-		return this.get<Evaluation[]>(`/api/v1/modules/${moduleId}/evaluations`);
+		return this.getAll<Evaluation>(`/api/v1/modules/${moduleId}/evaluations`);
 	}
 
 	/**
@@ -51,7 +51,7 @@ export class QuestionController extends BaseController {
 	 * Get questions by evaluation
 	 */
 	async getQuestionsByEvaluation(evaluationId: number): Promise<Question[]> {
-		return this.get<Question[]>(`/api/v1/evaluations/${evaluationId}/questions`);
+		return this.getAll<Question>(`/api/v1/evaluations/${evaluationId}/questions`);
 	}
 
 	/**
@@ -102,6 +102,6 @@ export class AnswerController extends BaseController {
 	 * Get all answers for a specific question
 	 */
 	async getAnswersByQuestion(questionId: number): Promise<Answer[]> {
-		return this.get<Answer[]>(`/api/v1/questions/${questionId}/answers`);
+		return this.getAll<Answer>(`/api/v1/questions/${questionId}/answers`);
 	}
 }
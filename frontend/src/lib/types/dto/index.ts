@@ -1,6 +1,16 @@
 // DTOs for API requests and responses
 import { ContentType, QuestionType } from '../models/course';
 
+// Standard envelope returned by list endpoints
+export interface Page<T> {
+	items: T[];
+	total: number;
+	limit: number;
+	offset: number;
+	next_cursor?: string;
+	has_more: boolean;
+}
+
 export interface CreateCourseDTO {
 	title: string;
 	description: string;